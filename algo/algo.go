@@ -0,0 +1,57 @@
+// Package algo provides generic algorithms over any structure of kozo implementing collection.Iterable,
+// so that the same code applies to a Set, a Queue, a List or a tree.
+package algo
+
+import "github.com/dullkingsman/kozo/collection"
+
+// ForEach calls fn for each element of c, in the iteration order of c.
+func ForEach[T any](c collection.Iterable[T], fn func(T)) {
+	for v := range c.All() {
+		fn(v)
+	}
+}
+
+// CollectSlice returns the elements of c as a new slice, in the iteration order of c.
+func CollectSlice[T any](c collection.Iterable[T]) []T {
+	var res []T
+	if sized, ok := c.(interface{ Len() int }); ok {
+		res = make([]T, 0, sized.Len())
+	}
+	for v := range c.All() {
+		res = append(res, v)
+	}
+	return res
+}
+
+// CountIf returns the number of elements of c satisfying pred.
+func CountIf[T any](c collection.Iterable[T], pred func(T) bool) int {
+	n := 0
+	for v := range c.All() {
+		if pred(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// AnyMatch returns true if an element of c satisfies pred, stopping at the first one.
+// It returns false if c is empty.
+func AnyMatch[T any](c collection.Iterable[T], pred func(T) bool) bool {
+	for v := range c.All() {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllMatch returns true if every element of c satisfies pred, stopping at the first that does not.
+// It returns true if c is empty.
+func AllMatch[T any](c collection.Iterable[T], pred func(T) bool) bool {
+	for v := range c.All() {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package algo
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dullkingsman/kozo/avltree"
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/list"
+	"github.com/dullkingsman/kozo/queue"
+	"github.com/dullkingsman/kozo/set"
+	"github.com/dullkingsman/kozo/stack"
+)
+
+func isEven(v int) bool { return v%2 == 0 }
+
+func TestAlgo_Collections(t *testing.T) {
+	q := queue.New[int]()
+	q.EnqueueAll(1, 2, 3, 4)
+	s := stack.New[int]()
+	s.Push(1, 2, 3, 4)
+	l := list.New[int]()
+	for _, v := range []int{1, 2, 3, 4} {
+		l.PushBack(v)
+	}
+	tree := avltree.NewOrdered[int]()
+	for _, v := range []int{3, 1, 4, 2} {
+		tree.Insert(v)
+	}
+
+	cases := []struct {
+		name string
+		c    collection.Collection[int]
+		want []int
+	}{
+		{"Queue", q, []int{1, 2, 3, 4}},
+		{"Stack", s, []int{4, 3, 2, 1}},
+		{"List", l, []int{1, 2, 3, 4}},
+		{"AVLTree", tree, []int{1, 2, 3, 4}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := CollectSlice(tc.c); !slices.Equal(got, tc.want) {
+				t.Errorf("CollectSlice() = %v, want %v", got, tc.want)
+			}
+			var visited []int
+			ForEach(tc.c, func(v int) { visited = append(visited, v) })
+			if !slices.Equal(visited, tc.want) {
+				t.Errorf("ForEach() visited %v, want %v", visited, tc.want)
+			}
+			if n := CountIf(tc.c, isEven); n != 2 {
+				t.Errorf("CountIf(even) = %d, want 2", n)
+			}
+			if !AnyMatch(tc.c, func(v int) bool { return v == 3 }) || AnyMatch(tc.c, func(v int) bool { return v > 4 }) {
+				t.Error("AnyMatch() did not find exactly the present values")
+			}
+			if !AllMatch(tc.c, func(v int) bool { return v > 0 }) || AllMatch(tc.c, isEven) {
+				t.Error("AllMatch() did not hold exactly for the common property")
+			}
+			if tc.c.Len() != 4 || tc.c.IsEmpty() {
+				t.Errorf("Len() = %d, want 4", tc.c.Len())
+			}
+		})
+	}
+}
+
+func TestAlgo_Empty(t *testing.T) {
+	s := set.New[int]()
+	if got := CollectSlice(s); len(got) != 0 {
+		t.Errorf("CollectSlice() = %v, want empty", got)
+	}
+	if CountIf(s, isEven) != 0 || AnyMatch(s, isEven) || !AllMatch(s, isEven) {
+		t.Error("Expected no matches and vacuous AllMatch on an empty set")
+	}
+}
+
+func TestAlgo_StopsEarly(t *testing.T) {
+	q := queue.NewUnsync[int]()
+	for i := range 100 {
+		q.Enqueue(i)
+	}
+	calls := 0
+	AnyMatch(q, func(v int) bool { calls++; return v == 2 })
+	if calls != 3 {
+		t.Errorf("AnyMatch() called pred %d times, want 3", calls)
+	}
+	calls = 0
+	AllMatch(q, func(v int) bool { calls++; return v < 5 })
+	if calls != 6 {
+		t.Errorf("AllMatch() called pred %d times, want 6", calls)
+	}
+}
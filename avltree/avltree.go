@@ -0,0 +1,361 @@
+// Package avltree provides an ordered multiset backed by an AVL tree with order statistics.
+package avltree
+
+import (
+	"cmp"
+	"iter"
+	"math"
+	"sync"
+
+	"github.com/dullkingsman/kozo/cmpx"
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/internal/arena"
+)
+
+// Tree is a thread-safe sorted multiset backed by an AVL tree augmented with subtree sizes,
+// so that besides membership it answers order statistics: the k-th smallest value (Select),
+// the number of values below a value (Rank), and percentiles (Quantile), over live data without sorting snapshots.
+//
+// Equal values share a node with a count. Insert, Delete, Select, Rank and Quantile are O(log n)
+// in the worst case, where n is the number of distinct values.
+type Tree[T any] struct {
+	mu    sync.RWMutex
+	root  *node[T]
+	less  func(T, T) bool
+	nodes *arena.Arena[node[T]] // nil unless created with NewWithArena
+}
+
+var _ collection.Collection[int] = (*Tree[int])(nil)
+
+type node[T any] struct {
+	value       T
+	count       int // occurrences of value
+	size        int // occurrences in the subtree
+	height      int
+	left, right *node[T]
+}
+
+// New returns a new empty Tree ordered by the given less function.
+func New[T any](less func(T, T) bool) *Tree[T] {
+	return &Tree[T]{less: less}
+}
+
+// NewFunc returns a new empty Tree ordered by the comparator c, e.g. cmpx.By(key).Then(cmpx.By(other)).
+// Values that c finds equivalent are equal.
+func NewFunc[T any](c cmpx.Comparator[T]) *Tree[T] {
+	return New(c.Less())
+}
+
+// NewWithArena returns a new empty Tree ordered by the given less function, allocating its nodes in chunks
+// of chunkSize. Deleted nodes are reused and Clear releases them all at once, which cuts allocations and
+// garbage collection work for trees of millions of short-lived values. It panics if chunkSize is less than 1.
+func NewWithArena[T any](less func(T, T) bool, chunkSize int) *Tree[T] {
+	return &Tree[T]{less: less, nodes: arena.New[node[T]](chunkSize)}
+}
+
+// NewOrdered returns a new empty Tree for cmp.Ordered values.
+func NewOrdered[T cmp.Ordered]() *Tree[T] {
+	return New(cmp.Less[T])
+}
+
+// Insert adds one occurrence of value.
+func (t *Tree[T]) Insert(value T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = t.insert(t.root, value)
+}
+
+// Delete removes one occurrence of value. It returns true if value was present.
+func (t *Tree[T]) Delete(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var deleted bool
+	t.root, deleted = t.delete(t.root, value)
+	return deleted
+}
+
+// Contains returns true if value is present.
+func (t *Tree[T]) Contains(value T) bool {
+	return t.Count(value) > 0
+}
+
+// Count returns the number of occurrences of value.
+func (t *Tree[T]) Count(value T) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	n := t.root
+	for n != nil {
+		switch {
+		case t.less(value, n.value):
+			n = n.left
+		case t.less(n.value, value):
+			n = n.right
+		default:
+			return n.count
+		}
+	}
+	return 0
+}
+
+// Select returns the k-th smallest value, counting from 0 and including duplicates,
+// so Select(0) is the minimum and Select(Len()-1) the maximum. Returns false if k is out of range.
+func (t *Tree[T]) Select(k int) (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.selectAt(k)
+}
+
+func (t *Tree[T]) selectAt(k int) (T, bool) {
+	if k < 0 || k >= size(t.root) {
+		var zero T
+		return zero, false
+	}
+	n := t.root
+	for {
+		left := size(n.left)
+		switch {
+		case k < left:
+			n = n.left
+		case k < left+n.count:
+			return n.value, true
+		default:
+			k -= left + n.count
+			n = n.right
+		}
+	}
+}
+
+// Rank returns the number of values less than value, which is the index Select returns
+// its first occurrence at if it is present.
+func (t *Tree[T]) Rank(value T) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	rank := 0
+	n := t.root
+	for n != nil {
+		switch {
+		case t.less(value, n.value):
+			n = n.left
+		case t.less(n.value, value):
+			rank += size(n.left) + n.count
+			n = n.right
+		default:
+			return rank + size(n.left)
+		}
+	}
+	return rank
+}
+
+// Quantile returns the value at quantile q in [0, 1] by the nearest-rank method,
+// e.g. Quantile(0.5) is the median and Quantile(0.99) the 99th percentile.
+// Returns false if the tree is empty or q is out of range.
+func (t *Tree[T]) Quantile(q float64) (T, bool) {
+	if !(q >= 0 && q <= 1) {
+		var zero T
+		return zero, false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	// The smallest value with at least q*n values at or below it.
+	return t.selectAt(max(0, int(math.Ceil(q*float64(size(t.root))))-1))
+}
+
+// Min returns the smallest value. Returns false if the tree is empty.
+func (t *Tree[T]) Min() (T, bool) {
+	return t.Select(0)
+}
+
+// Max returns the largest value. Returns false if the tree is empty.
+func (t *Tree[T]) Max() (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	n := t.root
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.value, true
+}
+
+// All returns an iterator over a snapshot of the values in ascending order, repeating duplicates.
+// The snapshot is taken when iteration starts, so the tree may be modified while iterating.
+func (t *Tree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range t.Values() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns the values in ascending order, repeating duplicates.
+func (t *Tree[T]) Values() []T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	values := make([]T, 0, size(t.root))
+	var walk func(n *node[T])
+	walk = func(n *node[T]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		for range n.count {
+			values = append(values, n.value)
+		}
+		walk(n.right)
+	}
+	walk(t.root)
+	return values
+}
+
+// Len returns the number of values, including duplicates.
+func (t *Tree[T]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return size(t.root)
+}
+
+// IsEmpty returns true if the tree has no values.
+func (t *Tree[T]) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Clear removes all values.
+func (t *Tree[T]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = nil
+	t.nodes.Reset()
+}
+
+// insert adds value to the subtree of n and returns its new, balanced root.
+func (t *Tree[T]) insert(n *node[T], value T) *node[T] {
+	switch {
+	case n == nil:
+		n = t.nodes.Alloc()
+		*n = node[T]{value: value, count: 1, size: 1, height: 1}
+		return n
+	case t.less(value, n.value):
+		n.left = t.insert(n.left, value)
+	case t.less(n.value, value):
+		n.right = t.insert(n.right, value)
+	default:
+		n.count++
+		n.size++
+		return n
+	}
+	return balance(n)
+}
+
+// delete removes one occurrence of value from the subtree of n and returns its new, balanced root.
+func (t *Tree[T]) delete(n *node[T], value T) (*node[T], bool) {
+	if n == nil {
+		return nil, false
+	}
+
+	var deleted bool
+	switch {
+	case t.less(value, n.value):
+		n.left, deleted = t.delete(n.left, value)
+	case t.less(n.value, value):
+		n.right, deleted = t.delete(n.right, value)
+	case n.count > 1:
+		n.count--
+		n.size--
+		return n, true
+	case n.left == nil:
+		right := n.right
+		t.nodes.Free(n)
+		return right, true
+	case n.right == nil:
+		left := n.left
+		t.nodes.Free(n)
+		return left, true
+	default:
+		// Replace n by its successor, the minimum of its right subtree.
+		var successor *node[T]
+		n.right, successor = removeMin(n.right)
+		successor.left, successor.right = n.left, n.right
+		t.nodes.Free(n)
+		return balance(successor), true
+	}
+	if !deleted {
+		return n, false
+	}
+	return balance(n), true
+}
+
+// removeMin detaches the node with the smallest value from the subtree of n,
+// returning the new root of the subtree and the detached node.
+func removeMin[T any](n *node[T]) (*node[T], *node[T]) {
+	if n.left == nil {
+		return n.right, n
+	}
+	var m *node[T]
+	n.left, m = removeMin(n.left)
+	return balance(n), m
+}
+
+// balance updates the height and size of n, whose subtrees are balanced and differ in height by at most 2,
+// and rotates it if they differ by 2. It returns the new root of the subtree.
+func balance[T any](n *node[T]) *node[T] {
+	update(n)
+	switch bf := height(n.left) - height(n.right); {
+	case bf > 1:
+		if height(n.left.left) < height(n.left.right) {
+			n.left = rotateLeft(n.left)
+		}
+		return rotateRight(n)
+	case bf < -1:
+		if height(n.right.right) < height(n.right.left) {
+			n.right = rotateRight(n.right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+func rotateLeft[T any](n *node[T]) *node[T] {
+	r := n.right
+	n.right, r.left = r.left, n
+	update(n)
+	update(r)
+	return r
+}
+
+func rotateRight[T any](n *node[T]) *node[T] {
+	l := n.left
+	n.left, l.right = l.right, n
+	update(n)
+	update(l)
+	return l
+}
+
+func update[T any](n *node[T]) {
+	n.height = 1 + max(height(n.left), height(n.right))
+	n.size = size(n.left) + n.count + size(n.right)
+}
+
+func height[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func size[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
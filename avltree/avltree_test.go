@@ -0,0 +1,194 @@
+package avltree
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/dullkingsman/kozo/cmpx"
+)
+
+// checkInvariants verifies the order, the AVL balance and the cached heights and sizes.
+func checkInvariants[T any](t *testing.T, tr *Tree[T]) {
+	t.Helper()
+
+	var check func(n *node[T], lower, upper *T) (int, int)
+	check = func(n *node[T], lower, upper *T) (int, int) {
+		if n == nil {
+			return 0, 0
+		}
+		if (lower != nil && !tr.less(*lower, n.value)) || (upper != nil && !tr.less(n.value, *upper)) {
+			t.Fatalf("Node %v is out of order", n.value)
+		}
+		if n.count < 1 {
+			t.Fatalf("Node %v has count %d", n.value, n.count)
+		}
+		lh, ls := check(n.left, lower, &n.value)
+		rh, rs := check(n.right, &n.value, upper)
+		if lh-rh > 1 || rh-lh > 1 {
+			t.Fatalf("Node %v is unbalanced: %d and %d", n.value, lh, rh)
+		}
+		h, s := 1+max(lh, rh), ls+n.count+rs
+		if n.height != h || n.size != s {
+			t.Fatalf("Node %v caches height %d and size %d, want %d and %d", n.value, n.height, n.size, h, s)
+		}
+		return h, s
+	}
+	check(tr.root, nil, nil)
+}
+
+func TestTree_OrderStatistics(t *testing.T) {
+	tr := NewOrdered[int]()
+	for _, v := range []int{50, 20, 80, 20, 70, 10, 90, 20} {
+		tr.Insert(v)
+	}
+	checkInvariants(t, tr)
+
+	sorted := []int{10, 20, 20, 20, 50, 70, 80, 90}
+	if values := tr.Values(); !slices.Equal(values, sorted) {
+		t.Errorf("Values() = %v, want %v", values, sorted)
+	}
+	for k, expected := range sorted {
+		if v, ok := tr.Select(k); !ok || v != expected {
+			t.Errorf("Select(%d) = %d, %v; want %d", k, v, ok, expected)
+		}
+	}
+	if _, ok := tr.Select(len(sorted)); ok {
+		t.Error("Expected Select to fail past the end")
+	}
+
+	ranks := map[int]int{5: 0, 10: 0, 20: 1, 30: 4, 50: 4, 90: 7, 100: 8}
+	for v, expected := range ranks {
+		if r := tr.Rank(v); r != expected {
+			t.Errorf("Rank(%d) = %d, want %d", v, r, expected)
+		}
+	}
+
+	quantiles := map[float64]int{0: 10, 0.25: 20, 0.5: 20, 0.51: 50, 0.9: 90, 1: 90}
+	for q, expected := range quantiles {
+		if v, ok := tr.Quantile(q); !ok || v != expected {
+			t.Errorf("Quantile(%v) = %d, %v; want %d", q, v, ok, expected)
+		}
+	}
+	if _, ok := tr.Quantile(1.5); ok {
+		t.Error("Expected Quantile to reject q > 1")
+	}
+
+	if tr.Count(20) != 3 || !tr.Contains(70) || tr.Contains(60) {
+		t.Error("Count or Contains disagree with the inserted values")
+	}
+	if !tr.Delete(20) || tr.Count(20) != 2 || tr.Delete(60) {
+		t.Error("Expected Delete to remove one occurrence")
+	}
+	if lo, _ := tr.Min(); lo != 10 {
+		t.Errorf("Min() = %d", lo)
+	}
+	if hi, _ := tr.Max(); hi != 90 {
+		t.Errorf("Max() = %d", hi)
+	}
+	checkInvariants(t, tr)
+
+	tr.Clear()
+	if !tr.IsEmpty() {
+		t.Error("Expected an empty tree")
+	}
+	if _, ok := tr.Quantile(0.5); ok {
+		t.Error("Expected no median of an empty tree")
+	}
+	if _, ok := tr.Max(); ok {
+		t.Error("Expected no maximum of an empty tree")
+	}
+}
+
+func TestTree_NewFunc(t *testing.T) {
+	tr := NewFunc(cmpx.Natural[int]().Reverse())
+	for _, v := range []int{20, 50, 10, 50} {
+		tr.Insert(v)
+	}
+	checkInvariants(t, tr)
+
+	if values := tr.Values(); !slices.Equal(values, []int{50, 50, 20, 10}) {
+		t.Errorf("Values() = %v, want [50 50 20 10]", values)
+	}
+	if v, ok := tr.Select(0); !ok || v != 50 {
+		t.Errorf("Select(0) = %d, %v; want 50", v, ok)
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	t.Run("Heap", func(t *testing.T) { testRandom(t, NewOrdered[int]()) })
+	t.Run("Arena", func(t *testing.T) { testRandom(t, NewWithArena(cmp.Less[int], 64)) })
+}
+
+func testRandom(t *testing.T, tr *Tree[int]) {
+	rng := rand.New(rand.NewSource(1))
+	var ref []int // sorted
+
+	for i := range 10000 {
+		v := rng.Intn(500)
+		pos, found := slices.BinarySearch(ref, v)
+		if rng.Intn(3) == 0 {
+			if tr.Delete(v) != found {
+				t.Fatalf("Delete(%d) disagrees with the reference", v)
+			}
+			if found {
+				ref = slices.Delete(ref, pos, pos+1)
+			}
+		} else {
+			tr.Insert(v)
+			ref = slices.Insert(ref, pos, v)
+		}
+		if i%100 == 0 {
+			checkInvariants(t, tr)
+		}
+
+		if k := rng.Intn(len(ref) + 1); k < len(ref) {
+			if got, _ := tr.Select(k); got != ref[k] {
+				t.Fatalf("Select(%d) = %d, want %d", k, got, ref[k])
+			}
+		}
+		probe := rng.Intn(500)
+		if r, _ := slices.BinarySearch(ref, probe); tr.Rank(probe) != r {
+			t.Fatalf("Rank(%d) = %d, want %d", probe, tr.Rank(probe), r)
+		}
+	}
+	checkInvariants(t, tr)
+
+	if !slices.Equal(tr.Values(), ref) || tr.Len() != len(ref) {
+		t.Fatal("Values() disagree with the reference")
+	}
+
+	tr.Clear()
+	tr.Insert(3)
+	tr.Insert(1)
+	checkInvariants(t, tr)
+	if !slices.Equal(tr.Values(), []int{1, 3}) {
+		t.Errorf("Values() = %v after Clear()", tr.Values())
+	}
+}
+
+func TestTree_Concurrency(t *testing.T) {
+	tr := NewOrdered[int]()
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				tr.Insert(g*1000 + i)
+				tr.Quantile(0.5)
+				if i%2 == 0 {
+					tr.Delete(g*1000 + i)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tr.Len() != 8*250 {
+		t.Errorf("Len() = %d, want %d", tr.Len(), 8*250)
+	}
+	checkInvariants(t, tr)
+}
@@ -0,0 +1,208 @@
+package bimap
+
+import (
+	"errors"
+	"iter"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+var (
+	// ErrKeyExists is returned by Insert under the Reject policy when the key is mapped to another value.
+	ErrKeyExists = errors.New("bimap: key already mapped to another value")
+	// ErrValueExists is returned by Insert under the Reject policy when the value is mapped to another key.
+	ErrValueExists = errors.New("bimap: value already mapped to another key")
+)
+
+// Policy decides what Insert does when the key or the value is already part of another pair.
+type Policy int
+
+const (
+	// Reject leaves the map unchanged and returns ErrKeyExists or ErrValueExists.
+	Reject Policy = iota
+	// Overwrite removes the pairs holding the key or the value, then inserts the new pair.
+	Overwrite
+)
+
+// BiMap is a thread-safe one-to-one map that can be looked up by key as well as by value,
+// e.g. an ID ↔ name table. All operations are O(1).
+type BiMap[K, V comparable] struct {
+	mu      sync.RWMutex
+	forward map[K]V
+	inverse map[V]K
+	policy  Policy
+}
+
+var _ collection.Map[int, int] = (*BiMap[int, int])(nil)
+
+// New creates an empty BiMap with the Reject policy.
+func New[K, V comparable]() *BiMap[K, V] {
+	return NewWithPolicy[K, V](Reject)
+}
+
+// NewWithPolicy creates an empty BiMap with the given collision policy.
+func NewWithPolicy[K, V comparable](policy Policy) *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: make(map[K]V),
+		inverse: make(map[V]K),
+		policy:  policy,
+	}
+}
+
+// Insert maps key to value and value to key. Inserting a pair that is already present is a no-op.
+// If the key or the value belongs to another pair, the policy of the map applies.
+func (m *BiMap[K, V]) Insert(key K, value V) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existingValue, keyExists := m.forward[key]
+	existingKey, valueExists := m.inverse[value]
+	if keyExists && valueExists && existingValue == value {
+		return nil
+	}
+
+	if m.policy == Reject {
+		if keyExists {
+			return ErrKeyExists
+		}
+		if valueExists {
+			return ErrValueExists
+		}
+	}
+
+	if keyExists {
+		delete(m.inverse, existingValue)
+	}
+	if valueExists {
+		delete(m.forward, existingKey)
+	}
+	m.forward[key] = value
+	m.inverse[value] = key
+	return nil
+}
+
+// GetByKey returns the value mapped to key. Returns (zero-value, false) if the key is not present.
+func (m *BiMap[K, V]) GetByKey(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	v, ok := m.forward[key]
+	return v, ok
+}
+
+// GetByValue returns the key mapped to value. Returns (zero-value, false) if the value is not present.
+func (m *BiMap[K, V]) GetByValue(value V) (K, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	k, ok := m.inverse[value]
+	return k, ok
+}
+
+// HasKey returns true if the key is present.
+func (m *BiMap[K, V]) HasKey(key K) bool {
+	_, ok := m.GetByKey(key)
+	return ok
+}
+
+// HasValue returns true if the value is present.
+func (m *BiMap[K, V]) HasValue(value V) bool {
+	_, ok := m.GetByValue(value)
+	return ok
+}
+
+// DeleteByKey removes the pair holding key and returns its value.
+// Returns (zero-value, false) if the key is not present.
+func (m *BiMap[K, V]) DeleteByKey(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	v, ok := m.forward[key]
+	if ok {
+		delete(m.forward, key)
+		delete(m.inverse, v)
+	}
+	return v, ok
+}
+
+// DeleteByValue removes the pair holding value and returns its key.
+// Returns (zero-value, false) if the value is not present.
+func (m *BiMap[K, V]) DeleteByValue(value V) (K, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k, ok := m.inverse[value]
+	if ok {
+		delete(m.inverse, value)
+		delete(m.forward, k)
+	}
+	return k, ok
+}
+
+// Len returns the number of pairs.
+func (m *BiMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.forward)
+}
+
+// IsEmpty returns true if the map has no pairs.
+func (m *BiMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Clear removes all pairs.
+func (m *BiMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.forward = make(map[K]V)
+	m.inverse = make(map[V]K)
+}
+
+// All returns an iterator over a snapshot of the pairs. The order is non-deterministic.
+func (m *BiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m.ToMap() {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// ToMap returns a copy of the key → value mapping.
+func (m *BiMap[K, V]) ToMap() map[K]V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := make(map[K]V, len(m.forward))
+	for k, v := range m.forward {
+		res[k] = v
+	}
+	return res
+}
+
+// Inverse returns a new BiMap with keys and values swapped and the same policy.
+func (m *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := NewWithPolicy[V, K](m.policy)
+	for k, v := range m.forward {
+		res.forward[v] = k
+		res.inverse[k] = v
+	}
+	return res
+}
+
+// Clone returns a new BiMap with the same pairs and policy.
+func (m *BiMap[K, V]) Clone() *BiMap[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := NewWithPolicy[K, V](m.policy)
+	for k, v := range m.forward {
+		res.forward[k] = v
+		res.inverse[v] = k
+	}
+	return res
+}
@@ -0,0 +1,134 @@
+package bimap
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestBiMap_Basic(t *testing.T) {
+	m := New[int, string]()
+	if err := m.Insert(1, "one"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Insert(2, "two"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Insert(1, "one"); err != nil {
+		t.Errorf("Expected re-inserting a pair to be a no-op, got %v", err)
+	}
+
+	if v, ok := m.GetByKey(1); !ok || v != "one" {
+		t.Errorf("GetByKey(1) = %q, %v", v, ok)
+	}
+	if k, ok := m.GetByValue("two"); !ok || k != 2 {
+		t.Errorf("GetByValue(two) = %d, %v", k, ok)
+	}
+	if m.HasKey(3) || m.HasValue("three") {
+		t.Error("Expected missing entries not to be found")
+	}
+
+	if v, ok := m.DeleteByKey(1); !ok || v != "one" || m.HasValue("one") {
+		t.Error("Expected DeleteByKey to remove both directions")
+	}
+	if k, ok := m.DeleteByValue("two"); !ok || k != 2 || m.HasKey(2) {
+		t.Error("Expected DeleteByValue to remove both directions")
+	}
+	if _, ok := m.DeleteByKey(1); ok {
+		t.Error("Expected a second delete to fail")
+	}
+	if !m.IsEmpty() {
+		t.Error("Expected the map to be empty")
+	}
+}
+
+func TestBiMap_Reject(t *testing.T) {
+	m := New[int, string]()
+	_ = m.Insert(1, "one")
+	_ = m.Insert(2, "two")
+
+	if err := m.Insert(1, "uno"); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("Insert(1, uno) = %v, want ErrKeyExists", err)
+	}
+	if err := m.Insert(3, "one"); !errors.Is(err, ErrValueExists) {
+		t.Errorf("Insert(3, one) = %v, want ErrValueExists", err)
+	}
+	if v, _ := m.GetByKey(1); v != "one" || m.Len() != 2 {
+		t.Error("Expected rejected inserts to leave the map unchanged")
+	}
+}
+
+func TestBiMap_Overwrite(t *testing.T) {
+	m := NewWithPolicy[int, string](Overwrite)
+	_ = m.Insert(1, "one")
+	_ = m.Insert(2, "two")
+
+	// Collides with both pairs: 1 → one and 2 → two are replaced by 1 → two.
+	if err := m.Insert(1, "two"); err != nil {
+		t.Fatal(err)
+	}
+	if m.Len() != 1 || m.HasKey(2) || m.HasValue("one") {
+		t.Errorf("Expected both colliding pairs to be removed, got %v", m.ToMap())
+	}
+	if k, _ := m.GetByValue("two"); k != 1 {
+		t.Errorf("GetByValue(two) = %d, want 1", k)
+	}
+}
+
+func TestBiMap_InverseClone(t *testing.T) {
+	m := New[int, string]()
+	_ = m.Insert(1, "one")
+	_ = m.Insert(2, "two")
+
+	inv := m.Inverse()
+	if k, _ := inv.GetByKey("two"); k != 2 {
+		t.Errorf("Inverse GetByKey(two) = %d", k)
+	}
+
+	c := m.Clone()
+	c.DeleteByKey(1)
+	if !m.HasKey(1) {
+		t.Error("Expected Clone to be independent")
+	}
+
+	count := 0
+	for k, v := range m.All() {
+		if got, _ := m.GetByKey(k); got != v {
+			t.Errorf("All() yielded %d → %q", k, v)
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("All() yielded %d pairs", count)
+	}
+
+	m.Clear()
+	if m.Len() != 0 || m.HasValue("one") {
+		t.Error("Expected Clear to empty both directions")
+	}
+}
+
+func TestBiMap_Concurrency(t *testing.T) {
+	m := NewWithPolicy[int, int](Overwrite)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				_ = m.Insert(i, g*1000+i)
+				m.GetByValue(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if m.Len() != 200 {
+		t.Errorf("Len() = %d, want 200", m.Len())
+	}
+	for k, v := range m.All() {
+		if got, _ := m.GetByValue(v); got != k {
+			t.Fatalf("Directions disagree for %d ↔ %d", k, v)
+		}
+	}
+}
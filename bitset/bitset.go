@@ -0,0 +1,345 @@
+// Package bitset provides a growable bit vector for compact sets of small non-negative integers.
+package bitset
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"sync"
+
+	"github.com/dullkingsman/kozo/codec"
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// ErrInvalidEncoding is returned when decoding data that does not encode a bitset,
+// such as binary data that is not a sequence of 64-bit words, or a negative index in JSON.
+var ErrInvalidEncoding = errors.New("bitset: invalid encoding")
+
+// BitSet is a thread-safe set of non-negative integers stored as a vector of bits, one bit per possible member.
+// It suits dense small IDs and flags: a million IDs take 125 KiB. It grows on demand to hold the highest bit set;
+// reading or clearing bits beyond it is valid and finds them unset.
+//
+// Set, Clear and Test are O(1); Count, NextSet and the operations between bitsets are O(n/64) for n bits.
+// Indexes must not be negative; BitSet panics on negative indexes like a slice.
+//
+// Clear removes a single bit and ClearAll every bit, so a BitSet is a collection.Iterable over its set bits
+// but not a collection.Container.
+type BitSet struct {
+	mu    sync.RWMutex
+	words []uint64
+}
+
+var (
+	_ codec.Snapshotter        = (*BitSet)(nil)
+	_ collection.Iterable[int] = (*BitSet)(nil)
+)
+
+// New returns a new empty BitSet holding the given bits.
+func New(indexes ...int) *BitSet {
+	b := &BitSet{}
+	for _, i := range indexes {
+		b.set(i)
+	}
+	return b
+}
+
+// NewWithCapacity returns a new empty BitSet with room for bits 0 to n-1 without growing.
+func NewWithCapacity(n int) *BitSet {
+	return &BitSet{words: make([]uint64, 0, (max(n, 0)+63)/64)}
+}
+
+// Set sets bit i, growing the bitset if needed.
+func (b *BitSet) Set(i int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.set(i)
+}
+
+// Clear clears bit i.
+func (b *BitSet) Clear(i int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if w := word(i); w < len(b.words) {
+		b.words[w] &^= mask(i)
+	}
+}
+
+// Flip inverts bit i, growing the bitset if needed.
+func (b *BitSet) Flip(i int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.grow(word(i))
+	b.words[word(i)] ^= mask(i)
+}
+
+// Test returns true if bit i is set.
+func (b *BitSet) Test(i int) bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.test(i)
+}
+
+// Count returns the number of set bits.
+func (b *BitSet) Count() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// IsEmpty returns true if no bit is set.
+func (b *BitSet) IsEmpty() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return !slices.ContainsFunc(b.words, func(w uint64) bool { return w != 0 })
+}
+
+// NextSet returns the lowest set bit at or after i. Returns (0, false) if there is none.
+//
+//	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) { ... }
+func (b *BitSet) NextSet(i int) (int, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.nextSet(i)
+}
+
+// All returns an iterator over the set bits in increasing order. Bits are read as they are reached,
+// without holding the lock while yielding, so the bitset may be modified during the iteration.
+func (b *BitSet) All() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice returns the set bits in increasing order as a new slice.
+func (b *BitSet) ToSlice() []int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	var out []int
+	for i, ok := b.nextSet(0); ok; i, ok = b.nextSet(i + 1) {
+		out = append(out, i)
+	}
+	return out
+}
+
+// And returns a new bitset holding the bits set in both bitsets.
+func (b *BitSet) And(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x & y })
+}
+
+// Or returns a new bitset holding the bits set in either bitset.
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x | y })
+}
+
+// Xor returns a new bitset holding the bits set in exactly one of the bitsets.
+func (b *BitSet) Xor(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// AndNot returns a new bitset holding the bits set in b but not in other.
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	return b.combine(other, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// Equal returns true if both bitsets have the same bits set, regardless of their capacities.
+func (b *BitSet) Equal(other *BitSet) bool {
+	if b == other {
+		return true
+	}
+	b.mu.RLock()
+	other.mu.RLock()
+	defer b.mu.RUnlock()
+	defer other.mu.RUnlock()
+	return slices.Equal(trim(b.words), trim(other.words))
+}
+
+// ClearAll clears every bit.
+func (b *BitSet) ClearAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clear(b.words)
+}
+
+// Clone returns a copy of the bitset.
+func (b *BitSet) Clone() *BitSet {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return &BitSet{words: slices.Clone(trim(b.words))}
+}
+
+// MarshalBinary encodes the bitset as big-endian 64-bit words, bit 0 being the lowest bit of the first word.
+// Trailing zero words are omitted, so equal bitsets have equal encodings.
+func (b *BitSet) MarshalBinary() ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	words := trim(b.words)
+	data := make([]byte, 8*len(words))
+	for i, w := range words {
+		binary.BigEndian.PutUint64(data[8*i:], w)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary replaces the contents of the bitset with the encoding of MarshalBinary.
+// It returns ErrInvalidEncoding if the length of data is not a multiple of 8.
+func (b *BitSet) UnmarshalBinary(data []byte) error {
+	if len(data)%8 != 0 {
+		return ErrInvalidEncoding
+	}
+	words := make([]uint64, len(data)/8)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint64(data[8*i:])
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.words = words
+	return nil
+}
+
+// MarshalJSON encodes the bitset as a JSON array of its set bits in increasing order, e.g. [1,5,64].
+func (b *BitSet) MarshalJSON() ([]byte, error) {
+	indexes := b.ToSlice()
+	if indexes == nil {
+		indexes = []int{}
+	}
+	return json.Marshal(indexes)
+}
+
+// UnmarshalJSON replaces the contents of the bitset with the bits of a JSON array.
+// It returns ErrInvalidEncoding if an index is negative.
+func (b *BitSet) UnmarshalJSON(data []byte) error {
+	var indexes []int
+	if err := json.Unmarshal(data, &indexes); err != nil {
+		return err
+	}
+
+	decoded := &BitSet{}
+	for _, i := range indexes {
+		if i < 0 {
+			return ErrInvalidEncoding
+		}
+		decoded.set(i)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.words = decoded.words
+	return nil
+}
+
+// Snapshot encodes the bitset as its binary encoding in a snapshot envelope of kind "bitset";
+// see codec.Snapshotter.
+func (b *BitSet) Snapshot() ([]byte, error) {
+	payload, err := b.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return codec.EncodeSnapshot("bitset", payload), nil
+}
+
+// Restore replaces the contents of the bitset with those of a snapshot made by Snapshot.
+func (b *BitSet) Restore(data []byte) error {
+	payload, err := codec.DecodeSnapshot("bitset", data)
+	if err != nil {
+		return err
+	}
+	if err := b.UnmarshalBinary(payload); err != nil {
+		return fmt.Errorf("%w: %v", codec.ErrInvalidSnapshot, err)
+	}
+	return nil
+}
+
+func (b *BitSet) combine(other *BitSet, op func(x, y uint64) uint64) *BitSet {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if other != b {
+		other.mu.RLock()
+		defer other.mu.RUnlock()
+	}
+
+	words := make([]uint64, max(len(b.words), len(other.words)))
+	for i := range words {
+		var x, y uint64
+		if i < len(b.words) {
+			x = b.words[i]
+		}
+		if i < len(other.words) {
+			y = other.words[i]
+		}
+		words[i] = op(x, y)
+	}
+	return &BitSet{words: trim(words)}
+}
+
+func (b *BitSet) set(i int) {
+	b.grow(word(i))
+	b.words[word(i)] |= mask(i)
+}
+
+func (b *BitSet) test(i int) bool {
+	w := word(i)
+	return w < len(b.words) && b.words[w]&mask(i) != 0
+}
+
+func (b *BitSet) nextSet(i int) (int, bool) {
+	w := word(i)
+	if w >= len(b.words) {
+		return 0, false
+	}
+	// Discard the bits below i in its word, then scan whole words.
+	if rest := b.words[w] >> (i % 64); rest != 0 {
+		return i + bits.TrailingZeros64(rest), true
+	}
+	for w++; w < len(b.words); w++ {
+		if b.words[w] != 0 {
+			return w*64 + bits.TrailingZeros64(b.words[w]), true
+		}
+	}
+	return 0, false
+}
+
+// grow extends the words to include word w.
+func (b *BitSet) grow(w int) {
+	if w >= len(b.words) {
+		b.words = append(b.words, make([]uint64, w+1-len(b.words))...)
+	}
+}
+
+// word returns the position of the word holding bit i, panicking if i is negative.
+func word(i int) int {
+	if i < 0 {
+		panic("bitset: negative index")
+	}
+	return i / 64
+}
+
+func mask(i int) uint64 {
+	return 1 << (i % 64)
+}
+
+// trim returns words without its trailing zero words.
+func trim(words []uint64) []uint64 {
+	n := len(words)
+	for n > 0 && words[n-1] == 0 {
+		n--
+	}
+	return words[:n]
+}
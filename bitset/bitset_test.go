@@ -0,0 +1,223 @@
+package bitset
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/dullkingsman/kozo/codec"
+)
+
+func TestBitSet_Basic(t *testing.T) {
+	b := New(1, 5, 64)
+	if !b.Test(1) || !b.Test(64) || b.Test(2) || b.Test(1000) {
+		t.Error("Unexpected bits")
+	}
+	if b.Count() != 3 || b.IsEmpty() {
+		t.Errorf("Count() = %d", b.Count())
+	}
+
+	b.Set(200)
+	b.Clear(5)
+	b.Clear(10_000) // beyond the end
+	b.Flip(1)
+	b.Flip(2)
+	if got := b.ToSlice(); !slices.Equal(got, []int{2, 64, 200}) {
+		t.Errorf("ToSlice() = %v", got)
+	}
+	if got := slices.Collect(b.All()); !slices.Equal(got, []int{2, 64, 200}) {
+		t.Errorf("All() = %v", got)
+	}
+
+	tests := []struct {
+		from, next int
+		ok         bool
+	}{
+		{0, 2, true}, {2, 2, true}, {3, 64, true}, {65, 200, true}, {201, 0, false}, {5000, 0, false},
+	}
+	for _, tt := range tests {
+		if i, ok := b.NextSet(tt.from); i != tt.next || ok != tt.ok {
+			t.Errorf("NextSet(%d) = %d, %v", tt.from, i, ok)
+		}
+	}
+
+	c := b.Clone()
+	b.ClearAll()
+	if !b.IsEmpty() || b.Count() != 0 || c.Count() != 3 {
+		t.Error("Expected ClearAll to clear only the original")
+	}
+	if !b.Equal(New()) || b.Equal(c) {
+		t.Error("Unexpected equality")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a negative index to panic")
+		}
+	}()
+	b.Set(-1)
+}
+
+func TestBitSet_Operations(t *testing.T) {
+	a := New(1, 2, 3, 100)
+	b := New(2, 3, 4)
+
+	tests := []struct {
+		name string
+		got  *BitSet
+		want []int
+	}{
+		{"And", a.And(b), []int{2, 3}},
+		{"Or", a.Or(b), []int{1, 2, 3, 4, 100}},
+		{"Xor", a.Xor(b), []int{1, 4, 100}},
+		{"AndNot", a.AndNot(b), []int{1, 100}},
+		{"AndNot reversed", b.AndNot(a), []int{4}},
+		{"Self", a.And(a), []int{1, 2, 3, 100}},
+	}
+	for _, tt := range tests {
+		if got := tt.got.ToSlice(); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if got := a.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 100}) {
+		t.Errorf("Expected operations not to modify their operands, got %v", got)
+	}
+
+	// Capacity does not affect equality.
+	c := NewWithCapacity(1000)
+	c.Set(200)
+	c.Clear(200)
+	if !c.Equal(New()) {
+		t.Error("Expected empty bitsets of different capacities to be equal")
+	}
+}
+
+func TestBitSet_Serialization(t *testing.T) {
+	b := New(0, 63, 64, 130)
+	b.Set(1000)
+	b.Clear(1000) // leaves trailing zero words
+
+	data, err := b.MarshalBinary()
+	if err != nil || len(data) != 24 {
+		t.Fatalf("MarshalBinary() = %d bytes, %v", len(data), err)
+	}
+	decoded := New(7)
+	if err := decoded.UnmarshalBinary(data); err != nil || !decoded.Equal(b) {
+		t.Errorf("UnmarshalBinary() = %v, %v", decoded.ToSlice(), err)
+	}
+	if err := decoded.UnmarshalBinary(data[:5]); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+	}
+
+	js, err := json.Marshal(b)
+	if err != nil || string(js) != "[0,63,64,130]" {
+		t.Errorf("MarshalJSON() = %s, %v", js, err)
+	}
+	if js, _ := json.Marshal(New()); string(js) != "[]" {
+		t.Errorf("MarshalJSON() of an empty bitset = %s", js)
+	}
+
+	var fromJSON BitSet
+	if err := json.Unmarshal([]byte("[5,3,70]"), &fromJSON); err != nil || !slices.Equal(fromJSON.ToSlice(), []int{3, 5, 70}) {
+		t.Errorf("UnmarshalJSON() = %v, %v", fromJSON.ToSlice(), err)
+	}
+	if err := json.Unmarshal([]byte("[-1]"), &fromJSON); !errors.Is(err, ErrInvalidEncoding) {
+		t.Errorf("Expected ErrInvalidEncoding, got %v", err)
+	}
+}
+
+func TestBitSet_Snapshot(t *testing.T) {
+	b := New(0, 63, 64, 130)
+	data, err := b.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	restored := New(7)
+	if err := restored.Restore(data); err != nil || !restored.Equal(b) {
+		t.Errorf("Restore() = %v, %v", restored.ToSlice(), err)
+	}
+	if err := restored.Restore(data[:len(data)-1]); !errors.Is(err, codec.ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+	}
+	if err := restored.Restore(codec.EncodeSnapshot("bitset", []byte{1, 2, 3})); !errors.Is(err, codec.ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot for a bad payload, got %v", err)
+	}
+	if !restored.Equal(b) {
+		t.Errorf("Expected a failed Restore to keep the bitset, got %v", restored.ToSlice())
+	}
+}
+
+func TestBitSet_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	b := New()
+	ref := map[int]bool{}
+
+	for range 20000 {
+		i := rng.Intn(1000)
+		switch rng.Intn(3) {
+		case 0:
+			b.Set(i)
+			ref[i] = true
+		case 1:
+			b.Clear(i)
+			delete(ref, i)
+		default:
+			b.Flip(i)
+			if ref[i] {
+				delete(ref, i)
+			} else {
+				ref[i] = true
+			}
+		}
+	}
+
+	var want []int
+	for i := range 1000 {
+		if ref[i] {
+			want = append(want, i)
+		}
+		if b.Test(i) != ref[i] {
+			t.Fatalf("Test(%d) disagrees with the reference", i)
+		}
+	}
+	if got := b.ToSlice(); !slices.Equal(got, want) || b.Count() != len(want) {
+		t.Fatalf("ToSlice() has %d bits, want %d", len(got), len(want))
+	}
+
+	other := New()
+	for range 300 {
+		other.Set(rng.Intn(1200))
+	}
+	for i := range 1200 {
+		x, y := b.Test(i), other.Test(i)
+		if b.And(other).Test(i) != (x && y) || b.Or(other).Test(i) != (x || y) ||
+			b.Xor(other).Test(i) != (x != y) || b.AndNot(other).Test(i) != (x && !y) {
+			t.Fatalf("Operations disagree at bit %d", i)
+		}
+	}
+}
+
+func TestBitSet_Concurrency(t *testing.T) {
+	b := New()
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				b.Set(i*8 + g)
+				b.Test(i * 8)
+				b.Count()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if b.Count() != 8000 {
+		t.Errorf("Count() = %d, want 8000", b.Count())
+	}
+}
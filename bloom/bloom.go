@@ -0,0 +1,103 @@
+// Package bloom provides Bloom filters, probabilistic sets that answer membership queries
+// with no false negatives and a tunable rate of false positives, in a fraction of the memory of a set.
+package bloom
+
+import (
+	"hash/maphash"
+	"iter"
+	"math"
+	"sync"
+)
+
+// Filter is a thread-safe Bloom filter. Items cannot be removed; see CountingFilter for that.
+// Filters do not store their items, so they have no Len and are not collection.Container implementations.
+//
+// Items are hashed with hash/maphash using seeds chosen at construction, so a filter
+// is only meaningful within the process that built it.
+type Filter[T comparable] struct {
+	mu     sync.RWMutex
+	bits   []uint64
+	hasher hasher[T]
+}
+
+// New returns a new empty Filter sized to hold expected items with the given false positive rate,
+// e.g. New[string](1_000_000, 0.01). It panics if expected is less than 1 or the rate is not in (0, 1).
+func New[T comparable](expected int, falsePositiveRate float64) *Filter[T] {
+	m, k := optimal(expected, falsePositiveRate)
+	return &Filter[T]{bits: make([]uint64, (m+63)/64), hasher: newHasher[T](m, k)}
+}
+
+// Add adds item to the filter.
+func (f *Filter[T]) Add(item T) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.hasher.indexes(item) {
+		f.bits[i/64] |= 1 << (i % 64)
+	}
+}
+
+// MayContain returns false if item was definitely not added, and true if it probably was.
+func (f *Filter[T]) MayContain(item T) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	for i := range f.hasher.indexes(item) {
+		if f.bits[i/64]&(1<<(i%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear removes all items.
+func (f *Filter[T]) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	clear(f.bits)
+}
+
+// Size returns the number of bits of the filter.
+func (f *Filter[T]) Size() uint64 {
+	return f.hasher.m
+}
+
+// Hashes returns the number of bits set per item.
+func (f *Filter[T]) Hashes() int {
+	return f.hasher.k
+}
+
+// hasher maps an item to k indexes in [0, m) by double hashing: index i is h1 + i*h2 mod m.
+type hasher[T comparable] struct {
+	m      uint64
+	k      int
+	s1, s2 maphash.Seed
+}
+
+func newHasher[T comparable](m uint64, k int) hasher[T] {
+	return hasher[T]{m: m, k: k, s1: maphash.MakeSeed(), s2: maphash.MakeSeed()}
+}
+
+func (h hasher[T]) indexes(item T) iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		h1 := maphash.Comparable(h.s1, item)
+		h2 := maphash.Comparable(h.s2, item) | 1 // odd, so the indexes do not collapse when m is even
+		for i := range uint64(h.k) {
+			if !yield((h1 + i*h2) % h.m) {
+				return
+			}
+		}
+	}
+}
+
+// optimal returns the number of bits m and of hashes k minimizing memory for n items at false positive rate p:
+// m = -n ln p / (ln 2)², k = m/n ln 2.
+func optimal(n int, p float64) (uint64, int) {
+	if n < 1 {
+		panic("bloom: expected number of items must be at least 1")
+	}
+	if !(p > 0 && p < 1) {
+		panic("bloom: false positive rate must be between 0 and 1")
+	}
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := max(1, int(math.Round(m/float64(n)*math.Ln2)))
+	return uint64(m), k
+}
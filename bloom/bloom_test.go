@@ -0,0 +1,56 @@
+package bloom
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestOptimal(t *testing.T) {
+	m, k := optimal(1000, 0.01)
+	if m != 9586 || k != 7 {
+		t.Errorf("optimal(1000, 0.01) = %d, %d; want 9586, 7", m, k)
+	}
+
+	for _, tt := range []struct {
+		n int
+		p float64
+	}{{0, 0.01}, {10, 0}, {10, 1}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected optimal(%d, %v) to panic", tt.n, tt.p)
+				}
+			}()
+			optimal(tt.n, tt.p)
+		}()
+	}
+}
+
+func TestFilter(t *testing.T) {
+	const n = 10000
+	f := New[string](n, 0.01)
+	for i := range n {
+		f.Add(fmt.Sprint("member-", i))
+	}
+
+	for i := range n {
+		if !f.MayContain(fmt.Sprint("member-", i)) {
+			t.Fatalf("False negative for member-%d", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := range n {
+		if f.MayContain(fmt.Sprint("other-", i)) {
+			falsePositives++
+		}
+	}
+	if rate := float64(falsePositives) / n; rate > 0.02 {
+		t.Errorf("False positive rate %.4f exceeds twice the target", rate)
+	}
+
+	f.Clear()
+	if f.MayContain("member-0") {
+		t.Error("Expected an empty filter after Clear")
+	}
+}
@@ -0,0 +1,88 @@
+package bloom
+
+import (
+	"math"
+	"sync"
+)
+
+// CountingFilter is a thread-safe Bloom filter that supports removal, for sets whose membership
+// changes over time, such as the keys of a cache, which a plain Filter could only follow by being rebuilt.
+//
+// Each bit is replaced by an 8-bit counter, so it takes 8 times the memory of a Filter with the same error rate.
+// A counter that reaches its maximum sticks there and is never decremented, trading a slightly higher
+// false positive rate for never introducing false negatives.
+//
+// Only remove items that were added: removing others decrements counters shared with added items,
+// which can cause false negatives.
+type CountingFilter[T comparable] struct {
+	mu       sync.RWMutex
+	counters []uint8
+	hasher   hasher[T]
+}
+
+// NewCounting returns a new empty CountingFilter sized to hold expected items with the given false positive rate.
+// It panics if expected is less than 1 or the rate is not in (0, 1).
+func NewCounting[T comparable](expected int, falsePositiveRate float64) *CountingFilter[T] {
+	m, k := optimal(expected, falsePositiveRate)
+	return &CountingFilter[T]{counters: make([]uint8, m), hasher: newHasher[T](m, k)}
+}
+
+// Add adds item to the filter. Adding an item several times requires removing it as many times.
+func (f *CountingFilter[T]) Add(item T) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := range f.hasher.indexes(item) {
+		if f.counters[i] < math.MaxUint8 {
+			f.counters[i]++
+		}
+	}
+}
+
+// Remove removes one occurrence of item. It returns false, leaving the filter unchanged,
+// if item was definitely not added.
+func (f *CountingFilter[T]) Remove(item T) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.mayContain(item) {
+		return false
+	}
+	for i := range f.hasher.indexes(item) {
+		if f.counters[i] < math.MaxUint8 {
+			f.counters[i]--
+		}
+	}
+	return true
+}
+
+// MayContain returns false if item was definitely not added (or was removed), and true if it probably was.
+func (f *CountingFilter[T]) MayContain(item T) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.mayContain(item)
+}
+
+func (f *CountingFilter[T]) mayContain(item T) bool {
+	for i := range f.hasher.indexes(item) {
+		if f.counters[i] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Clear removes all items.
+func (f *CountingFilter[T]) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	clear(f.counters)
+}
+
+// Size returns the number of counters of the filter.
+func (f *CountingFilter[T]) Size() uint64 {
+	return f.hasher.m
+}
+
+// Hashes returns the number of counters incremented per item.
+func (f *CountingFilter[T]) Hashes() int {
+	return f.hasher.k
+}
@@ -0,0 +1,61 @@
+package bloom
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCountingFilter(t *testing.T) {
+	const n = 5000
+	f := NewCounting[int](n, 0.01)
+	for i := range n {
+		f.Add(i)
+	}
+	f.Add(0) // added twice
+
+	for i := 1; i < n; i += 2 {
+		if !f.Remove(i) {
+			t.Fatalf("Expected %d to be removable", i)
+		}
+	}
+	for i := 0; i < n; i += 2 {
+		if !f.MayContain(i) {
+			t.Fatalf("False negative for %d after removing others", i)
+		}
+	}
+
+	remaining := 0
+	for i := 1; i < n; i += 2 {
+		if f.MayContain(i) {
+			remaining++
+		}
+	}
+	if rate := float64(remaining) / (n / 2); rate > 0.02 {
+		t.Errorf("Removed items still match at rate %.4f", rate)
+	}
+
+	if !f.Remove(0) || !f.MayContain(0) {
+		t.Error("Expected an item added twice to survive one removal")
+	}
+	if !f.Remove(0) {
+		t.Error("Expected an item added twice to be removable twice")
+	}
+
+	f.Clear()
+	if f.Remove(2) || f.MayContain(2) {
+		t.Error("Expected an empty filter after Clear")
+	}
+}
+
+func TestCountingFilterSaturation(t *testing.T) {
+	f := NewCounting[string](10, 0.1)
+	for range math.MaxUint8 + 10 {
+		f.Add("hot")
+	}
+	for range math.MaxUint8 + 10 {
+		f.Remove("hot")
+	}
+	if !f.MayContain("hot") {
+		t.Error("Expected saturated counters never to be decremented")
+	}
+}
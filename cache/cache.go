@@ -0,0 +1,30 @@
+// Package cache provides thread-safe, generic in-memory caches with different expiry and eviction policies,
+// all implementing Cache so that callers can swap policies by changing the constructor.
+package cache
+
+import "github.com/dullkingsman/kozo/collection"
+
+// Cache is the interface shared by every cache of this package.
+type Cache[K comparable, V any] interface {
+	// Get returns the value of key if it is cached.
+	Get(key K) (V, bool)
+	// Set caches value under key, possibly evicting another entry.
+	Set(key K, value V)
+	// Delete removes key, returning true if it was cached.
+	Delete(key K) bool
+	// Len returns the number of cached entries.
+	Len() int
+	// Clear removes all entries.
+	Clear()
+}
+
+var (
+	_ Cache[int, int] = (*TTLCache[int, int])(nil)
+	_ Cache[int, int] = (*LRUCache[int, int])(nil)
+	_ Cache[int, int] = (*LFUCache[int, int])(nil)
+
+	_ collection.Container = (*TTLCache[int, int])(nil)
+	_ collection.Container = (*LRUCache[int, int])(nil)
+	_ collection.Container = (*LFUCache[int, int])(nil)
+	_ collection.Container = (*WeakMap[int, int])(nil)
+)
@@ -0,0 +1,199 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/dullkingsman/kozo/list"
+)
+
+// LFUCache is a thread-safe cache holding at most a fixed number of entries,
+// evicting the least frequently used entry when full. Ties are broken by recency,
+// evicting the least recently used of the least frequently used entries.
+//
+// It suits workloads where frequency matters more than recency, e.g. a few hot keys
+// that must survive a scan over many cold ones. Get and Set are O(1).
+type LFUCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[K]*list.Element[*lfuEntry[K, V]]
+	freqs    map[int]*list.UnsyncList[*lfuEntry[K, V]] // use count → entries with that count, front is most recently used
+	minFreq  int
+	onEvict  func(K, V)
+}
+
+type lfuEntry[K comparable, V any] struct {
+	key   K
+	value V
+	freq  int
+}
+
+// NewLFU returns a new empty LFUCache holding at most capacity entries. It panics if capacity is less than 1.
+func NewLFU[K comparable, V any](capacity int) *LFUCache[K, V] {
+	if capacity < 1 {
+		panic("cache: capacity must be at least 1")
+	}
+	return &LFUCache[K, V]{
+		capacity: capacity,
+		entries:  make(map[K]*list.Element[*lfuEntry[K, V]], capacity),
+		freqs:    make(map[int]*list.UnsyncList[*lfuEntry[K, V]]),
+	}
+}
+
+// SetOnEvict registers a callback invoked with every entry evicted to make room for another.
+// Deleted, overwritten and cleared entries are not reported.
+// The callback runs without the cache lock held, so it may use the cache.
+func (c *LFUCache[K, V]) SetOnEvict(fn func(key K, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = fn
+}
+
+// Get returns the value of key if it is cached, counting it as a use.
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.touch(e)
+	return e.Value.value, true
+}
+
+// Peek returns the value of key if it is cached, without counting it as a use.
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// Frequency returns the number of uses of key, counting the Set that added it, or 0 if it is not cached.
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.entries[key]; ok {
+		return e.Value.freq
+	}
+	return 0
+}
+
+// Set caches value under key, counting it as a use. If the cache is full, the least frequently used entry
+// is evicted to make room for a new key.
+func (c *LFUCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.value = value
+		c.touch(e)
+		c.mu.Unlock()
+		return
+	}
+
+	var evicted *lfuEntry[K, V]
+	if len(c.entries) >= c.capacity {
+		evicted = c.remove(c.freqs[c.minFreq].Back())
+	}
+	c.entries[key] = c.bucket(1).PushFront(&lfuEntry[K, V]{key: key, value: value, freq: 1})
+	c.minFreq = 1
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if evicted != nil && onEvict != nil {
+		onEvict(evicted.key, evicted.value)
+	}
+}
+
+// Delete removes key, returning true if it was cached.
+func (c *LFUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return false
+	}
+	c.remove(e)
+	if _, ok := c.freqs[c.minFreq]; !ok {
+		// The deleted entry may have been the only one with the lowest count, and the next count may have no entries.
+		c.minFreq = 0
+		for freq := range c.freqs {
+			if c.minFreq == 0 || freq < c.minFreq {
+				c.minFreq = freq
+			}
+		}
+	}
+	return true
+}
+
+// Len returns the number of cached entries.
+func (c *LFUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// IsEmpty returns true if no entries are cached.
+func (c *LFUCache[K, V]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+// Cap returns the maximum number of entries.
+func (c *LFUCache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Clear removes all entries without reporting them as evicted.
+func (c *LFUCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[K]*list.Element[*lfuEntry[K, V]], c.capacity)
+	c.freqs = make(map[int]*list.UnsyncList[*lfuEntry[K, V]])
+	c.minFreq = 0
+}
+
+// touch moves an entry to the bucket of its next use count.
+func (c *LFUCache[K, V]) touch(e *list.Element[*lfuEntry[K, V]]) {
+	entry := e.Value
+	c.unlink(e)
+	entry.freq++
+	c.entries[entry.key] = c.bucket(entry.freq).PushFront(entry)
+}
+
+// remove deletes an entry from the cache and returns it.
+func (c *LFUCache[K, V]) remove(e *list.Element[*lfuEntry[K, V]]) *lfuEntry[K, V] {
+	entry := c.unlink(e)
+	delete(c.entries, entry.key)
+	return entry
+}
+
+// unlink removes an entry from its bucket, dropping the bucket and advancing minFreq if it becomes empty.
+// Advancing by one is correct when the entry moves to the next bucket; Delete corrects it otherwise.
+func (c *LFUCache[K, V]) unlink(e *list.Element[*lfuEntry[K, V]]) *lfuEntry[K, V] {
+	entry := e.Value
+	b := c.freqs[entry.freq]
+	b.Remove(e)
+	if b.Len() == 0 {
+		delete(c.freqs, entry.freq)
+		if c.minFreq == entry.freq {
+			c.minFreq++
+		}
+	}
+	return entry
+}
+
+func (c *LFUCache[K, V]) bucket(freq int) *list.UnsyncList[*lfuEntry[K, V]] {
+	b, ok := c.freqs[freq]
+	if !ok {
+		b = list.NewUnsync[*lfuEntry[K, V]]()
+		c.freqs[freq] = b
+	}
+	return b
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestLFUCacheEviction(t *testing.T) {
+	c := NewLFU[string, int](2)
+	var evicted []string
+	c.SetOnEvict(func(k string, v int) { evicted = append(evicted, k) })
+
+	c.Set("hot", 1)
+	c.Get("hot")
+	c.Get("hot")
+	for i, k := range []string{"x", "y", "z"} {
+		c.Set(k, i)
+	}
+
+	if v, ok := c.Get("hot"); !ok || v != 1 {
+		t.Errorf("Expected the hot key to survive a scan, got %v (ok: %v)", v, ok)
+	}
+	if len(evicted) != 2 || evicted[0] != "x" || evicted[1] != "y" {
+		t.Errorf("Expected eviction of x and y, got %v", evicted)
+	}
+	if f := c.Frequency("hot"); f != 4 {
+		t.Errorf("Expected frequency 4, got %d", f)
+	}
+
+	c.Set("hot", 2)
+	if v, _ := c.Peek("hot"); v != 2 || c.Frequency("hot") != 5 {
+		t.Errorf("Expected an updated value counted as a use, got %d with frequency %d", v, c.Frequency("hot"))
+	}
+}
+
+func TestLFUCacheDeleteLowest(t *testing.T) {
+	c := NewLFU[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	for range 3 {
+		c.Get("b")
+	}
+
+	// Deleting the only entry with the lowest count leaves no entry with the next count.
+	c.Delete("a")
+	c.Set("c", 3)
+	c.Get("c")
+	c.Set("d", 4)
+
+	if _, ok := c.Peek("c"); ok {
+		t.Error("Expected c to be evicted")
+	}
+	if c.Len() != 2 || c.Frequency("b") != 4 || c.Frequency("d") != 1 {
+		t.Errorf("Unexpected state: length %d, b %d, d %d", c.Len(), c.Frequency("b"), c.Frequency("d"))
+	}
+}
+
+// TestLFUCacheRandom compares the cache against a naive model.
+func TestLFUCacheRandom(t *testing.T) {
+	type modelEntry struct{ value, freq, used int }
+
+	const capacity = 8
+	c := NewLFU[int, int](capacity)
+	model := map[int]*modelEntry{}
+	clock := 0
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for range 10000 {
+		clock++
+		k := r.IntN(20)
+		switch r.IntN(3) {
+		case 0:
+			v, ok := c.Get(k)
+			m, exists := model[k]
+			if ok != exists || (ok && v != m.value) {
+				t.Fatalf("Get(%d) = %d, %v; want %v", k, v, ok, m)
+			}
+			if exists {
+				m.freq++
+				m.used = clock
+			}
+		case 1:
+			c.Set(k, clock)
+			if m, exists := model[k]; exists {
+				m.value, m.freq, m.used = clock, m.freq+1, clock
+				continue
+			}
+			if len(model) >= capacity {
+				victim := -1
+				for mk, m := range model {
+					if victim < 0 || m.freq < model[victim].freq || (m.freq == model[victim].freq && m.used < model[victim].used) {
+						victim = mk
+					}
+				}
+				delete(model, victim)
+			}
+			model[k] = &modelEntry{value: clock, freq: 1, used: clock}
+		case 2:
+			_, exists := model[k]
+			if c.Delete(k) != exists {
+				t.Fatalf("Delete(%d) != %v", k, exists)
+			}
+			delete(model, k)
+		}
+		if c.Len() != len(model) {
+			t.Fatalf("Len() = %d, want %d", c.Len(), len(model))
+		}
+	}
+}
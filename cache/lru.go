@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/dullkingsman/kozo/list"
+)
+
+// LRUCache is a thread-safe cache holding at most a fixed number of entries,
+// evicting the least recently used entry when full.
+type LRUCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[K]*list.Element[*lruEntry[K, V]]
+	order    *list.UnsyncList[*lruEntry[K, V]] // front is most recently used
+	onEvict  func(K, V)
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// NewLRU returns a new empty LRUCache holding at most capacity entries. It panics if capacity is less than 1.
+func NewLRU[K comparable, V any](capacity int) *LRUCache[K, V] {
+	if capacity < 1 {
+		panic("cache: capacity must be at least 1")
+	}
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		entries:  make(map[K]*list.Element[*lruEntry[K, V]], capacity),
+		order:    list.NewUnsync[*lruEntry[K, V]](),
+	}
+}
+
+// SetOnEvict registers a callback invoked with every entry evicted to make room for another.
+// Deleted, overwritten and cleared entries are not reported.
+// The callback runs without the cache lock held, so it may use the cache.
+func (c *LRUCache[K, V]) SetOnEvict(fn func(key K, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = fn
+}
+
+// Get returns the value of key if it is cached, marking it as most recently used.
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.value, true
+}
+
+// Peek returns the value of key if it is cached, without marking it as used.
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.Value.value, true
+}
+
+// Set caches value under key as the most recently used entry, evicting the least recently used one if full.
+func (c *LRUCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.value = value
+		c.order.MoveToFront(e)
+		c.mu.Unlock()
+		return
+	}
+
+	var evicted *lruEntry[K, V]
+	if c.order.Len() >= c.capacity {
+		evicted, _ = c.order.PopBack()
+		delete(c.entries, evicted.key)
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if evicted != nil && onEvict != nil {
+		onEvict(evicted.key, evicted.value)
+	}
+}
+
+// Delete removes key, returning true if it was cached.
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if ok {
+		c.order.Remove(e)
+		delete(c.entries, key)
+	}
+	return ok
+}
+
+// Len returns the number of cached entries.
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// IsEmpty returns true if no entries are cached.
+func (c *LRUCache[K, V]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+// Cap returns the maximum number of entries.
+func (c *LRUCache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Clear removes all entries without reporting them as evicted.
+func (c *LRUCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[K]*list.Element[*lruEntry[K, V]], c.capacity)
+	c.order.Clear()
+}
@@ -0,0 +1,42 @@
+package cache
+
+import "testing"
+
+func TestLRUCacheEviction(t *testing.T) {
+	c := NewLRU[string, int](2)
+	var evicted []string
+	c.SetOnEvict(func(k string, v int) { evicted = append(evicted, k) })
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Get("a")
+	c.Set("c", 3) // evicts b
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Expected 1, got %v (ok: %v)", v, ok)
+	}
+
+	c.Peek("c")
+	c.Set("a", 10)
+	c.Set("d", 4) // evicts c, since Peek does not count as a use
+	if _, ok := c.Peek("c"); ok {
+		t.Error("Expected c to be evicted")
+	}
+	if len(evicted) != 2 || evicted[0] != "b" || evicted[1] != "c" {
+		t.Errorf("Expected eviction of b and c, got %v", evicted)
+	}
+
+	if c.Len() != 2 || c.Cap() != 2 {
+		t.Errorf("Expected length and capacity 2, got %d and %d", c.Len(), c.Cap())
+	}
+	if !c.Delete("a") || c.Delete("a") {
+		t.Error("Expected Delete to report presence once")
+	}
+	c.Clear()
+	if c.Len() != 0 || len(evicted) != 2 {
+		t.Errorf("Expected Clear to empty the cache silently, got length %d and %v", c.Len(), evicted)
+	}
+}
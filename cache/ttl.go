@@ -0,0 +1,263 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrLoaderPanicked is returned by GetOrLoad to the callers waiting on a loader that panicked.
+// The caller running the loader gets the panic.
+var ErrLoaderPanicked = errors.New("cache: loader panicked")
+
+// TTLCache is a thread-safe cache whose entries expire after a per-entry or default time-to-live.
+// Expired entries are removed lazily when they are looked up, and in bulk by Sweep, which
+// StartSweeping runs in the background.
+type TTLCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	entries  map[K]ttlEntry[V]
+	ttl      time.Duration
+	onExpire func(K, V)
+	loading  map[K]*call[V]
+	now      func() time.Time
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+type ttlEntry[V any] struct {
+	value     V
+	expiresAt time.Time // zero means never
+}
+
+// call is an in-flight GetOrLoad shared by concurrent callers of the same key.
+type call[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+	stale bool // whether the key was set or deleted during the load, guarded by the cache lock
+}
+
+// NewTTL returns a new empty TTLCache whose entries expire after ttl by default.
+// A ttl of zero or less means entries never expire unless set with their own TTL.
+func NewTTL[K comparable, V any](ttl time.Duration) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		entries: make(map[K]ttlEntry[V]),
+		ttl:     ttl,
+		loading: make(map[K]*call[V]),
+		now:     time.Now,
+	}
+}
+
+// SetOnExpire registers a callback invoked with every entry removed because it expired,
+// whether found by a lookup or by Sweep. Deleted, overwritten and cleared entries are not reported.
+// The callback runs without the cache lock held, so it may use the cache.
+func (c *TTLCache[K, V]) SetOnExpire(fn func(key K, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onExpire = fn
+}
+
+// Set stores value under key with the default TTL.
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL stores value under key, expiring after ttl. A ttl of zero or less means never.
+func (c *TTLCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidate(key)
+	c.set(key, value, ttl)
+}
+
+func (c *TTLCache[K, V]) set(key K, value V, ttl time.Duration) {
+	e := ttlEntry[V]{value: value}
+	if ttl > 0 {
+		e.expiresAt = c.now().Add(ttl)
+	}
+	c.entries[key] = e
+}
+
+// Get returns the value of key if it is present and has not expired.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	v, ok, expired := c.get(key)
+	onExpire := c.onExpire
+	c.mu.Unlock()
+
+	if expired && onExpire != nil {
+		onExpire(key, v)
+	}
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return v, true
+}
+
+// get looks up key, removing it if it has expired. expired reports such a removal, with v the removed value.
+func (c *TTLCache[K, V]) get(key K) (v V, ok, expired bool) {
+	e, found := c.entries[key]
+	if !found {
+		return v, false, false
+	}
+	if c.expired(e, c.now()) {
+		delete(c.entries, key)
+		return e.value, false, true
+	}
+	return e.value, true, false
+}
+
+// invalidate keeps the load of key in flight, if any, from caching its result over a newer change.
+func (c *TTLCache[K, V]) invalidate(key K) {
+	if cl, ok := c.loading[key]; ok {
+		cl.stale = true
+	}
+}
+
+func (c *TTLCache[K, V]) expired(e ttlEntry[V], now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+// GetOrLoad returns the value of key, calling loader to produce and cache it (with the default TTL)
+// if it is missing or expired. Concurrent calls for the same key share a single loader call.
+// Errors are returned to every waiting caller and are not cached. If loader panics, the panic propagates
+// to the caller running it and the waiting callers get ErrLoaderPanicked. A value loaded while the key
+// was set, deleted or cleared is returned but not cached, so that it does not overwrite the newer change.
+func (c *TTLCache[K, V]) GetOrLoad(key K, loader func(K) (V, error)) (V, error) {
+	c.mu.Lock()
+	v, ok, expired := c.get(key)
+	onExpire := c.onExpire
+	if ok {
+		c.mu.Unlock()
+		return v, nil
+	}
+	if cl, loading := c.loading[key]; loading {
+		c.mu.Unlock()
+		<-cl.done
+		return cl.value, cl.err
+	}
+	cl := &call[V]{done: make(chan struct{})}
+	c.loading[key] = cl
+	c.mu.Unlock()
+
+	if expired && onExpire != nil {
+		onExpire(key, v)
+	}
+
+	completed := false
+	defer func() {
+		c.mu.Lock()
+		delete(c.loading, key)
+		if !completed {
+			var zero V
+			cl.value, cl.err = zero, ErrLoaderPanicked
+		} else if cl.err == nil && !cl.stale {
+			c.set(key, cl.value, c.ttl)
+		}
+		c.mu.Unlock()
+		close(cl.done)
+	}()
+	cl.value, cl.err = loader(key)
+	completed = true
+	return cl.value, cl.err
+}
+
+// Delete removes key. It returns true if it was present and had not expired.
+func (c *TTLCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidate(key)
+	e, ok := c.entries[key]
+	delete(c.entries, key)
+	return ok && !c.expired(e, c.now())
+}
+
+// Len returns the number of stored entries, including expired ones that have not been removed yet.
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// IsEmpty returns true if no entries are stored, counting expired ones like Len.
+func (c *TTLCache[K, V]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+// Clear removes all entries without reporting them as expired.
+func (c *TTLCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cl := range c.loading {
+		cl.stale = true
+	}
+	c.entries = make(map[K]ttlEntry[V])
+}
+
+// Sweep removes every expired entry, reporting each to the expiry callback, and returns how many were removed.
+func (c *TTLCache[K, V]) Sweep() int {
+	type expiredEntry struct {
+		key   K
+		value V
+	}
+
+	c.mu.Lock()
+	now := c.now()
+	var removed []expiredEntry
+	for k, e := range c.entries {
+		if c.expired(e, now) {
+			delete(c.entries, k)
+			removed = append(removed, expiredEntry{k, e.value})
+		}
+	}
+	onExpire := c.onExpire
+	c.mu.Unlock()
+
+	if onExpire != nil {
+		for _, e := range removed {
+			onExpire(e.key, e.value)
+		}
+	}
+	return len(removed)
+}
+
+// StartSweeping runs Sweep every interval in a background goroutine until Close is called.
+// Calling it again replaces the previous sweeper.
+func (c *TTLCache[K, V]) StartSweeping(interval time.Duration) {
+	c.Close()
+
+	c.mu.Lock()
+	stop, done := make(chan struct{}), make(chan struct{})
+	c.stop, c.done = stop, done
+	c.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.Sweep()
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops the background sweeper, if any, and waits for it to exit. The cache remains usable.
+func (c *TTLCache[K, V]) Close() {
+	c.mu.Lock()
+	stop, done := c.stop, c.done
+	c.stop, c.done = nil, nil
+	c.mu.Unlock()
+
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+}
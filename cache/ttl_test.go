@@ -0,0 +1,199 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock replaces the cache's clock so that expiry can be tested without sleeping.
+func fakeClock[K comparable, V any](c *TTLCache[K, V]) *time.Time {
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	return &now
+}
+
+func TestTTLCacheExpiry(t *testing.T) {
+	c := NewTTL[string, int](time.Minute)
+	now := fakeClock(c)
+
+	var expired []string
+	c.SetOnExpire(func(k string, v int) { expired = append(expired, k) })
+
+	c.Set("a", 1)
+	c.SetWithTTL("b", 2, time.Hour)
+	c.SetWithTTL("forever", 3, 0)
+
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Expected 1, got %v (ok: %v)", v, ok)
+	}
+
+	*now = now.Add(time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Error("Expected a to have expired")
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected length 2, got %d", c.Len())
+	}
+
+	*now = now.Add(24 * time.Hour)
+	if n := c.Sweep(); n != 1 {
+		t.Errorf("Expected 1 swept entry, got %d", n)
+	}
+	if v, ok := c.Get("forever"); !ok || v != 3 {
+		t.Errorf("Expected 3, got %v (ok: %v)", v, ok)
+	}
+	if len(expired) != 2 || expired[0] != "a" || expired[1] != "b" {
+		t.Errorf("Expected expiry of a and b, got %v", expired)
+	}
+
+	if !c.Delete("forever") || c.Delete("forever") {
+		t.Error("Expected Delete to report presence once")
+	}
+	c.Set("x", 1)
+	c.Clear()
+	if c.Len() != 0 || len(expired) != 2 {
+		t.Errorf("Expected Clear to empty the cache silently, got length %d and %v", c.Len(), expired)
+	}
+}
+
+func TestTTLCacheGetOrLoad(t *testing.T) {
+	c := NewTTL[string, int](time.Minute)
+	now := fakeClock(c)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(k string) (int, error) {
+		calls.Add(1)
+		<-release
+		return len(k), nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Go(func() {
+			v, err := c.GetOrLoad("four", loader)
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = v
+		})
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected a single loader call, got %d", n)
+	}
+	for _, v := range results {
+		if v != 4 {
+			t.Errorf("Expected 4, got %d", v)
+		}
+	}
+
+	if _, err := c.GetOrLoad("four", loader); err != nil || calls.Load() != 1 {
+		t.Errorf("Expected a cached value, got %v after %d calls", err, calls.Load())
+	}
+	*now = now.Add(time.Minute)
+	if _, err := c.GetOrLoad("four", loader); err != nil || calls.Load() != 2 {
+		t.Errorf("Expected a reload after expiry, got %v after %d calls", err, calls.Load())
+	}
+
+	failure := errors.New("unavailable")
+	if _, err := c.GetOrLoad("bad", func(string) (int, error) { return 0, failure }); !errors.Is(err, failure) {
+		t.Errorf("Expected the loader error, got %v", err)
+	}
+	if _, ok := c.Get("bad"); ok {
+		t.Error("Expected errors not to be cached")
+	}
+}
+
+func TestTTLCacheGetOrLoadPanic(t *testing.T) {
+	c := NewTTL[string, int](time.Minute)
+
+	started := make(chan struct{})
+	waited := make(chan error, 1)
+	go func() {
+		<-started
+		_, err := c.GetOrLoad("k", func(string) (int, error) { return 1, nil })
+		waited <- err
+	}()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the loader panic to propagate")
+			}
+		}()
+		c.GetOrLoad("k", func(string) (int, error) {
+			close(started)
+			time.Sleep(20 * time.Millisecond) // let the other caller wait on this load
+			panic("boom")
+		})
+	}()
+
+	if err := <-waited; err != nil && !errors.Is(err, ErrLoaderPanicked) {
+		t.Errorf("Expected ErrLoaderPanicked, got %v", err)
+	}
+
+	c = NewTTL[string, int](time.Minute)
+	func() {
+		defer func() { recover() }()
+		c.GetOrLoad("k", func(string) (int, error) { panic("boom") })
+	}()
+	if v, ok := c.Get("k"); ok {
+		t.Errorf("Expected nothing cached after a panic, got %d", v)
+	}
+}
+
+func TestTTLCacheGetOrLoadStale(t *testing.T) {
+	for name, change := range map[string]func(c *TTLCache[string, int]){
+		"Set":    func(c *TTLCache[string, int]) { c.Set("k", 2) },
+		"Delete": func(c *TTLCache[string, int]) { c.Delete("k") },
+		"Clear":  func(c *TTLCache[string, int]) { c.Clear() },
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := NewTTL[string, int](time.Minute)
+			v, err := c.GetOrLoad("k", func(string) (int, error) {
+				change(c)
+				return 1, nil
+			})
+			if err != nil || v != 1 {
+				t.Errorf("Expected the loaded value 1, got %d (err: %v)", v, err)
+			}
+			got, ok := c.Get("k")
+			if name == "Set" && (!ok || got != 2) {
+				t.Errorf("Expected the newer value 2, got %d (ok: %v)", got, ok)
+			}
+			if name != "Set" && ok {
+				t.Errorf("Expected the key to stay removed, got %d", got)
+			}
+		})
+	}
+}
+
+func TestTTLCacheSweeping(t *testing.T) {
+	c := NewTTL[int, int](time.Millisecond)
+	done := make(chan int, 1)
+	c.SetOnExpire(func(k, v int) { done <- k })
+
+	c.Set(1, 1)
+	c.StartSweeping(time.Millisecond)
+	defer c.Close()
+
+	select {
+	case k := <-done:
+		if k != 1 {
+			t.Errorf("Expected key 1 to expire, got %d", k)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the sweeper to expire the entry")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Expected an empty cache, got length %d", c.Len())
+	}
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"runtime"
+	"sync"
+	"weak"
+
+	optional "github.com/dullkingsman/kozo/optional"
+)
+
+// WeakMap is a thread-safe map holding its values through weak pointers, so that it never keeps them alive:
+// once the rest of the program drops a value, the garbage collector may reclaim it and its entry disappears.
+// It caches large decoded objects, such as parsed documents or images, for as long as something else uses
+// them, without pinning memory or needing an eviction policy.
+//
+// Values are pointers, since only pointed-to memory can be reclaimed. Entries of reclaimed values are removed
+// by a cleanup registered with runtime.AddCleanup, which runs some time after the collection, so Len may
+// count entries whose values are already gone until then; Get never returns them.
+type WeakMap[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]weak.Pointer[V]
+}
+
+// NewWeak returns a new empty WeakMap.
+func NewWeak[K comparable, V any]() *WeakMap[K, V] {
+	return &WeakMap[K, V]{entries: make(map[K]weak.Pointer[V])}
+}
+
+// weakEntry identifies the entry a cleanup removes, so that it leaves a newer value of the same key alone.
+type weakEntry[K comparable, V any] struct {
+	key K
+	ptr weak.Pointer[V]
+}
+
+// Set stores value under key without keeping it alive. A nil value deletes key.
+func (m *WeakMap[K, V]) Set(key K, value *V) {
+	if value == nil {
+		m.Delete(key)
+		return
+	}
+	ptr := weak.Make(value)
+	m.mu.Lock()
+	m.entries[key] = ptr
+	m.mu.Unlock()
+	runtime.AddCleanup(value, m.cleanup, weakEntry[K, V]{key, ptr})
+}
+
+// Get returns the value of key, or None if it is absent or was reclaimed.
+func (m *WeakMap[K, V]) Get(key K) optional.Optional[*V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ptr, ok := m.entries[key]
+	if !ok {
+		return optional.None[*V]()
+	}
+	value := ptr.Value()
+	if value == nil {
+		delete(m.entries, key)
+		return optional.None[*V]()
+	}
+	return optional.Some(value)
+}
+
+// GetOrSet returns the value of key if it is still alive, or else stores and returns the value made by create,
+// which runs with the map locked. This shares one decoded object between concurrent users of the same key.
+func (m *WeakMap[K, V]) GetOrSet(key K, create func() *V) *V {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if value := m.entries[key].Value(); value != nil {
+		return value
+	}
+	value := create()
+	if value == nil {
+		delete(m.entries, key)
+		return nil
+	}
+	ptr := weak.Make(value)
+	m.entries[key] = ptr
+	runtime.AddCleanup(value, m.cleanup, weakEntry[K, V]{key, ptr})
+	return value
+}
+
+// Delete removes key. It returns true if it was present with a value not yet reclaimed.
+func (m *WeakMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ptr, ok := m.entries[key]
+	delete(m.entries, key)
+	return ok && ptr.Value() != nil
+}
+
+// Len returns the number of entries, including those whose values were reclaimed but not yet cleaned up.
+func (m *WeakMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+// IsEmpty returns true if the map has no entries, counting those whose values were reclaimed but not yet cleaned up.
+func (m *WeakMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Clear removes all entries.
+func (m *WeakMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.entries)
+}
+
+// cleanup removes the entry of a reclaimed value, unless the key was set again since.
+func (m *WeakMap[K, V]) cleanup(e weakEntry[K, V]) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries[e.key] == e.ptr {
+		delete(m.entries, e.key)
+	}
+}
@@ -0,0 +1,75 @@
+package cache
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+type blob struct {
+	data [1 << 12]byte
+	id   int
+}
+
+func TestWeakMap_Basic(t *testing.T) {
+	m := NewWeak[string, blob]()
+	if !m.Get("a").IsNone() {
+		t.Fatal("Expected None for an absent key")
+	}
+
+	a := &blob{id: 1}
+	m.Set("a", a)
+	if got, ok := m.Get("a").Unwrap(); !ok || got != a {
+		t.Fatalf("Get(a) = %v, %v", got, ok)
+	}
+	if got := m.GetOrSet("a", func() *blob { return &blob{id: 2} }); got != a {
+		t.Error("Expected GetOrSet to return the live value")
+	}
+	if got := m.GetOrSet("b", func() *blob { return &blob{id: 2} }); got.id != 2 || m.Len() != 2 {
+		t.Errorf("GetOrSet(b) = %d, Len() = %d", got.id, m.Len())
+	}
+
+	m.Set("a", nil)
+	if !m.Get("a").IsNone() || m.Delete("a") {
+		t.Error("Expected Set with nil to delete the key")
+	}
+	m.Clear()
+	if m.Len() != 0 || !m.IsEmpty() {
+		t.Errorf("Len() = %d after Clear()", m.Len())
+	}
+	runtime.KeepAlive(a)
+}
+
+func TestWeakMap_Reclaimed(t *testing.T) {
+	m := NewWeak[int, blob]()
+	kept := &blob{id: 1}
+	m.Set(1, kept)
+	m.Set(2, &blob{id: 2})
+
+	// The unreferenced value is reclaimed, then its entry cleaned up.
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Len() > 1 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if !m.Get(2).IsNone() || m.Len() != 1 {
+		t.Fatalf("Expected the unreferenced value to be reclaimed, Len() = %d", m.Len())
+	}
+	if got, ok := m.Get(1).Unwrap(); !ok || got.id != 1 {
+		t.Errorf("Expected the referenced value to survive, got %v, %v", got, ok)
+	}
+
+	// A cleanup of an old value leaves a newer value of the same key alone.
+	m.Set(3, &blob{id: 3})
+	newer := &blob{id: 4}
+	m.Set(3, newer)
+	for range 5 {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if got, ok := m.Get(3).Unwrap(); !ok || got != newer {
+		t.Errorf("Get(3) = %v, %v after the old value was reclaimed", got, ok)
+	}
+	runtime.KeepAlive(kept)
+	runtime.KeepAlive(newer)
+}
@@ -0,0 +1,59 @@
+// Package cmpx provides composable comparators, so that orders such as "by priority descending, then by name"
+// are built once and shared by the sorted structures, heaps and sort specifications instead of hand-written
+// comparison functions.
+package cmpx
+
+import "cmp"
+
+// Comparator orders values of type T like cmp.Compare: it returns a negative number if a sorts before b,
+// a positive number if it sorts after, and zero if they are equivalent. It is accepted wherever a three-way
+// comparison is, such as by slices.SortFunc, and converts to the less function of the sorted structures
+// and heaps of kozo with Less.
+type Comparator[T any] func(a, b T) int
+
+// Natural returns the comparator of the natural order of T, cmp.Compare.
+func Natural[T cmp.Ordered]() Comparator[T] {
+	return cmp.Compare[T]
+}
+
+// By returns a comparator ordering values by the natural order of the key extracted by key,
+// e.g. cmpx.By(func(u User) string { return u.Name }).
+func By[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ByFunc returns a comparator ordering values by the key extracted by key, ordered by c.
+func ByFunc[T, K any](key func(T) K, c Comparator[K]) Comparator[T] {
+	return func(a, b T) int {
+		return c(key(a), key(b))
+	}
+}
+
+// Reverse returns the comparator of the opposite order.
+func (c Comparator[T]) Reverse() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Then returns the lexicographic comparator ordering values by c, and values equivalent by c by next,
+// e.g. cmpx.By(priority).Reverse().Then(cmpx.By(name)).
+func (c Comparator[T]) Then(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if r := c(a, b); r != 0 {
+			return r
+		}
+		return next(a, b)
+	}
+}
+
+// Less returns the less function of the order, reporting whether a sorts strictly before b,
+// as taken by heap.New, sortedmap.New, rbtree.New and avltree.New. Their NewFunc constructors take
+// a Comparator directly.
+func (c Comparator[T]) Less() func(a, b T) bool {
+	return func(a, b T) bool {
+		return c(a, b) < 0
+	}
+}
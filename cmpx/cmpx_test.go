@@ -0,0 +1,112 @@
+package cmpx
+
+import (
+	"slices"
+	"testing"
+
+	optional "github.com/dullkingsman/kozo/optional"
+)
+
+type task struct {
+	name     string
+	priority int
+}
+
+func names(tasks []task) []string {
+	res := make([]string, len(tasks))
+	for i, t := range tasks {
+		res[i] = t.name
+	}
+	return res
+}
+
+var tasks = []task{{"b", 1}, {"c", 2}, {"a", 1}, {"d", 2}}
+
+func TestComparator_Compose(t *testing.T) {
+	byPriority := By(func(t task) int { return t.priority })
+	byName := By(func(t task) string { return t.name })
+
+	tests := []struct {
+		name     string
+		c        Comparator[task]
+		expected []string
+	}{
+		{"By", byName, []string{"a", "b", "c", "d"}},
+		{"Reverse", byName.Reverse(), []string{"d", "c", "b", "a"}},
+		{"Then", byPriority.Then(byName), []string{"a", "b", "c", "d"}},
+		{"Reverse Then", byPriority.Reverse().Then(byName), []string{"c", "d", "a", "b"}},
+		{"Then Reverse", byPriority.Then(byName.Reverse()), []string{"b", "a", "d", "c"}},
+		{"ByFunc", ByFunc(func(t task) string { return t.name }, Natural[string]().Reverse()), []string{"d", "c", "b", "a"}},
+	}
+	for _, tt := range tests {
+		items := slices.Clone(tasks)
+		slices.SortFunc(items, tt.c)
+		if got := names(items); !slices.Equal(got, tt.expected) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestComparator_Less(t *testing.T) {
+	less := Natural[int]().Reverse().Less()
+	if !less(2, 1) || less(1, 2) || less(1, 1) {
+		t.Error("Expected Less of the reverse order to hold only for greater values")
+	}
+}
+
+func TestPtr(t *testing.T) {
+	one, two := 1, 2
+	items := []*int{&two, nil, &one}
+
+	slices.SortFunc(items, Ptr(Natural[int](), NullsFirst))
+	if items[0] != nil || *items[1] != 1 || *items[2] != 2 {
+		t.Errorf("Expected [nil 1 2], got %v", items)
+	}
+	slices.SortFunc(items, Ptr(Natural[int]().Reverse(), NullsFirst))
+	if items[0] != nil || *items[1] != 2 || *items[2] != 1 {
+		t.Errorf("Expected [nil 2 1], got %v", items)
+	}
+	slices.SortFunc(items, Ptr(Natural[int](), NullsLast))
+	if *items[0] != 1 || *items[1] != 2 || items[2] != nil {
+		t.Errorf("Expected [1 2 nil], got %v", items)
+	}
+	if c := Ptr(Natural[int](), NullsLast)(nil, nil); c != 0 {
+		t.Errorf("Expected nil pointers to be equivalent, got %d", c)
+	}
+}
+
+func TestOptional(t *testing.T) {
+	items := []optional.Optional[int]{optional.Some(2), optional.None[int](), optional.Some(1), optional.Null[int]()}
+	c := Optional(Natural[int](), NullsLast)
+
+	slices.SortStableFunc(items, c)
+	expected := []string{"Some(1)", "Some(2)", "None", "Some(null)"}
+	for i, o := range items {
+		if o.String() != expected[i] {
+			t.Errorf("Position %d: got %v, want %s", i, o, expected[i])
+		}
+	}
+	if c(optional.None[int](), optional.Null[int]()) != 0 {
+		t.Error("Expected None and Some(null) to be equivalent")
+	}
+	if Optional(Natural[int](), NullsFirst)(optional.None[int](), optional.Some(0)) >= 0 {
+		t.Error("Expected None before Some(0) with NullsFirst")
+	}
+}
+
+func TestNullable(t *testing.T) {
+	type nullString struct {
+		s     string
+		valid bool
+	}
+	unwrap := func(n nullString) (string, bool) { return n.s, n.valid }
+	items := []nullString{{"b", true}, {}, {"a", true}}
+
+	slices.SortFunc(items, Nullable(unwrap, Natural[string]().Reverse(), NullsLast))
+	if items[0].s != "b" || items[1].s != "a" || items[2].valid {
+		t.Errorf("Expected [b a null], got %v", items)
+	}
+	if Nullable(unwrap, Natural[string](), NullsFirst)(nullString{}, nullString{s: "x"}) != 0 {
+		t.Error("Expected nulls to be equivalent")
+	}
+}
@@ -0,0 +1,53 @@
+package cmpx
+
+import optional "github.com/dullkingsman/kozo/optional"
+
+// Nulls places null values, such as nil pointers or absent optionals, before or after all other values.
+type Nulls int
+
+const (
+	// NullsFirst sorts null values before all other values.
+	NullsFirst Nulls = iota
+	// NullsLast sorts null values after all other values.
+	NullsLast
+)
+
+// Ptr returns a comparator of pointers ordering the values they point to by c, and nil pointers as nulls.
+// Nil pointers are equivalent to each other. Reversing the result moves the nulls to the other end too;
+// reverse c instead to keep them in place.
+func Ptr[T any](c Comparator[T], nulls Nulls) Comparator[*T] {
+	return Nullable(func(p *T) (T, bool) {
+		if p == nil {
+			var zero T
+			return zero, false
+		}
+		return *p, true
+	}, c, nulls)
+}
+
+// Optional returns a comparator of optionals ordering their values by c, and both None and Some(null) as nulls,
+// as SQL does with absent and NULL columns. Reversing the result moves the nulls to the other end too;
+// reverse c instead to keep them in place.
+func Optional[T any](c Comparator[T], nulls Nulls) Comparator[optional.Optional[T]] {
+	return Nullable(optional.Optional[T].Unwrap, c, nulls)
+}
+
+// Nullable returns a comparator of values of a nullable type N, such as a sql.Null or a reflected field,
+// ordering the values unwrap extracts by c and those it returns false for as nulls, which are equivalent
+// to each other. Ptr and Optional are Nullable with the unwrapping of pointers and optionals.
+func Nullable[N, T any](unwrap func(N) (T, bool), c Comparator[T], nulls Nulls) Comparator[N] {
+	return func(a, b N) int {
+		va, aok := unwrap(a)
+		vb, bok := unwrap(b)
+		switch {
+		case aok && bok:
+			return c(va, vb)
+		case aok == bok:
+			return 0
+		case !aok == (nulls == NullsFirst):
+			return -1
+		default:
+			return 1
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Codec converts values of type T to and from their wire representation.
+//
+// A Codec registered for T is used by every kozo type that marshals values of T
+// (Optional, Set, Queue, Range, ExistenceClaim, ...), so a single registration keeps
+// the wire format consistent across all of them.
+type Codec[T any] interface {
+	Marshal(v T) ([]byte, error)
+	Unmarshal(data []byte, v *T) error
+}
+
+// funcCodec adapts a pair of functions to the Codec interface.
+type funcCodec[T any] struct {
+	marshal   func(T) ([]byte, error)
+	unmarshal func([]byte, *T) error
+}
+
+func (c funcCodec[T]) Marshal(v T) ([]byte, error) {
+	return c.marshal(v)
+}
+
+func (c funcCodec[T]) Unmarshal(data []byte, v *T) error {
+	return c.unmarshal(data, v)
+}
+
+// Funcs returns a Codec built from the given marshal and unmarshal functions.
+func Funcs[T any](marshal func(T) ([]byte, error), unmarshal func([]byte, *T) error) Codec[T] {
+	return funcCodec[T]{marshal: marshal, unmarshal: unmarshal}
+}
+
+var (
+	mu       sync.RWMutex
+	registry = make(map[reflect.Type]any)
+)
+
+// Register installs c as the codec for values of type T, replacing any previous registration.
+func Register[T any](c Codec[T]) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[reflect.TypeFor[T]()] = c
+}
+
+// Unregister removes the codec registered for type T, restoring the default JSON behavior.
+func Unregister[T any]() {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(registry, reflect.TypeFor[T]())
+}
+
+// Lookup returns the codec registered for type T, if any.
+func Lookup[T any]() (Codec[T], bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	c, ok := registry[reflect.TypeFor[T]()]
+	if !ok {
+		return nil, false
+	}
+	return c.(Codec[T]), true
+}
+
+// Marshal encodes v using the codec registered for T.
+// Falls back to encoding/json when no codec is registered.
+func Marshal[T any](v T) ([]byte, error) {
+	if c, ok := Lookup[T](); ok {
+		return c.Marshal(v)
+	}
+	return json.Marshal(v)
+}
+
+// Unmarshal decodes data into v using the codec registered for T.
+// Falls back to encoding/json when no codec is registered.
+func Unmarshal[T any](data []byte, v *T) error {
+	if c, ok := Lookup[T](); ok {
+		return c.Unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// MarshalSlice encodes items as a JSON array, encoding each element with Marshal.
+func MarshalSlice[T any](items []T) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		data, err := Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalSlice decodes a JSON array, decoding each element with Unmarshal.
+// A JSON null decodes to a nil slice.
+func UnmarshalSlice[T any](data []byte) ([]T, error) {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		return nil, nil
+	}
+
+	items := make([]T, len(raw))
+	for i, r := range raw {
+		if err := Unmarshal(r, &items[i]); err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return items, nil
+}
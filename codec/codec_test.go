@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"strconv"
+	"testing"
+)
+
+type id int64
+
+var idAsString = Funcs(
+	func(v id) ([]byte, error) {
+		return []byte(strconv.Quote(strconv.FormatInt(int64(v), 10))), nil
+	},
+	func(data []byte, v *id) error {
+		s, err := strconv.Unquote(string(data))
+		if err != nil {
+			return err
+		}
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		*v = id(n)
+		return nil
+	},
+)
+
+func TestCodecDefault(t *testing.T) {
+	data, err := Marshal(42)
+	if err != nil || string(data) != "42" {
+		t.Errorf("Expected 42, got %s (err: %v)", data, err)
+	}
+
+	var v int
+	if err := Unmarshal([]byte("7"), &v); err != nil || v != 7 {
+		t.Errorf("Expected 7, got %d (err: %v)", v, err)
+	}
+}
+
+func TestCodecRegister(t *testing.T) {
+	Register(idAsString)
+	defer Unregister[id]()
+
+	if _, ok := Lookup[id](); !ok {
+		t.Fatal("Expected codec to be registered")
+	}
+
+	data, err := Marshal(id(12))
+	if err != nil || string(data) != `"12"` {
+		t.Errorf(`Expected "12", got %s (err: %v)`, data, err)
+	}
+
+	var v id
+	if err := Unmarshal([]byte(`"34"`), &v); err != nil || v != 34 {
+		t.Errorf("Expected 34, got %d (err: %v)", v, err)
+	}
+
+	Unregister[id]()
+	data, _ = Marshal(id(12))
+	if string(data) != "12" {
+		t.Errorf("Expected default encoding after Unregister, got %s", data)
+	}
+}
+
+func TestCodecSlice(t *testing.T) {
+	Register(idAsString)
+	defer Unregister[id]()
+
+	data, err := MarshalSlice([]id{1, 2})
+	if err != nil || string(data) != `["1","2"]` {
+		t.Errorf(`Expected ["1","2"], got %s (err: %v)`, data, err)
+	}
+
+	items, err := UnmarshalSlice[id](data)
+	if err != nil || len(items) != 2 || items[0] != 1 || items[1] != 2 {
+		t.Errorf("Expected [1 2], got %v (err: %v)", items, err)
+	}
+
+	items, err = UnmarshalSlice[id]([]byte("null"))
+	if err != nil || items != nil {
+		t.Errorf("Expected nil slice for null, got %v (err: %v)", items, err)
+	}
+
+	if _, err := UnmarshalSlice[id]([]byte(`[1]`)); err == nil {
+		t.Error("Expected error decoding unquoted id")
+	}
+}
@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// Snapshotter is implemented by the collections of kozo whose contents can be persisted and restored,
+// such as Set, Queue, Stack and OrderedMap, so that an application saves its in-memory state uniformly.
+//
+// Snapshots wrap the encoding of the collection, whose values go through the codec registered for their type,
+// in a versioned envelope naming the kind of collection and guarded by a checksum; see EncodeSnapshot.
+type Snapshotter interface {
+	// Snapshot encodes the contents of the collection.
+	Snapshot() ([]byte, error)
+	// Restore replaces the contents of the collection with those of a snapshot. It returns an error
+	// wrapping ErrInvalidSnapshot, leaving the collection unchanged, if data is not a valid snapshot of its kind.
+	Restore(data []byte) error
+}
+
+// SnapshotVersion is the version of the envelope written by EncodeSnapshot. DecodeSnapshot reads
+// every version up to it.
+const SnapshotVersion = 1
+
+// ErrInvalidSnapshot is returned when restoring data that is not a valid snapshot of the expected kind.
+var ErrInvalidSnapshot = errors.New("codec: invalid snapshot")
+
+// snapshotMagic starts every snapshot, to recognize them among other files.
+var snapshotMagic = []byte("KOZO")
+
+// EncodeSnapshot wraps payload in a snapshot envelope: the magic "KOZO", the version as one byte, the kind
+// and the payload each prefixed with their length as a uvarint, then the big-endian CRC-32 (IEEE) of all
+// the preceding bytes. Collections implementing Snapshotter call it with their kind, such as "queue".
+func EncodeSnapshot(kind string, payload []byte) []byte {
+	buf := bytes.NewBuffer(make([]byte, 0, len(snapshotMagic)+1+2*binary.MaxVarintLen64+len(kind)+len(payload)+4))
+	buf.Write(snapshotMagic)
+	buf.WriteByte(SnapshotVersion)
+	buf.Write(binary.AppendUvarint(nil, uint64(len(kind))))
+	buf.WriteString(kind)
+	buf.Write(binary.AppendUvarint(nil, uint64(len(payload))))
+	buf.Write(payload)
+	return binary.BigEndian.AppendUint32(buf.Bytes(), crc32.ChecksumIEEE(buf.Bytes()))
+}
+
+// DecodeSnapshot returns the payload of a snapshot of the given kind. It returns an error wrapping
+// ErrInvalidSnapshot if data is truncated or corrupted, of a later version, or of another kind.
+func DecodeSnapshot(kind string, data []byte) ([]byte, error) {
+	if len(data) < len(snapshotMagic)+1+4 || !bytes.HasPrefix(data, snapshotMagic) {
+		return nil, fmt.Errorf("%w: not a snapshot", ErrInvalidSnapshot)
+	}
+	body, sum := data[:len(data)-4], binary.BigEndian.Uint32(data[len(data)-4:])
+	if crc32.ChecksumIEEE(body) != sum {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidSnapshot)
+	}
+
+	rest := body[len(snapshotMagic):]
+	if version := rest[0]; version == 0 || version > SnapshotVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidSnapshot, version)
+	}
+	rest = rest[1:]
+
+	got, rest, ok := readPrefixed(rest)
+	if !ok {
+		return nil, fmt.Errorf("%w: malformed kind", ErrInvalidSnapshot)
+	}
+	if string(got) != kind {
+		return nil, fmt.Errorf("%w: snapshot of a %s, not a %s", ErrInvalidSnapshot, got, kind)
+	}
+	payload, rest, ok := readPrefixed(rest)
+	if !ok || len(rest) != 0 {
+		return nil, fmt.Errorf("%w: malformed payload", ErrInvalidSnapshot)
+	}
+	return payload, nil
+}
+
+// readPrefixed reads bytes prefixed with their length as a uvarint, returning them and the bytes after them.
+func readPrefixed(data []byte) ([]byte, []byte, bool) {
+	n, size := binary.Uvarint(data)
+	if size <= 0 || n > uint64(len(data)-size) {
+		return nil, nil, false
+	}
+	data = data[size:]
+	return data[:n], data[n:], true
+}
@@ -0,0 +1,55 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"testing"
+)
+
+func TestSnapshotEnvelope(t *testing.T) {
+	payload := []byte(`[1,2,3]`)
+	data := EncodeSnapshot("queue", payload)
+	if !bytes.HasPrefix(data, []byte("KOZO\x01")) {
+		t.Errorf("Expected the magic and version 1, got %q", data[:5])
+	}
+
+	got, err := DecodeSnapshot("queue", data)
+	if err != nil || !bytes.Equal(got, payload) {
+		t.Errorf("Expected %s, got %s (err: %v)", payload, got, err)
+	}
+
+	empty, err := DecodeSnapshot("set", EncodeSnapshot("set", nil))
+	if err != nil || len(empty) != 0 {
+		t.Errorf("Expected an empty payload, got %q (err: %v)", empty, err)
+	}
+}
+
+func TestSnapshotInvalid(t *testing.T) {
+	data := EncodeSnapshot("queue", []byte(`[1,2,3]`))
+
+	corrupted := bytes.Clone(data)
+	corrupted[len(corrupted)-6] ^= 1
+	newer := bytes.Clone(data[:len(data)-4])
+	newer[4] = SnapshotVersion + 1
+	newer = binary.BigEndian.AppendUint32(newer, crc32.ChecksumIEEE(newer))
+
+	cases := map[string][]byte{
+		"empty":          nil,
+		"not a snapshot": []byte(`[1,2,3]`),
+		"truncated":      data[:len(data)-1],
+		"corrupted":      corrupted,
+		"newer version":  newer, // rejected by the checksum too, which covers the version byte
+		"trailing":       append(bytes.Clone(data), 0),
+	}
+	for name, data := range cases {
+		if _, err := DecodeSnapshot("queue", data); !errors.Is(err, ErrInvalidSnapshot) {
+			t.Errorf("%s: expected ErrInvalidSnapshot, got %v", name, err)
+		}
+	}
+
+	if _, err := DecodeSnapshot("stack", data); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot for another kind, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+// Package collection defines the interfaces shared by the data structures of kozo, so that code handles
+// them polymorphically, such as with the generic algorithms of the algo package.
+package collection
+
+import "iter"
+
+// Container is implemented by the structures holding elements that can be counted and removed at once.
+// Structures that are fixed-length, immutable or probabilistic, or whose operations can fail with I/O errors,
+// do not implement it and say so in their documentation.
+type Container interface {
+	// Len returns the number of elements.
+	Len() int
+	// IsEmpty returns true if there are no elements.
+	IsEmpty() bool
+	// Clear removes all elements.
+	Clear()
+}
+
+// Iterable is implemented by structures iterating over their elements, in an order documented by each.
+// Thread-safe structures iterate over a snapshot unless documented otherwise.
+type Iterable[T any] interface {
+	// All returns an iterator over the elements.
+	All() iter.Seq[T]
+}
+
+// Iterable2 is implemented by structures iterating over pairs, such as the keys and values of maps.
+type Iterable2[K, V any] interface {
+	// All returns an iterator over the pairs.
+	All() iter.Seq2[K, V]
+}
+
+// Collection is a Container iterating over its elements, such as a Set, a Queue or a Stack.
+type Collection[T any] interface {
+	Container
+	Iterable[T]
+}
+
+// Map is a Container iterating over pairs, such as an OrderedMap, a SortedMap or a Trie.
+type Map[K, V any] interface {
+	Container
+	Iterable2[K, V]
+}
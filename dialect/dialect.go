@@ -0,0 +1,47 @@
+package dialect
+
+import "strconv"
+
+// Dialect describes how an SQL database writes bind parameters,
+// so that kozo types can generate parameterized predicates for it.
+type Dialect interface {
+	// Placeholder returns the bind parameter for the n-th argument of a statement, counting from 1.
+	Placeholder(n int) string
+}
+
+// Func adapts a function to the Dialect interface.
+type Func func(n int) string
+
+// Placeholder calls f(n).
+func (f Func) Placeholder(n int) string {
+	return f(n)
+}
+
+var (
+	// Question uses "?" for every argument, as MySQL, MariaDB and SQLite expect.
+	Question Dialect = Func(func(int) string { return "?" })
+	// Dollar uses numbered "$1", "$2", ... arguments, as PostgreSQL expects.
+	Dollar Dialect = Func(func(n int) string { return "$" + strconv.Itoa(n) })
+	// AtP uses named "@p1", "@p2", ... arguments, as SQL Server expects.
+	AtP Dialect = Func(func(n int) string { return "@p" + strconv.Itoa(n) })
+	// Colon uses numbered ":1", ":2", ... arguments, as Oracle expects.
+	Colon Dialect = Func(func(n int) string { return ":" + strconv.Itoa(n) })
+)
+
+// Aliases by database.
+var (
+	MySQL      = Question
+	SQLite     = Question
+	PostgreSQL = Dollar
+	SQLServer  = AtP
+	Oracle     = Colon
+)
+
+// Offset returns a Dialect whose numbering starts after the first n arguments.
+// It is used to append a generated fragment to a statement that already has n arguments.
+func Offset(d Dialect, n int) Dialect {
+	if n == 0 {
+		return d
+	}
+	return Func(func(i int) string { return d.Placeholder(i + n) })
+}
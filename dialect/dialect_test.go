@@ -0,0 +1,32 @@
+package dialect
+
+import "testing"
+
+func TestPlaceholders(t *testing.T) {
+	tests := []struct {
+		name     string
+		d        Dialect
+		expected string
+	}{
+		{"Question", MySQL, "?"},
+		{"Dollar", PostgreSQL, "$3"},
+		{"AtP", SQLServer, "@p3"},
+		{"Colon", Oracle, ":3"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.d.Placeholder(3); got != tt.expected {
+			t.Errorf("%s.Placeholder(3) = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestOffset(t *testing.T) {
+	d := Offset(Offset(PostgreSQL, 2), 3)
+	if got := d.Placeholder(1); got != "$6" {
+		t.Errorf("Offset().Placeholder(1) = %q, want $6", got)
+	}
+	if got := Offset(MySQL, 5).Placeholder(1); got != "?" {
+		t.Errorf("Offset() should not change unnumbered placeholders, got %q", got)
+	}
+}
@@ -0,0 +1,166 @@
+// Package dsu provides a disjoint-set union, also known as union-find.
+package dsu
+
+import (
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// DisjointSet is a thread-safe partition of comparable elements into disjoint sets, which can be merged
+// and queried for the set an element belongs to. It uses union by size and path halving, so every operation
+// runs in near-constant amortized time.
+//
+// Elements are added on first use, each in a set of its own.
+type DisjointSet[T comparable] struct {
+	mu     sync.Mutex
+	index  map[T]int
+	items  []T
+	parent []int
+	size   []int // size of the set, for roots
+	sets   int
+}
+
+var _ collection.Container = (*DisjointSet[int])(nil)
+
+// New returns a new DisjointSet holding the given elements, each in a set of its own.
+func New[T comparable](items ...T) *DisjointSet[T] {
+	d := &DisjointSet[T]{index: make(map[T]int, len(items))}
+	for _, x := range items {
+		d.add(x)
+	}
+	return d
+}
+
+// Add adds x in a set of its own. It returns true if x was new.
+func (d *DisjointSet[T]) Add(x T) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, existed := d.index[x]
+	d.add(x)
+	return !existed
+}
+
+// Contains returns true if x has been added.
+func (d *DisjointSet[T]) Contains(x T) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.index[x]
+	return ok
+}
+
+// Find returns the representative of the set containing x, which is the same for all elements of a set
+// until it is merged with another.
+func (d *DisjointSet[T]) Find(x T) T {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.items[d.root(d.add(x))]
+}
+
+// Union merges the sets containing a and b. It returns true if they were different sets.
+func (d *DisjointSet[T]) Union(a, b T) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ra, rb := d.root(d.add(a)), d.root(d.add(b))
+	if ra == rb {
+		return false
+	}
+	if d.size[ra] < d.size[rb] {
+		ra, rb = rb, ra
+	}
+	d.parent[rb] = ra
+	d.size[ra] += d.size[rb]
+	d.sets--
+	return true
+}
+
+// Connected returns true if a and b are in the same set.
+func (d *DisjointSet[T]) Connected(a, b T) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.root(d.add(a)) == d.root(d.add(b))
+}
+
+// SizeOf returns the number of elements in the set containing x.
+func (d *DisjointSet[T]) SizeOf(x T) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.size[d.root(d.add(x))]
+}
+
+// Sets returns the number of disjoint sets.
+func (d *DisjointSet[T]) Sets() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.sets
+}
+
+// Groups returns the elements of every set, in the order they were added.
+// Sets are ordered by their first element.
+func (d *DisjointSet[T]) Groups() [][]T {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var (
+		groups = make([][]T, 0, d.sets)
+		group  = make(map[int]int, d.sets) // root to position in groups
+	)
+	for i, x := range d.items {
+		r := d.root(i)
+		g, ok := group[r]
+		if !ok {
+			g = len(groups)
+			group[r] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], x)
+	}
+	return groups
+}
+
+// Len returns the number of elements.
+func (d *DisjointSet[T]) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.items)
+}
+
+// IsEmpty returns true if there are no elements.
+func (d *DisjointSet[T]) IsEmpty() bool {
+	return d.Len() == 0
+}
+
+// Clear removes all elements.
+func (d *DisjointSet[T]) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.index = make(map[T]int)
+	d.items, d.parent, d.size = nil, nil, nil
+	d.sets = 0
+}
+
+// add returns the position of x, adding it in a set of its own if needed.
+func (d *DisjointSet[T]) add(x T) int {
+	if i, ok := d.index[x]; ok {
+		return i
+	}
+	i := len(d.items)
+	d.index[x] = i
+	d.items = append(d.items, x)
+	d.parent = append(d.parent, i)
+	d.size = append(d.size, 1)
+	d.sets++
+	return i
+}
+
+// root returns the root of the set containing position i, pointing every other node on the way to its grandparent.
+func (d *DisjointSet[T]) root(i int) int {
+	for d.parent[i] != i {
+		d.parent[i] = d.parent[d.parent[i]]
+		i = d.parent[i]
+	}
+	return i
+}
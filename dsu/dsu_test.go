@@ -0,0 +1,134 @@
+package dsu
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// checkInvariants verifies that set sizes add up and that the number of roots matches Sets.
+func checkInvariants[T comparable](t *testing.T, d *DisjointSet[T]) {
+	t.Helper()
+	roots, total := 0, 0
+	for i := range d.items {
+		if d.parent[i] == i {
+			roots++
+			total += d.size[i]
+		}
+		if d.index[d.items[i]] != i {
+			t.Fatalf("Index of %v is %d, want %d", d.items[i], d.index[d.items[i]], i)
+		}
+	}
+	if roots != d.sets || total != len(d.items) {
+		t.Fatalf("Found %d roots covering %d elements; want %d and %d", roots, total, d.sets, len(d.items))
+	}
+}
+
+func TestDisjointSet_Basic(t *testing.T) {
+	d := New("a", "b", "c")
+	if d.Len() != 3 || d.Sets() != 3 {
+		t.Fatalf("Got %d elements in %d sets", d.Len(), d.Sets())
+	}
+	if d.Add("a") || !d.Add("d") {
+		t.Error("Add() reported the wrong novelty")
+	}
+
+	if !d.Union("a", "b") || d.Union("b", "a") {
+		t.Error("Expected Union to merge a and b once")
+	}
+	if !d.Connected("a", "b") || d.Connected("a", "c") {
+		t.Error("Unexpected connectivity")
+	}
+	if d.Find("a") != d.Find("b") || d.Find("a") == d.Find("c") {
+		t.Error("Unexpected representatives")
+	}
+	if d.SizeOf("b") != 2 || d.Sets() != 3 {
+		t.Errorf("SizeOf(b) = %d, Sets() = %d", d.SizeOf("b"), d.Sets())
+	}
+
+	// Union adds unknown elements.
+	d.Union("e", "c")
+	if !d.Contains("e") || d.Len() != 5 || d.Sets() != 3 {
+		t.Errorf("Got %d elements in %d sets", d.Len(), d.Sets())
+	}
+	got := d.Groups()
+	want := [][]string{{"a", "b"}, {"c", "e"}, {"d"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("Groups() = %v", got)
+	}
+	checkInvariants(t, d)
+
+	d.Clear()
+	if !d.IsEmpty() || d.Sets() != 0 || d.Contains("a") {
+		t.Error("Expected Clear to remove all elements")
+	}
+}
+
+func TestDisjointSet_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 200
+	d := New[int]()
+	label := make([]int, n) // reference: every element labeled with its set
+	for i := range label {
+		label[i] = i
+		d.Add(i)
+	}
+
+	for i := range 2000 {
+		a, b := rng.Intn(n), rng.Intn(n)
+		if rng.Intn(2) == 0 {
+			merged := label[a] != label[b]
+			if d.Union(a, b) != merged {
+				t.Fatalf("Union(%d, %d) disagrees with the reference", a, b)
+			}
+			if merged {
+				from := label[b]
+				for j := range label {
+					if label[j] == from {
+						label[j] = label[a]
+					}
+				}
+			}
+		} else if d.Connected(a, b) != (label[a] == label[b]) {
+			t.Fatalf("Connected(%d, %d) disagrees with the reference", a, b)
+		}
+		if i%200 == 0 {
+			checkInvariants(t, d)
+		}
+	}
+
+	sets := map[int]int{}
+	for _, l := range label {
+		sets[l]++
+	}
+	if d.Sets() != len(sets) {
+		t.Errorf("Sets() = %d, want %d", d.Sets(), len(sets))
+	}
+	for x := range n {
+		if d.SizeOf(x) != sets[label[x]] {
+			t.Fatalf("SizeOf(%d) = %d, want %d", x, d.SizeOf(x), sets[label[x]])
+		}
+	}
+}
+
+func TestDisjointSet_Concurrency(t *testing.T) {
+	d := New[int]()
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				d.Union(g*1000+i, g*1000)
+				d.Connected(g*1000, g*1000+i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if d.Len() != 8*500 || d.Sets() != 8 {
+		t.Errorf("Got %d elements in %d sets", d.Len(), d.Sets())
+	}
+	checkInvariants(t, d)
+}
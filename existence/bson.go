@@ -0,0 +1,18 @@
+package existence
+
+// ToBSON returns the claim as a MongoDB field condition, {"$in": values} or {"$nin": values}.
+// The result can be used directly as a bson.M value, e.g. bson.M{"status": claim.ToBSON()}.
+// An empty In claim matches no document and an empty NotIn claim matches every document, as in Check.
+func (e ExistenceClaim[T]) ToBSON() map[string]any {
+	values := e.Values
+	if values == nil {
+		// A nil slice would be encoded as null, which MongoDB rejects.
+		values = []T{}
+	}
+
+	op := "$nin"
+	if e.Contains {
+		op = "$in"
+	}
+	return map[string]any{op: values}
+}
@@ -0,0 +1,24 @@
+package existence
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExistenceClaim_ToBSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		claim    ExistenceClaim[string]
+		expected map[string]any
+	}{
+		{"In", In("a", "b"), map[string]any{"$in": []string{"a", "b"}}},
+		{"NotIn", NotIn("x"), map[string]any{"$nin": []string{"x"}}},
+		{"Empty", ExistenceClaim[string]{Contains: true}, map[string]any{"$in": []string{}}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.claim.ToBSON(); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: ToBSON() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
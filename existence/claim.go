@@ -0,0 +1,93 @@
+package existence
+
+// Claim is a predicate over values of type R, typically records whose fields are checked by ExistenceClaims.
+// Claims can be combined with And, Or and Not into a single filter that is evaluated as one object.
+type Claim[R any] interface {
+	Matches(v R) bool
+}
+
+// ClaimFunc adapts an ordinary function to the Claim interface,
+// so other predicates (e.g. a Range check) can be combined with existence claims.
+type ClaimFunc[R any] func(v R) bool
+
+// Matches returns f(v).
+func (f ClaimFunc[R]) Matches(v R) bool {
+	return f(v)
+}
+
+// Conjunction is a Claim that matches when all of its claims match. An empty Conjunction matches everything.
+type Conjunction[R any] struct {
+	Claims []Claim[R]
+}
+
+// Matches returns true if every claim matches v, stopping at the first that does not.
+func (c Conjunction[R]) Matches(v R) bool {
+	for _, claim := range c.Claims {
+		if !claim.Matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Disjunction is a Claim that matches when any of its claims matches. An empty Disjunction matches nothing.
+type Disjunction[R any] struct {
+	Claims []Claim[R]
+}
+
+// Matches returns true if some claim matches v, stopping at the first that does.
+func (d Disjunction[R]) Matches(v R) bool {
+	for _, claim := range d.Claims {
+		if claim.Matches(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Negation is a Claim that matches when its claim does not.
+type Negation[R any] struct {
+	Claim Claim[R]
+}
+
+// Matches returns true if the negated claim does not match v.
+func (n Negation[R]) Matches(v R) bool {
+	return !n.Claim.Matches(v)
+}
+
+// And combines claims into one that matches when all of them match.
+func And[R any](claims ...Claim[R]) Conjunction[R] {
+	return Conjunction[R]{Claims: claims}
+}
+
+// Or combines claims into one that matches when any of them matches.
+func Or[R any](claims ...Claim[R]) Disjunction[R] {
+	return Disjunction[R]{Claims: claims}
+}
+
+// Not returns a claim that matches when c does not.
+func Not[R any](c Claim[R]) Negation[R] {
+	return Negation[R]{Claim: c}
+}
+
+// FieldClaim is a Claim that checks one field of a record of type R against an ExistenceClaim.
+type FieldClaim[R, T any] struct {
+	Get    func(R) T
+	Claim  ExistenceClaim[T]
+	Equals func(T, T) bool
+}
+
+// Field returns a claim that checks the value extracted by get against e using a custom equality function.
+func Field[R, T any](get func(R) T, e ExistenceClaim[T], equals func(T, T) bool) FieldClaim[R, T] {
+	return FieldClaim[R, T]{Get: get, Claim: e, Equals: equals}
+}
+
+// FieldComparable returns a claim that checks the value extracted by get against e for comparable types.
+func FieldComparable[R any, T comparable](get func(R) T, e ExistenceClaim[T]) FieldClaim[R, T] {
+	return Field(get, e, func(a, b T) bool { return a == b })
+}
+
+// Matches returns true if the extracted field satisfies the existence claim.
+func (f FieldClaim[R, T]) Matches(v R) bool {
+	return f.Claim.Check(f.Get(v), f.Equals)
+}
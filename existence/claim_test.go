@@ -0,0 +1,58 @@
+package existence
+
+import "testing"
+
+type order struct {
+	Status string
+	Region string
+	Total  int
+}
+
+func TestClaim_Composite(t *testing.T) {
+	// status in (A, B) and region not in (X)
+	filter := And[order](
+		FieldComparable(func(o order) string { return o.Status }, In("A", "B")),
+		FieldComparable(func(o order) string { return o.Region }, NotIn("X")),
+	)
+
+	tests := []struct {
+		o        order
+		expected bool
+	}{
+		{order{"A", "Y", 0}, true},
+		{order{"B", "X", 0}, false},
+		{order{"C", "Y", 0}, false},
+	}
+
+	for _, tt := range tests {
+		if got := filter.Matches(tt.o); got != tt.expected {
+			t.Errorf("Matches(%+v) = %v, want %v", tt.o, got, tt.expected)
+		}
+	}
+}
+
+func TestClaim_OrNot(t *testing.T) {
+	large := ClaimFunc[order](func(o order) bool { return o.Total > 100 })
+	vip := Field(func(o order) string { return o.Region }, In("VIP"), func(a, b string) bool { return a == b })
+
+	filter := Or[order](large, Not[order](vip))
+
+	if !filter.Matches(order{Region: "VIP", Total: 500}) {
+		t.Error("Expected a large order to match")
+	}
+	if filter.Matches(order{Region: "VIP", Total: 5}) {
+		t.Error("Expected a small VIP order not to match")
+	}
+	if !filter.Matches(order{Region: "EU", Total: 5}) {
+		t.Error("Expected a non-VIP order to match")
+	}
+}
+
+func TestClaim_Empty(t *testing.T) {
+	if !And[int]().Matches(1) {
+		t.Error("Expected an empty And to match everything")
+	}
+	if Or[int]().Matches(1) {
+		t.Error("Expected an empty Or to match nothing")
+	}
+}
@@ -0,0 +1,48 @@
+package existence
+
+import (
+	"iter"
+
+	"github.com/dullkingsman/kozo/set"
+)
+
+// FromSet creates an inclusive ExistenceClaim from the elements of a set.
+// The values are unique by construction; their order is unspecified. Use Negate for an exclusive claim.
+func FromSet[T comparable](s *set.Set[T]) ExistenceClaim[T] {
+	return In(s.ToSlice()...)
+}
+
+// FromSeq creates an inclusive ExistenceClaim from the values yielded by seq,
+// dropping duplicates while keeping the order of first occurrence. Use Negate for an exclusive claim.
+func FromSeq[T comparable](seq iter.Seq[T]) ExistenceClaim[T] {
+	seen := make(map[T]struct{})
+	values := make([]T, 0)
+	for v := range seq {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+	return In(values...)
+}
+
+// Map returns a claim with f applied to each value and the same Contains flag,
+// e.g. to translate external identifiers into internal IDs before evaluation.
+// Values that map to the same result are kept; use a Normalize function to drop them.
+func Map[T, U any](e ExistenceClaim[T], f func(T) U) ExistenceClaim[U] {
+	var values []U
+	if e.Values != nil {
+		values = make([]U, len(e.Values))
+		for i, v := range e.Values {
+			values[i] = f(v)
+		}
+	}
+	return ExistenceClaim[U]{Values: values, Contains: e.Contains}
+}
+
+// ToSet returns the claim's values as a set, dropping duplicates.
+// The Contains flag is not represented and must be tracked by the caller.
+func ToSet[T comparable](e ExistenceClaim[T]) *set.Set[T] {
+	return set.New(e.Values...)
+}
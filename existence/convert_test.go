@@ -0,0 +1,57 @@
+package existence
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dullkingsman/kozo/set"
+)
+
+func TestFromSet(t *testing.T) {
+	ec := FromSet(set.New("a", "b", "a"))
+	if !ec.Contains || ec.Len() != 2 {
+		t.Errorf("FromSet() = %+v, want an In claim with 2 values", ec)
+	}
+	if !CheckComparable(ec, "a") || CheckComparable(ec, "c") {
+		t.Error("Claim from set does not match expected behavior")
+	}
+}
+
+func TestFromSeq(t *testing.T) {
+	ec := FromSeq(slices.Values([]int{3, 1, 3, 2, 1}))
+	if !reflect.DeepEqual(ec.Values, []int{3, 1, 2}) || !ec.Contains {
+		t.Errorf("FromSeq() = %+v, want In(3, 1, 2)", ec)
+	}
+
+	keys := FromSeq(maps.Keys(map[string]int{"x": 1}))
+	if !reflect.DeepEqual(keys.Values, []string{"x"}) {
+		t.Errorf("FromSeq(maps.Keys) = %+v", keys)
+	}
+
+	if empty := FromSeq(slices.Values([]int(nil))); !empty.IsEmpty() || empty.Values == nil {
+		t.Errorf("FromSeq() of an empty sequence = %+v, want an empty non-nil claim", empty)
+	}
+}
+
+func TestToSet(t *testing.T) {
+	s := ToSet(NotIn(1, 2, 2))
+	if s.Len() != 2 || !s.Contains(1) || !s.Contains(2) {
+		t.Errorf("ToSet() = %v", s.ToSlice())
+	}
+}
+
+func TestMap(t *testing.T) {
+	ids := map[string]int{"alice": 1, "bob": 2}
+	ec := Map(NotIn("alice", "bob"), func(name string) int { return ids[name] })
+
+	if !reflect.DeepEqual(ec.Values, []int{1, 2}) || ec.Contains {
+		t.Errorf("Map() = %+v, want NotIn(1, 2)", ec)
+	}
+
+	if empty := Map(ExistenceClaim[string]{Contains: true}, strings.ToUpper); empty.Values != nil || !empty.Contains {
+		t.Errorf("Map() of an empty claim = %+v", empty)
+	}
+}
@@ -1,5 +1,14 @@
 package existence
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"iter"
+
+	"github.com/dullkingsman/kozo/codec"
+)
+
 // ExistenceClaim represents a filter condition:
 // either "the value must be in this set" (Contains=true)
 // or "the value must NOT be in this set" (Contains=false).
@@ -78,3 +87,146 @@ func (e ExistenceClaim[T]) Apply(slice []T, equals func(T, T) bool) []T {
 	}
 	return result
 }
+
+// CheckAll returns true if every element of the slice satisfies the claim. An empty slice satisfies it trivially.
+func (e ExistenceClaim[T]) CheckAll(slice []T, equals func(T, T) bool) bool {
+	for _, v := range slice {
+		if !e.Check(v, equals) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckAny returns true if at least one element of the slice satisfies the claim.
+func (e ExistenceClaim[T]) CheckAny(slice []T, equals func(T, T) bool) bool {
+	for _, v := range slice {
+		if e.Check(v, equals) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter removes the elements that do not satisfy the claim from the slice in place, keeping their order.
+// Unlike Apply it does not allocate: the kept elements are compacted into the existing backing array
+// and the vacated tail is zeroed so the garbage collector can reclaim it.
+func (e ExistenceClaim[T]) Filter(slice *[]T, equals func(T, T) bool) {
+	*slice = filterInPlace(*slice, func(v T) bool { return e.Check(v, equals) })
+}
+
+// FilterSeq returns an iterator over the elements of seq that satisfy the claim.
+// Elements are checked lazily as the iterator is consumed, so no intermediate slice is materialized.
+func (e ExistenceClaim[T]) FilterSeq(seq iter.Seq[T], equals func(T, T) bool) iter.Seq[T] {
+	return filterSeq(seq, func(v T) bool { return e.Check(v, equals) })
+}
+
+// Chunk splits the claim into claims of at most maxLen values each, all with the same Contains flag,
+// e.g. to stay below a database driver's limit on bind parameters.
+// The original claim is equivalent to the chunks combined with OR for an In claim,
+// and with AND for a NotIn claim. A claim without values, or a non-positive maxLen, yields the claim itself.
+// The chunks share the claim's backing array.
+func (e ExistenceClaim[T]) Chunk(maxLen int) []ExistenceClaim[T] {
+	if maxLen <= 0 || len(e.Values) <= maxLen {
+		return []ExistenceClaim[T]{e}
+	}
+
+	chunks := make([]ExistenceClaim[T], 0, (len(e.Values)+maxLen-1)/maxLen)
+	for start := 0; start < len(e.Values); start += maxLen {
+		end := min(start+maxLen, len(e.Values))
+		// Cap each chunk so that appending to it cannot overwrite the next one.
+		chunks = append(chunks, ExistenceClaim[T]{Values: e.Values[start:end:end], Contains: e.Contains})
+	}
+	return chunks
+}
+
+// MarshalJSON encodes the claim, encoding each value with the codec registered for T.
+func (e ExistenceClaim[T]) MarshalJSON() ([]byte, error) {
+	values := []byte("null")
+	if e.Values != nil {
+		var err error
+		if values, err = codec.MarshalSlice(e.Values); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(struct {
+		Values   json.RawMessage `json:"in"`
+		Contains bool            `json:"contains"`
+	}{values, e.Contains})
+}
+
+// UnmarshalJSON decodes the claim, decoding each value with the codec registered for T.
+// Both the default form {"in": [...], "contains": bool} and the keyed {"not_in": [...]} written by Keyed
+// are accepted. An "in" without a "contains" flag keeps its historical meaning of an exclusive claim,
+// the zero value of Contains, so that stored claims decode as before; decode through Keyed to read
+// a bare "in" as inclusive.
+func (e *ExistenceClaim[T]) UnmarshalJSON(data []byte) error {
+	return e.unmarshalJSON(data, false)
+}
+
+// unmarshalJSON decodes either form, reading an "in" without a "contains" flag as inclusive if keyed.
+func (e *ExistenceClaim[T]) unmarshalJSON(data []byte, keyed bool) error {
+	var raw struct {
+		Values    json.RawMessage `json:"in"`
+		NotValues json.RawMessage `json:"not_in"`
+		Contains  *bool           `json:"contains"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	e.Values = nil
+
+	values := raw.Values
+	switch {
+	case raw.NotValues != nil && raw.Values != nil:
+		return errors.New("existence: claim has both \"in\" and \"not_in\"")
+	case raw.NotValues != nil:
+		values = raw.NotValues
+		e.Contains = false
+	case raw.Contains != nil:
+		e.Contains = *raw.Contains
+	default:
+		e.Contains = keyed && raw.Values != nil
+	}
+
+	if len(values) == 0 || bytes.Equal(values, []byte("null")) {
+		return nil
+	}
+
+	decoded, err := codec.UnmarshalSlice[T]([]byte(values))
+	if err != nil {
+		return err
+	}
+	e.Values = decoded
+
+	return nil
+}
+
+// filterSeq returns an iterator over the elements of seq satisfying keep.
+func filterSeq[T any](seq iter.Seq[T], keep func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range seq {
+			if keep(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// filterInPlace compacts the elements satisfying keep to the front of s and zeroes the rest.
+func filterInPlace[T any](s []T, keep func(T) bool) []T {
+	n := 0
+	for _, v := range s {
+		if keep(v) {
+			s[n] = v
+			n++
+		}
+	}
+
+	// Zero out the vacated tail to prevent memory leaks (GC can reclaim it)
+	clear(s[n:])
+
+	return s[:n]
+}
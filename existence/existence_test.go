@@ -2,8 +2,11 @@ package existence
 
 import (
 	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
+
+	"github.com/dullkingsman/kozo/codec"
 )
 
 func TestExistenceClaim_In(t *testing.T) {
@@ -144,3 +147,125 @@ func TestExistenceClaim_JSON(t *testing.T) {
 		}
 	})
 }
+
+func TestExistenceClaim_JSONCodec(t *testing.T) {
+	type code int
+	codec.Register(codec.Funcs(
+		func(v code) ([]byte, error) { return json.Marshal(fmt.Sprintf("C%d", v)) },
+		func(data []byte, v *code) error {
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return err
+			}
+			_, err := fmt.Sscanf(s, "C%d", (*int)(v))
+			return err
+		},
+	))
+	defer codec.Unregister[code]()
+
+	data, err := json.Marshal(In[code](1, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"in":["C1","C2"],"contains":true}`
+	if string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", string(data), expected)
+	}
+
+	var ec ExistenceClaim[code]
+	if err := json.Unmarshal(data, &ec); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ec.Values, []code{1, 2}) || !ec.Contains {
+		t.Errorf("Unmarshal mismatch: %+v", ec)
+	}
+}
+
+func TestExistenceClaim_CheckAllAny(t *testing.T) {
+	equals := func(a, b int) bool { return a == b }
+	ec := In(1, 2)
+
+	if !ec.CheckAll([]int{1, 2, 1}, equals) || ec.CheckAll([]int{1, 3}, equals) {
+		t.Error("CheckAll mismatch")
+	}
+	if !ec.CheckAny([]int{3, 2}, equals) || ec.CheckAny([]int{3, 4}, equals) {
+		t.Error("CheckAny mismatch")
+	}
+	if !ec.CheckAll(nil, equals) || ec.CheckAny(nil, equals) {
+		t.Error("Expected an empty slice to satisfy CheckAll but not CheckAny")
+	}
+}
+
+func TestExistenceClaim_Filter(t *testing.T) {
+	equals := func(a, b int) bool { return a == b }
+	input := []int{1, 2, 3, 4, 1}
+	backing := input
+
+	NotIn(2, 4).Filter(&input, equals)
+
+	expected := []int{1, 3, 1}
+	if !reflect.DeepEqual(input, expected) {
+		t.Errorf("Filter failed. Got %v, want %v", input, expected)
+	}
+	if &input[0] != &backing[0] {
+		t.Error("Filter should reuse the backing array")
+	}
+	if backing[3] != 0 || backing[4] != 0 {
+		t.Errorf("Filter should zero the vacated tail, got %v", backing)
+	}
+}
+
+func TestExistenceClaim_FilterSeq(t *testing.T) {
+	equals := func(a, b int) bool { return a == b }
+
+	checked := 0
+	source := func(yield func(int) bool) {
+		for i := 1; i <= 100; i++ {
+			checked++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	var got []int
+	for v := range NotIn(1, 3).FilterSeq(source, equals) {
+		got = append(got, v)
+		if len(got) == 3 {
+			break
+		}
+	}
+
+	if !reflect.DeepEqual(got, []int{2, 4, 5}) {
+		t.Errorf("FilterSeq() = %v, want [2 4 5]", got)
+	}
+	if checked != 5 {
+		t.Errorf("Expected FilterSeq to consume lazily, consumed %d elements", checked)
+	}
+}
+
+func TestExistenceClaim_Chunk(t *testing.T) {
+	chunks := NotIn(1, 2, 3, 4, 5).Chunk(2)
+
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks, got %d", len(chunks))
+	}
+	expected := [][]int{{1, 2}, {3, 4}, {5}}
+	for i, c := range chunks {
+		if !reflect.DeepEqual(c.Values, expected[i]) || c.Contains {
+			t.Errorf("chunk %d = %+v, want NotIn%v", i, c, expected[i])
+		}
+	}
+
+	chunks[0].Values = append(chunks[0].Values, 99)
+	if chunks[1].Values[0] != 3 {
+		t.Error("Appending to a chunk should not overwrite the next one")
+	}
+
+	if got := In(1, 2).Chunk(5); len(got) != 1 || got[0].Len() != 2 {
+		t.Errorf("Expected a small claim to stay whole, got %+v", got)
+	}
+	if got := In[int]().Chunk(5); len(got) != 1 || !got[0].Contains {
+		t.Errorf("Expected an empty claim to yield itself, got %+v", got)
+	}
+}
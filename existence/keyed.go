@@ -0,0 +1,36 @@
+package existence
+
+import (
+	"encoding/json"
+
+	"github.com/dullkingsman/kozo/codec"
+)
+
+// Keyed wraps an ExistenceClaim to use the keyed wire format, where the operator is the key:
+// {"in": [...]} for inclusive claims and {"not_in": [...]} for exclusive ones.
+// It decodes both the keyed and the default {"in": [...], "contains": bool} form,
+// so an API can switch its output format without breaking older clients.
+type Keyed[T any] struct {
+	ExistenceClaim[T]
+}
+
+// MarshalJSON encodes the claim in the keyed form, encoding each value with the codec registered for T.
+// A claim without values is written with an empty list rather than null.
+func (k Keyed[T]) MarshalJSON() ([]byte, error) {
+	values, err := codec.MarshalSlice(k.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	key := "not_in"
+	if k.Contains {
+		key = "in"
+	}
+	return json.Marshal(map[string]json.RawMessage{key: values})
+}
+
+// UnmarshalJSON decodes the claim from either the keyed or the default form.
+// Unlike ExistenceClaim.UnmarshalJSON, an "in" without a "contains" flag is inclusive.
+func (k *Keyed[T]) UnmarshalJSON(data []byte) error {
+	return k.ExistenceClaim.unmarshalJSON(data, true)
+}
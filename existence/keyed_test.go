@@ -0,0 +1,92 @@
+package existence
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestKeyed_Marshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		claim    ExistenceClaim[int]
+		expected string
+	}{
+		{"In", In(1, 2), `{"in":[1,2]}`},
+		{"NotIn", NotIn(3), `{"not_in":[3]}`},
+		{"Empty", NotIn[int](), `{"not_in":[]}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(Keyed[int]{tt.claim})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.expected {
+			t.Errorf("%s: Marshal mismatch. Got %s, want %s", tt.name, data, tt.expected)
+		}
+	}
+}
+
+func TestKeyed_Unmarshal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ExistenceClaim[int]
+	}{
+		{`{"in":[1,2]}`, In(1, 2)},
+		{`{"not_in":[3]}`, NotIn(3)},
+		{`{"in":[1],"contains":false}`, NotIn(1)},
+		{`{"in":[1],"contains":true}`, In(1)},
+	}
+
+	for _, tt := range tests {
+		var k Keyed[int]
+		if err := json.Unmarshal([]byte(tt.input), &k); err != nil {
+			t.Fatalf("Unmarshal(%s) failed: %v", tt.input, err)
+		}
+		if !reflect.DeepEqual(k.ExistenceClaim, tt.expected) {
+			t.Errorf("Unmarshal(%s) = %+v, want %+v", tt.input, k.ExistenceClaim, tt.expected)
+		}
+
+	}
+
+	// ExistenceClaim decodes the keyed form too, but reads a bare "in" as it always has: exclusive.
+	for input, expected := range map[string]ExistenceClaim[int]{
+		`{"in":[1,2]}`:                NotIn(1, 2),
+		`{"not_in":[3]}`:              NotIn(3),
+		`{"in":[1],"contains":true}`:  In(1),
+		`{"in":[1],"contains":false}`: NotIn(1),
+	} {
+		var ec ExistenceClaim[int]
+		if err := json.Unmarshal([]byte(input), &ec); err != nil || !reflect.DeepEqual(ec, expected) {
+			t.Errorf("ExistenceClaim Unmarshal(%s) = %+v, %v; want %+v", input, ec, err, expected)
+		}
+	}
+
+	var k Keyed[int]
+	if err := json.Unmarshal([]byte(`{"in":[1],"not_in":[2]}`), &k); err == nil {
+		t.Error("Expected an error for a claim with both operators")
+	}
+}
+
+func TestKeyed_InStruct(t *testing.T) {
+	type filter struct {
+		Status Keyed[string] `json:"status"`
+	}
+
+	data, err := json.Marshal(filter{Keyed[string]{NotIn("deleted")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"status":{"not_in":["deleted"]}}` {
+		t.Errorf("Marshal mismatch. Got %s", data)
+	}
+
+	var f filter
+	if err := json.Unmarshal(data, &f); err != nil {
+		t.Fatal(err)
+	}
+	if CheckComparable(f.Status.ExistenceClaim, "deleted") {
+		t.Error("Expected the decoded claim to exclude \"deleted\"")
+	}
+}
@@ -0,0 +1,72 @@
+package existence
+
+import "iter"
+
+// Matcher is a compiled form of an ExistenceClaim over comparable values.
+// It holds the claim's values in a map so that each Check is O(1) instead of O(n),
+// which pays off when the same claim is evaluated against many values.
+// A Matcher is immutable and safe for concurrent use.
+type Matcher[T comparable] struct {
+	values   map[T]struct{}
+	contains bool
+}
+
+// Compile builds a Matcher from the claim. Later changes to the claim's Values do not affect the Matcher.
+func Compile[T comparable](e ExistenceClaim[T]) *Matcher[T] {
+	values := make(map[T]struct{}, len(e.Values))
+	for _, v := range e.Values {
+		values[v] = struct{}{}
+	}
+	return &Matcher[T]{values: values, contains: e.Contains}
+}
+
+// CheckerComparable compiles the claim and returns its Check function, for use as a plain predicate.
+func CheckerComparable[T comparable](e ExistenceClaim[T]) func(T) bool {
+	return Compile(e).Check
+}
+
+// Check determines if a value satisfies the compiled claim.
+func (m *Matcher[T]) Check(val T) bool {
+	_, found := m.values[val]
+	return found == m.contains
+}
+
+// Matches is the same as Check, so that a Matcher can be combined with other claims.
+func (m *Matcher[T]) Matches(val T) bool {
+	return m.Check(val)
+}
+
+// Len returns the number of distinct values in the compiled claim.
+func (m *Matcher[T]) Len() int {
+	return len(m.values)
+}
+
+// CheckAll returns true if every element of the slice satisfies the compiled claim.
+func (m *Matcher[T]) CheckAll(slice []T) bool {
+	for _, v := range slice {
+		if !m.Check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckAny returns true if at least one element of the slice satisfies the compiled claim.
+func (m *Matcher[T]) CheckAny(slice []T) bool {
+	for _, v := range slice {
+		if m.Check(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Filter removes the elements that do not satisfy the compiled claim from the slice in place, keeping their order.
+func (m *Matcher[T]) Filter(slice *[]T) {
+	*slice = filterInPlace(*slice, m.Check)
+}
+
+// FilterSeq returns an iterator over the elements of seq that satisfy the compiled claim, checked lazily.
+func (m *Matcher[T]) FilterSeq(seq iter.Seq[T]) iter.Seq[T] {
+	return filterSeq(seq, m.Check)
+}
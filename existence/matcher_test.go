@@ -0,0 +1,91 @@
+package existence
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMatcher(t *testing.T) {
+	in := Compile(In("a", "b", "a"))
+	if !in.Check("a") || in.Check("c") {
+		t.Error("Compiled In claim does not match expected behavior")
+	}
+	if in.Len() != 2 {
+		t.Errorf("Expected duplicates to collapse, got Len %d", in.Len())
+	}
+
+	notIn := CheckerComparable(NotIn(1, 2))
+	if notIn(1) || !notIn(3) {
+		t.Error("Compiled NotIn claim does not match expected behavior")
+	}
+
+	if Compile(In[int]()).Check(1) || !Compile(NotIn[int]()).Check(1) {
+		t.Error("Compiled empty claims do not match expected behavior")
+	}
+}
+
+func TestMatcher_Isolated(t *testing.T) {
+	claim := In(1, 2)
+	m := Compile(claim)
+	claim.Values[0] = 3
+
+	if !m.Check(1) || m.Check(3) {
+		t.Error("Matcher should not observe later changes to the claim")
+	}
+}
+
+func TestMatcher_Claim(t *testing.T) {
+	var c Claim[int] = And[int](Compile(In(1, 2, 3)), Compile(NotIn(2)))
+	if !c.Matches(1) || c.Matches(2) || c.Matches(4) {
+		t.Error("Matcher should compose as a Claim")
+	}
+}
+
+func BenchmarkCheckComparable(b *testing.B) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i
+	}
+	claim := In(values...)
+
+	b.Run("Linear", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			CheckComparable(claim, 999)
+		}
+	})
+
+	b.Run("Compiled", func(b *testing.B) {
+		m := Compile(claim)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			m.Check(999)
+		}
+	})
+}
+
+func TestMatcher_Bulk(t *testing.T) {
+	m := Compile(In("a", "b"))
+
+	if !m.CheckAll([]string{"a", "b"}) || m.CheckAll([]string{"a", "c"}) {
+		t.Error("CheckAll mismatch")
+	}
+	if !m.CheckAny([]string{"c", "b"}) || m.CheckAny([]string{"c"}) {
+		t.Error("CheckAny mismatch")
+	}
+
+	s := []string{"c", "a", "d", "b"}
+	m.Filter(&s)
+	if len(s) != 2 || s[0] != "a" || s[1] != "b" {
+		t.Errorf("Filter failed. Got %v", s)
+	}
+}
+
+func TestMatcher_FilterSeq(t *testing.T) {
+	var got []string
+	for v := range Compile(In("a", "c")).FilterSeq(slices.Values([]string{"a", "b", "c"})) {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("FilterSeq() = %v", got)
+	}
+}
@@ -0,0 +1,51 @@
+package existence
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Normalize returns a copy of the claim with duplicate values removed using a custom equality function,
+// keeping the order of first occurrence. It runs in O(n²); prefer NormalizeComparable or NormalizeSorted
+// for large claims.
+func (e ExistenceClaim[T]) Normalize(equals func(T, T) bool) ExistenceClaim[T] {
+	values := make([]T, 0, len(e.Values))
+	for _, v := range e.Values {
+		if !slices.ContainsFunc(values, func(u T) bool { return equals(u, v) }) {
+			values = append(values, v)
+		}
+	}
+	return ExistenceClaim[T]{Values: values, Contains: e.Contains}
+}
+
+// NormalizeSorted returns a copy of the claim with its values sorted by compare and duplicates removed,
+// so that claims with the same values compare equal and encode identically.
+func (e ExistenceClaim[T]) NormalizeSorted(compare func(T, T) int) ExistenceClaim[T] {
+	values := slices.Clone(e.Values)
+	if values == nil {
+		values = make([]T, 0)
+	}
+	slices.SortFunc(values, compare)
+	values = slices.CompactFunc(values, func(a, b T) bool { return compare(a, b) == 0 })
+	return ExistenceClaim[T]{Values: values, Contains: e.Contains}
+}
+
+// NormalizeComparable returns a copy of the claim with duplicate values removed for comparable types,
+// keeping the order of first occurrence.
+func NormalizeComparable[T comparable](e ExistenceClaim[T]) ExistenceClaim[T] {
+	seen := make(map[T]struct{}, len(e.Values))
+	values := make([]T, 0, len(e.Values))
+	for _, v := range e.Values {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		values = append(values, v)
+	}
+	return ExistenceClaim[T]{Values: values, Contains: e.Contains}
+}
+
+// NormalizeOrdered returns a copy of the claim with its values sorted and duplicates removed for ordered types.
+func NormalizeOrdered[T cmp.Ordered](e ExistenceClaim[T]) ExistenceClaim[T] {
+	return e.NormalizeSorted(cmp.Compare[T])
+}
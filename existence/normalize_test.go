@@ -0,0 +1,44 @@
+package existence
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExistenceClaim_Normalize(t *testing.T) {
+	ec := In("a", "B", "A", "b", "c")
+	got := ec.Normalize(strings.EqualFold)
+	if !reflect.DeepEqual(got.Values, []string{"a", "B", "c"}) || !got.Contains {
+		t.Errorf("Normalize() = %+v", got)
+	}
+	if len(ec.Values) != 5 {
+		t.Error("Normalize should not modify the original claim")
+	}
+}
+
+func TestNormalizeComparable(t *testing.T) {
+	got := NormalizeComparable(NotIn(3, 1, 3, 2, 1))
+	if !reflect.DeepEqual(got.Values, []int{3, 1, 2}) || got.Contains {
+		t.Errorf("NormalizeComparable() = %+v", got)
+	}
+}
+
+func TestNormalizeOrdered(t *testing.T) {
+	a := NormalizeOrdered(In(3, 1, 3, 2))
+	b := NormalizeOrdered(In(2, 1, 3))
+
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("Expected normalized claims to be equal, got %+v and %+v", a, b)
+	}
+
+	data, _ := json.Marshal(a)
+	if string(data) != `{"in":[1,2,3],"contains":true}` {
+		t.Errorf("Unexpected encoding %s", data)
+	}
+
+	if empty := NormalizeOrdered(In[int]()); empty.Values == nil {
+		t.Error("Expected an empty claim to keep a non-nil slice")
+	}
+}
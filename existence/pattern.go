@@ -0,0 +1,158 @@
+package existence
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Syntax selects how the patterns of a PatternClaim are interpreted.
+type Syntax string
+
+const (
+	// Glob patterns match the whole string. "*" matches any run of characters except "/",
+	// "**" also matches across "/", "?" matches one character except "/", and "[...]" is a
+	// character class ("[!...]" negates it). Other characters match themselves.
+	Glob Syntax = "glob"
+	// Regexp patterns use RE2 syntax (package regexp) and match anywhere in the string unless anchored.
+	Regexp Syntax = "regexp"
+)
+
+// PatternClaim is the pattern-based sibling of ExistenceClaim: a string satisfies it if it matches
+// any of the patterns (Contains=true), or none of them (Contains=false).
+// An empty Syntax is treated as Glob.
+type PatternClaim struct {
+	Patterns []string `json:"in"`
+	Contains bool     `json:"contains"`
+	Syntax   Syntax   `json:"syntax,omitempty"`
+}
+
+// InGlob creates an inclusive PatternClaim matching strings that match any of the glob patterns.
+func InGlob(patterns ...string) PatternClaim {
+	return PatternClaim{Patterns: patterns, Contains: true, Syntax: Glob}
+}
+
+// NotInGlob creates an exclusive PatternClaim matching strings that match none of the glob patterns,
+// e.g. NotInGlob("/admin/**", "/internal/**").
+func NotInGlob(patterns ...string) PatternClaim {
+	return PatternClaim{Patterns: patterns, Contains: false, Syntax: Glob}
+}
+
+// InRegexp creates an inclusive PatternClaim matching strings that match any of the regular expressions.
+func InRegexp(patterns ...string) PatternClaim {
+	return PatternClaim{Patterns: patterns, Contains: true, Syntax: Regexp}
+}
+
+// NotInRegexp creates an exclusive PatternClaim matching strings that match none of the regular expressions.
+func NotInRegexp(patterns ...string) PatternClaim {
+	return PatternClaim{Patterns: patterns, Contains: false, Syntax: Regexp}
+}
+
+// Negate returns a new PatternClaim with the Contains flag flipped.
+func (p PatternClaim) Negate() PatternClaim {
+	return PatternClaim{Patterns: p.Patterns, Contains: !p.Contains, Syntax: p.Syntax}
+}
+
+// Check determines if a string satisfies the claim. It compiles the patterns on every call;
+// use Compile to evaluate the same claim repeatedly.
+func (p PatternClaim) Check(s string) (bool, error) {
+	m, err := p.Compile()
+	if err != nil {
+		return false, err
+	}
+	return m.Check(s), nil
+}
+
+// Compile validates the patterns and combines them into a single PatternMatcher.
+func (p PatternClaim) Compile() (*PatternMatcher, error) {
+	if len(p.Patterns) == 0 {
+		return &PatternMatcher{contains: p.Contains}, nil
+	}
+
+	alternatives := make([]string, len(p.Patterns))
+	for i, pattern := range p.Patterns {
+		switch p.Syntax {
+		case Glob, "":
+			expr, err := globToRegexp(pattern)
+			if err != nil {
+				return nil, err
+			}
+			alternatives[i] = expr
+		case Regexp:
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, err
+			}
+			alternatives[i] = "(?:" + pattern + ")"
+		default:
+			return nil, fmt.Errorf("existence: unknown pattern syntax %q", p.Syntax)
+		}
+	}
+
+	re, err := regexp.Compile(strings.Join(alternatives, "|"))
+	if err != nil {
+		return nil, err
+	}
+	return &PatternMatcher{re: re, contains: p.Contains}, nil
+}
+
+// MustCompile is like Compile but panics if a pattern is invalid.
+func (p PatternClaim) MustCompile() *PatternMatcher {
+	m, err := p.Compile()
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// PatternMatcher is a compiled PatternClaim. It is immutable and safe for concurrent use.
+type PatternMatcher struct {
+	re       *regexp.Regexp // nil when there are no patterns
+	contains bool
+}
+
+// Check determines if a string satisfies the compiled claim.
+func (m *PatternMatcher) Check(s string) bool {
+	found := m.re != nil && m.re.MatchString(s)
+	return found == m.contains
+}
+
+// Matches is the same as Check, so that a PatternMatcher can be combined with other claims.
+func (m *PatternMatcher) Matches(s string) bool {
+	return m.Check(s)
+}
+
+// globToRegexp translates a glob pattern into an anchored regular expression.
+func globToRegexp(glob string) (string, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				return "", fmt.Errorf("existence: unterminated character class in glob %q", glob)
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+	return "(?:" + b.String() + ")", nil
+}
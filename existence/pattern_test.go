@@ -0,0 +1,102 @@
+package existence
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPatternClaim_Glob(t *testing.T) {
+	m := NotInGlob("/admin/**", "/internal/*", "*.tmp", "/v?/[!x]*").MustCompile()
+
+	tests := []struct {
+		s        string
+		expected bool
+	}{
+		{"/admin/users/1", false},
+		{"/internal/health", false},
+		{"/internal/a/b", true},
+		{"report.tmp", false},
+		{"dir/report.tmp", true},
+		{"/v1/users", false},
+		{"/v1/xusers", true},
+		{"/public", true},
+	}
+
+	for _, tt := range tests {
+		if got := m.Check(tt.s); got != tt.expected {
+			t.Errorf("Check(%q) = %v, want %v", tt.s, got, tt.expected)
+		}
+	}
+}
+
+func TestPatternClaim_Regexp(t *testing.T) {
+	claim := InRegexp(`^user-\d+$`, `@example\.com`)
+
+	for s, expected := range map[string]bool{
+		"user-42":           true,
+		"user-x":            false,
+		"bob@example.com":   true,
+		"bob@exampleXcom":   false,
+		"prefix user-42 ok": false,
+	} {
+		got, err := claim.Check(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != expected {
+			t.Errorf("Check(%q) = %v, want %v", s, got, expected)
+		}
+	}
+
+	if ok, _ := claim.Negate().Check("user-42"); ok {
+		t.Error("Expected the negated claim to exclude matches")
+	}
+}
+
+func TestPatternClaim_Invalid(t *testing.T) {
+	for _, claim := range []PatternClaim{
+		InRegexp(`(unclosed`),
+		InGlob(`[abc`),
+		{Patterns: []string{"a"}, Syntax: "sql"},
+	} {
+		if _, err := claim.Compile(); err == nil {
+			t.Errorf("Expected Compile(%+v) to fail", claim)
+		}
+	}
+}
+
+func TestPatternClaim_Empty(t *testing.T) {
+	if InGlob().MustCompile().Check("a") || !NotInGlob().MustCompile().Check("a") {
+		t.Error("Empty pattern claims do not match expected behavior")
+	}
+}
+
+func TestPatternClaim_JSON(t *testing.T) {
+	data, err := json.Marshal(NotInGlob("/admin/**"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"in":["/admin/**"],"contains":false,"syntax":"glob"}`
+	if string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", data, expected)
+	}
+
+	var claim PatternClaim
+	if err := json.Unmarshal([]byte(`{"in":["a*"],"contains":true}`), &claim); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := claim.Check("abc"); !ok || err != nil {
+		t.Errorf("Expected a claim without syntax to default to glob, got %v, %v", ok, err)
+	}
+}
+
+func TestPatternMatcher_Claim(t *testing.T) {
+	type request struct{ Path string }
+
+	paths := NotInGlob("/admin/**").MustCompile()
+	filter := And[request](ClaimFunc[request](func(r request) bool { return paths.Matches(r.Path) }))
+
+	if filter.Matches(request{"/admin/x"}) || !filter.Matches(request{"/home"}) {
+		t.Error("PatternMatcher should compose with other claims")
+	}
+}
@@ -0,0 +1,42 @@
+package existence
+
+import (
+	"strings"
+
+	"github.com/dullkingsman/kozo/dialect"
+)
+
+// ToSQL returns a parameterized SQL predicate restricting column to the claim, with its arguments,
+// e.g. "col IN (?, ?)" or "col NOT IN (?, ?)". A claim without values yields "1=0" for In and "1=1" for NotIn,
+// matching Check. Note that in SQL a NULL column satisfies neither IN nor NOT IN.
+//
+// Values are always passed as arguments, never interpolated. The column is inserted verbatim,
+// so it must come from trusted code rather than user input. See Chunk for very large claims.
+func (e ExistenceClaim[T]) ToSQL(column string, d dialect.Dialect) (string, []any) {
+	if len(e.Values) == 0 {
+		if e.Contains {
+			return "1=0", nil
+		}
+		return "1=1", nil
+	}
+
+	var b strings.Builder
+	b.WriteString(column)
+	if e.Contains {
+		b.WriteString(" IN (")
+	} else {
+		b.WriteString(" NOT IN (")
+	}
+
+	args := make([]any, len(e.Values))
+	for i, v := range e.Values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(d.Placeholder(i + 1))
+		args[i] = v
+	}
+	b.WriteString(")")
+
+	return b.String(), args
+}
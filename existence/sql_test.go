@@ -0,0 +1,31 @@
+package existence
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dullkingsman/kozo/dialect"
+)
+
+func TestExistenceClaim_ToSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		claim    ExistenceClaim[string]
+		d        dialect.Dialect
+		expected string
+		args     []any
+	}{
+		{"In", In("a", "b"), dialect.MySQL, "status IN (?, ?)", []any{"a", "b"}},
+		{"NotIn", NotIn("x"), dialect.PostgreSQL, "status NOT IN ($1)", []any{"x"}},
+		{"Offset", In("a", "b"), dialect.Offset(dialect.PostgreSQL, 3), "status IN ($4, $5)", []any{"a", "b"}},
+		{"Empty In", In[string](), dialect.MySQL, "1=0", nil},
+		{"Empty NotIn", NotIn[string](), dialect.MySQL, "1=1", nil},
+	}
+
+	for _, tt := range tests {
+		sql, args := tt.claim.ToSQL("status", tt.d)
+		if sql != tt.expected || !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("%s: ToSQL() = %q, %v; want %q, %v", tt.name, sql, args, tt.expected, tt.args)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+// Package fenwick provides a Fenwick tree (binary indexed tree) for prefix sums over a mutable array.
+package fenwick
+
+import (
+	"fmt"
+	"math/bits"
+	"sync"
+
+	_range "github.com/dullkingsman/kozo/range"
+)
+
+// Tree is a thread-safe array of numbers that answers prefix and range sums, e.g. cumulative counts per bucket
+// of a histogram. Add, Set, PrefixSum, RangeSum and LowerBound are O(log n), using n values of memory:
+// a lighter alternative to a segment tree when only sums are needed.
+//
+// Indexes are 0-based, and out-of-range indexes panic like slice indexes. The length is fixed at construction,
+// so a Tree is not a collection.Container.
+type Tree[T _range.Number] struct {
+	mu sync.RWMutex
+	// sums[i-1] holds the sum of the values in (i - lowbit(i), i], using the 1-based indexes of the classic structure.
+	sums []T
+}
+
+// New returns a new Tree of n zero values.
+func New[T _range.Number](n int) *Tree[T] {
+	return &Tree[T]{sums: make([]T, n)}
+}
+
+// From returns a new Tree holding a copy of values, built in O(n).
+func From[T _range.Number](values []T) *Tree[T] {
+	sums := append([]T(nil), values...)
+	for i := 1; i <= len(sums); i++ {
+		if parent := i + i&-i; parent <= len(sums) {
+			sums[parent-1] += sums[i-1]
+		}
+	}
+	return &Tree[T]{sums: sums}
+}
+
+// Add adds delta to the value at index i.
+func (t *Tree[T]) Add(i int, delta T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.add(i, delta)
+}
+
+// Set sets the value at index i.
+func (t *Tree[T]) Set(i int, value T) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.add(i, value-t.rangeSum(i, i+1))
+}
+
+// Get returns the value at index i.
+func (t *Tree[T]) Get(i int) T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.rangeSum(i, i+1)
+}
+
+// PrefixSum returns the sum of the values at indexes [0, n).
+func (t *Tree[T]) PrefixSum(n int) T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	checkIndex(n, len(t.sums))
+	return t.prefixSum(n)
+}
+
+// RangeSum returns the sum of the values at indexes [from, to).
+func (t *Tree[T]) RangeSum(from, to int) T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.rangeSum(from, to)
+}
+
+// Total returns the sum of all values.
+func (t *Tree[T]) Total() T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.prefixSum(len(t.sums))
+}
+
+// LowerBound returns the smallest index i such that PrefixSum(i+1) >= target, or Len() if the total is less than target.
+// The values must not be negative, so that prefix sums are non-decreasing.
+//
+// When the values count occurrences of their index, LowerBound(k) is the index of the k-th occurrence, counting from 1,
+// e.g. the bucket holding the median of a histogram is LowerBound((Total()+1)/2).
+func (t *Tree[T]) LowerBound(target T) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	// Descend the implicit tree, skipping blocks whose sums stay below the target.
+	pos := 0
+	for step := highestPowerOfTwo(len(t.sums)); step > 0; step >>= 1 {
+		if next := pos + step; next <= len(t.sums) && t.sums[next-1] < target {
+			pos = next
+			target -= t.sums[next-1]
+		}
+	}
+	return pos
+}
+
+// Len returns the number of values.
+func (t *Tree[T]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.sums)
+}
+
+// Values returns a copy of the values.
+func (t *Tree[T]) Values() []T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	values := append([]T(nil), t.sums...)
+	for i := len(values); i >= 1; i-- {
+		if parent := i + i&-i; parent <= len(values) {
+			values[parent-1] -= values[i-1]
+		}
+	}
+	return values
+}
+
+// Clear sets all values to zero, keeping the length. Unlike collection.Container.Clear, it removes no elements.
+func (t *Tree[T]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	clear(t.sums)
+}
+
+func (t *Tree[T]) add(i int, delta T) {
+	checkIndex(i, len(t.sums)-1)
+	for i++; i <= len(t.sums); i += i & -i {
+		t.sums[i-1] += delta
+	}
+}
+
+func (t *Tree[T]) prefixSum(n int) T {
+	var sum T
+	for ; n > 0; n -= n & -n {
+		sum += t.sums[n-1]
+	}
+	return sum
+}
+
+func (t *Tree[T]) rangeSum(from, to int) T {
+	checkIndex(to, len(t.sums))
+	if from < 0 || from > to {
+		panic(fmt.Sprintf("fenwick: invalid range [%d, %d)", from, to))
+	}
+	return t.prefixSum(to) - t.prefixSum(from)
+}
+
+func checkIndex(i, limit int) {
+	if i < 0 || i > limit {
+		panic(fmt.Sprintf("fenwick: index %d out of range [0, %d]", i, limit))
+	}
+}
+
+// highestPowerOfTwo returns the largest power of two not greater than n, or 0 if n is 0.
+func highestPowerOfTwo(n int) int {
+	if n == 0 {
+		return 0
+	}
+	return 1 << (bits.Len(uint(n)) - 1)
+}
@@ -0,0 +1,132 @@
+package fenwick
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	tr := From([]int{3, 1, 4, 1, 5, 9, 2, 6})
+	if tr.Len() != 8 || tr.Total() != 31 {
+		t.Errorf("Len() = %d, Total() = %d", tr.Len(), tr.Total())
+	}
+	if s := tr.PrefixSum(3); s != 8 {
+		t.Errorf("PrefixSum(3) = %d, want 8", s)
+	}
+	if s := tr.RangeSum(2, 6); s != 19 {
+		t.Errorf("RangeSum(2, 6) = %d, want 19", s)
+	}
+	if s := tr.PrefixSum(0); s != 0 {
+		t.Errorf("PrefixSum(0) = %d, want 0", s)
+	}
+
+	tr.Add(5, -4)
+	tr.Set(0, 10)
+	if v := tr.Get(5); v != 5 {
+		t.Errorf("Get(5) = %d, want 5", v)
+	}
+	if values := tr.Values(); !slices.Equal(values, []int{10, 1, 4, 1, 5, 5, 2, 6}) {
+		t.Errorf("Values() = %v", values)
+	}
+
+	tr.Clear()
+	if tr.Total() != 0 || !slices.Equal(tr.Values(), make([]int, 8)) {
+		t.Errorf("Expected Clear to zero the values, got %v", tr.Values())
+	}
+	if tr.Len() != 8 {
+		t.Errorf("Expected Clear to keep the length 8, got %d", tr.Len())
+	}
+}
+
+func TestTree_LowerBound(t *testing.T) {
+	// A histogram: 2 values in bucket 0, none in 1, 3 in 2, 1 in 3.
+	tr := From([]int{2, 0, 3, 1})
+	tests := map[int]int{0: 0, 1: 0, 2: 0, 3: 2, 5: 2, 6: 3, 7: 4}
+	for k, expected := range tests {
+		if i := tr.LowerBound(k); i != expected {
+			t.Errorf("LowerBound(%d) = %d, want %d", k, i, expected)
+		}
+	}
+	if i := New[int](0).LowerBound(1); i != 0 {
+		t.Errorf("LowerBound on an empty tree = %d, want 0", i)
+	}
+}
+
+func TestTree_Float(t *testing.T) {
+	tr := New[float64](3)
+	tr.Add(0, 0.5)
+	tr.Add(2, 1.25)
+	if s := tr.RangeSum(1, 3); s != 1.25 {
+		t.Errorf("RangeSum(1, 3) = %v, want 1.25", s)
+	}
+}
+
+func TestTree_Panics(t *testing.T) {
+	tr := New[int](4)
+	for name, f := range map[string]func(){
+		"Add":       func() { tr.Add(4, 1) },
+		"Get":       func() { tr.Get(-1) },
+		"PrefixSum": func() { tr.PrefixSum(5) },
+		"RangeSum":  func() { tr.RangeSum(3, 2) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Expected %s to panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 100
+	ref := make([]int, n)
+	for i := range ref {
+		ref[i] = rng.Intn(10)
+	}
+	tr := From(ref)
+
+	for range 5000 {
+		i := rng.Intn(n)
+		if rng.Intn(2) == 0 {
+			d := rng.Intn(10)
+			tr.Add(i, d)
+			ref[i] += d
+		} else {
+			v := rng.Intn(10)
+			tr.Set(i, v)
+			ref[i] = v
+		}
+
+		from, to := rng.Intn(n+1), rng.Intn(n+1)
+		if from > to {
+			from, to = to, from
+		}
+		sum := 0
+		for _, v := range ref[from:to] {
+			sum += v
+		}
+		if got := tr.RangeSum(from, to); got != sum {
+			t.Fatalf("RangeSum(%d, %d) = %d, want %d", from, to, got, sum)
+		}
+
+		target := rng.Intn(tr.Total() + 2)
+		expected, prefix := n, 0
+		for j, v := range ref {
+			if prefix += v; prefix >= target {
+				expected = j
+				break
+			}
+		}
+		if got := tr.LowerBound(target); got != expected {
+			t.Fatalf("LowerBound(%d) = %d, want %d", target, got, expected)
+		}
+	}
+	if !slices.Equal(tr.Values(), ref) {
+		t.Error("Values() disagree with the reference")
+	}
+}
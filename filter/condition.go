@@ -0,0 +1,175 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dullkingsman/kozo/codec"
+	"github.com/dullkingsman/kozo/existence"
+	optional "github.com/dullkingsman/kozo/optional"
+	_range "github.com/dullkingsman/kozo/range"
+)
+
+// Condition is a filter on a single field of type T. Every part that is set must hold;
+// a Condition with no parts set matches everything.
+//
+//   - Eq: None places no restriction, Some(value) requires equality and Some(null) requires NULL.
+//   - Claim: In or NotIn membership.
+//   - Between: the value must lie in the range.
+//   - IsNull: true requires NULL, false requires a value.
+//
+// As in SQL, a NULL value satisfies neither an In nor a NotIn claim, nor any range.
+type Condition[T any] struct {
+	Eq      optional.Optional[T]
+	Claim   *existence.ExistenceClaim[T]
+	Between *_range.Range[T]
+	IsNull  *bool
+}
+
+// Eq returns a condition requiring the field to equal v.
+func Eq[T any](v T) Condition[T] {
+	return Condition[T]{Eq: optional.Some(v)}
+}
+
+// In returns a condition requiring the field to be one of values.
+func In[T any](values ...T) Condition[T] {
+	claim := existence.In(values...)
+	return Condition[T]{Claim: &claim}
+}
+
+// NotIn returns a condition requiring the field to be none of values.
+func NotIn[T any](values ...T) Condition[T] {
+	claim := existence.NotIn(values...)
+	return Condition[T]{Claim: &claim}
+}
+
+// Between returns a condition requiring the field to lie in r.
+func Between[T any](r _range.Range[T]) Condition[T] {
+	return Condition[T]{Between: &r}
+}
+
+// IsNull returns a condition requiring the field to be NULL.
+func IsNull[T any]() Condition[T] {
+	isNull := true
+	return Condition[T]{IsNull: &isNull}
+}
+
+// IsNotNull returns a condition requiring the field to have a value.
+func IsNotNull[T any]() Condition[T] {
+	isNull := false
+	return Condition[T]{IsNull: &isNull}
+}
+
+// IsZero returns true if no part of the condition is set, so that it matches everything.
+func (c Condition[T]) IsZero() bool {
+	return c.Eq.IsNone() && c.Claim == nil && c.Between == nil && c.IsNull == nil
+}
+
+// Matches reports whether a field value satisfies the condition, where nil stands for NULL.
+// compare must return a negative number, zero or a positive number as a is less than, equal to or greater than b.
+func (c Condition[T]) Matches(v *T, compare func(a, b T) int) bool {
+	if c.IsNull != nil && *c.IsNull != (v == nil) {
+		return false
+	}
+
+	if c.Eq.IsSome() {
+		want, ok := c.Eq.Unwrap()
+		if !ok {
+			if v != nil {
+				return false
+			}
+		} else if v == nil || compare(*v, want) != 0 {
+			return false
+		}
+	}
+
+	if c.Claim != nil {
+		if v == nil || !c.Claim.Check(*v, func(a, b T) bool { return compare(a, b) == 0 }) {
+			return false
+		}
+	}
+
+	if c.Between != nil {
+		if v == nil || !c.Between.Contains(*v, func(a, b T) bool { return compare(a, b) < 0 }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// MarshalJSON encodes the condition as an object with the keys "eq", "in" or "not_in", "between" and "is_null",
+// omitting the parts that are not set.
+func (c Condition[T]) MarshalJSON() ([]byte, error) {
+	obj := make(map[string]any, 4)
+	if c.Eq.IsSome() {
+		obj["eq"] = c.Eq
+	}
+	if c.Claim != nil {
+		key := "not_in"
+		if c.Claim.Contains {
+			key = "in"
+		}
+		values, err := codec.MarshalSlice(c.Claim.Values)
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = json.RawMessage(values)
+	}
+	if c.Between != nil {
+		obj["between"] = c.Between
+	}
+	if c.IsNull != nil {
+		obj["is_null"] = *c.IsNull
+	}
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON decodes a condition written by MarshalJSON. "between" also accepts a string
+// in interval notation, e.g. "[10,20)". Unknown keys and combining "in" with "not_in" are errors.
+func (c *Condition[T]) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*c = Condition[T]{}
+	for key, value := range raw {
+		var err error
+		switch key {
+		case "eq":
+			err = c.Eq.UnmarshalJSON(value)
+		case "in", "not_in":
+			if c.Claim != nil {
+				return errors.New("filter: condition has both \"in\" and \"not_in\"")
+			}
+			var values []T
+			values, err = codec.UnmarshalSlice[T](value)
+			c.Claim = &existence.ExistenceClaim[T]{Values: values, Contains: key == "in"}
+		case "between":
+			var r _range.Range[T]
+			if bytes.HasPrefix(bytes.TrimSpace(value), []byte(`"`)) {
+				var text string
+				if err = json.Unmarshal(value, &text); err == nil {
+					r, err = _range.Parse[T](text)
+				}
+			} else {
+				err = json.Unmarshal(value, &r)
+			}
+			c.Between = &r
+		case "is_null":
+			var isNull bool
+			err = json.Unmarshal(value, &isNull)
+			c.IsNull = &isNull
+		default:
+			return fmt.Errorf("filter: unknown condition %q", key)
+		}
+		if err != nil {
+			return fmt.Errorf("filter: condition %q: %w", key, err)
+		}
+	}
+
+	return nil
+}
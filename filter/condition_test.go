@@ -0,0 +1,112 @@
+package filter
+
+import (
+	"cmp"
+	"encoding/json"
+	"testing"
+
+	_range "github.com/dullkingsman/kozo/range"
+)
+
+func ptr[T any](v T) *T { return &v }
+
+func TestCondition_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		c        Condition[int]
+		v        *int
+		expected bool
+	}{
+		{"Zero matches value", Condition[int]{}, ptr(1), true},
+		{"Zero matches null", Condition[int]{}, nil, true},
+		{"Eq", Eq(5), ptr(5), true},
+		{"Eq mismatch", Eq(5), ptr(6), false},
+		{"Eq null value", Eq(5), nil, false},
+		{"In", In(1, 2), ptr(2), true},
+		{"In null", In(1, 2), nil, false},
+		{"NotIn", NotIn(1, 2), ptr(3), true},
+		{"NotIn null", NotIn(1, 2), nil, false},
+		{"Between", Between(_range.HalfOpen(1, 5)), ptr(4), true},
+		{"Between excluded", Between(_range.HalfOpen(1, 5)), ptr(5), false},
+		{"IsNull", IsNull[int](), nil, true},
+		{"IsNull value", IsNull[int](), ptr(1), false},
+		{"IsNotNull", IsNotNull[int](), ptr(1), true},
+		{"Combined", Condition[int]{Claim: In(1, 2, 8).Claim, Between: Between(_range.AtLeast(2)).Between}, ptr(8), true},
+		{"Combined mismatch", Condition[int]{Claim: In(1, 2, 8).Claim, Between: Between(_range.AtLeast(2)).Between}, ptr(1), false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.c.Matches(tt.v, cmp.Compare[int]); got != tt.expected {
+			t.Errorf("%s: Matches() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestCondition_EqNull(t *testing.T) {
+	var c Condition[string]
+	if err := json.Unmarshal([]byte(`{"eq":null}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Matches(nil, cmp.Compare[string]) || c.Matches(ptr("a"), cmp.Compare[string]) {
+		t.Error("Expected eq null to match only NULL")
+	}
+}
+
+func TestCondition_JSON(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Condition[int]
+		json string
+	}{
+		{"Eq", Eq(5), `{"eq":5}`},
+		{"In", In(1, 2), `{"in":[1,2]}`},
+		{"NotIn", NotIn(3), `{"not_in":[3]}`},
+		{"Between", Between(_range.AtLeast(10)), `{"between":{"min":{"value":10,"inclusive":true},"max":null}}`},
+		{"IsNull", IsNull[int](), `{"is_null":true}`},
+		{"Zero", Condition[int]{}, `{}`},
+	}
+
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != tt.json {
+			t.Errorf("%s: Marshal mismatch. Got %s, want %s", tt.name, data, tt.json)
+		}
+
+		var c Condition[int]
+		if err := json.Unmarshal(data, &c); err != nil {
+			t.Fatalf("%s: Unmarshal failed: %v", tt.name, err)
+		}
+		again, _ := json.Marshal(c)
+		if string(again) != tt.json {
+			t.Errorf("%s: round trip mismatch. Got %s, want %s", tt.name, again, tt.json)
+		}
+	}
+}
+
+func TestCondition_UnmarshalBetweenText(t *testing.T) {
+	var c Condition[int]
+	if err := json.Unmarshal([]byte(`{"between":"[18,65)"}`), &c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Matches(ptr(18), cmp.Compare[int]) || c.Matches(ptr(65), cmp.Compare[int]) {
+		t.Error("Expected interval notation to be parsed")
+	}
+}
+
+func TestCondition_UnmarshalInvalid(t *testing.T) {
+	for _, input := range []string{
+		`{"like":"a%"}`,
+		`{"in":[1],"not_in":[2]}`,
+		`{"between":"[1;2]"}`,
+		`{"in":"a"}`,
+		`[1]`,
+	} {
+		var c Condition[int]
+		if err := json.Unmarshal([]byte(input), &c); err == nil {
+			t.Errorf("Expected Unmarshal(%s) to fail", input)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+)
+
+// FieldMask returns the paths of the set fields of the patch, in declaration order, for use as the paths of a
+// google.protobuf.FieldMask: &fieldmaskpb.FieldMask{Paths: u.FieldMask()}.
+// Fields are named by JSON name (or Go name if untagged), which should match the proto field names.
+func (u UpdateSpec[P]) FieldMask() []string {
+	set := reflect.ValueOf(u.Set)
+	paths := make([]string, 0)
+	if set.Kind() != reflect.Struct {
+		return paths
+	}
+
+	for i := range set.NumField() {
+		field := set.Type().Field(i)
+		if f, ok := set.Field(i).Interface().(patchField); ok && field.IsExported() && !f.IsNone() {
+			paths = append(paths, jsonName(field))
+		}
+	}
+	return paths
+}
+
+// FromFieldMask builds an update from a patch decoded from a gRPC update request and the paths of its
+// google.protobuf.FieldMask (mask.GetPaths()), so that the mask, not the presence of values, decides what changes.
+//
+// A field in the mask keeps its value if it is Some and becomes Some(null) otherwise, clearing it as
+// protobuf clears masked fields missing from the request. A field outside the mask becomes None.
+// The path "*" selects every field. Unknown and nested paths are errors.
+func FromFieldMask[P any](patch P, paths []string) (UpdateSpec[P], error) {
+	set := reflect.ValueOf(&patch).Elem()
+	if set.Kind() != reflect.Struct {
+		return UpdateSpec[P]{}, fmt.Errorf("filter: %v is not a struct", set.Type())
+	}
+
+	masked := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		masked[path] = true
+	}
+	all := masked["*"]
+	delete(masked, "*")
+
+	for i := range set.NumField() {
+		field := set.Type().Field(i)
+		f, ok := set.Field(i).Interface().(patchField)
+		if !field.IsExported() || !ok {
+			continue
+		}
+
+		name := jsonName(field)
+		switch {
+		case !all && !masked[name]:
+			set.Field(i).SetZero()
+		case f.IsNone():
+			if err := set.Field(i).Addr().Interface().(json.Unmarshaler).UnmarshalJSON([]byte("null")); err != nil {
+				return UpdateSpec[P]{}, err
+			}
+		}
+		delete(masked, name)
+	}
+
+	if len(masked) > 0 {
+		unknown := make([]string, 0, len(masked))
+		for path := range masked {
+			unknown = append(unknown, path)
+		}
+		slices.Sort(unknown)
+		return UpdateSpec[P]{}, fmt.Errorf("filter: %v has no fields for mask paths %q", set.Type(), unknown)
+	}
+	return UpdateSpec[P]{Set: patch}, nil
+}
@@ -0,0 +1,52 @@
+package filter
+
+import (
+	"slices"
+	"testing"
+
+	optional "github.com/dullkingsman/kozo/optional"
+)
+
+func TestUpdateSpec_FieldMask(t *testing.T) {
+	u := UpdateSpec[userPatch]{Set: userPatch{Name: optional.Some("ann"), Age: optional.Null[int]()}}
+	if got := u.FieldMask(); !slices.Equal(got, []string{"name", "age"}) {
+		t.Errorf("FieldMask() = %v", got)
+	}
+	if got := (UpdateSpec[userPatch]{}).FieldMask(); got == nil || len(got) != 0 {
+		t.Errorf("Expected an empty mask, got %#v", got)
+	}
+}
+
+func TestFromFieldMask(t *testing.T) {
+	// A request carrying every value, of which the mask selects name and age.
+	patch := userPatch{Name: optional.Some("ann"), Nickname: optional.Some("annie")}
+	u, err := FromFieldMask(patch, []string{"name", "age"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v, _ := u.Set.Name.Unwrap(); v != "ann" {
+		t.Errorf("Expected a masked value to be kept, got %v", u.Set.Name)
+	}
+	if !u.Set.Age.IsSome() || !u.Set.Age.IsNull() {
+		t.Errorf("Expected a masked field without a value to be cleared, got %v", u.Set.Age)
+	}
+	if !u.Set.Nickname.IsNone() || !u.Set.Email.IsNone() {
+		t.Error("Expected fields outside the mask to be None")
+	}
+	if got := u.FieldMask(); !slices.Equal(got, []string{"name", "age"}) {
+		t.Errorf("Expected the mask to round-trip, got %v", got)
+	}
+
+	all, err := FromFieldMask(patch, []string{"*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := all.FieldMask(); len(got) != 4 {
+		t.Errorf("Expected \"*\" to select every field, got %v", got)
+	}
+
+	if _, err := FromFieldMask(patch, []string{"name", "address.city"}); err == nil {
+		t.Error("Expected an error for an unknown path")
+	}
+}
@@ -0,0 +1,235 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/dullkingsman/kozo/dialect"
+)
+
+// Match reports whether item satisfies the spec, evaluating it in memory so that the same filter
+// received from an API can be applied to cached data as well as pushed to a database.
+//
+// Each Condition field of F is checked against the field of the same name in item, which must be a struct
+// or a pointer to one; a `filter:"Name"` tag on the F field selects a differently named item field.
+// The item field may have type T, *T or optional.Optional[T] for a Condition[T], where a nil pointer,
+// None and Some(null) all count as NULL. Values are compared with their natural order: numbers, strings,
+// booleans (false before true) and types with a Compare(T) int method such as time.Time.
+// Other types support every condition except Between.
+//
+// A mismatch between F and the item type is a programming error and panics; see Bind to check it upfront.
+// A nil item pointer matches nothing.
+func Match[F, T any](spec Spec[F], item T) bool {
+	b, err := bindingFor[F, T]()
+	if err != nil {
+		panic(err)
+	}
+
+	v := reflect.ValueOf(&item).Elem()
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return false
+		}
+		v = v.Elem()
+	}
+	return matchSpec(b, spec, v)
+}
+
+// ApplySlice returns a new slice containing the items that satisfy the spec, in their original order.
+func ApplySlice[F, T any](spec Spec[F], items []T) []T {
+	res := make([]T, 0)
+	for _, item := range items {
+		if Match(spec, item) {
+			res = append(res, item)
+		}
+	}
+	return res
+}
+
+// binding maps the conditions of a filter struct onto the fields of an item type.
+type binding struct {
+	fields []fieldBinding
+}
+
+type fieldBinding struct {
+	condition int   // field index in F
+	item      []int // field index path in the item struct
+}
+
+// fieldCondition is implemented by every Condition[T], so that specs can be evaluated, rendered and validated through reflection.
+type fieldCondition interface {
+	IsZero() bool
+	matchField(v reflect.Value) bool
+	checkField(t reflect.Type) error
+	toSQL(column string, d dialect.Dialect) (string, []any)
+	claimSize() int
+	rangeBounded() (set, bounded bool)
+	checkRange() error
+}
+
+// bindings caches bindings by filter and item type.
+var bindings sync.Map
+
+type bindingKey struct {
+	filter, item reflect.Type
+}
+
+type bindingEntry struct {
+	binding *binding
+	err     error
+}
+
+// Bind checks that the conditions of F can be evaluated against items of type T by Match.
+// It returns an error if F has no Condition fields, or an item field is missing or has an incompatible type.
+func Bind[F, T any]() error {
+	_, err := bindingFor[F, T]()
+	return err
+}
+
+// bindingFor resolves, and caches, how the conditions of F map onto the fields of T.
+func bindingFor[F, T any]() (*binding, error) {
+	key := bindingKey{reflect.TypeFor[F](), reflect.TypeFor[T]()}
+	if entry, ok := bindings.Load(key); ok {
+		e := entry.(bindingEntry)
+		return e.binding, e.err
+	}
+
+	b, err := bind(key.filter, key.item)
+	bindings.Store(key, bindingEntry{b, err})
+	return b, err
+}
+
+func bind(ft, it reflect.Type) (*binding, error) {
+	for it.Kind() == reflect.Pointer {
+		it = it.Elem()
+	}
+	if ft.Kind() != reflect.Struct || it.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("filter: cannot bind %v to %v: both must be structs", ft, it)
+	}
+
+	conditionType := reflect.TypeFor[fieldCondition]()
+	b := &binding{}
+	for i := range ft.NumField() {
+		f := ft.Field(i)
+		if !f.IsExported() || !f.Type.Implements(conditionType) {
+			continue
+		}
+
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("filter"); ok && tag != "" {
+			name = tag
+		}
+		itemField, ok := it.FieldByName(name)
+		if !ok || !itemField.IsExported() {
+			return nil, fmt.Errorf("filter: %v has no exported field %s for %v.%s", it, name, ft, f.Name)
+		}
+
+		zero := reflect.Zero(f.Type).Interface().(fieldCondition)
+		if err := zero.checkField(itemField.Type); err != nil {
+			return nil, fmt.Errorf("filter: %v.%s: %w", it, name, err)
+		}
+
+		b.fields = append(b.fields, fieldBinding{condition: i, item: itemField.Index})
+	}
+
+	if len(b.fields) == 0 {
+		return nil, fmt.Errorf("filter: %v has no Condition fields", ft)
+	}
+	return b, nil
+}
+
+// matchSpec evaluates the spec against an item struct value.
+func matchSpec[F any](b *binding, spec Spec[F], item reflect.Value) bool {
+	where := reflect.ValueOf(spec.Where)
+	for _, f := range b.fields {
+		c := where.Field(f.condition).Interface().(fieldCondition)
+		if c.IsZero() {
+			continue
+		}
+		v, err := item.FieldByIndexErr(f.item)
+		if err != nil {
+			// The field sits behind a nil embedded pointer.
+			return false
+		}
+		if !c.matchField(v) {
+			return false
+		}
+	}
+
+	for _, s := range spec.And {
+		if !matchSpec(b, s, item) {
+			return false
+		}
+	}
+
+	if len(spec.Or) == 0 {
+		return true
+	}
+	for _, s := range spec.Or {
+		if matchSpec(b, s, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrapper is implemented by optional.Optional[T].
+type unwrapper[T any] interface {
+	UnwrapPtr() (*T, bool)
+}
+
+func (c Condition[T]) checkField(t reflect.Type) error {
+	target := reflect.TypeFor[T]()
+	switch {
+	case t == target, t == reflect.PointerTo(target), t.Implements(reflect.TypeFor[unwrapper[T]]()):
+		return nil
+	default:
+		return fmt.Errorf("type %v is not compatible with Condition[%v]", t, target)
+	}
+}
+
+func (c Condition[T]) matchField(v reflect.Value) bool {
+	var value *T
+	switch x := v.Interface().(type) {
+	case T:
+		value = &x
+	case *T:
+		value = x
+	case unwrapper[T]:
+		value, _ = x.UnwrapPtr()
+	}
+
+	if c.Between != nil && value != nil && !isOrdered[T]() {
+		panic(fmt.Sprintf("filter: Between requires an ordered type, got %v", reflect.TypeFor[T]()))
+	}
+	return c.Matches(value, naturalCompare[T])
+}
+
+// isOrdered reports whether naturalCompare defines an order on T, rather than only equality.
+func isOrdered[T any]() bool {
+	return isOrderedType(reflect.TypeFor[T]())
+}
+
+// naturalCompare compares two values by their natural order, see Match.
+// Unordered types compare as 0 when equal and 1 otherwise.
+func naturalCompare[T any](a, b T) int {
+	if c, ok := any(a).(interface{ Compare(T) int }); ok {
+		return c.Compare(b)
+	}
+	if c, ok := compareValues(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem()); ok {
+		return c
+	}
+
+	if reflect.DeepEqual(a, b) {
+		return 0
+	}
+	return 1
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	optional "github.com/dullkingsman/kozo/optional"
+	_range "github.com/dullkingsman/kozo/range"
+)
+
+type user struct {
+	Name     string
+	Status   string
+	Age      int
+	Email    *string
+	Nickname optional.Optional[string]
+	Joined   time.Time
+}
+
+type fullUserFilter struct {
+	Status   Condition[string]    `json:"status,omitzero"`
+	Age      Condition[int]       `json:"age,omitzero"`
+	Email    Condition[string]    `json:"email,omitzero"`
+	Nickname Condition[string]    `json:"nickname,omitzero"`
+	Since    Condition[time.Time] `json:"since,omitzero" filter:"Joined"`
+}
+
+var (
+	jan = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	jun = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	users = []user{
+		{Name: "ann", Status: "active", Age: 30, Email: ptr("ann@x"), Nickname: optional.Some("annie"), Joined: jan},
+		{Name: "bob", Status: "pending", Age: 17, Joined: jun},
+		{Name: "cid", Status: "deleted", Age: 45, Email: ptr("cid@x"), Joined: jun},
+	}
+)
+
+func names(us []user) []string {
+	res := make([]string, len(us))
+	for i, u := range us {
+		res[i] = u.Name
+	}
+	return res
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected []string
+	}{
+		{"Empty", `{}`, []string{"ann", "bob", "cid"}},
+		{"In", `{"status":{"in":["active","pending"]}}`, []string{"ann", "bob"}},
+		{"Between", `{"age":{"between":"[18,65)"}}`, []string{"ann", "cid"}},
+		{"Pointer null", `{"email":{"is_null":true}}`, []string{"bob"}},
+		{"Optional null", `{"nickname":{"eq":null}}`, []string{"bob", "cid"}},
+		{"Optional value", `{"nickname":{"eq":"annie"}}`, []string{"ann"}},
+		{"Time", `{"since":{"between":"[2024-03-01T00:00:00Z,)"}}`, []string{"bob", "cid"}},
+		{"Or", `{"or":[{"age":{"between":"(,18)"}},{"status":{"eq":"deleted"}}]}`, []string{"bob", "cid"}},
+		{"And", `{"status":{"not_in":["deleted"]},"and":[{"age":{"between":"[18,)"}}]}`, []string{"ann"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec Spec[fullUserFilter]
+			if err := json.Unmarshal([]byte(tt.spec), &spec); err != nil {
+				t.Fatal(err)
+			}
+			got := names(ApplySlice(spec, users))
+			if len(got) != len(tt.expected) {
+				t.Fatalf("ApplySlice() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Fatalf("ApplySlice() = %v, want %v", got, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestMatch_Pointer(t *testing.T) {
+	spec := Spec[fullUserFilter]{Where: fullUserFilter{Age: Between(_range.AtLeast(18))}}
+
+	if !Match(spec, &users[0]) || Match(spec, &users[1]) {
+		t.Error("Expected pointer items to be matched like values")
+	}
+	if Match(spec, (*user)(nil)) {
+		t.Error("Expected a nil item to match nothing")
+	}
+}
+
+func TestBind(t *testing.T) {
+	if err := Bind[fullUserFilter, user](); err != nil {
+		t.Errorf("Bind() failed: %v", err)
+	}
+
+	type missing struct {
+		Status Condition[string]
+		Region Condition[string]
+	}
+	if err := Bind[missing, user](); err == nil {
+		t.Error("Expected Bind() to reject a missing item field")
+	}
+
+	type mistyped struct {
+		Age Condition[string]
+	}
+	if err := Bind[mistyped, user](); err == nil {
+		t.Error("Expected Bind() to reject an incompatible field type")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Match() to panic on a misconfigured filter")
+		}
+	}()
+	Match(Spec[mistyped]{}, users[0])
+}
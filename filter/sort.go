@@ -0,0 +1,406 @@
+package filter
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dullkingsman/kozo/cmpx"
+)
+
+// Direction is the sort direction of a SortField.
+type Direction string
+
+const (
+	Asc  Direction = "asc"
+	Desc Direction = "desc"
+)
+
+// Nulls places NULL values before or after all other values of a SortField. It is the wire form of
+// cmpx.Nulls, adding NullsDefault for the placement that depends on the direction.
+type Nulls string
+
+const (
+	// NullsDefault sorts NULL as larger than any value, so it comes last in ascending
+	// and first in descending order, as PostgreSQL and Oracle do.
+	NullsDefault Nulls = ""
+	NullsFirst   Nulls = "first"
+	NullsLast    Nulls = "last"
+)
+
+// SortField orders by a single field. An empty Direction means Asc.
+type SortField struct {
+	Field     string    `json:"field"`
+	Direction Direction `json:"direction,omitempty"`
+	Nulls     Nulls     `json:"nulls,omitempty"`
+}
+
+// SortSpec is a multi-column ordering: items are ordered by the first field, ties are broken by the second, and so on.
+type SortSpec []SortField
+
+// ParseSort parses the compact form used in query strings: a comma-separated list of fields,
+// each optionally prefixed with "-" for descending or "+" for ascending order, e.g. "-created_at,name".
+// An empty string yields an empty spec.
+func ParseSort(s string) (SortSpec, error) {
+	if strings.TrimSpace(s) == "" {
+		return SortSpec{}, nil
+	}
+
+	parts := strings.Split(s, ",")
+	spec := make(SortSpec, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		f := SortField{Field: part, Direction: Asc}
+		switch {
+		case strings.HasPrefix(part, "-"):
+			f = SortField{Field: part[1:], Direction: Desc}
+		case strings.HasPrefix(part, "+"):
+			f.Field = part[1:]
+		}
+		if f.Field == "" {
+			return nil, fmt.Errorf("filter: empty field in sort %q", s)
+		}
+		spec = append(spec, f)
+	}
+	return spec, nil
+}
+
+// UnmarshalJSON decodes either a list of SortField objects or a string in the compact form of ParseSort.
+func (s *SortSpec) UnmarshalJSON(data []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(`"`)) {
+		var text string
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+		spec, err := ParseSort(text)
+		if err != nil {
+			return err
+		}
+		*s = spec
+		return nil
+	}
+
+	var fields []SortField
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	*s = fields
+	return nil
+}
+
+// Validate checks that every field is in allowed and appears only once, and that directions and nulls placements are known.
+// Sort fields usually come from clients, so validate them before rendering SQL or building a comparator.
+func (s SortSpec) Validate(allowed ...string) error {
+	seen := make(map[string]bool, len(s))
+	for _, f := range s {
+		if !slices.Contains(allowed, f.Field) {
+			return fmt.Errorf("filter: sorting by %q is not allowed", f.Field)
+		}
+		if seen[f.Field] {
+			return fmt.Errorf("filter: duplicate sort field %q", f.Field)
+		}
+		seen[f.Field] = true
+
+		switch f.Direction {
+		case "", Asc, Desc:
+		default:
+			return fmt.Errorf("filter: unknown sort direction %q for %q", f.Direction, f.Field)
+		}
+		switch f.Nulls {
+		case NullsDefault, NullsFirst, NullsLast:
+		default:
+			return fmt.Errorf("filter: unknown nulls placement %q for %q", f.Nulls, f.Field)
+		}
+	}
+	return nil
+}
+
+// Reverse returns the spec with every direction and nulls placement flipped.
+func (s SortSpec) Reverse() SortSpec {
+	res := make(SortSpec, len(s))
+	for i, f := range s {
+		res[i] = SortField{Field: f.Field, Direction: Asc, Nulls: NullsFirst}
+		if !f.descending() {
+			res[i].Direction = Desc
+		}
+		if f.nulls() == cmpx.NullsFirst {
+			res[i].Nulls = NullsLast
+		}
+	}
+	return res
+}
+
+func (f SortField) descending() bool {
+	return f.Direction == Desc
+}
+
+// nulls returns the placement of NULL values, resolving NullsDefault.
+func (f SortField) nulls() cmpx.Nulls {
+	if f.Nulls == NullsFirst || (f.Nulls == NullsDefault && f.descending()) {
+		return cmpx.NullsFirst
+	}
+	return cmpx.NullsLast
+}
+
+// ToSQL renders the spec as an ORDER BY list (without the ORDER BY keywords), e.g. "u.created_at DESC, u.id ASC".
+// columns maps sort fields to trusted column expressions; an unmapped field is an error.
+// NullsFirst and NullsLast render as NULLS FIRST and NULLS LAST, which MySQL and SQL Server do not support.
+// An empty spec yields an empty string.
+func (s SortSpec) ToSQL(columns map[string]string) (string, error) {
+	parts := make([]string, 0, len(s))
+	for _, f := range s {
+		column, ok := columns[f.Field]
+		if !ok {
+			return "", fmt.Errorf("filter: no column mapped for sort field %q", f.Field)
+		}
+
+		sql := column + " ASC"
+		if f.descending() {
+			sql = column + " DESC"
+		}
+		switch f.Nulls {
+		case NullsFirst:
+			sql += " NULLS FIRST"
+		case NullsLast:
+			sql += " NULLS LAST"
+		}
+		parts = append(parts, sql)
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// Comparator returns a comparator ordering items of type T, a struct or pointer to one, by the spec.
+// Sort fields are resolved to item fields by JSON name, falling back to the Go name.
+// Item fields may be of any type Match can order, or a pointer or optional.Optional of one;
+// nil pointers, None and Some(null) count as NULL. Nil items compare as NULL in every field.
+// The comparator composes with those of the cmpx package, e.g. to break ties with Then.
+func Comparator[T any](s SortSpec) (cmpx.Comparator[T], error) {
+	keys, err := sortKeys(reflect.TypeFor[T](), s)
+	if err != nil {
+		return nil, err
+	}
+
+	compare := cmpx.Comparator[reflect.Value](func(a, b reflect.Value) int { return 0 })
+	for i, k := range keys {
+		if i == 0 {
+			compare = k.comparator()
+		} else {
+			compare = compare.Then(k.comparator())
+		}
+	}
+	return cmpx.ByFunc(func(item T) reflect.Value {
+		return indirect(reflect.ValueOf(&item).Elem())
+	}, compare), nil
+}
+
+// SortSlice sorts items in place by the spec, keeping the original order of equal items.
+func SortSlice[T any](s SortSpec, items []T) error {
+	compare, err := Comparator[T](s)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(items, compare)
+	return nil
+}
+
+// sortKey is a sort field resolved against an item struct type.
+type sortKey struct {
+	field SortField
+	index []int // field index path in the item struct
+}
+
+func sortKeys(it reflect.Type, s SortSpec) ([]sortKey, error) {
+	for it.Kind() == reflect.Pointer {
+		it = it.Elem()
+	}
+	if it.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("filter: cannot sort %v: not a struct", it)
+	}
+
+	keys := make([]sortKey, 0, len(s))
+	for _, f := range s {
+		itemField, ok := fieldByJSONName(it, f.Field)
+		if !ok {
+			return nil, fmt.Errorf("filter: %v has no exported field for sort field %q", it, f.Field)
+		}
+		if t := nullableBase(itemField.Type); !isOrderedType(t) {
+			return nil, fmt.Errorf("filter: cannot sort by %v.%s: type %v is not ordered", it, itemField.Name, t)
+		}
+		keys = append(keys, sortKey{field: f, index: itemField.Index})
+	}
+	return keys, nil
+}
+
+// fieldByJSONName finds the exported field of a struct type by its JSON name, or by its Go name.
+func fieldByJSONName(t reflect.Type, name string) (reflect.StructField, bool) {
+	fields := reflect.VisibleFields(t)
+	for _, f := range fields {
+		if f.IsExported() && !f.Anonymous && jsonName(f) == name {
+			return f, true
+		}
+	}
+	for _, f := range fields {
+		if f.IsExported() && f.Name == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// comparator returns the comparator of item struct values, which are invalid for nil items, by the key.
+// Only the order of the values follows the direction: nulls are placed independently of it.
+func (k sortKey) comparator() cmpx.Comparator[reflect.Value] {
+	values := cmpx.Comparator[reflect.Value](func(a, b reflect.Value) int {
+		c, _ := compareValues(a, b)
+		return c
+	})
+	if k.field.descending() {
+		values = values.Reverse()
+	}
+	return cmpx.Nullable(k.value, values, k.field.nulls())
+}
+
+// value returns the field of an item, and false if it is NULL.
+func (k sortKey) value(item reflect.Value) (reflect.Value, bool) {
+	if !item.IsValid() {
+		return reflect.Value{}, false
+	}
+	v, err := item.FieldByIndexErr(k.index)
+	if err != nil {
+		return reflect.Value{}, false
+	}
+	return unwrapNullable(v)
+}
+
+// indirect dereferences pointers, returning the invalid Value for nil.
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// nullableBase returns the type a field of type t holds once pointers and optional.Optional are unwrapped.
+func nullableBase(t reflect.Type) reflect.Type {
+	for {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		} else if m, ok := unwrapPtrMethod(t); ok {
+			t = m.Type.Out(0).Elem()
+		} else {
+			return t
+		}
+	}
+}
+
+// unwrapNullable unwraps pointers and optional.Optional values, returning false for NULL.
+func unwrapNullable(v reflect.Value) (reflect.Value, bool) {
+	for {
+		if v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		} else if _, ok := unwrapPtrMethod(v.Type()); ok {
+			out := v.MethodByName("UnwrapPtr").Call(nil)
+			if out[0].IsNil() {
+				return reflect.Value{}, false
+			}
+			v = out[0].Elem()
+		} else {
+			return v, true
+		}
+	}
+}
+
+// unwrapPtrMethod returns the UnwrapPtr() (*T, bool) method of optional.Optional[T].
+func unwrapPtrMethod(t reflect.Type) (reflect.Method, bool) {
+	m, ok := t.MethodByName("UnwrapPtr")
+	if !ok || m.Type.NumIn() != 1 || m.Type.NumOut() != 2 ||
+		m.Type.Out(0).Kind() != reflect.Pointer || m.Type.Out(1).Kind() != reflect.Bool {
+		return reflect.Method{}, false
+	}
+	return m, true
+}
+
+// isOrderedType reports whether compareValues defines an order on t.
+func isOrderedType(t reflect.Type) bool {
+	if compareMethod(t).Func.IsValid() {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+		return true
+	}
+	return false
+}
+
+// compareMethod returns the Compare(T) int method of t, or the zero Method.
+func compareMethod(t reflect.Type) reflect.Method {
+	m, ok := t.MethodByName("Compare")
+	if !ok || m.Type.NumIn() != 2 || m.Type.In(1) != t || m.Type.NumOut() != 1 || m.Type.Out(0).Kind() != reflect.Int {
+		return reflect.Method{}
+	}
+	return m
+}
+
+// compareValues compares two values of the same type by their natural order, see Match.
+// It returns false if the type is not ordered.
+func compareValues(a, b reflect.Value) (int, bool) {
+	if m := compareMethod(a.Type()); m.Func.IsValid() {
+		return int(m.Func.Call([]reflect.Value{a, b})[0].Int()), true
+	}
+
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float()), true
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String()), true
+	case reflect.Bool:
+		return cmp.Compare(boolRank(a.Bool()), boolRank(b.Bool())), true
+	}
+	return 0, false
+}
+
+// Equal reports whether two specs order items identically, treating an empty Direction as Asc
+// and NullsDefault as the placement it resolves to.
+func (s SortSpec) Equal(other SortSpec) bool {
+	return slices.EqualFunc(s, other, func(a, b SortField) bool {
+		return a.Field == b.Field && a.descending() == b.descending() && a.nulls() == b.nulls()
+	})
+}
+
+// SortKey returns the values of the sort fields of item, a struct or pointer to one, in spec order,
+// with nil for NULL. Fields are resolved as by Comparator. It is the keyset a cursor resumes after.
+func SortKey[T any](s SortSpec, item T) ([]any, error) {
+	keys, err := sortKeys(reflect.TypeFor[T](), s)
+	if err != nil {
+		return nil, err
+	}
+
+	v := indirect(reflect.ValueOf(&item).Elem())
+	if !v.IsValid() {
+		return nil, errors.New("filter: cannot take the sort key of a nil item")
+	}
+	values := make([]any, len(keys))
+	for i, k := range keys {
+		if fv, ok := k.value(v); ok {
+			values[i] = fv.Interface()
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,178 @@
+package filter
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/dullkingsman/kozo/cmpx"
+)
+
+func TestParseSort(t *testing.T) {
+	spec, err := ParseSort("-created_at, +name,id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := SortSpec{{Field: "created_at", Direction: Desc}, {Field: "name", Direction: Asc}, {Field: "id", Direction: Asc}}
+	if !slices.Equal(spec, expected) {
+		t.Errorf("ParseSort() = %v, want %v", spec, expected)
+	}
+
+	for _, input := range []string{"name,", "-", "a,,b"} {
+		if _, err := ParseSort(input); err == nil {
+			t.Errorf("Expected ParseSort(%q) to fail", input)
+		}
+	}
+}
+
+func TestSortSpec_JSON(t *testing.T) {
+	var compact, full SortSpec
+	if err := json.Unmarshal([]byte(`"-age,name"`), &compact); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`[{"field":"age","direction":"desc"},{"field":"name","direction":"asc"}]`), &full); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(compact, full) {
+		t.Errorf("Compact form %v differs from %v", compact, full)
+	}
+
+	data, err := json.Marshal(SortSpec{{Field: "age", Direction: Desc, Nulls: NullsLast}, {Field: "name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `[{"field":"age","direction":"desc","nulls":"last"},{"field":"name"}]`; string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", data, expected)
+	}
+}
+
+func TestSortSpec_Validate(t *testing.T) {
+	allowed := []string{"age", "name"}
+	tests := []struct {
+		spec  SortSpec
+		valid bool
+	}{
+		{SortSpec{}, true},
+		{SortSpec{{Field: "age", Direction: Desc, Nulls: NullsFirst}, {Field: "name"}}, true},
+		{SortSpec{{Field: "password"}}, false},
+		{SortSpec{{Field: "age"}, {Field: "age", Direction: Desc}}, false},
+		{SortSpec{{Field: "age", Direction: "up"}}, false},
+		{SortSpec{{Field: "age", Nulls: "middle"}}, false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.spec.Validate(allowed...); (err == nil) != tt.valid {
+			t.Errorf("Validate(%v) = %v, want valid=%v", tt.spec, err, tt.valid)
+		}
+	}
+}
+
+func TestSortSpec_ToSQL(t *testing.T) {
+	spec := SortSpec{{Field: "age", Direction: Desc}, {Field: "email", Nulls: NullsFirst}, {Field: "name"}}
+	sql, err := spec.ToSQL(map[string]string{"age": "u.age", "email": "u.email", "name": "u.name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "u.age DESC, u.email ASC NULLS FIRST, u.name ASC"; sql != expected {
+		t.Errorf("ToSQL() = %q, want %q", sql, expected)
+	}
+
+	if _, err := spec.ToSQL(map[string]string{"age": "u.age"}); err == nil {
+		t.Error("Expected an error for a sort field without a column")
+	}
+}
+
+func TestSortSlice(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected []string
+	}{
+		{"Age", []string{"bob", "ann", "cid"}},
+		{"-Age", []string{"cid", "ann", "bob"}},
+		{"-Joined,Name", []string{"bob", "cid", "ann"}},
+		// NULL is larger than any value by default.
+		{"Email,Name", []string{"ann", "cid", "bob"}},
+		{"-Email", []string{"bob", "cid", "ann"}},
+		{"Nickname,-Name", []string{"ann", "cid", "bob"}},
+	}
+
+	for _, tt := range tests {
+		spec, err := ParseSort(tt.spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		items := slices.Clone(users)
+		if err := SortSlice(spec, items); err != nil {
+			t.Fatal(err)
+		}
+		if got := names(items); !slices.Equal(got, tt.expected) {
+			t.Errorf("SortSlice(%q) = %v, want %v", tt.spec, got, tt.expected)
+		}
+	}
+
+	items := slices.Clone(users)
+	if err := SortSlice(SortSpec{{Field: "Email", Nulls: NullsFirst}}, items); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(items); !slices.Equal(got, []string{"bob", "ann", "cid"}) {
+		t.Errorf("SortSlice(nulls first) = %v", got)
+	}
+
+	// Nulls are placed independently of the direction.
+	if err := SortSlice(SortSpec{{Field: "Email", Direction: Desc, Nulls: NullsLast}}, items); err != nil {
+		t.Fatal(err)
+	}
+	if got := names(items); !slices.Equal(got, []string{"cid", "ann", "bob"}) {
+		t.Errorf("SortSlice(desc nulls last) = %v", got)
+	}
+}
+
+func TestComparator_Invalid(t *testing.T) {
+	if _, err := Comparator[user](SortSpec{{Field: "Region"}}); err == nil {
+		t.Error("Expected an error for a missing item field")
+	}
+
+	type unordered struct {
+		Tags []string `json:"tags"`
+	}
+	if _, err := Comparator[*unordered](SortSpec{{Field: "tags"}}); err == nil {
+		t.Error("Expected an error for an unordered field type")
+	}
+}
+
+func TestComparator_Then(t *testing.T) {
+	byJoined, err := Comparator[user](SortSpec{{Field: "Joined", Direction: Desc}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := slices.Clone(users)
+	slices.SortFunc(items, byJoined.Then(cmpx.By(func(u user) string { return u.Name })))
+	if got := names(items); !slices.Equal(got, []string{"bob", "cid", "ann"}) {
+		t.Errorf("Sorted by -Joined then name = %v", got)
+	}
+}
+
+func TestSortSpec_Equal(t *testing.T) {
+	a := SortSpec{{Field: "age", Direction: Desc}, {Field: "name"}}
+	b := SortSpec{{Field: "age", Direction: Desc, Nulls: NullsFirst}, {Field: "name", Direction: Asc, Nulls: NullsLast}}
+	if !a.Equal(b) {
+		t.Error("Expected explicit defaults to be equal to implicit ones")
+	}
+	if a.Equal(a.Reverse()) || !a.Equal(a.Reverse().Reverse()) {
+		t.Error("Expected Reverse to change the order and to be its own inverse")
+	}
+}
+
+func TestSortKey(t *testing.T) {
+	values, err := SortKey(SortSpec{{Field: "Age"}, {Field: "Email"}, {Field: "Nickname"}}, &users[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(values, []any{17, nil, nil}) {
+		t.Errorf("SortKey() = %v", values)
+	}
+
+	if _, err := SortKey(SortSpec{{Field: "Age"}}, (*user)(nil)); err == nil {
+		t.Error("Expected an error for a nil item")
+	}
+}
@@ -0,0 +1,76 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Spec is a struct-level filter specification. Where is a struct whose fields are Conditions,
+// one per filterable field, for example:
+//
+//	type UserFilter struct {
+//		Status filter.Condition[string] `json:"status"`
+//		Age    filter.Condition[int]    `json:"age"`
+//	}
+//
+// A Spec matches when every set condition of Where holds, every spec in And matches,
+// and, if Or is not empty, at least one spec in Or matches.
+type Spec[F any] struct {
+	Where F
+	And   []Spec[F]
+	Or    []Spec[F]
+}
+
+// MarshalJSON encodes the spec as a single object holding the fields of Where
+// next to the optional "and" and "or" lists.
+func (s Spec[F]) MarshalJSON() ([]byte, error) {
+	where, err := json.Marshal(s.Where)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(where, &obj); err != nil {
+		return nil, err
+	}
+
+	for key, specs := range map[string][]Spec[F]{"and": s.And, "or": s.Or} {
+		if len(specs) == 0 {
+			continue
+		}
+		data, err := json.Marshal(specs)
+		if err != nil {
+			return nil, err
+		}
+		obj[key] = data
+	}
+
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON decodes a spec written by MarshalJSON. Keys other than "and", "or"
+// and the fields of Where are rejected, so that misspelled filters fail loudly instead of matching everything.
+func (s *Spec[F]) UnmarshalJSON(data []byte) error {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	*s = Spec[F]{}
+	for key, target := range map[string]*[]Spec[F]{"and": &s.And, "or": &s.Or} {
+		if value, ok := obj[key]; ok {
+			if err := json.Unmarshal(value, target); err != nil {
+				return err
+			}
+			delete(obj, key)
+		}
+	}
+
+	where, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(where))
+	dec.DisallowUnknownFields()
+	return dec.Decode(&s.Where)
+}
@@ -0,0 +1,45 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type userFilter struct {
+	Status Condition[string] `json:"status,omitzero"`
+	Age    Condition[int]    `json:"age,omitzero"`
+}
+
+func TestSpec_JSON(t *testing.T) {
+	input := `{"status":{"in":["active"]},"or":[{"age":{"between":"[18,65)"}},{"age":{"is_null":true}}]}`
+
+	var spec Spec[userFilter]
+	if err := json.Unmarshal([]byte(input), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	if spec.Where.Status.Claim == nil || !spec.Where.Age.IsZero() || len(spec.Or) != 2 || len(spec.And) != 0 {
+		t.Fatalf("Unexpected spec %+v", spec)
+	}
+	if spec.Or[0].Where.Age.Between == nil || spec.Or[1].Where.Age.IsNull == nil {
+		t.Errorf("Unexpected nested specs %+v", spec.Or)
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"or":[{"age":{"between":{"min":{"value":18,"inclusive":true},"max":{"value":65,"inclusive":false}}}},{"age":{"is_null":true}}],"status":{"in":["active"]}}`
+	if string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", data, expected)
+	}
+}
+
+func TestSpec_UnknownField(t *testing.T) {
+	for _, input := range []string{`{"stauts":{"eq":"a"}}`, `{"and":[{"nope":{}}]}`} {
+		var spec Spec[userFilter]
+		if err := json.Unmarshal([]byte(input), &spec); err == nil {
+			t.Errorf("Expected Unmarshal(%s) to reject the unknown field", input)
+		}
+	}
+}
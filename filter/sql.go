@@ -0,0 +1,141 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dullkingsman/kozo/dialect"
+)
+
+// ToSQL renders the spec as a parameterized SQL WHERE clause (without the WHERE keyword) and its arguments.
+// Field conditions and And specs are joined with AND, and Or specs are grouped with OR.
+// A spec without conditions yields "1=1".
+//
+// columns maps the fields of F, by JSON name (or Go name if untagged), to trusted column expressions.
+// A condition on a field missing from columns is an error, so field names from clients never reach the SQL.
+// Use dialect.Offset to append the clause to a statement that already has arguments.
+func (s Spec[F]) ToSQL(d dialect.Dialect, columns map[string]string) (string, []any, error) {
+	var args []any
+	sql, err := specToSQL(s, d, columns, &args)
+	if err != nil {
+		return "", nil, err
+	}
+	if sql == "" {
+		sql = "1=1"
+	}
+	return sql, args, nil
+}
+
+// specToSQL renders a spec, appending its arguments to args. An empty result means no restriction.
+func specToSQL[F any](s Spec[F], d dialect.Dialect, columns map[string]string, args *[]any) (string, error) {
+	var parts []string
+
+	where := reflect.ValueOf(s.Where)
+	if where.Kind() != reflect.Struct {
+		return "", fmt.Errorf("filter: %v is not a struct", where.Type())
+	}
+	for i := range where.NumField() {
+		field := where.Type().Field(i)
+		c, ok := where.Field(i).Interface().(fieldCondition)
+		if !field.IsExported() || !ok || c.IsZero() {
+			continue
+		}
+
+		name := jsonName(field)
+		column, ok := columns[name]
+		if !ok {
+			return "", fmt.Errorf("filter: no column mapped for field %q", name)
+		}
+
+		sql, fieldArgs := c.toSQL(column, dialect.Offset(d, len(*args)))
+		if sql != "" {
+			parts = append(parts, sql)
+			*args = append(*args, fieldArgs...)
+		}
+	}
+
+	for _, sub := range s.And {
+		sql, err := specToSQL(sub, d, columns, args)
+		if err != nil {
+			return "", err
+		}
+		if sql != "" {
+			parts = append(parts, "("+sql+")")
+		}
+	}
+
+	if len(s.Or) > 0 {
+		alternatives := make([]string, 0, len(s.Or))
+		for _, sub := range s.Or {
+			sql, err := specToSQL(sub, d, columns, args)
+			if err != nil {
+				return "", err
+			}
+			if sql == "" {
+				// An unrestricted alternative makes the whole disjunction true.
+				sql = "1=1"
+			}
+			alternatives = append(alternatives, "("+sql+")")
+		}
+		parts = append(parts, "("+strings.Join(alternatives, " OR ")+")")
+	}
+
+	return strings.Join(parts, " AND "), nil
+}
+
+// toSQL renders the set parts of the condition joined with AND. An empty result means no restriction.
+// A claim or range that renders as "1=1" still rejects NULL, as Matches does, so it becomes IS NOT NULL.
+func (c Condition[T]) toSQL(column string, d dialect.Dialect) (string, []any) {
+	var (
+		parts []string
+		args  []any
+	)
+	add := func(sql string, partArgs []any) {
+		if sql == "" || sql == "1=1" || (len(partArgs) == 0 && slices.Contains(parts, sql)) {
+			return
+		}
+		parts = append(parts, sql)
+		args = append(args, partArgs...)
+	}
+	notNull := func(sql string, partArgs []any) (string, []any) {
+		if sql == "1=1" {
+			return column + " IS NOT NULL", nil
+		}
+		return sql, partArgs
+	}
+
+	if c.IsNull != nil {
+		if *c.IsNull {
+			add(column+" IS NULL", nil)
+		} else {
+			add(column+" IS NOT NULL", nil)
+		}
+	}
+	if c.Eq.IsSome() {
+		if v, ok := c.Eq.Unwrap(); ok {
+			add(column+" = "+dialect.Offset(d, len(args)).Placeholder(1), []any{v})
+		} else {
+			add(column+" IS NULL", nil)
+		}
+	}
+	if c.Claim != nil {
+		add(notNull(c.Claim.ToSQL(column, dialect.Offset(d, len(args)))))
+	}
+	if c.Between != nil {
+		add(notNull(c.Between.ToSQL(column, dialect.Offset(d, len(args)))))
+	}
+
+	return strings.Join(parts, " AND "), args
+}
+
+// jsonName returns the JSON key of a struct field, falling back to its Go name.
+func jsonName(f reflect.StructField) string {
+	if tag, ok := f.Tag.Lookup("json"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" && name != "-" {
+			return name
+		}
+	}
+	return f.Name
+}
@@ -0,0 +1,112 @@
+package filter
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dullkingsman/kozo/dialect"
+)
+
+var userColumns = map[string]string{
+	"status":   "u.status",
+	"age":      "u.age",
+	"email":    "u.email",
+	"nickname": "u.nickname",
+}
+
+func TestSpec_ToSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		d        dialect.Dialect
+		expected string
+		args     []any
+	}{
+		{"Empty", `{}`, dialect.PostgreSQL, "1=1", nil},
+		{
+			"Fields",
+			`{"status":{"in":["active","pending"]},"age":{"between":"[18,65)"}}`,
+			dialect.PostgreSQL,
+			"u.status IN ($1, $2) AND u.age >= $3 AND u.age < $4",
+			[]any{"active", "pending", 18, 65},
+		},
+		{
+			"Nulls",
+			`{"email":{"is_null":false},"nickname":{"eq":null}}`,
+			dialect.MySQL,
+			"u.email IS NOT NULL AND u.nickname IS NULL",
+			nil,
+		},
+		{
+			"Or",
+			`{"status":{"not_in":["deleted"]},"or":[{"age":{"between":"(,18)"}},{"email":{"eq":"a@x"}}]}`,
+			dialect.PostgreSQL,
+			"u.status NOT IN ($1) AND ((u.age < $2) OR (u.email = $3))",
+			[]any{"deleted", 18, "a@x"},
+		},
+		{
+			"And",
+			`{"and":[{"age":{"eq":30}},{"status":{"eq":"active","in":["active"]}}]}`,
+			dialect.SQLServer,
+			"(u.age = @p1) AND (u.status = @p2 AND u.status IN (@p3))",
+			[]any{30, "active", "active"},
+		},
+		{
+			"Unrestricted alternative",
+			`{"or":[{},{"age":{"eq":1}}]}`,
+			dialect.MySQL,
+			"((1=1) OR (u.age = ?))",
+			[]any{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec Spec[fullUserFilter]
+			if err := json.Unmarshal([]byte(tt.spec), &spec); err != nil {
+				t.Fatal(err)
+			}
+			sql, args, err := spec.ToSQL(tt.d, userColumns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != tt.expected || !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("ToSQL() = %q, %v; want %q, %v", sql, args, tt.expected, tt.args)
+			}
+		})
+	}
+}
+
+func TestSpec_ToSQLMatchesNulls(t *testing.T) {
+	// Claims and ranges that restrict nothing still reject NULL, in SQL as in memory.
+	for _, input := range []string{
+		`{"email":{"not_in":[]}}`,
+		`{"email":{"between":"(,)"}}`,
+		`{"email":{"not_in":[],"between":"(,)"}}`,
+	} {
+		var spec Spec[fullUserFilter]
+		if err := json.Unmarshal([]byte(input), &spec); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := names(ApplySlice(spec, users)); !reflect.DeepEqual(got, []string{"ann", "cid"}) {
+			t.Errorf("ApplySlice(%s) = %v, want [ann cid]", input, got)
+		}
+		sql, args, err := spec.ToSQL(dialect.PostgreSQL, userColumns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sql != "u.email IS NOT NULL" || len(args) != 0 {
+			t.Errorf("ToSQL(%s) = %q, %v; want %q", input, sql, args, "u.email IS NOT NULL")
+		}
+	}
+}
+
+func TestSpec_ToSQLUnmappedField(t *testing.T) {
+	spec := Spec[fullUserFilter]{Where: fullUserFilter{Since: IsNull[time.Time]()}}
+	if _, _, err := spec.ToSQL(dialect.MySQL, userColumns); err == nil {
+		t.Error("Expected an error for a field without a column")
+	}
+}
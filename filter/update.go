@@ -0,0 +1,115 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dullkingsman/kozo/dialect"
+)
+
+// ErrEmptyUpdate is returned by UpdateSpec.ToSQL when no field of the patch is set,
+// since an UPDATE without assignments is invalid SQL.
+var ErrEmptyUpdate = errors.New("filter: update sets no fields")
+
+// UpdateSpec is a partial update. Set is a struct whose exported fields are optional.Optional values,
+// one per updatable field, for example:
+//
+//	type UserPatch struct {
+//		Name     optional.Optional[string] `json:"name,omitzero"`
+//		Nickname optional.Optional[string] `json:"nickname,omitzero"`
+//	}
+//
+// Following the three-state model, None leaves a field unchanged, Some(null) sets it to NULL
+// and Some(value) sets it to value.
+type UpdateSpec[P any] struct {
+	Set P
+}
+
+// patchField is implemented by every optional.Optional[T], which also has an UnwrapPtr() (*T, bool) method.
+type patchField interface {
+	IsNone() bool
+	IsNull() bool
+}
+
+// IsZero returns true if no field of the patch is set.
+func (u UpdateSpec[P]) IsZero() bool {
+	set := reflect.ValueOf(u.Set)
+	if set.Kind() != reflect.Struct {
+		return true
+	}
+	for i := range set.NumField() {
+		if f, ok := set.Field(i).Interface().(patchField); ok && set.Type().Field(i).IsExported() && !f.IsNone() {
+			return false
+		}
+	}
+	return true
+}
+
+// ToSQL renders the set fields of the patch as a parameterized assignment list for an UPDATE statement
+// (without the SET keyword), e.g. "name = $1, nickname = NULL", and its arguments.
+// Fields are assigned in declaration order.
+//
+// columns maps the fields of P, by JSON name (or Go name if untagged), to trusted column names;
+// a set field missing from columns is an error, as is an exported field that is not an Optional.
+// It returns ErrEmptyUpdate if no field is set. Use dialect.Offset to render the WHERE clause after it.
+func (u UpdateSpec[P]) ToSQL(d dialect.Dialect, columns map[string]string) (string, []any, error) {
+	set := reflect.ValueOf(u.Set)
+	if set.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("filter: %v is not a struct", set.Type())
+	}
+
+	var (
+		parts []string
+		args  []any
+	)
+	for i := range set.NumField() {
+		field := set.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		f, ok := set.Field(i).Interface().(patchField)
+		if !ok {
+			return "", nil, fmt.Errorf("filter: patch field %v.%s is not an Optional", set.Type(), field.Name)
+		}
+		if f.IsNone() {
+			continue
+		}
+
+		name := jsonName(field)
+		column, ok := columns[name]
+		if !ok {
+			return "", nil, fmt.Errorf("filter: no column mapped for field %q", name)
+		}
+
+		if f.IsNull() {
+			parts = append(parts, column+" = NULL")
+			continue
+		}
+		value := set.Field(i).MethodByName("UnwrapPtr").Call(nil)[0].Elem()
+		args = append(args, value.Interface())
+		parts = append(parts, column+" = "+d.Placeholder(len(args)))
+	}
+
+	if len(parts) == 0 {
+		return "", nil, ErrEmptyUpdate
+	}
+	return strings.Join(parts, ", "), args, nil
+}
+
+// MarshalJSON encodes the spec as its patch.
+func (u UpdateSpec[P]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.Set)
+}
+
+// UnmarshalJSON decodes a patch, rejecting unknown fields so that misspelled updates fail loudly
+// instead of being silently dropped. Absent fields stay None and null fields become Some(null).
+func (u *UpdateSpec[P]) UnmarshalJSON(data []byte) error {
+	*u = UpdateSpec[P]{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(&u.Set)
+}
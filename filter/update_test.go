@@ -0,0 +1,86 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/dullkingsman/kozo/dialect"
+	optional "github.com/dullkingsman/kozo/optional"
+)
+
+type userPatch struct {
+	Name     optional.Optional[string]  `json:"name,omitzero"`
+	Nickname optional.Optional[string]  `json:"nickname,omitzero"`
+	Age      optional.Optional[int]     `json:"age,omitzero"`
+	Email    optional.Optional[*string] `json:"email,omitzero"`
+}
+
+var patchColumns = map[string]string{"name": "name", "nickname": "nickname", "age": "age", "email": "email"}
+
+func TestUpdateSpec_ToSQL(t *testing.T) {
+	var u UpdateSpec[userPatch]
+	if err := json.Unmarshal([]byte(`{"name":"ann","nickname":null,"age":31}`), &u); err != nil {
+		t.Fatal(err)
+	}
+
+	sql, args, err := u.ToSQL(dialect.PostgreSQL, patchColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "name = $1, nickname = NULL, age = $2"; sql != expected || !reflect.DeepEqual(args, []any{"ann", 31}) {
+		t.Errorf("ToSQL() = %q, %v; want %q", sql, args, expected)
+	}
+
+	// The WHERE clause continues the numbering.
+	where, whereArgs, err := Spec[fullUserFilter]{Where: fullUserFilter{Status: Eq("active")}}.ToSQL(dialect.Offset(dialect.PostgreSQL, len(args)), userColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if where != "u.status = $3" || !reflect.DeepEqual(whereArgs, []any{"active"}) {
+		t.Errorf("ToSQL() = %q, %v", where, whereArgs)
+	}
+}
+
+func TestUpdateSpec_Pointer(t *testing.T) {
+	email := "a@x"
+	u := UpdateSpec[userPatch]{Set: userPatch{Email: optional.Some(&email)}}
+	sql, args, err := u.ToSQL(dialect.MySQL, patchColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "email = ?" || len(args) != 1 || args[0] != &email {
+		t.Errorf("ToSQL() = %q, %v", sql, args)
+	}
+}
+
+func TestUpdateSpec_Invalid(t *testing.T) {
+	var empty UpdateSpec[userPatch]
+	if !empty.IsZero() {
+		t.Error("Expected an empty patch to be zero")
+	}
+	if _, _, err := empty.ToSQL(dialect.MySQL, patchColumns); !errors.Is(err, ErrEmptyUpdate) {
+		t.Errorf("Expected ErrEmptyUpdate, got %v", err)
+	}
+
+	u := UpdateSpec[userPatch]{Set: userPatch{Age: optional.Some(1)}}
+	if u.IsZero() {
+		t.Error("Expected a patch with a set field not to be zero")
+	}
+	if _, _, err := u.ToSQL(dialect.MySQL, map[string]string{"name": "name"}); err == nil {
+		t.Error("Expected an error for a field without a column")
+	}
+
+	type mixed struct {
+		Name optional.Optional[string]
+		Age  int
+	}
+	if _, _, err := (UpdateSpec[mixed]{Set: mixed{Name: optional.Some("x")}}).ToSQL(dialect.MySQL, map[string]string{"Name": "name"}); err == nil {
+		t.Error("Expected an error for a field that is not an Optional")
+	}
+
+	if err := json.Unmarshal([]byte(`{"nmae":"x"}`), &u); err == nil {
+		t.Error("Expected Unmarshal to reject an unknown field")
+	}
+}
@@ -0,0 +1,182 @@
+package filter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Rules limits the specs that Validate accepts. Fields are named by JSON name (or Go name if untagged),
+// and zero values place no limit.
+type Rules struct {
+	// Allowed lists the fields that may have conditions; nil allows every field.
+	Allowed []string
+	// Required lists the fields that must have a condition in the top-level Where,
+	// so that every match is restricted by them.
+	Required []string
+	// Bounded lists the fields whose ranges must be bounded on both sides, e.g. timestamps of partitioned tables.
+	// Combined with Required, it requires a bounded range.
+	Bounded []string
+	// MaxInSize limits the number of values of an in or not_in condition.
+	MaxInSize int
+	// MaxDepth limits the nesting of specs, where a spec without And or Or has depth 1.
+	MaxDepth int
+}
+
+// Rule identifies the rule a ValidationError violates.
+type Rule string
+
+const (
+	RuleAllowed   Rule = "allowed"
+	RuleRequired  Rule = "required"
+	RuleBounded   Rule = "bounded"
+	RuleMaxInSize Rule = "max_in_size"
+	RuleMaxDepth  Rule = "max_depth"
+	// RuleRange is violated by an inverted or empty range, whatever the Rules.
+	RuleRange Rule = "range"
+)
+
+// ValidationError describes one violation of the Rules.
+type ValidationError struct {
+	// Path locates the violation in the spec, e.g. "or[1].status", or is empty for the top-level spec.
+	Path    string `json:"path"`
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return "filter: " + e.Message
+	}
+	return "filter: " + e.Path + ": " + e.Message
+}
+
+// ValidationErrors is the error returned by Validate, listing every violation in spec order.
+type ValidationErrors []*ValidationError
+
+func (es ValidationErrors) Error() string {
+	msgs := make([]string, len(es))
+	for i, e := range es {
+		msgs[i] = e.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the individual errors, so that errors.As finds a *ValidationError.
+func (es ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(es))
+	for i, e := range es {
+		errs[i] = e
+	}
+	return errs
+}
+
+// Validate checks a spec, usually decoded from a client request, against the rules, so that
+// pathological filters are rejected before they reach a database. It returns nil or ValidationErrors.
+func Validate[F any](spec Spec[F], rules Rules) error {
+	where := reflect.ValueOf(spec.Where)
+	if where.Kind() != reflect.Struct {
+		return fmt.Errorf("filter: %v is not a struct", where.Type())
+	}
+
+	v := validator{rules: rules}
+	validateSpec(&v, spec, "", 1)
+
+	for _, name := range rules.Required {
+		if c, ok := conditionByName(where, name); !ok || c.IsZero() {
+			v.add("", RuleRequired, fmt.Sprintf("a condition on %q is required", name))
+		}
+	}
+
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+type validator struct {
+	rules Rules
+	errs  ValidationErrors
+}
+
+func (v *validator) add(path string, rule Rule, message string) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Rule: rule, Message: message})
+}
+
+func validateSpec[F any](v *validator, spec Spec[F], path string, depth int) {
+	if limit := v.rules.MaxDepth; limit > 0 && depth > limit {
+		v.add(path, RuleMaxDepth, fmt.Sprintf("specs may be nested at most %d deep", limit))
+		return
+	}
+
+	where := reflect.ValueOf(spec.Where)
+	for i := range where.NumField() {
+		field := where.Type().Field(i)
+		c, ok := where.Field(i).Interface().(fieldCondition)
+		if !field.IsExported() || !ok || c.IsZero() {
+			continue
+		}
+
+		name := jsonName(field)
+		fieldPath := join(path, name)
+		if v.rules.Allowed != nil && !slices.Contains(v.rules.Allowed, name) {
+			v.add(fieldPath, RuleAllowed, fmt.Sprintf("filtering by %q is not allowed", name))
+			continue
+		}
+		if limit := v.rules.MaxInSize; limit > 0 && c.claimSize() > limit {
+			v.add(fieldPath, RuleMaxInSize, fmt.Sprintf("%d values exceed the limit of %d", c.claimSize(), limit))
+		}
+		if err := c.checkRange(); err != nil {
+			v.add(fieldPath, RuleRange, err.Error())
+		} else if set, bounded := c.rangeBounded(); set && !bounded && slices.Contains(v.rules.Bounded, name) {
+			v.add(fieldPath, RuleBounded, "the range must be bounded on both sides")
+		}
+	}
+
+	for i, sub := range spec.And {
+		validateSpec(v, sub, join(path, fmt.Sprintf("and[%d]", i)), depth+1)
+	}
+	for i, sub := range spec.Or {
+		validateSpec(v, sub, join(path, fmt.Sprintf("or[%d]", i)), depth+1)
+	}
+}
+
+// conditionByName returns the condition of the filter struct field with the given JSON name.
+func conditionByName(where reflect.Value, name string) (fieldCondition, bool) {
+	for i := range where.NumField() {
+		field := where.Type().Field(i)
+		if c, ok := where.Field(i).Interface().(fieldCondition); ok && field.IsExported() && jsonName(field) == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// claimSize returns the number of values of the in or not_in part, or 0 if it is not set.
+func (c Condition[T]) claimSize() int {
+	if c.Claim == nil {
+		return 0
+	}
+	return len(c.Claim.Values)
+}
+
+// rangeBounded reports whether the range part is set, and whether it is bounded on both sides.
+func (c Condition[T]) rangeBounded() (set, bounded bool) {
+	return c.Between != nil, c.Between != nil && c.Between.IsBounded()
+}
+
+// checkRange returns an error if the range part is inverted or empty. Ranges over unordered types are not checked.
+func (c Condition[T]) checkRange() error {
+	if c.Between == nil || !isOrdered[T]() {
+		return nil
+	}
+	return c.Between.Validate(func(a, b T) bool { return naturalCompare(a, b) < 0 })
+}
@@ -0,0 +1,95 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	rules := Rules{
+		Allowed:   []string{"status", "age", "since"},
+		Required:  []string{"since"},
+		Bounded:   []string{"since"},
+		MaxInSize: 2,
+		MaxDepth:  2,
+	}
+
+	tests := []struct {
+		name     string
+		spec     string
+		expected []ValidationError
+	}{
+		{
+			"Valid",
+			`{"since":{"between":"[2024-01-01T00:00:00Z,2024-02-01T00:00:00Z)"},"or":[{"status":{"in":["a","b"]}},{"age":{"eq":3}}]}`,
+			nil,
+		},
+		{
+			"Violations",
+			`{"email":{"eq":"x"},"since":{"between":"[2024-01-01T00:00:00Z,)"},"or":[{"status":{"not_in":["a","b","c"]}},{"age":{"between":"[9,3]"}}]}`,
+			[]ValidationError{
+				{Path: "email", Rule: RuleAllowed},
+				{Path: "since", Rule: RuleBounded},
+				{Path: "or[0].status", Rule: RuleMaxInSize},
+				{Path: "or[1].age", Rule: RuleRange},
+			},
+		},
+		{
+			"Missing required",
+			`{"status":{"eq":"a"}}`,
+			[]ValidationError{{Path: "", Rule: RuleRequired}},
+		},
+		{
+			"Too deep",
+			`{"since":{"eq":"2024-01-01T00:00:00Z"},"and":[{"or":[{"age":{"eq":1}}]},{"age":{"eq":2}}]}`,
+			[]ValidationError{{Path: "and[0].or[0]", Rule: RuleMaxDepth}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var spec Spec[fullUserFilter]
+			if err := json.Unmarshal([]byte(tt.spec), &spec); err != nil {
+				t.Fatal(err)
+			}
+
+			err := Validate(spec, rules)
+			if tt.expected == nil {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() = %v, want ValidationErrors", err)
+			}
+			if len(errs) != len(tt.expected) {
+				t.Fatalf("Validate() = %v, want %d errors", errs, len(tt.expected))
+			}
+			for i, e := range errs {
+				if e.Path != tt.expected[i].Path || e.Rule != tt.expected[i].Rule {
+					t.Errorf("Error %d = %s (%s), want %q %s", i, e, e.Rule, tt.expected[i].Path, tt.expected[i].Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestValidate_Unlimited(t *testing.T) {
+	var spec Spec[fullUserFilter]
+	if err := json.Unmarshal([]byte(`{"email":{"in":["a","b","c"]},"and":[{"and":[{"and":[{}]}]}]}`), &spec); err != nil {
+		t.Fatal(err)
+	}
+	if err := Validate(spec, Rules{}); err != nil {
+		t.Errorf("Expected zero Rules to accept any spec, got %v", err)
+	}
+
+	err := Validate(spec, Rules{MaxInSize: 1})
+	var e *ValidationError
+	if !errors.As(err, &e) || e.Rule != RuleMaxInSize || e.Error() != "filter: email: 3 values exceed the limit of 1" {
+		t.Errorf("Expected errors.As to find the violation, got %v", err)
+	}
+}
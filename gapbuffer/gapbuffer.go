@@ -0,0 +1,203 @@
+// Package gapbuffer provides a gap buffer, a text buffer optimized for edits around a movable cursor.
+package gapbuffer
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// minGap is the smallest gap the buffer opens when it grows.
+const minGap = 64
+
+// Buffer is a thread-safe text buffer with a cursor, stored as an array of runes with a gap at the cursor.
+// Inserting and deleting at the cursor is O(1) amortized; moving the cursor by d costs O(d), since the gap moves with it.
+// This makes it the simplest fit for line editors and small documents, where edits cluster around the cursor.
+// For large documents edited at scattered positions, use a rope.
+//
+// Positions are rune offsets, so the cursor moves by characters. Out-of-range positions panic like slices.
+type Buffer struct {
+	mu       sync.Mutex
+	data     []rune
+	gapStart int // position of the cursor
+	gapEnd   int // start of the text after the cursor
+}
+
+var _ collection.Container = (*Buffer)(nil)
+
+// New returns a new Buffer holding s, with the cursor at the end.
+func New(s string) *Buffer {
+	b := NewWithCapacity(utf8.RuneCountInString(s))
+	b.insert(s)
+	return b
+}
+
+// NewWithCapacity returns a new empty Buffer with room for n runes before it grows.
+func NewWithCapacity(n int) *Buffer {
+	n = max(n, minGap)
+	return &Buffer{data: make([]rune, n), gapEnd: n}
+}
+
+// Cursor returns the position of the cursor.
+func (b *Buffer) Cursor() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.gapStart
+}
+
+// MoveTo moves the cursor to position i.
+func (b *Buffer) MoveTo(i int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if i < 0 || i > b.len() {
+		panic("gapbuffer: position out of range")
+	}
+	b.moveGap(i)
+}
+
+// Move moves the cursor by delta runes, forward if positive, stopping at either end, and returns its new position.
+func (b *Buffer) Move(delta int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.moveGap(min(max(b.gapStart+delta, 0), b.len()))
+	return b.gapStart
+}
+
+// Insert inserts s at the cursor, leaving the cursor after it.
+func (b *Buffer) Insert(s string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.insert(s)
+}
+
+// InsertRune inserts r at the cursor, leaving the cursor after it.
+func (b *Buffer) InsertRune(r rune) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.reserve(1)
+	b.data[b.gapStart] = r
+	b.gapStart++
+}
+
+// Delete deletes up to n runes after the cursor, like the Delete key, and returns the number deleted.
+func (b *Buffer) Delete(n int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n = min(max(n, 0), len(b.data)-b.gapEnd)
+	b.gapEnd += n
+	return n
+}
+
+// Backspace deletes up to n runes before the cursor, like the Backspace key, and returns the number deleted.
+func (b *Buffer) Backspace(n int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	n = min(max(n, 0), b.gapStart)
+	b.gapStart -= n
+	return n
+}
+
+// At returns the rune at position i.
+func (b *Buffer) At(i int) rune {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if i < 0 || i >= b.len() {
+		panic("gapbuffer: index out of range")
+	}
+	if i < b.gapStart {
+		return b.data[i]
+	}
+	return b.data[i+b.gapEnd-b.gapStart]
+}
+
+// Slice returns the text from position from up to, but not including, to.
+func (b *Buffer) Slice(from, to int) string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if from < 0 || to < from || to > b.len() {
+		panic("gapbuffer: range out of bounds")
+	}
+	var s strings.Builder
+	for i := from; i < to; i++ {
+		if i < b.gapStart {
+			s.WriteRune(b.data[i])
+		} else {
+			s.WriteRune(b.data[i+b.gapEnd-b.gapStart])
+		}
+	}
+	return s.String()
+}
+
+// String returns the text of the buffer.
+func (b *Buffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.data[:b.gapStart]) + string(b.data[b.gapEnd:])
+}
+
+// Len returns the length of the text in runes.
+func (b *Buffer) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.len()
+}
+
+// IsEmpty returns true if the buffer holds no text.
+func (b *Buffer) IsEmpty() bool {
+	return b.Len() == 0
+}
+
+// Clear removes all text, keeping the allocated capacity.
+func (b *Buffer) Clear() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.gapStart, b.gapEnd = 0, len(b.data)
+}
+
+func (b *Buffer) len() int {
+	return len(b.data) - (b.gapEnd - b.gapStart)
+}
+
+func (b *Buffer) insert(s string) {
+	b.reserve(utf8.RuneCountInString(s))
+	for _, r := range s {
+		b.data[b.gapStart] = r
+		b.gapStart++
+	}
+}
+
+// moveGap moves the gap so that it starts at position i, copying the runes between the old and new positions.
+func (b *Buffer) moveGap(i int) {
+	switch {
+	case i < b.gapStart:
+		n := b.gapStart - i
+		copy(b.data[b.gapEnd-n:b.gapEnd], b.data[i:b.gapStart])
+		b.gapStart, b.gapEnd = i, b.gapEnd-n
+	case i > b.gapStart:
+		n := i - b.gapStart
+		copy(b.data[b.gapStart:], b.data[b.gapEnd:b.gapEnd+n])
+		b.gapStart, b.gapEnd = i, b.gapEnd+n
+	}
+}
+
+// reserve grows the gap to hold at least n runes, doubling the capacity so that insertions are amortized O(1).
+func (b *Buffer) reserve(n int) {
+	if b.gapEnd-b.gapStart >= n {
+		return
+	}
+	size := max(2*len(b.data), b.len()+n+minGap)
+	data := make([]rune, size)
+	copy(data, b.data[:b.gapStart])
+	after := len(b.data) - b.gapEnd
+	copy(data[size-after:], b.data[b.gapEnd:])
+	b.data, b.gapEnd = data, size-after
+}
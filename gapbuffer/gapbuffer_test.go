@@ -0,0 +1,126 @@
+package gapbuffer
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestBuffer_Basic(t *testing.T) {
+	b := New("hello")
+	if b.Cursor() != 5 || b.Len() != 5 || b.String() != "hello" {
+		t.Fatalf("New() = %q with cursor %d", b.String(), b.Cursor())
+	}
+
+	b.Insert(" wörld")
+	b.MoveTo(5)
+	b.InsertRune(',')
+	if b.String() != "hello, wörld" || b.Cursor() != 6 {
+		t.Errorf("String() = %q, Cursor() = %d", b.String(), b.Cursor())
+	}
+	if b.At(8) != 'ö' || b.At(2) != 'l' || b.Len() != 12 {
+		t.Errorf("At(8) = %q, Len() = %d", b.At(8), b.Len())
+	}
+	if s := b.Slice(4, 9); s != "o, wö" {
+		t.Errorf("Slice() = %q", s)
+	}
+
+	if n := b.Backspace(2); n != 2 || b.String() != "hell wörld" {
+		t.Errorf("Backspace() = %d, %q", n, b.String())
+	}
+	if n := b.Delete(100); n != 6 || b.String() != "hell" {
+		t.Errorf("Delete() = %d, %q", n, b.String())
+	}
+	if pos := b.Move(-10); pos != 0 {
+		t.Errorf("Move(-10) = %d", pos)
+	}
+	if n := b.Backspace(1); n != 0 {
+		t.Errorf("Backspace() at the start = %d", n)
+	}
+	if pos := b.Move(2); pos != 2 {
+		t.Errorf("Move(2) = %d", pos)
+	}
+
+	b.Clear()
+	if !b.IsEmpty() || b.Cursor() != 0 || b.String() != "" {
+		t.Error("Expected Clear to empty the buffer")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected an out-of-range position to panic")
+		}
+	}()
+	b.MoveTo(1)
+}
+
+func TestBuffer_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	b := NewWithCapacity(0)
+	var ref []rune
+	cursor := 0
+	alphabet := []rune("abcé€😀")
+
+	for i := range 20000 {
+		switch rng.Intn(5) {
+		case 0, 1:
+			s := make([]rune, rng.Intn(10))
+			for j := range s {
+				s[j] = alphabet[rng.Intn(len(alphabet))]
+			}
+			b.Insert(string(s))
+			ref = append(ref[:cursor], append(s, ref[cursor:]...)...)
+			cursor += len(s)
+		case 2:
+			n := min(rng.Intn(5), len(ref)-cursor)
+			if got := b.Delete(n); got != n {
+				t.Fatalf("Delete() = %d, want %d", got, n)
+			}
+			ref = append(ref[:cursor], ref[cursor+n:]...)
+		case 3:
+			n := min(rng.Intn(5), cursor)
+			b.Backspace(n)
+			ref = append(ref[:cursor-n], ref[cursor:]...)
+			cursor -= n
+		default:
+			cursor = rng.Intn(len(ref) + 1)
+			b.MoveTo(cursor)
+		}
+
+		if b.Len() != len(ref) || b.Cursor() != cursor {
+			t.Fatalf("Step %d: Len() = %d, Cursor() = %d; want %d, %d", i, b.Len(), b.Cursor(), len(ref), cursor)
+		}
+		if i%200 == 0 {
+			if b.String() != string(ref) {
+				t.Fatalf("Step %d: text disagrees with the reference", i)
+			}
+			if len(ref) > 0 {
+				j := rng.Intn(len(ref))
+				if b.At(j) != ref[j] {
+					t.Fatalf("Step %d: At(%d) disagrees with the reference", i, j)
+				}
+			}
+		}
+	}
+}
+
+func TestBuffer_Concurrency(t *testing.T) {
+	b := New("")
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 500 {
+				b.Insert("ab")
+				b.Move(-1)
+				_ = b.String()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if b.Len() != 8*500*2 {
+		t.Errorf("Len() = %d, want %d", b.Len(), 8*500*2)
+	}
+}
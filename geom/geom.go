@@ -0,0 +1,80 @@
+// Package geom provides the planar points and rectangles shared by the spatial indexes of kozo.
+package geom
+
+import "math"
+
+// Point is a position in the plane, such as projected coordinates or a longitude and latitude.
+type Point struct {
+	X, Y float64
+}
+
+// Rect is a closed axis-aligned rectangle: the points whose coordinates lie between those of Min and Max, inclusive.
+// A rectangle whose Min equals its Max is a single point.
+type Rect struct {
+	Min, Max Point
+}
+
+// NewRect returns the rectangle with the given corners, in any order.
+func NewRect(x0, y0, x1, y1 float64) Rect {
+	return Rect{Min: Point{min(x0, x1), min(y0, y1)}, Max: Point{max(x0, x1), max(y0, y1)}}
+}
+
+// Distance returns the Euclidean distance between p and q.
+func (p Point) Distance(q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// Bounds returns the rectangle holding only p.
+func (p Point) Bounds() Rect {
+	return Rect{Min: p, Max: p}
+}
+
+// Width returns the extent of the rectangle along X.
+func (r Rect) Width() float64 {
+	return r.Max.X - r.Min.X
+}
+
+// Height returns the extent of the rectangle along Y.
+func (r Rect) Height() float64 {
+	return r.Max.Y - r.Min.Y
+}
+
+// Area returns the area of the rectangle.
+func (r Rect) Area() float64 {
+	return r.Width() * r.Height()
+}
+
+// Center returns the center of the rectangle.
+func (r Rect) Center() Point {
+	return Point{(r.Min.X + r.Max.X) / 2, (r.Min.Y + r.Max.Y) / 2}
+}
+
+// Contains returns true if p lies in the rectangle, boundary included.
+func (r Rect) Contains(p Point) bool {
+	return p.X >= r.Min.X && p.X <= r.Max.X && p.Y >= r.Min.Y && p.Y <= r.Max.Y
+}
+
+// ContainsRect returns true if other lies entirely in the rectangle, boundary included.
+func (r Rect) ContainsRect(other Rect) bool {
+	return r.Contains(other.Min) && r.Contains(other.Max)
+}
+
+// Intersects returns true if the rectangles share at least one point, so touching rectangles intersect.
+func (r Rect) Intersects(other Rect) bool {
+	return r.Min.X <= other.Max.X && other.Min.X <= r.Max.X && r.Min.Y <= other.Max.Y && other.Min.Y <= r.Max.Y
+}
+
+// Union returns the smallest rectangle containing both rectangles.
+func (r Rect) Union(other Rect) Rect {
+	return Rect{
+		Min: Point{min(r.Min.X, other.Min.X), min(r.Min.Y, other.Min.Y)},
+		Max: Point{max(r.Max.X, other.Max.X), max(r.Max.Y, other.Max.Y)},
+	}
+}
+
+// Distance returns the Euclidean distance from p to the nearest point of the rectangle, which is 0 if p lies in it.
+func (r Rect) Distance(p Point) float64 {
+	dx := max(r.Min.X-p.X, 0, p.X-r.Max.X)
+	dy := max(r.Min.Y-p.Y, 0, p.Y-r.Max.Y)
+	return math.Hypot(dx, dy)
+}
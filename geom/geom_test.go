@@ -0,0 +1,44 @@
+package geom
+
+import "testing"
+
+func TestRect(t *testing.T) {
+	r := NewRect(4, 3, 0, 1)
+	if r != (Rect{Point{0, 1}, Point{4, 3}}) {
+		t.Fatalf("NewRect() = %v", r)
+	}
+	if r.Width() != 4 || r.Height() != 2 || r.Area() != 8 || r.Center() != (Point{2, 2}) {
+		t.Errorf("Unexpected measures of %v", r)
+	}
+
+	if !r.Contains(Point{0, 1}) || !r.Contains(Point{4, 3}) || r.Contains(Point{4.1, 2}) {
+		t.Error("Unexpected point containment")
+	}
+	if !r.ContainsRect(NewRect(1, 1, 4, 2)) || r.ContainsRect(NewRect(1, 1, 5, 2)) {
+		t.Error("Unexpected rectangle containment")
+	}
+	if !r.Intersects(NewRect(4, 3, 6, 6)) || r.Intersects(NewRect(5, 0, 6, 6)) {
+		t.Error("Unexpected intersection")
+	}
+	if u := r.Union(NewRect(-1, 5, 0, 6)); u != NewRect(-1, 1, 4, 6) {
+		t.Errorf("Union() = %v", u)
+	}
+
+	tests := []struct {
+		p    Point
+		want float64
+	}{
+		{Point{2, 2}, 0}, {Point{4, 3}, 0}, {Point{7, 2}, 3}, {Point{2, -2}, 3}, {Point{7, 7}, 5},
+	}
+	for _, tt := range tests {
+		if d := r.Distance(tt.p); d != tt.want {
+			t.Errorf("Distance(%v) = %v, want %v", tt.p, d, tt.want)
+		}
+	}
+	if d := (Point{0, 0}).Distance(Point{3, 4}); d != 5 {
+		t.Errorf("Point.Distance() = %v", d)
+	}
+	if b := (Point{1, 2}).Bounds(); b.Area() != 0 || !b.Contains(Point{1, 2}) {
+		t.Errorf("Bounds() = %v", b)
+	}
+}
@@ -0,0 +1,358 @@
+// Package graph provides directed and undirected graphs stored as adjacency lists, with traversals
+// and algorithms for dependency analysis.
+package graph
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// Graph is a thread-safe graph whose nodes are comparable values, such as module names or IDs,
+// stored as adjacency lists. It is either directed or undirected, which is fixed at construction.
+//
+// Nodes and neighbors are visited in the order they were added, so every traversal is deterministic.
+// Adding and looking up nodes and edges is O(1); removing an edge is O(degree) and removing a node O(n + degree).
+type Graph[N comparable] struct {
+	mu       sync.RWMutex
+	directed bool
+	nodes    []N
+	vertices map[N]*vertex[N]
+	edges    int
+	negative int // number of edges with a negative weight
+}
+
+var _ collection.Container = (*Graph[int])(nil)
+
+type vertex[N comparable] struct {
+	index int          // position in nodes
+	out   adjacency[N] // successors, or all neighbors of an undirected graph
+	in    adjacency[N] // predecessors of a directed graph
+}
+
+// adjacency is an insertion-ordered set of neighbors with the weights of the edges to them.
+type adjacency[N comparable] struct {
+	order   []N
+	weights map[N]float64
+}
+
+// NewDirected returns a new empty directed graph.
+func NewDirected[N comparable]() *Graph[N] {
+	return &Graph[N]{directed: true, vertices: make(map[N]*vertex[N])}
+}
+
+// NewUndirected returns a new empty undirected graph.
+func NewUndirected[N comparable]() *Graph[N] {
+	return &Graph[N]{vertices: make(map[N]*vertex[N])}
+}
+
+// IsDirected returns true if the graph is directed.
+func (g *Graph[N]) IsDirected() bool {
+	return g.directed
+}
+
+// AddNode adds a node without edges. It returns true if the node was new.
+func (g *Graph[N]) AddNode(n N) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	_, existed := g.vertices[n]
+	g.vertex(n)
+	return !existed
+}
+
+// RemoveNode removes a node and all its edges. It returns true if the node was present.
+func (g *Graph[N]) RemoveNode(n N) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	v, ok := g.vertices[n]
+	if !ok {
+		return false
+	}
+	for _, m := range slices.Clone(v.out.order) {
+		g.removeEdge(n, m)
+	}
+	for _, m := range slices.Clone(v.in.order) {
+		g.removeEdge(m, n)
+	}
+
+	delete(g.vertices, n)
+	g.nodes = slices.Delete(g.nodes, v.index, v.index+1)
+	for i := v.index; i < len(g.nodes); i++ {
+		g.vertices[g.nodes[i]].index = i
+	}
+	return true
+}
+
+// HasNode returns true if the node is present.
+func (g *Graph[N]) HasNode(n N) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	_, ok := g.vertices[n]
+	return ok
+}
+
+// AddEdge adds an edge of weight 1 from one node to another, adding the nodes if needed.
+// In an undirected graph, the edge connects both ways. It returns true if the edge was new;
+// an existing edge keeps its weight.
+func (g *Graph[N]) AddEdge(from, to N) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if u, ok := g.vertices[from]; ok && u.out.has(to) {
+		return false
+	}
+	return g.setEdge(from, to, 1)
+}
+
+// RemoveEdge removes the edge from one node to another. It returns true if the edge was present.
+func (g *Graph[N]) RemoveEdge(from, to N) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.removeEdge(from, to)
+}
+
+// HasEdge returns true if there is an edge from one node to another.
+func (g *Graph[N]) HasEdge(from, to N) bool {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	u, ok := g.vertices[from]
+	return ok && u.out.has(to)
+}
+
+// Neighbors returns an iterator over a snapshot of the nodes that n has an edge to:
+// its successors in a directed graph, or all adjacent nodes in an undirected one.
+func (g *Graph[N]) Neighbors(n N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		for _, m := range g.successors(n) {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+// Predecessors returns an iterator over a snapshot of the nodes that have an edge to n.
+// In an undirected graph, these are its neighbors.
+func (g *Graph[N]) Predecessors(n N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		g.mu.RLock()
+		var nodes []N
+		if v, ok := g.vertices[n]; ok {
+			if g.directed {
+				nodes = slices.Clone(v.in.order)
+			} else {
+				nodes = slices.Clone(v.out.order)
+			}
+		}
+		g.mu.RUnlock()
+
+		for _, m := range nodes {
+			if !yield(m) {
+				return
+			}
+		}
+	}
+}
+
+// OutDegree returns the number of edges leaving n, or in an undirected graph, the number of its neighbors.
+func (g *Graph[N]) OutDegree(n N) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if v, ok := g.vertices[n]; ok {
+		return len(v.out.order)
+	}
+	return 0
+}
+
+// InDegree returns the number of edges entering n, or in an undirected graph, the number of its neighbors.
+func (g *Graph[N]) InDegree(n N) int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	v, ok := g.vertices[n]
+	switch {
+	case !ok:
+		return 0
+	case g.directed:
+		return len(v.in.order)
+	default:
+		return len(v.out.order)
+	}
+}
+
+// Nodes returns an iterator over a snapshot of the nodes, in the order they were added.
+func (g *Graph[N]) Nodes() iter.Seq[N] {
+	return func(yield func(N) bool) {
+		g.mu.RLock()
+		nodes := slices.Clone(g.nodes)
+		g.mu.RUnlock()
+
+		for _, n := range nodes {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Edges returns an iterator over a snapshot of the edges as (from, to) pairs.
+// Each edge of an undirected graph is visited once, from the node that was added first.
+func (g *Graph[N]) Edges() iter.Seq2[N, N] {
+	return func(yield func(N, N) bool) {
+		g.mu.RLock()
+		edges := g.edgeList()
+		g.mu.RUnlock()
+
+		for _, e := range edges {
+			if !yield(e.From, e.To) {
+				return
+			}
+		}
+	}
+}
+
+// NodeCount returns the number of nodes.
+func (g *Graph[N]) NodeCount() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return len(g.nodes)
+}
+
+// Len returns the number of nodes, like NodeCount.
+func (g *Graph[N]) Len() int {
+	return g.NodeCount()
+}
+
+// IsEmpty returns true if the graph has no nodes.
+func (g *Graph[N]) IsEmpty() bool {
+	return g.NodeCount() == 0
+}
+
+// EdgeCount returns the number of edges. Each edge of an undirected graph counts once.
+func (g *Graph[N]) EdgeCount() int {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.edges
+}
+
+// Clear removes all nodes and edges.
+func (g *Graph[N]) Clear() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.nodes = nil
+	g.vertices = make(map[N]*vertex[N])
+	g.edges = 0
+	g.negative = 0
+}
+
+// Clone returns a copy of the graph.
+func (g *Graph[N]) Clone() *Graph[N] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	c := &Graph[N]{directed: g.directed, nodes: slices.Clone(g.nodes), vertices: make(map[N]*vertex[N], len(g.vertices)), edges: g.edges, negative: g.negative}
+	for n, v := range g.vertices {
+		c.vertices[n] = &vertex[N]{index: v.index, out: v.out.clone(), in: v.in.clone()}
+	}
+	return c
+}
+
+// vertex returns the vertex of n, adding it if needed.
+func (g *Graph[N]) vertex(n N) *vertex[N] {
+	v, ok := g.vertices[n]
+	if !ok {
+		v = &vertex[N]{index: len(g.nodes)}
+		g.vertices[n] = v
+		g.nodes = append(g.nodes, n)
+	}
+	return v
+}
+
+// setEdge adds the edge from one node to another or changes its weight. It returns true if the edge was new.
+func (g *Graph[N]) setEdge(from, to N, weight float64) bool {
+	u, v := g.vertex(from), g.vertex(to)
+	if old, ok := u.out.weights[to]; ok && old < 0 {
+		g.negative--
+	}
+	if weight < 0 {
+		g.negative++
+	}
+
+	isNew := u.out.set(to, weight)
+	if g.directed {
+		v.in.set(from, weight)
+	} else {
+		v.out.set(from, weight)
+	}
+	if isNew {
+		g.edges++
+	}
+	return isNew
+}
+
+func (g *Graph[N]) removeEdge(from, to N) bool {
+	u, ok := g.vertices[from]
+	if !ok || !u.out.has(to) {
+		return false
+	}
+	if u.out.weights[to] < 0 {
+		g.negative--
+	}
+	u.out.remove(to)
+	if g.directed {
+		g.vertices[to].in.remove(from)
+	} else {
+		g.vertices[to].out.remove(from)
+	}
+	g.edges--
+	return true
+}
+
+// successors returns a copy of the neighbors n has an edge to, or nil if n is not present.
+func (g *Graph[N]) successors(n N) []N {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if v, ok := g.vertices[n]; ok {
+		return slices.Clone(v.out.order)
+	}
+	return nil
+}
+
+// set adds n or changes its weight. It returns true if n was new.
+func (a *adjacency[N]) set(n N, weight float64) bool {
+	isNew := !a.has(n)
+	if a.weights == nil {
+		a.weights = make(map[N]float64)
+	}
+	a.weights[n] = weight
+	if isNew {
+		a.order = append(a.order, n)
+	}
+	return isNew
+}
+
+func (a *adjacency[N]) remove(n N) bool {
+	if !a.has(n) {
+		return false
+	}
+	delete(a.weights, n)
+	a.order = slices.DeleteFunc(a.order, func(m N) bool { return m == n })
+	return true
+}
+
+func (a *adjacency[N]) has(n N) bool {
+	_, ok := a.weights[n]
+	return ok
+}
+
+func (a *adjacency[N]) clone() adjacency[N] {
+	return adjacency[N]{order: slices.Clone(a.order), weights: maps.Clone(a.weights)}
+}
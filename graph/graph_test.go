@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func edges[N comparable](g *Graph[N]) [][2]N {
+	var out [][2]N
+	for from, to := range g.Edges() {
+		out = append(out, [2]N{from, to})
+	}
+	return out
+}
+
+func TestDirected(t *testing.T) {
+	g := NewDirected[string]()
+	if !g.AddEdge("app", "db") || !g.AddEdge("app", "log") || !g.AddEdge("db", "log") || g.AddEdge("app", "db") {
+		t.Error("AddEdge() reported the wrong novelty")
+	}
+	if g.AddNode("app") || !g.AddNode("cli") {
+		t.Error("AddNode() reported the wrong novelty")
+	}
+	if !g.IsDirected() || g.NodeCount() != 4 || g.EdgeCount() != 3 {
+		t.Errorf("Got %d nodes and %d edges", g.NodeCount(), g.EdgeCount())
+	}
+
+	if !g.HasEdge("app", "db") || g.HasEdge("db", "app") {
+		t.Error("Expected edges to be directed")
+	}
+	if got := slices.Collect(g.Neighbors("app")); !slices.Equal(got, []string{"db", "log"}) {
+		t.Errorf("Neighbors(app) = %v", got)
+	}
+	if got := slices.Collect(g.Predecessors("log")); !slices.Equal(got, []string{"app", "db"}) {
+		t.Errorf("Predecessors(log) = %v", got)
+	}
+	if g.OutDegree("app") != 2 || g.InDegree("log") != 2 || g.InDegree("missing") != 0 {
+		t.Error("Unexpected degrees")
+	}
+	if got := slices.Collect(g.Nodes()); !slices.Equal(got, []string{"app", "db", "log", "cli"}) {
+		t.Errorf("Nodes() = %v", got)
+	}
+
+	if !g.RemoveEdge("app", "log") || g.RemoveEdge("app", "log") || g.RemoveEdge("x", "y") {
+		t.Error("Expected RemoveEdge to report presence once")
+	}
+	if !g.RemoveNode("db") || g.RemoveNode("db") {
+		t.Error("Expected RemoveNode to report presence once")
+	}
+	if g.EdgeCount() != 0 || g.InDegree("log") != 0 || g.HasNode("db") {
+		t.Errorf("Expected the edges of db to be removed, got %v", edges(g))
+	}
+	if got := slices.Collect(g.Nodes()); !slices.Equal(got, []string{"app", "log", "cli"}) {
+		t.Errorf("Nodes() = %v", got)
+	}
+	g.AddEdge("cli", "app")
+	if got := edges(g); !slices.Equal(got, [][2]string{{"cli", "app"}}) {
+		t.Errorf("Edges() = %v", got)
+	}
+
+	g.Clear()
+	if g.NodeCount() != 0 || g.EdgeCount() != 0 || g.Len() != 0 || !g.IsEmpty() {
+		t.Error("Expected an empty graph after Clear")
+	}
+}
+
+func TestUndirected(t *testing.T) {
+	g := NewUndirected[int]()
+	g.AddEdge(1, 2)
+	g.AddEdge(3, 1)
+	g.AddEdge(2, 2)
+	if g.AddEdge(2, 1) {
+		t.Error("Expected 2-1 to be the same edge as 1-2")
+	}
+	if g.EdgeCount() != 3 || !g.HasEdge(1, 3) {
+		t.Errorf("Got %d edges", g.EdgeCount())
+	}
+	if got := edges(g); !slices.Equal(got, [][2]int{{1, 2}, {1, 3}, {2, 2}}) {
+		t.Errorf("Edges() = %v", got)
+	}
+	if got := slices.Collect(g.Predecessors(1)); !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("Predecessors(1) = %v", got)
+	}
+
+	c := g.Clone()
+	if !g.RemoveEdge(3, 1) || g.HasEdge(1, 3) || g.EdgeCount() != 2 {
+		t.Error("Expected removing 3-1 to remove 1-3")
+	}
+	if !g.RemoveNode(2) || g.EdgeCount() != 0 {
+		t.Errorf("Expected no edges left, got %v", edges(g))
+	}
+	if c.EdgeCount() != 3 || !c.HasEdge(3, 1) {
+		t.Error("Expected the clone to be unaffected")
+	}
+}
+
+func TestTraversal(t *testing.T) {
+	//   a → b → d
+	//   ↓   ↓
+	//   c → e   f (unreachable)
+	g := NewDirected[string]()
+	for _, e := range [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"b", "e"}, {"c", "e"}, {"e", "a"}} {
+		g.AddEdge(e[0], e[1])
+	}
+	g.AddNode("f")
+
+	if got := slices.Collect(g.BFS("a")); !slices.Equal(got, []string{"a", "b", "c", "d", "e"}) {
+		t.Errorf("BFS(a) = %v", got)
+	}
+	if got := slices.Collect(g.DFS("a")); !slices.Equal(got, []string{"a", "b", "d", "e", "c"}) {
+		t.Errorf("DFS(a) = %v", got)
+	}
+	if got := slices.Collect(g.BFS("missing")); len(got) != 0 {
+		t.Errorf("BFS(missing) = %v", got)
+	}
+
+	var first []string
+	for n := range g.DFS("a") {
+		first = append(first, n)
+		if len(first) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(first, []string{"a", "b"}) {
+		t.Errorf("Stopped DFS = %v", first)
+	}
+}
+
+func TestConcurrency(t *testing.T) {
+	g := NewDirected[int]()
+	var wg sync.WaitGroup
+	for w := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 200 {
+				g.AddEdge(w*1000+i, w*1000+i+1)
+				for range g.BFS(w * 1000) {
+				}
+				if i%2 == 0 {
+					g.RemoveEdge(w*1000+i, w*1000+i+1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if g.EdgeCount() != 8*100 {
+		t.Errorf("EdgeCount() = %d, want %d", g.EdgeCount(), 8*100)
+	}
+}
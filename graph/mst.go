@@ -0,0 +1,103 @@
+package graph
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+
+	"github.com/dullkingsman/kozo/dsu"
+	"github.com/dullkingsman/kozo/heap"
+)
+
+// ErrDirected is returned by algorithms that are only defined on undirected graphs.
+var ErrDirected = errors.New("graph: directed graph")
+
+// Kruskal returns the edges of a minimum spanning tree and their total weight, computed by Kruskal's algorithm
+// in O(m log m) for m edges, using a disjoint-set union. If the graph is not connected, it returns a minimum
+// spanning forest, with a tree for each connected component. Edges of equal weight are taken in the order of Edges.
+// It returns ErrDirected for a directed graph.
+func (g *Graph[N]) Kruskal() ([]Edge[N], float64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+
+	edges := g.edgeList()
+	slices.SortStableFunc(edges, func(a, b Edge[N]) int { return cmp.Compare(a.Weight, b.Weight) })
+
+	var (
+		tree  []Edge[N]
+		total float64
+		sets  = dsu.New(g.nodes...)
+	)
+	for _, e := range edges {
+		if sets.Sets() == 1 {
+			break
+		}
+		if sets.Union(e.From, e.To) {
+			tree = append(tree, e)
+			total += e.Weight
+		}
+	}
+	return tree, total, nil
+}
+
+// Prim returns the edges of a minimum spanning tree and their total weight, computed by Prim's algorithm
+// in O(m log m) for m edges, using a binary heap. Each tree grows from the earliest added node of its component,
+// and each edge leads from the tree to the node it adds. Like Kruskal, it returns a minimum spanning forest
+// if the graph is not connected, and ErrDirected for a directed graph.
+func (g *Graph[N]) Prim() ([]Edge[N], float64, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.directed {
+		return nil, 0, ErrDirected
+	}
+
+	// Candidate edges of equal weight are taken in the order they were found, so that the tree is deterministic.
+	type candidate struct {
+		edge Edge[N]
+		seq  int
+	}
+	var (
+		tree    []Edge[N]
+		total   float64
+		seq     int
+		inTree  = make(map[N]bool, len(g.nodes))
+		pending = heap.New(func(a, b candidate) bool {
+			if a.edge.Weight != b.edge.Weight {
+				return a.edge.Weight < b.edge.Weight
+			}
+			return a.seq < b.seq
+		})
+	)
+	grow := func(n N) {
+		inTree[n] = true
+		out := &g.vertices[n].out
+		for _, m := range out.order {
+			if !inTree[m] {
+				pending.Push(candidate{Edge[N]{From: n, To: m, Weight: out.weights[m]}, seq})
+				seq++
+			}
+		}
+	}
+
+	for _, root := range g.nodes {
+		if inTree[root] {
+			continue
+		}
+		grow(root)
+		for !pending.IsEmpty() {
+			c, _ := pending.Pop()
+			if inTree[c.edge.To] {
+				continue
+			}
+			tree = append(tree, c.edge)
+			total += c.edge.Weight
+			grow(c.edge.To)
+		}
+	}
+	return tree, total, nil
+}
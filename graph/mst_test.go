@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/dullkingsman/kozo/dsu"
+)
+
+func TestMinimumSpanningTree(t *testing.T) {
+	g := NewUndirected[string]()
+	g.AddWeightedEdge("a", "b", 4)
+	g.AddWeightedEdge("a", "c", 1)
+	g.AddWeightedEdge("b", "c", 2)
+	g.AddWeightedEdge("c", "d", 5)
+	g.AddWeightedEdge("b", "d", 3)
+	g.AddWeightedEdge("d", "d", -1)
+	g.AddWeightedEdge("x", "y", 7)
+
+	tree, total, err := g.Kruskal()
+	want := []Edge[string]{{"a", "c", 1}, {"b", "c", 2}, {"b", "d", 3}, {"x", "y", 7}}
+	if err != nil || total != 13 || !slices.Equal(tree, want) {
+		t.Errorf("Kruskal() = %v, %v, %v", tree, total, err)
+	}
+
+	tree, total, err = g.Prim()
+	want = []Edge[string]{{"a", "c", 1}, {"c", "b", 2}, {"b", "d", 3}, {"x", "y", 7}}
+	if err != nil || total != 13 || !slices.Equal(tree, want) {
+		t.Errorf("Prim() = %v, %v, %v", tree, total, err)
+	}
+
+	d := NewDirected[string]()
+	d.AddEdge("a", "b")
+	if _, _, err := d.Kruskal(); !errors.Is(err, ErrDirected) {
+		t.Errorf("Expected ErrDirected from Kruskal, got %v", err)
+	}
+	if _, _, err := d.Prim(); !errors.Is(err, ErrDirected) {
+		t.Errorf("Expected ErrDirected from Prim, got %v", err)
+	}
+
+	if tree, total, err := NewUndirected[int]().Prim(); err != nil || tree != nil || total != 0 {
+		t.Errorf("Prim() of an empty graph = %v, %v, %v", tree, total, err)
+	}
+}
+
+// bruteForceForest returns the least total weight of a spanning forest, trying every subset of edges.
+func bruteForceForest(g *Graph[int]) float64 {
+	edges := slices.Collect(g.WeightedEdges())
+	components := len(g.StronglyConnectedComponents())
+	best := math.Inf(1)
+	for mask := range 1 << len(edges) {
+		sets := dsu.New(slices.Collect(g.Nodes())...)
+		total, forest := 0.0, true
+		for i, e := range edges {
+			if mask&(1<<i) == 0 {
+				continue
+			}
+			if !sets.Union(e.From, e.To) {
+				forest = false
+				break
+			}
+			total += e.Weight
+		}
+		if forest && sets.Sets() == components {
+			best = min(best, total)
+		}
+	}
+	return best
+}
+
+// checkForest verifies that the edges exist in g and form a spanning forest of it.
+func checkForest(t *testing.T, g *Graph[int], tree []Edge[int]) {
+	t.Helper()
+	sets := dsu.New(slices.Collect(g.Nodes())...)
+	for _, e := range tree {
+		if w, ok := g.Weight(e.From, e.To); !ok || w != e.Weight {
+			t.Fatalf("Edge %v is not in the graph", e)
+		}
+		if !sets.Union(e.From, e.To) {
+			t.Fatalf("Edge %v closes a cycle", e)
+		}
+	}
+	if sets.Sets() != len(g.StronglyConnectedComponents()) {
+		t.Fatalf("Forest has %d trees for %d components", sets.Sets(), len(g.StronglyConnectedComponents()))
+	}
+}
+
+func TestMinimumSpanningTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := range 200 {
+		const n = 7
+		g := NewUndirected[int]()
+		for i := range n {
+			g.AddNode(i)
+		}
+		for range rng.Intn(13) {
+			g.AddWeightedEdge(rng.Intn(n), rng.Intn(n), float64(rng.Intn(10)-3))
+		}
+
+		want := bruteForceForest(g)
+		kruskal, kTotal, err := g.Kruskal()
+		if err != nil || kTotal != want {
+			t.Fatalf("Round %d: Kruskal() weighs %v, %v; want %v", round, kTotal, err, want)
+		}
+		checkForest(t, g, kruskal)
+
+		prim, pTotal, err := g.Prim()
+		if err != nil || pTotal != want {
+			t.Fatalf("Round %d: Prim() weighs %v, %v; want %v", round, pTotal, err, want)
+		}
+		checkForest(t, g, prim)
+	}
+}
@@ -0,0 +1,135 @@
+package graph
+
+import "slices"
+
+// Condensation is the graph of the strongly connected components of a graph, in which each component
+// is collapsed into a single node. It has no cycles, so it can be sorted topologically.
+type Condensation[N comparable] struct {
+	components [][]N
+	component  map[N]int
+	graph      *Graph[int]
+}
+
+// StronglyConnectedComponents returns the strongly connected components of the graph: the largest sets of nodes
+// that can all reach each other, such as mutually dependent modules. In an undirected graph, these are
+// the connected components. It uses Tarjan's algorithm in O(n + m) for n nodes and m edges.
+//
+// Components are in topological order, so that edges between components only lead to later ones,
+// and the nodes of each component are in the order they were added.
+func (g *Graph[N]) StronglyConnectedComponents() [][]N {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.components()
+}
+
+// Condensation returns the condensation of the graph, a directed graph with a node for each strongly
+// connected component and an edge between two components wherever an edge joins their nodes.
+// The components are numbered from 0 in the order of StronglyConnectedComponents, which is topological.
+func (g *Graph[N]) Condensation() *Condensation[N] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	c := &Condensation[N]{components: g.components(), component: make(map[N]int, len(g.nodes)), graph: NewDirected[int]()}
+	for i, component := range c.components {
+		c.graph.vertex(i)
+		for _, n := range component {
+			c.component[n] = i
+		}
+	}
+	for _, n := range g.nodes {
+		for _, m := range g.vertices[n].out.order {
+			if from, to := c.component[n], c.component[m]; from != to && !c.graph.vertices[from].out.has(to) {
+				c.graph.setEdge(from, to, 1)
+			}
+		}
+	}
+	return c
+}
+
+// Graph returns the directed acyclic graph of the components, whose nodes are component numbers.
+func (c *Condensation[N]) Graph() *Graph[int] {
+	return c.graph
+}
+
+// Len returns the number of components.
+func (c *Condensation[N]) Len() int {
+	return len(c.components)
+}
+
+// Component returns a copy of the nodes of component i. It panics if i is out of range.
+func (c *Condensation[N]) Component(i int) []N {
+	return slices.Clone(c.components[i])
+}
+
+// ComponentOf returns the number of the component n belongs to. Returns false if n was not in the graph.
+func (c *Condensation[N]) ComponentOf(n N) (int, bool) {
+	i, ok := c.component[n]
+	return i, ok
+}
+
+// components runs Tarjan's algorithm with an explicit stack, so that long paths cannot overflow the call stack.
+func (g *Graph[N]) components() [][]N {
+	type frame struct {
+		n    N
+		next int // position of the next neighbor to visit
+	}
+	var (
+		index      = make(map[N]int, len(g.nodes)) // discovery order
+		low        = make(map[N]int, len(g.nodes)) // lowest discovery order reachable on the stack
+		onStack    = make(map[N]bool)
+		stack      []N
+		frames     []frame
+		components [][]N
+	)
+	visit := func(n N) {
+		index[n], low[n] = len(index), len(index)
+		stack = append(stack, n)
+		onStack[n] = true
+		frames = append(frames, frame{n: n})
+	}
+
+	for _, root := range g.nodes {
+		if _, seen := index[root]; seen {
+			continue
+		}
+		visit(root)
+
+		for len(frames) > 0 {
+			f := &frames[len(frames)-1]
+			if out := g.vertices[f.n].out.order; f.next < len(out) {
+				m := out[f.next]
+				f.next++
+				if _, seen := index[m]; !seen {
+					visit(m)
+				} else if onStack[m] {
+					low[f.n] = min(low[f.n], index[m])
+				}
+				continue
+			}
+
+			n := f.n
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				parent := frames[len(frames)-1].n
+				low[parent] = min(low[parent], low[n])
+			}
+			if low[n] != index[n] {
+				continue
+			}
+
+			// n is the root of a component, made of the nodes above it on the stack.
+			i := slices.Index(stack, n)
+			component := slices.Clone(stack[i:])
+			stack = stack[:i]
+			for _, m := range component {
+				delete(onStack, m)
+			}
+			slices.SortFunc(component, func(a, b N) int { return g.vertices[a].index - g.vertices[b].index })
+			components = append(components, component)
+		}
+	}
+
+	// Tarjan's algorithm completes components after all the components they lead to.
+	slices.Reverse(components)
+	return components
+}
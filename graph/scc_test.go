@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestStronglyConnectedComponents(t *testing.T) {
+	g := NewDirected[string]()
+	// api and auth depend on each other, as do db and cache.
+	g.AddEdge("app", "api")
+	g.AddEdge("api", "auth")
+	g.AddEdge("auth", "api")
+	g.AddEdge("api", "db")
+	g.AddEdge("db", "cache")
+	g.AddEdge("cache", "db")
+	g.AddEdge("auth", "log")
+	g.AddNode("cli")
+
+	got := g.StronglyConnectedComponents()
+	want := [][]string{{"cli"}, {"app"}, {"api", "auth"}, {"db", "cache"}, {"log"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("StronglyConnectedComponents() = %v", got)
+	}
+
+	c := g.Condensation()
+	if c.Len() != 5 || !c.Graph().IsDirected() {
+		t.Fatalf("Condensation has %d components", c.Len())
+	}
+	i, ok := c.ComponentOf("auth")
+	if !ok || !slices.Equal(c.Component(i), []string{"api", "auth"}) {
+		t.Errorf("ComponentOf(auth) = %d, %v", i, ok)
+	}
+	if _, ok := c.ComponentOf("missing"); ok {
+		t.Error("Expected no component for a missing node")
+	}
+	if got := edges(c.Graph()); !slices.Equal(got, [][2]int{{1, 2}, {2, 3}, {2, 4}}) {
+		t.Errorf("Condensation edges = %v", got)
+	}
+	if order, err := c.Graph().TopologicalSort(); err != nil || !slices.Equal(order, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("TopologicalSort() of the condensation = %v, %v", order, err)
+	}
+
+	u := NewUndirected[int]()
+	u.AddEdge(1, 2)
+	u.AddEdge(3, 4)
+	u.AddEdge(4, 2)
+	u.AddNode(5)
+	if got := u.StronglyConnectedComponents(); !slices.EqualFunc(got, [][]int{{5}, {1, 2, 3, 4}}, slices.Equal) {
+		t.Errorf("Undirected components = %v", got)
+	}
+}
+
+func TestTopologicalSort(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddEdge("log", "db")
+	g.AddEdge("db", "api")
+	g.AddEdge("log", "api")
+	g.AddEdge("api", "app")
+	g.AddNode("cli")
+
+	order, err := g.TopologicalSort()
+	if err != nil || !slices.Equal(order, []string{"log", "cli", "db", "api", "app"}) {
+		t.Errorf("TopologicalSort() = %v, %v", order, err)
+	}
+
+	g.AddEdge("app", "log")
+	if _, err := g.TopologicalSort(); !errors.Is(err, ErrCycle) {
+		t.Errorf("Expected ErrCycle, got %v", err)
+	}
+	g.RemoveEdge("app", "log")
+	g.AddEdge("cli", "cli")
+	if _, err := g.TopologicalSort(); !errors.Is(err, ErrCycle) {
+		t.Errorf("Expected a self-loop to be a cycle, got %v", err)
+	}
+
+	u := NewUndirected[int]()
+	u.AddNode(1)
+	u.AddNode(2)
+	if order, err := u.TopologicalSort(); err != nil || !slices.Equal(order, []int{1, 2}) {
+		t.Errorf("TopologicalSort() of edgeless graph = %v, %v", order, err)
+	}
+	u.AddEdge(1, 2)
+	if _, err := u.TopologicalSort(); !errors.Is(err, ErrCycle) {
+		t.Errorf("Expected an undirected edge to be a cycle, got %v", err)
+	}
+}
+
+func TestStronglyConnectedComponents_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := range 200 {
+		const n = 15
+		g := NewDirected[int]()
+		for i := range n {
+			g.AddNode(i)
+		}
+		for range rng.Intn(30) {
+			g.AddEdge(rng.Intn(n), rng.Intn(n))
+		}
+
+		reach := make([]map[int]bool, n)
+		for i := range n {
+			reach[i] = map[int]bool{}
+			for m := range g.BFS(i) {
+				reach[i][m] = true
+			}
+		}
+
+		c := g.Condensation()
+		seen := 0
+		for i := range c.Len() {
+			for _, a := range c.Component(i) {
+				seen++
+				for b := range n {
+					j, _ := c.ComponentOf(b)
+					if same := reach[a][b] && reach[b][a]; same != (i == j) {
+						t.Fatalf("Round %d: %d and %d mutually reachable %v, components %d and %d", round, a, b, same, i, j)
+					}
+				}
+			}
+		}
+		if seen != n {
+			t.Fatalf("Round %d: components hold %d nodes, want %d", round, seen, n)
+		}
+
+		order, err := c.Graph().TopologicalSort()
+		if err != nil || len(order) != c.Len() {
+			t.Fatalf("Round %d: TopologicalSort() = %v, %v", round, order, err)
+		}
+		for from, to := range c.Graph().Edges() {
+			if from >= to {
+				t.Fatalf("Round %d: component edge %d→%d is not in topological order", round, from, to)
+			}
+		}
+		for from, to := range g.Edges() {
+			i, _ := c.ComponentOf(from)
+			j, _ := c.ComponentOf(to)
+			if i != j && !c.Graph().HasEdge(i, j) {
+				t.Fatalf("Round %d: edge %d→%d is missing from the condensation", round, from, to)
+			}
+		}
+	}
+}
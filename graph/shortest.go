@@ -0,0 +1,171 @@
+package graph
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/dullkingsman/kozo/queue"
+)
+
+var (
+	// ErrNoPath is returned by ShortestPath when the target is not reachable from the source.
+	ErrNoPath = errors.New("graph: no path")
+	// ErrNegativeWeight is returned by Dijkstra when the graph has an edge with a negative weight.
+	ErrNegativeWeight = errors.New("graph: negative edge weight")
+	// ErrNegativeCycle is returned when a cycle of negative total weight is reachable from the source,
+	// so that shortest paths are undefined.
+	ErrNegativeCycle = errors.New("graph: negative cycle")
+)
+
+// ShortestPaths holds the shortest paths from a source node to every node reachable from it.
+type ShortestPaths[N comparable] struct {
+	source   N
+	distance map[N]float64
+	previous map[N]N
+}
+
+// Source returns the node the paths start at.
+func (p *ShortestPaths[N]) Source() N {
+	return p.source
+}
+
+// Distance returns the total weight of the shortest path to n. Returns false if n is not reachable.
+func (p *ShortestPaths[N]) Distance(n N) (float64, bool) {
+	d, ok := p.distance[n]
+	return d, ok
+}
+
+// Path returns the nodes of the shortest path to n, from the source to n. Returns false if n is not reachable.
+func (p *ShortestPaths[N]) Path(n N) ([]N, bool) {
+	if _, ok := p.distance[n]; !ok {
+		return nil, false
+	}
+	path := []N{n}
+	for n != p.source {
+		n = p.previous[n]
+		path = append(path, n)
+	}
+	slices.Reverse(path)
+	return path, true
+}
+
+// ShortestPath returns the path of least total weight from one node to another, and its weight.
+// It uses Dijkstra's algorithm, or Bellman-Ford's if the graph has edges with negative weights.
+// It returns ErrNoPath if the target is not reachable, and ErrNegativeCycle if shortest paths are undefined.
+func (g *Graph[N]) ShortestPath(from, to N) ([]N, float64, error) {
+	g.mu.RLock()
+	var (
+		paths *ShortestPaths[N]
+		err   error
+	)
+	if g.negative > 0 {
+		paths, err = g.bellmanFord(from)
+	} else {
+		paths = g.dijkstra(from)
+	}
+	g.mu.RUnlock()
+
+	if err != nil {
+		return nil, 0, err
+	}
+	path, ok := paths.Path(to)
+	if !ok {
+		return nil, 0, ErrNoPath
+	}
+	d, _ := paths.Distance(to)
+	return path, d, nil
+}
+
+// Dijkstra returns the shortest paths from source, computed by Dijkstra's algorithm in O((n + m) log n)
+// for n nodes and m edges. It returns ErrNegativeWeight if an edge has a negative weight; use BellmanFord then.
+// If source is not present, no node is reachable.
+func (g *Graph[N]) Dijkstra(source N) (*ShortestPaths[N], error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if g.negative > 0 {
+		return nil, ErrNegativeWeight
+	}
+	return g.dijkstra(source), nil
+}
+
+// BellmanFord returns the shortest paths from source, computed by the Bellman-Ford algorithm in O(n m),
+// which allows negative weights. It returns ErrNegativeCycle if a cycle of negative weight is reachable from source.
+// In an undirected graph, any negative edge forms such a cycle.
+func (g *Graph[N]) BellmanFord(source N) (*ShortestPaths[N], error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.bellmanFord(source)
+}
+
+func (g *Graph[N]) dijkstra(source N) *ShortestPaths[N] {
+	paths := &ShortestPaths[N]{source: source, distance: map[N]float64{}, previous: map[N]N{}}
+	if _, ok := g.vertices[source]; !ok {
+		return paths
+	}
+
+	settled := map[N]bool{}
+	pending := queue.NewIndexedMinPriority[N, float64]()
+	pending.Push(source, 0)
+	paths.distance[source] = 0
+
+	for !pending.IsEmpty() {
+		n, d, _ := pending.Pop()
+		settled[n] = true
+
+		out := &g.vertices[n].out
+		for _, m := range out.order {
+			if settled[m] {
+				continue
+			}
+			if pending.DecreaseKey(m, d+out.weights[m]) {
+				paths.distance[m] = d + out.weights[m]
+				paths.previous[m] = n
+			}
+		}
+	}
+	return paths
+}
+
+func (g *Graph[N]) bellmanFord(source N) (*ShortestPaths[N], error) {
+	paths := &ShortestPaths[N]{source: source, distance: map[N]float64{}, previous: map[N]N{}}
+	if _, ok := g.vertices[source]; !ok {
+		return paths, nil
+	}
+
+	edges := g.edgeList()
+	if !g.directed {
+		for _, e := range edges {
+			edges = append(edges, Edge[N]{From: e.To, To: e.From, Weight: e.Weight})
+		}
+	}
+
+	// relax lowers the distances through every edge, returning true if any changed.
+	relax := func() bool {
+		changed := false
+		for _, e := range edges {
+			d, ok := paths.distance[e.From]
+			if !ok {
+				continue
+			}
+			if current, ok := paths.distance[e.To]; !ok || d+e.Weight < current {
+				paths.distance[e.To] = d + e.Weight
+				paths.previous[e.To] = e.From
+				changed = true
+			}
+		}
+		return changed
+	}
+
+	paths.distance[source] = 0
+	// Shortest paths have at most n-1 edges, so distances settle after n-1 rounds unless a negative cycle is reachable.
+	for range len(g.nodes) - 1 {
+		if !relax() {
+			return paths, nil
+		}
+	}
+	if relax() {
+		return nil, ErrNegativeCycle
+	}
+	return paths, nil
+}
@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestWeightedEdges(t *testing.T) {
+	g := NewUndirected[string]()
+	if !g.AddWeightedEdge("a", "b", 2.5) || g.AddWeightedEdge("b", "a", 4) {
+		t.Error("AddWeightedEdge() reported the wrong novelty")
+	}
+	if w, ok := g.Weight("a", "b"); !ok || w != 4 {
+		t.Errorf("Weight(a, b) = %v, %v", w, ok)
+	}
+	g.AddEdge("b", "c")
+	if w, _ := g.Weight("c", "b"); w != 1 {
+		t.Errorf("Weight(c, b) = %v, want 1", w)
+	}
+	if _, ok := g.Weight("a", "c"); ok {
+		t.Error("Expected no weight for a missing edge")
+	}
+
+	got := slices.Collect(g.WeightedEdges())
+	want := []Edge[string]{{"a", "b", 4}, {"b", "c", 1}}
+	if !slices.Equal(got, want) {
+		t.Errorf("WeightedEdges() = %v", got)
+	}
+}
+
+func TestShortestPath(t *testing.T) {
+	g := NewDirected[string]()
+	g.AddWeightedEdge("home", "a", 4)
+	g.AddWeightedEdge("home", "b", 1)
+	g.AddWeightedEdge("b", "a", 2)
+	g.AddWeightedEdge("a", "office", 1)
+	g.AddWeightedEdge("b", "office", 5)
+	g.AddNode("island")
+
+	path, d, err := g.ShortestPath("home", "office")
+	if err != nil || d != 4 || !slices.Equal(path, []string{"home", "b", "a", "office"}) {
+		t.Errorf("ShortestPath() = %v, %v, %v", path, d, err)
+	}
+	if path, d, err := g.ShortestPath("home", "home"); err != nil || d != 0 || !slices.Equal(path, []string{"home"}) {
+		t.Errorf("ShortestPath(home, home) = %v, %v, %v", path, d, err)
+	}
+	if _, _, err := g.ShortestPath("home", "island"); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath, got %v", err)
+	}
+	if _, _, err := g.ShortestPath("missing", "home"); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Expected ErrNoPath from a missing source, got %v", err)
+	}
+
+	paths, err := g.Dijkstra("b")
+	if err != nil || paths.Source() != "b" {
+		t.Fatalf("Dijkstra() = %v", err)
+	}
+	if _, ok := paths.Distance("home"); ok {
+		t.Error("Expected home not to be reachable from b")
+	}
+	if p, ok := paths.Path("office"); !ok || !slices.Equal(p, []string{"b", "a", "office"}) {
+		t.Errorf("Path(office) = %v, %v", p, ok)
+	}
+}
+
+func TestShortestPath_Negative(t *testing.T) {
+	g := NewDirected[int]()
+	g.AddWeightedEdge(0, 1, 5)
+	g.AddWeightedEdge(0, 2, 2)
+	g.AddWeightedEdge(1, 2, -4)
+	g.AddWeightedEdge(2, 3, 1)
+
+	if _, err := g.Dijkstra(0); !errors.Is(err, ErrNegativeWeight) {
+		t.Errorf("Expected ErrNegativeWeight, got %v", err)
+	}
+	path, d, err := g.ShortestPath(0, 3)
+	if err != nil || d != 2 || !slices.Equal(path, []int{0, 1, 2, 3}) {
+		t.Errorf("ShortestPath() = %v, %v, %v", path, d, err)
+	}
+
+	g.AddWeightedEdge(3, 1, 2)
+	if _, _, err := g.ShortestPath(0, 3); !errors.Is(err, ErrNegativeCycle) {
+		t.Errorf("Expected ErrNegativeCycle, got %v", err)
+	}
+	g.AddWeightedEdge(3, 1, 3)
+	if _, err := g.BellmanFord(0); err != nil {
+		t.Errorf("Expected a zero weight cycle to be allowed, got %v", err)
+	}
+
+	// Reweighting and removing negative edges makes Dijkstra usable again.
+	g.AddWeightedEdge(1, 2, 4)
+	if _, err := g.Dijkstra(0); err != nil {
+		t.Errorf("Expected Dijkstra to accept non-negative weights, got %v", err)
+	}
+	g.AddWeightedEdge(2, 0, -1)
+	g.RemoveNode(2)
+	if _, err := g.Dijkstra(0); err != nil {
+		t.Errorf("Expected Dijkstra to accept the graph after removing negative edges, got %v", err)
+	}
+
+	u := NewUndirected[int]()
+	u.AddWeightedEdge(0, 1, -1)
+	if _, err := u.BellmanFord(0); !errors.Is(err, ErrNegativeCycle) {
+		t.Errorf("Expected a negative undirected edge to be a negative cycle, got %v", err)
+	}
+}
+
+// floydWarshall returns the all-pairs shortest distances, with +Inf for unreachable pairs.
+func floydWarshall(g *Graph[int], n int) [][]float64 {
+	d := make([][]float64, n)
+	for i := range d {
+		d[i] = make([]float64, n)
+		for j := range d[i] {
+			d[i][j] = math.Inf(1)
+		}
+		d[i][i] = 0
+	}
+	for e := range g.WeightedEdges() {
+		d[e.From][e.To] = min(d[e.From][e.To], e.Weight)
+		if !g.IsDirected() {
+			d[e.To][e.From] = min(d[e.To][e.From], e.Weight)
+		}
+	}
+	for k := range n {
+		for i := range n {
+			for j := range n {
+				d[i][j] = min(d[i][j], d[i][k]+d[k][j])
+			}
+		}
+	}
+	return d
+}
+
+func TestShortestPath_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for round := range 200 {
+		const n = 12
+		directed := round%2 == 0
+		negative := directed && round%4 == 0
+
+		g := NewUndirected[int]()
+		if directed {
+			g = NewDirected[int]()
+		}
+		for i := range n {
+			g.AddNode(i)
+		}
+		for range 30 {
+			// Edges into larger nodes only, so negative weights never form a cycle.
+			from, to := rng.Intn(n), rng.Intn(n)
+			if negative && from >= to {
+				continue
+			}
+			w := float64(rng.Intn(20))
+			if negative {
+				w -= 5
+			}
+			g.AddWeightedEdge(from, to, w)
+		}
+
+		want := floydWarshall(g, n)
+		for source := range n {
+			var (
+				paths *ShortestPaths[int]
+				err   error
+			)
+			if negative {
+				paths, err = g.BellmanFord(source)
+			} else {
+				paths, err = g.Dijkstra(source)
+			}
+			if err != nil {
+				t.Fatalf("Round %d: %v", round, err)
+			}
+
+			for target := range n {
+				d, ok := paths.Distance(target)
+				if ok != !math.IsInf(want[source][target], 1) || (ok && d != want[source][target]) {
+					t.Fatalf("Round %d: distance %d→%d = %v, %v; want %v", round, source, target, d, ok, want[source][target])
+				}
+				if !ok {
+					continue
+				}
+				path, _ := paths.Path(target)
+				if path[0] != source || path[len(path)-1] != target {
+					t.Fatalf("Round %d: path %d→%d = %v", round, source, target, path)
+				}
+				total := 0.0
+				for i := 1; i < len(path); i++ {
+					w, ok := g.Weight(path[i-1], path[i])
+					if !ok {
+						t.Fatalf("Round %d: path %v uses a missing edge", round, path)
+					}
+					total += w
+				}
+				if total != d {
+					t.Fatalf("Round %d: path %v weighs %v, want %v", round, path, total, d)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/dullkingsman/kozo/queue"
+)
+
+// ErrCycle is returned by TopologicalSort when the graph has a cycle.
+var ErrCycle = errors.New("graph: cycle")
+
+// TopologicalSort returns the nodes ordered so that every edge leads from an earlier node to a later one,
+// such as an order in which to build modules after their dependencies when edges point from a dependency
+// to its dependents. It uses Kahn's algorithm in O(n + m), starting from the nodes without incoming edges
+// in the order they were added, so the result is deterministic.
+//
+// It returns ErrCycle if the graph has a cycle, which in an undirected graph is any edge.
+// Use Condensation to collapse cycles first.
+func (g *Graph[N]) TopologicalSort() ([]N, error) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if !g.directed && g.edges > 0 {
+		return nil, ErrCycle
+	}
+
+	indegree := make(map[N]int, len(g.nodes))
+	ready := queue.NewUnsync[N]()
+	for _, n := range g.nodes {
+		if indegree[n] = len(g.vertices[n].in.order); indegree[n] == 0 {
+			ready.Enqueue(n)
+		}
+	}
+
+	sorted := make([]N, 0, len(g.nodes))
+	for !ready.IsEmpty() {
+		n, _ := ready.Dequeue()
+		sorted = append(sorted, n)
+		for _, m := range g.vertices[n].out.order {
+			if indegree[m]--; indegree[m] == 0 {
+				ready.Enqueue(m)
+			}
+		}
+	}
+	if len(sorted) < len(g.nodes) {
+		return nil, ErrCycle
+	}
+	return sorted, nil
+}
@@ -0,0 +1,70 @@
+package graph
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/dullkingsman/kozo/queue"
+	"github.com/dullkingsman/kozo/stack"
+)
+
+// BFS returns an iterator over the nodes reachable from start, including start, in breadth-first order.
+// Neighbors are read as they are reached, without holding the lock while yielding,
+// so the graph may be modified during the traversal. It yields nothing if start is not present.
+func (g *Graph[N]) BFS(start N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		if !g.HasNode(start) {
+			return
+		}
+		visited := map[N]bool{start: true}
+		pending := queue.NewUnsync[N]()
+		pending.Enqueue(start)
+
+		for !pending.IsEmpty() {
+			n, _ := pending.Dequeue()
+			if !yield(n) {
+				return
+			}
+			for _, m := range g.successors(n) {
+				if !visited[m] {
+					visited[m] = true
+					pending.Enqueue(m)
+				}
+			}
+		}
+	}
+}
+
+// DFS returns an iterator over the nodes reachable from start, including start, in depth-first preorder,
+// visiting neighbors in the order they were added as a recursive traversal would.
+// Like BFS, it may run while the graph is modified, and yields nothing if start is not present.
+func (g *Graph[N]) DFS(start N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		if !g.HasNode(start) {
+			return
+		}
+		visited := map[N]bool{}
+		pending := stack.NewUnsync[N]()
+		pending.Push(start)
+
+		for !pending.IsEmpty() {
+			n, _ := pending.Pop()
+			if visited[n] {
+				continue
+			}
+			visited[n] = true
+			if !yield(n) {
+				return
+			}
+
+			// Push in reverse, so that the first neighbor is visited first.
+			next := g.successors(n)
+			slices.Reverse(next)
+			for _, m := range next {
+				if !visited[m] {
+					pending.Push(m)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package graph
+
+import "iter"
+
+// Edge is an edge with its weight.
+type Edge[N comparable] struct {
+	From, To N
+	Weight   float64
+}
+
+// AddWeightedEdge adds an edge from one node to another with the given weight, such as a cost or a distance,
+// adding the nodes if needed. If the edge exists, its weight is changed. It returns true if the edge was new.
+// Edges added by AddEdge have weight 1.
+func (g *Graph[N]) AddWeightedEdge(from, to N, weight float64) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.setEdge(from, to, weight)
+}
+
+// Weight returns the weight of the edge from one node to another. Returns false if there is no such edge.
+func (g *Graph[N]) Weight(from, to N) (float64, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	if u, ok := g.vertices[from]; ok {
+		w, ok := u.out.weights[to]
+		return w, ok
+	}
+	return 0, false
+}
+
+// WeightedEdges returns an iterator over a snapshot of the edges with their weights, in the order of Edges.
+func (g *Graph[N]) WeightedEdges() iter.Seq[Edge[N]] {
+	return func(yield func(Edge[N]) bool) {
+		g.mu.RLock()
+		edges := g.edgeList()
+		g.mu.RUnlock()
+
+		for _, e := range edges {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// edgeList returns the edges, each edge of an undirected graph once, from the node that was added first.
+func (g *Graph[N]) edgeList() []Edge[N] {
+	edges := make([]Edge[N], 0, g.edges)
+	for i, n := range g.nodes {
+		out := &g.vertices[n].out
+		for _, m := range out.order {
+			if g.directed || g.vertices[m].index >= i {
+				edges = append(edges, Edge[N]{From: n, To: m, Weight: out.weights[m]})
+			}
+		}
+	}
+	return edges
+}
@@ -0,0 +1,266 @@
+// Package grid provides a generic two-dimensional grid for boards, tile maps and other spatial algorithms.
+package grid
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// Point is the position of a cell, by row and column from the top-left corner.
+type Point struct {
+	Row, Col int
+}
+
+// Connectivity selects which cells count as neighbors.
+type Connectivity int
+
+const (
+	// Four connects cells sharing an edge: up, left, right and down.
+	Four Connectivity = 4
+	// Eight also connects cells sharing a corner.
+	Eight Connectivity = 8
+)
+
+// offsets of the neighbors of a cell, in row-major order.
+var (
+	offsets4 = []Point{{-1, 0}, {0, -1}, {0, 1}, {1, 0}}
+	offsets8 = []Point{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}}
+)
+
+// Grid is a thread-safe rectangle of rows × cols cells, stored in row-major order in a single slice.
+// Get and Set check bounds and report cells outside the grid instead of panicking, so that algorithms can probe
+// past the edges; operations on regions panic if the region does not fit, like slices.
+type Grid[T any] struct {
+	mu         sync.RWMutex
+	rows, cols int
+	cells      []T
+}
+
+var _ collection.Iterable2[Point, int] = (*Grid[int])(nil)
+
+// New returns a new grid of rows × cols zero values. It panics if either dimension is negative.
+func New[T any](rows, cols int) *Grid[T] {
+	if rows < 0 || cols < 0 {
+		panic("grid: negative dimension")
+	}
+	return &Grid[T]{rows: rows, cols: cols, cells: make([]T, rows*cols)}
+}
+
+// From returns a new grid holding a copy of the given rows. It panics if the rows have different lengths.
+func From[T any](rows [][]T) *Grid[T] {
+	cols := 0
+	if len(rows) > 0 {
+		cols = len(rows[0])
+	}
+	g := New[T](len(rows), cols)
+	for r, row := range rows {
+		if len(row) != cols {
+			panic("grid: rows have different lengths")
+		}
+		copy(g.cells[r*cols:], row)
+	}
+	return g
+}
+
+// Rows returns the number of rows.
+func (g *Grid[T]) Rows() int {
+	return g.rows
+}
+
+// Cols returns the number of columns.
+func (g *Grid[T]) Cols() int {
+	return g.cols
+}
+
+// InBounds returns true if the cell at (row, col) is in the grid.
+func (g *Grid[T]) InBounds(row, col int) bool {
+	return row >= 0 && row < g.rows && col >= 0 && col < g.cols
+}
+
+// Get returns the value of the cell at (row, col). Returns (zero-value, false) if it is outside the grid.
+func (g *Grid[T]) Get(row, col int) (T, bool) {
+	if !g.InBounds(row, col) {
+		var zero T
+		return zero, false
+	}
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.cells[row*g.cols+col], true
+}
+
+// Set sets the value of the cell at (row, col). Returns false if it is outside the grid.
+func (g *Grid[T]) Set(row, col int, v T) bool {
+	if !g.InBounds(row, col) {
+		return false
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.cells[row*g.cols+col] = v
+	return true
+}
+
+// Row returns an iterator over a snapshot of a row, as (column, value) pairs. It panics if the row is out of range.
+func (g *Grid[T]) Row(row int) iter.Seq2[int, T] {
+	if row < 0 || row >= g.rows {
+		panic("grid: row out of range")
+	}
+	return func(yield func(int, T) bool) {
+		g.mu.RLock()
+		values := slices.Clone(g.cells[row*g.cols : (row+1)*g.cols])
+		g.mu.RUnlock()
+
+		for c, v := range values {
+			if !yield(c, v) {
+				return
+			}
+		}
+	}
+}
+
+// Column returns an iterator over a snapshot of a column, as (row, value) pairs. It panics if the column is out of range.
+func (g *Grid[T]) Column(col int) iter.Seq2[int, T] {
+	if col < 0 || col >= g.cols {
+		panic("grid: column out of range")
+	}
+	return func(yield func(int, T) bool) {
+		g.mu.RLock()
+		values := make([]T, g.rows)
+		for r := range values {
+			values[r] = g.cells[r*g.cols+col]
+		}
+		g.mu.RUnlock()
+
+		for r, v := range values {
+			if !yield(r, v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over a snapshot of all cells in row-major order.
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		g.mu.RLock()
+		cells := slices.Clone(g.cells)
+		g.mu.RUnlock()
+
+		for i, v := range cells {
+			if !yield(Point{i / g.cols, i % g.cols}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors returns an iterator over the positions of the neighbors of (row, col) that are in the grid,
+// in row-major order. With Four connectivity, these are the cells sharing an edge; with Eight, also a corner.
+func (g *Grid[T]) Neighbors(row, col int, conn Connectivity) iter.Seq[Point] {
+	offsets := offsets4
+	if conn == Eight {
+		offsets = offsets8
+	}
+	return func(yield func(Point) bool) {
+		for _, d := range offsets {
+			p := Point{row + d.Row, col + d.Col}
+			if g.InBounds(p.Row, p.Col) && !yield(p) {
+				return
+			}
+		}
+	}
+}
+
+// Fill sets every cell to v.
+func (g *Grid[T]) Fill(v T) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for i := range g.cells {
+		g.cells[i] = v
+	}
+}
+
+// FillRect sets every cell of the rows × cols rectangle whose top-left cell is (row, col) to v.
+// It panics if the rectangle does not fit in the grid.
+func (g *Grid[T]) FillRect(row, col, rows, cols int, v T) {
+	g.checkRect(row, col, rows, cols)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for r := row; r < row+rows; r++ {
+		for c := col; c < col+cols; c++ {
+			g.cells[r*g.cols+c] = v
+		}
+	}
+}
+
+// SubGrid returns a new grid holding a copy of the rows × cols rectangle whose top-left cell is (row, col).
+// It panics if the rectangle does not fit in the grid.
+func (g *Grid[T]) SubGrid(row, col, rows, cols int) *Grid[T] {
+	g.checkRect(row, col, rows, cols)
+
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	sub := New[T](rows, cols)
+	for r := range rows {
+		copy(sub.cells[r*cols:(r+1)*cols], g.cells[(row+r)*g.cols+col:])
+	}
+	return sub
+}
+
+// Paste copies the cells of src into the grid, with the top-left cell of src at (row, col).
+// It panics if src does not fit in the grid.
+func (g *Grid[T]) Paste(row, col int, src *Grid[T]) {
+	g.checkRect(row, col, src.rows, src.cols)
+
+	cells := src.snapshot()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for r := range src.rows {
+		copy(g.cells[(row+r)*g.cols+col:], cells[r*src.cols:(r+1)*src.cols])
+	}
+}
+
+// Transpose returns a new cols × rows grid whose cell (c, r) holds the value of cell (r, c).
+func (g *Grid[T]) Transpose() *Grid[T] {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	t := New[T](g.cols, g.rows)
+	for r := range g.rows {
+		for c := range g.cols {
+			t.cells[c*g.rows+r] = g.cells[r*g.cols+c]
+		}
+	}
+	return t
+}
+
+// ToSlices returns a copy of the cells as a slice of rows.
+func (g *Grid[T]) ToSlices() [][]T {
+	cells := g.snapshot()
+	rows := make([][]T, g.rows)
+	for r := range rows {
+		rows[r] = cells[r*g.cols : (r+1)*g.cols : (r+1)*g.cols]
+	}
+	return rows
+}
+
+// Clone returns a copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{rows: g.rows, cols: g.cols, cells: g.snapshot()}
+}
+
+func (g *Grid[T]) snapshot() []T {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return slices.Clone(g.cells)
+}
+
+func (g *Grid[T]) checkRect(row, col, rows, cols int) {
+	if rows < 0 || cols < 0 || row < 0 || col < 0 || row+rows > g.rows || col+cols > g.cols {
+		panic("grid: rectangle out of bounds")
+	}
+}
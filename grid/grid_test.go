@@ -0,0 +1,159 @@
+package grid
+
+import (
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestGrid_Basic(t *testing.T) {
+	g := New[int](2, 3)
+	if g.Rows() != 2 || g.Cols() != 3 {
+		t.Fatalf("Got %d × %d", g.Rows(), g.Cols())
+	}
+	if !g.Set(1, 2, 7) || g.Set(2, 0, 1) || g.Set(0, -1, 1) {
+		t.Error("Set() reported the wrong bounds")
+	}
+	if v, ok := g.Get(1, 2); !ok || v != 7 {
+		t.Errorf("Get(1, 2) = %d, %v", v, ok)
+	}
+	if _, ok := g.Get(0, 3); ok {
+		t.Error("Expected Get outside the grid to fail")
+	}
+
+	f := From([][]string{{"a", "b", "c"}, {"d", "e", "f"}})
+	var row []string
+	for c, v := range f.Row(1) {
+		row = append(row, v)
+		if c != len(row)-1 {
+			t.Errorf("Row() yields column %d", c)
+		}
+	}
+	if !slices.Equal(row, []string{"d", "e", "f"}) {
+		t.Errorf("Row(1) = %v", row)
+	}
+	var col []string
+	for _, v := range f.Column(2) {
+		col = append(col, v)
+	}
+	if !slices.Equal(col, []string{"c", "f"}) {
+		t.Errorf("Column(2) = %v", col)
+	}
+	all := maps.Collect(f.All())
+	if len(all) != 6 || all[Point{1, 0}] != "d" {
+		t.Errorf("All() = %v", all)
+	}
+
+	tr := f.Transpose()
+	want := [][]string{{"a", "d"}, {"b", "e"}, {"c", "f"}}
+	if !slices.EqualFunc(tr.ToSlices(), want, slices.Equal) {
+		t.Errorf("Transpose() = %v", tr.ToSlices())
+	}
+
+	c := f.Clone()
+	f.Fill("x")
+	if v, _ := c.Get(0, 0); v != "a" {
+		t.Error("Expected Clone to be independent")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected ragged rows to panic")
+		}
+	}()
+	From([][]int{{1, 2}, {3}})
+}
+
+func TestGrid_Neighbors(t *testing.T) {
+	g := New[bool](3, 3)
+
+	tests := []struct {
+		p    Point
+		conn Connectivity
+		want []Point
+	}{
+		{Point{1, 1}, Four, []Point{{0, 1}, {1, 0}, {1, 2}, {2, 1}}},
+		{Point{0, 0}, Four, []Point{{0, 1}, {1, 0}}},
+		{Point{0, 0}, Eight, []Point{{0, 1}, {1, 0}, {1, 1}}},
+		{Point{2, 1}, Eight, []Point{{1, 0}, {1, 1}, {1, 2}, {2, 0}, {2, 2}}},
+	}
+	for _, tt := range tests {
+		if got := slices.Collect(g.Neighbors(tt.p.Row, tt.p.Col, tt.conn)); !slices.Equal(got, tt.want) {
+			t.Errorf("Neighbors(%v, %d) = %v", tt.p, tt.conn, got)
+		}
+	}
+	if got := slices.Collect(g.Neighbors(1, 1, Eight)); len(got) != 8 {
+		t.Errorf("Expected 8 neighbors of the center, got %v", got)
+	}
+}
+
+func TestGrid_Regions(t *testing.T) {
+	g := New[int](4, 5)
+	g.FillRect(1, 1, 2, 3, 9)
+	want := [][]int{
+		{0, 0, 0, 0, 0},
+		{0, 9, 9, 9, 0},
+		{0, 9, 9, 9, 0},
+		{0, 0, 0, 0, 0},
+	}
+	if !slices.EqualFunc(g.ToSlices(), want, slices.Equal) {
+		t.Errorf("FillRect() = %v", g.ToSlices())
+	}
+
+	sub := g.SubGrid(0, 2, 3, 3)
+	if !slices.EqualFunc(sub.ToSlices(), [][]int{{0, 0, 0}, {9, 9, 0}, {9, 9, 0}}, slices.Equal) {
+		t.Errorf("SubGrid() = %v", sub.ToSlices())
+	}
+	sub.Set(0, 0, 5)
+	if v, _ := g.Get(0, 2); v != 0 {
+		t.Error("Expected SubGrid to copy the cells")
+	}
+
+	g.Paste(2, 0, From([][]int{{1, 2}, {3, 4}}))
+	want = [][]int{
+		{0, 0, 0, 0, 0},
+		{0, 9, 9, 9, 0},
+		{1, 2, 9, 9, 0},
+		{3, 4, 0, 0, 0},
+	}
+	if !slices.EqualFunc(g.ToSlices(), want, slices.Equal) {
+		t.Errorf("Paste() = %v", g.ToSlices())
+	}
+
+	empty := g.SubGrid(4, 5, 0, 0)
+	if empty.Rows() != 0 || len(empty.ToSlices()) != 0 {
+		t.Error("Expected an empty sub-grid at the corner")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a sub-grid out of bounds to panic")
+		}
+	}()
+	g.SubGrid(3, 3, 2, 2)
+}
+
+func TestGrid_Concurrency(t *testing.T) {
+	g := New[int](8, 100)
+	var wg sync.WaitGroup
+	for r := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range 100 {
+				g.Set(r, c, r*100+c)
+				g.Get(r, c)
+				for range g.Row(r) {
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for p, v := range g.All() {
+		if v != p.Row*100+p.Col {
+			t.Fatalf("Cell %v = %d", p, v)
+		}
+	}
+}
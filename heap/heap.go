@@ -0,0 +1,224 @@
+// Package heap provides a generic binary heap, replacing the interface-based container/heap boilerplate.
+package heap
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/dullkingsman/kozo/cmpx"
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// Heap is a binary heap ordered by a less function: the element for which less holds against all others is at the top,
+// so cmp.Less gives a min-heap. Push, Pop, Fix and Remove are O(log n) and Peek is O(1).
+//
+// Like container/heap, Heap is a building block for other structures and is not safe for concurrent use;
+// guard it with the mutex of the structure that owns it.
+type Heap[T any] struct {
+	items  []T
+	less   func(T, T) bool
+	onMove func(T, int)
+}
+
+var _ collection.Collection[int] = (*Heap[int])(nil)
+
+// New returns a new empty Heap ordered by the given less function, with the least element on top.
+func New[T any](less func(T, T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// NewFunc returns a new empty Heap ordered by the comparator c, with the first element in its order on top.
+func NewFunc[T any](c cmpx.Comparator[T]) *Heap[T] {
+	return New(c.Less())
+}
+
+// NewMin returns a new empty min-heap for cmp.Ordered elements.
+func NewMin[T cmp.Ordered]() *Heap[T] {
+	return New(cmp.Less[T])
+}
+
+// NewMax returns a new empty max-heap for cmp.Ordered elements.
+func NewMax[T cmp.Ordered]() *Heap[T] {
+	return NewFunc(cmpx.Natural[T]().Reverse())
+}
+
+// NewWithIndex returns a new empty Heap that calls onMove with every element placed at a new index,
+// including when it is pushed. Elements usually record their index to pass it to Fix or Remove later,
+// replacing the Swap method that updates indexes in container/heap implementations. Removed elements get index -1.
+func NewWithIndex[T any](less func(T, T) bool, onMove func(item T, index int)) *Heap[T] {
+	return &Heap[T]{less: less, onMove: onMove}
+}
+
+// From returns a Heap of the given items ordered by less, built in O(n).
+// The heap takes ownership of the slice, which must not be used afterwards.
+func From[T any](items []T, less func(T, T) bool) *Heap[T] {
+	h := &Heap[T]{items: items, less: less}
+	for i := len(items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Push adds elements to the heap.
+func (h *Heap[T]) Push(items ...T) {
+	for _, item := range items {
+		h.items = append(h.items, item)
+		h.moved(len(h.items) - 1)
+		h.up(len(h.items) - 1)
+	}
+}
+
+// Pop removes and returns the top element. Returns (zero-value, false) if the heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.Remove(0), true
+}
+
+// Peek returns the top element without removing it. Returns (zero-value, false) if the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// PushPop pushes item and then pops the top element, in one O(log n) step.
+// It keeps the heap at a fixed size, e.g. a min-heap of the k largest elements seen so far.
+func (h *Heap[T]) PushPop(item T) T {
+	if len(h.items) == 0 || !h.less(h.items[0], item) {
+		return item
+	}
+	top := h.items[0]
+	h.items[0] = item
+	h.moved(0)
+	h.down(0)
+	h.removed(top)
+	return top
+}
+
+// Fix restores the heap order after the element at index i has changed. It panics if i is out of range.
+func (h *Heap[T]) Fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// Remove removes and returns the element at index i. It panics if i is out of range.
+func (h *Heap[T]) Remove(i int) T {
+	item := h.items[i]
+	n := len(h.items) - 1
+	if i != n {
+		h.swap(i, n)
+	}
+
+	var zero T
+	h.items[n] = zero // let the garbage collector reclaim it
+	h.items = h.items[:n]
+	if i != n {
+		h.Fix(i)
+	}
+	h.removed(item)
+	return item
+}
+
+// At returns the element at index i, where index 0 is the top. It panics if i is out of range.
+func (h *Heap[T]) At(i int) T {
+	return h.items[i]
+}
+
+// Len returns the number of elements.
+func (h *Heap[T]) Len() int {
+	return len(h.items)
+}
+
+// IsEmpty returns true if the heap has no elements.
+func (h *Heap[T]) IsEmpty() bool {
+	return len(h.items) == 0
+}
+
+// Clear removes all elements.
+func (h *Heap[T]) Clear() {
+	for _, item := range h.items {
+		h.removed(item)
+	}
+	clear(h.items)
+	h.items = h.items[:0]
+}
+
+// Drain removes all elements and returns them in pop order.
+func (h *Heap[T]) Drain() []T {
+	items := make([]T, 0, len(h.items))
+	for len(h.items) > 0 {
+		items = append(items, h.Remove(0))
+	}
+	return items
+}
+
+// ToSlice returns a copy of the elements in heap order, which is not sorted.
+func (h *Heap[T]) ToSlice() []T {
+	return append([]T(nil), h.items...)
+}
+
+// All returns an iterator over the elements in heap order, which is not sorted; Drain pops them in order.
+// The heap must not be modified during the iteration.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range h.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.less(h.items[i], h.items[parent]) {
+			return
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at index i down to its place. It returns true if it moved.
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	for {
+		smallest, left := i, 2*i+1
+		if left < len(h.items) && h.less(h.items[left], h.items[smallest]) {
+			smallest = left
+		}
+		if right := left + 1; right < len(h.items) && h.less(h.items[right], h.items[smallest]) {
+			smallest = right
+		}
+		if smallest == i {
+			return i > start
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.moved(i)
+	h.moved(j)
+}
+
+func (h *Heap[T]) moved(i int) {
+	if h.onMove != nil {
+		h.onMove(h.items[i], i)
+	}
+}
+
+func (h *Heap[T]) removed(item T) {
+	if h.onMove != nil {
+		h.onMove(item, -1)
+	}
+}
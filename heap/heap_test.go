@@ -0,0 +1,167 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/dullkingsman/kozo/cmpx"
+)
+
+// checkInvariants verifies that no element is less than its parent.
+func checkInvariants[T any](t *testing.T, h *Heap[T]) {
+	t.Helper()
+	for i := 1; i < len(h.items); i++ {
+		if h.less(h.items[i], h.items[(i-1)/2]) {
+			t.Fatalf("Element %d is less than its parent", i)
+		}
+	}
+}
+
+func TestHeap(t *testing.T) {
+	h := NewMin[int]()
+	if _, ok := h.Pop(); ok {
+		t.Error("Expected Pop on an empty heap to fail")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Error("Expected Peek on an empty heap to fail")
+	}
+
+	h.Push(5, 3, 8, 1, 9, 2)
+	checkInvariants(t, h)
+	if v, ok := h.Peek(); !ok || v != 1 {
+		t.Errorf("Peek() = %d, %v; want 1", v, ok)
+	}
+	if v, _ := h.Pop(); v != 1 || h.Len() != 5 {
+		t.Errorf("Pop() = %d with length %d", v, h.Len())
+	}
+	if drained := h.Drain(); !slices.Equal(drained, []int{2, 3, 5, 8, 9}) || !h.IsEmpty() {
+		t.Errorf("Drain() = %v", drained)
+	}
+
+	maxHeap := NewMax[string]()
+	maxHeap.Push("b", "c", "a")
+	if v, _ := maxHeap.Pop(); v != "c" {
+		t.Errorf("Max Pop() = %q, want c", v)
+	}
+
+	h.Push(4, 1)
+	h.Clear()
+	if !h.IsEmpty() {
+		t.Error("Expected an empty heap after Clear")
+	}
+}
+
+func TestNewFunc(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+	h := NewFunc(cmpx.By(func(j job) int { return j.priority }).Reverse().Then(cmpx.By(func(j job) string { return j.name })))
+	h.Push(job{"b", 1}, job{"c", 2}, job{"a", 1}, job{"d", 2})
+	checkInvariants(t, h)
+
+	var names []string
+	for _, j := range h.Drain() {
+		names = append(names, j.name)
+	}
+	if !slices.Equal(names, []string{"c", "d", "a", "b"}) {
+		t.Errorf("Drain() = %v, want [c d a b]", names)
+	}
+}
+
+func TestFrom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = rng.Intn(100)
+	}
+	sorted := slices.Sorted(slices.Values(items))
+
+	h := From(items, func(a, b int) bool { return a < b })
+	checkInvariants(t, h)
+	if drained := h.Drain(); !slices.Equal(drained, sorted) {
+		t.Error("Expected From to drain in sorted order")
+	}
+}
+
+func TestHeapAll(t *testing.T) {
+	h := NewMin[int]()
+	h.Push(5, 3, 8, 1)
+	if got := slices.Sorted(h.All()); !slices.Equal(got, []int{1, 3, 5, 8}) {
+		t.Errorf("All() = %v", got)
+	}
+	if v, _ := h.Peek(); v != 1 || h.Len() != 4 {
+		t.Error("Expected All not to modify the heap")
+	}
+
+	m := NewMinMaxOrdered[int]()
+	m.Push(5, 3, 8, 1)
+	if got := slices.Sorted(m.All()); !slices.Equal(got, []int{1, 3, 5, 8}) {
+		t.Errorf("MinMax All() = %v", got)
+	}
+}
+
+func TestPushPop(t *testing.T) {
+	// Keep the 3 largest values in a min-heap.
+	h := NewMin[int]()
+	h.Push(0, 0, 0)
+	for _, v := range []int{5, 1, 9, 3, 7, 2} {
+		h.PushPop(v)
+	}
+	if top := slices.Sorted(slices.Values(h.ToSlice())); !slices.Equal(top, []int{5, 7, 9}) {
+		t.Errorf("Largest 3 = %v", top)
+	}
+	if v := New(func(a, b int) bool { return a < b }).PushPop(4); v != 4 {
+		t.Errorf("PushPop on an empty heap = %d, want 4", v)
+	}
+}
+
+type task struct {
+	priority int
+	index    int
+}
+
+func TestIndexed(t *testing.T) {
+	h := NewWithIndex(func(a, b *task) bool { return a.priority < b.priority }, func(t *task, i int) { t.index = i })
+	rng := rand.New(rand.NewSource(1))
+
+	tasks := make([]*task, 200)
+	for i := range tasks {
+		tasks[i] = &task{priority: rng.Intn(1000)}
+		h.Push(tasks[i])
+	}
+	checkIndexes := func() {
+		t.Helper()
+		for i := range h.Len() {
+			if h.At(i).index != i {
+				t.Fatalf("Element at %d records index %d", i, h.At(i).index)
+			}
+		}
+	}
+	checkIndexes()
+
+	for _, tk := range tasks[:50] {
+		tk.priority = rng.Intn(1000)
+		h.Fix(tk.index)
+	}
+	checkInvariants(t, h)
+	checkIndexes()
+
+	for _, tk := range tasks[50:100] {
+		if h.Remove(tk.index) != tk || tk.index != -1 {
+			t.Fatal("Remove returned the wrong element or did not reset its index")
+		}
+	}
+	checkInvariants(t, h)
+	checkIndexes()
+
+	prev := -1
+	for !h.IsEmpty() {
+		tk, _ := h.Pop()
+		if tk.priority < prev || tk.index != -1 {
+			t.Fatalf("Popped priority %d after %d with index %d", tk.priority, prev, tk.index)
+		}
+		prev = tk.priority
+	}
+}
@@ -0,0 +1,219 @@
+package heap
+
+import (
+	"cmp"
+	"iter"
+	"math/bits"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// MinMax is a min-max heap, a double-ended priority queue giving access to both its least and greatest elements.
+// Elements on even levels are less than or equal to their descendants and elements on odd levels greater,
+// so the least element is the root and the greatest one of its children. Push, PopMin and PopMax are O(log n);
+// PeekMin and PeekMax are O(1).
+//
+// It keeps bounded "best N" buffers with a single heap: push, and pop the worst once over capacity.
+// Like Heap, it is not safe for concurrent use.
+type MinMax[T any] struct {
+	items []T
+	less  func(T, T) bool
+}
+
+var _ collection.Collection[int] = (*MinMax[int])(nil)
+
+// NewMinMax returns a new empty MinMax ordered by the given less function.
+func NewMinMax[T any](less func(T, T) bool) *MinMax[T] {
+	return &MinMax[T]{less: less}
+}
+
+// NewMinMaxOrdered returns a new empty MinMax for cmp.Ordered elements.
+func NewMinMaxOrdered[T cmp.Ordered]() *MinMax[T] {
+	return NewMinMax(cmp.Less[T])
+}
+
+// Push adds elements to the heap.
+func (h *MinMax[T]) Push(items ...T) {
+	for _, item := range items {
+		h.items = append(h.items, item)
+		h.up(len(h.items) - 1)
+	}
+}
+
+// PeekMin returns the least element without removing it. Returns (zero-value, false) if the heap is empty.
+func (h *MinMax[T]) PeekMin() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[0], true
+}
+
+// PeekMax returns the greatest element without removing it. Returns (zero-value, false) if the heap is empty.
+func (h *MinMax[T]) PeekMax() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.items[h.maxIndex()], true
+}
+
+// PopMin removes and returns the least element. Returns (zero-value, false) if the heap is empty.
+func (h *MinMax[T]) PopMin() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.remove(0), true
+}
+
+// PopMax removes and returns the greatest element. Returns (zero-value, false) if the heap is empty.
+func (h *MinMax[T]) PopMax() (T, bool) {
+	if len(h.items) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.remove(h.maxIndex()), true
+}
+
+// Len returns the number of elements.
+func (h *MinMax[T]) Len() int {
+	return len(h.items)
+}
+
+// IsEmpty returns true if the heap has no elements.
+func (h *MinMax[T]) IsEmpty() bool {
+	return len(h.items) == 0
+}
+
+// Clear removes all elements.
+func (h *MinMax[T]) Clear() {
+	clear(h.items)
+	h.items = h.items[:0]
+}
+
+// ToSlice returns a copy of the elements in heap order, which is not sorted.
+func (h *MinMax[T]) ToSlice() []T {
+	return append([]T(nil), h.items...)
+}
+
+// All returns an iterator over the elements in heap order, which is not sorted.
+// The heap must not be modified during the iteration.
+func (h *MinMax[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range h.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// maxIndex returns the index of the greatest element of a non-empty heap: the root if it is alone,
+// or the greater of its children.
+func (h *MinMax[T]) maxIndex() int {
+	switch len(h.items) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.less(h.items[1], h.items[2]) {
+		return 2
+	}
+	return 1
+}
+
+// remove replaces the element at index i with the last one and restores the heap order.
+func (h *MinMax[T]) remove(i int) T {
+	item := h.items[i]
+	n := len(h.items) - 1
+	h.items[i] = h.items[n]
+
+	var zero T
+	h.items[n] = zero // let the garbage collector reclaim it
+	h.items = h.items[:n]
+	if i < n {
+		h.down(i)
+	}
+	return item
+}
+
+// up moves a new element at index i up to its place.
+func (h *MinMax[T]) up(i int) {
+	if i == 0 {
+		return
+	}
+	parent := (i - 1) / 2
+	if isMinLevel(i) {
+		if h.less(h.items[parent], h.items[i]) {
+			h.swap(i, parent)
+			h.upLevels(parent, h.greater)
+		} else {
+			h.upLevels(i, h.less)
+		}
+	} else {
+		if h.less(h.items[i], h.items[parent]) {
+			h.swap(i, parent)
+			h.upLevels(parent, h.less)
+		} else {
+			h.upLevels(i, h.greater)
+		}
+	}
+}
+
+// upLevels moves the element at index i up through its grandparents while it comes before them.
+func (h *MinMax[T]) upLevels(i int, before func(T, T) bool) {
+	for i > 2 {
+		grandparent := (i - 3) / 4
+		if !before(h.items[i], h.items[grandparent]) {
+			return
+		}
+		h.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+// down moves the element at index i down to its place.
+func (h *MinMax[T]) down(i int) {
+	before := h.less
+	if !isMinLevel(i) {
+		before = h.greater
+	}
+
+	for {
+		// Find the first of the children and grandchildren of i.
+		first, child := -1, 2*i+1
+		for _, j := range [...]int{child, child + 1, 2*child + 1, 2*child + 2, 2*child + 3, 2*child + 4} {
+			if j < len(h.items) && (first < 0 || before(h.items[j], h.items[first])) {
+				first = j
+			}
+		}
+		if first < 0 || !before(h.items[first], h.items[i]) {
+			return
+		}
+
+		h.swap(i, first)
+		if first <= child+1 {
+			return // a child has no descendants on the same kind of level
+		}
+		// A grandchild may now come after its parent, which is on the opposite kind of level.
+		if parent := (first - 1) / 2; before(h.items[parent], h.items[first]) {
+			h.swap(first, parent)
+		}
+		i = first
+	}
+}
+
+func (h *MinMax[T]) greater(a, b T) bool {
+	return h.less(b, a)
+}
+
+func (h *MinMax[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+// isMinLevel returns true if index i is on an even level of the tree, counting the root as level 0.
+func isMinLevel(i int) bool {
+	return (bits.Len(uint(i+1))-1)%2 == 0
+}
@@ -0,0 +1,120 @@
+package heap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkMinMax verifies that elements on min levels are not greater than their descendants,
+// and elements on max levels not less.
+func checkMinMax[T any](t *testing.T, h *MinMax[T]) {
+	t.Helper()
+	for i := range h.items {
+		for a := i; a > 0; {
+			a = (a - 1) / 2
+			if isMinLevel(a) && h.less(h.items[i], h.items[a]) || !isMinLevel(a) && h.less(h.items[a], h.items[i]) {
+				t.Fatalf("Element %d violates the order of its ancestor %d", i, a)
+			}
+		}
+	}
+}
+
+func TestMinMax(t *testing.T) {
+	h := NewMinMaxOrdered[int]()
+	if _, ok := h.PopMin(); ok {
+		t.Error("Expected PopMin on an empty heap to fail")
+	}
+	if _, ok := h.PeekMax(); ok {
+		t.Error("Expected PeekMax on an empty heap to fail")
+	}
+
+	h.Push(7)
+	if lo, _ := h.PeekMin(); lo != 7 {
+		t.Errorf("PeekMin() = %d", lo)
+	}
+	if hi, _ := h.PeekMax(); hi != 7 {
+		t.Errorf("PeekMax() = %d", hi)
+	}
+
+	h.Push(3, 9, 1, 5)
+	checkMinMax(t, h)
+	if lo, _ := h.PopMin(); lo != 1 {
+		t.Errorf("PopMin() = %d, want 1", lo)
+	}
+	if hi, _ := h.PopMax(); hi != 9 {
+		t.Errorf("PopMax() = %d, want 9", hi)
+	}
+	if h.Len() != 3 || !slices.Equal(slices.Sorted(slices.Values(h.ToSlice())), []int{3, 5, 7}) {
+		t.Errorf("Remaining %v", h.ToSlice())
+	}
+
+	h.Clear()
+	if !h.IsEmpty() {
+		t.Error("Expected an empty heap after Clear")
+	}
+}
+
+func TestMinMaxBestN(t *testing.T) {
+	// Keep the 5 highest scores, evicting the lowest.
+	h := NewMinMaxOrdered[int]()
+	rng := rand.New(rand.NewSource(1))
+	var all []int
+	for range 1000 {
+		v := rng.Intn(10000)
+		all = append(all, v)
+		h.Push(v)
+		if h.Len() > 5 {
+			h.PopMin()
+		}
+	}
+	slices.Sort(all)
+
+	var best []int
+	for !h.IsEmpty() {
+		v, _ := h.PopMax()
+		best = append(best, v)
+	}
+	slices.Reverse(best)
+	if !slices.Equal(best, all[len(all)-5:]) {
+		t.Errorf("Best 5 = %v, want %v", best, all[len(all)-5:])
+	}
+}
+
+func TestMinMaxRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	h := NewMinMaxOrdered[int]()
+	var ref []int // sorted
+
+	for i := range 10000 {
+		switch rng.Intn(4) {
+		case 0, 1:
+			v := rng.Intn(1000)
+			h.Push(v)
+			pos, _ := slices.BinarySearch(ref, v)
+			ref = slices.Insert(ref, pos, v)
+		case 2:
+			v, ok := h.PopMin()
+			if ok != (len(ref) > 0) || ok && v != ref[0] {
+				t.Fatalf("PopMin() = %d, %v", v, ok)
+			}
+			if ok {
+				ref = ref[1:]
+			}
+		case 3:
+			v, ok := h.PopMax()
+			if ok != (len(ref) > 0) || ok && v != ref[len(ref)-1] {
+				t.Fatalf("PopMax() = %d, %v", v, ok)
+			}
+			if ok {
+				ref = ref[:len(ref)-1]
+			}
+		}
+		if i%100 == 0 {
+			checkMinMax(t, h)
+		}
+		if h.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", h.Len(), len(ref))
+		}
+	}
+}
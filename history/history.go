@@ -0,0 +1,185 @@
+// Package history provides undo/redo histories of commands or of states, such as the edits of a document.
+package history
+
+import (
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/stack"
+)
+
+// History is a thread-safe undo/redo history of commands: Push records a command once applied, Undo returns
+// the last command for the caller to revert, and Redo returns the last undone command to apply again.
+// Pushing a command discards the commands that could be redone, as editors do.
+//
+// With a capacity, the oldest commands are forgotten beyond it. A coalesce function merges a pushed command
+// into the previous one, so that undoing a word typed letter by letter takes one step.
+type History[T any] struct {
+	mu   sync.Mutex
+	core core[T]
+}
+
+var (
+	_ collection.Container = (*History[int])(nil)
+	_ collection.Container = (*States[int])(nil)
+)
+
+// New returns a new empty History keeping at most capacity commands to undo, or any number if capacity is 0.
+// It panics if capacity is negative.
+func New[T any](capacity int) *History[T] {
+	return &History[T]{core: newCore[T](capacity)}
+}
+
+// SetCoalesce registers a function merging a pushed command into the last one: it returns the merged command
+// and true to replace the last command with it, or false to push the new command separately.
+// Commands are only merged with one pushed just before, never across an Undo, a Redo or a Seal.
+func (h *History[T]) SetCoalesce(fn func(last, next T) (T, bool)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.core.coalesce = fn
+}
+
+// Push records command as the latest change, merging it into the last one if the coalesce function agrees,
+// and discards the commands that could be redone.
+func (h *History[T]) Push(command T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.core.redo.Clear()
+	if !h.core.merge(command) {
+		h.core.push(command)
+	}
+}
+
+// Undo moves the last command to the redo stack and returns it for the caller to revert.
+// Returns (zero-value, false) if there is nothing to undo.
+func (h *History[T]) Undo() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	command, ok := h.core.undo.Pop()
+	if ok {
+		h.core.redo.Push(command)
+		h.core.open = false
+	}
+	return command, ok
+}
+
+// Redo moves the last undone command back to the undo stack and returns it for the caller to apply again.
+// Returns (zero-value, false) if there is nothing to redo.
+func (h *History[T]) Redo() (T, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	command, ok := h.core.redo.Pop()
+	if ok {
+		h.core.undo.Push(command)
+		h.core.open = false
+	}
+	return command, ok
+}
+
+// Seal ends the current group of changes, so that the next pushed command is not merged into the last one,
+// e.g. when the cursor moves between two runs of typing.
+func (h *History[T]) Seal() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.core.open = false
+}
+
+// CanUndo returns true if there is a command to undo.
+func (h *History[T]) CanUndo() bool {
+	return h.UndoLen() > 0
+}
+
+// CanRedo returns true if there is a command to redo.
+func (h *History[T]) CanRedo() bool {
+	return h.RedoLen() > 0
+}
+
+// UndoLen returns the number of commands that can be undone.
+func (h *History[T]) UndoLen() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.core.undo.Len()
+}
+
+// RedoLen returns the number of commands that can be redone.
+func (h *History[T]) RedoLen() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.core.redo.Len()
+}
+
+// Len returns the number of commands kept, which can be undone or redone.
+func (h *History[T]) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.core.len()
+}
+
+// IsEmpty returns true if there is no command to undo or redo.
+func (h *History[T]) IsEmpty() bool {
+	return h.Len() == 0
+}
+
+// Capacity returns the largest number of commands kept to undo, or 0 if unbounded.
+func (h *History[T]) Capacity() int {
+	return h.core.capacity
+}
+
+// Clear forgets every command.
+func (h *History[T]) Clear() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.core.clear()
+}
+
+// core holds the stacks shared by History and States, guarded by the lock of its owner.
+type core[T any] struct {
+	undo, redo *stack.UnsyncStack[T]
+	capacity   int
+	coalesce   func(last, next T) (T, bool)
+	open       bool // whether the top of undo was pushed last, so that it may absorb the next push
+}
+
+func newCore[T any](capacity int) core[T] {
+	if capacity < 0 {
+		panic("history: capacity must not be negative")
+	}
+	return core[T]{undo: stack.NewUnsync[T](), redo: stack.NewUnsync[T](), capacity: capacity}
+}
+
+// push pushes entry onto the undo stack, forgetting the oldest entry beyond the capacity.
+func (c *core[T]) push(entry T) {
+	c.undo.Push(entry)
+	c.open = true
+	if c.capacity > 0 && c.undo.Len() > c.capacity {
+		c.undo.DropBottom(c.undo.Len() - c.capacity)
+	}
+}
+
+// merge replaces the top of the undo stack with its merge with entry if the coalesce function agrees.
+func (c *core[T]) merge(entry T) bool {
+	if !c.open || c.coalesce == nil {
+		return false
+	}
+	last, ok := c.undo.Peek()
+	if !ok {
+		return false
+	}
+	merged, ok := c.coalesce(last, entry)
+	if !ok {
+		return false
+	}
+	c.undo.Pop()
+	c.undo.Push(merged)
+	return true
+}
+
+func (c *core[T]) len() int {
+	return c.undo.Len() + c.redo.Len()
+}
+
+func (c *core[T]) clear() {
+	c.undo.Clear()
+	c.redo.Clear()
+	c.open = false
+}
@@ -0,0 +1,177 @@
+package history
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// edit is a command inserting text at a position.
+type edit struct {
+	at   int
+	text string
+}
+
+func TestHistory(t *testing.T) {
+	h := New[edit](0)
+	if _, ok := h.Undo(); ok || h.CanUndo() || h.CanRedo() {
+		t.Fatal("Expected nothing to undo or redo")
+	}
+
+	h.Push(edit{0, "a"})
+	h.Push(edit{1, "b"})
+	h.Push(edit{2, "c"})
+	if e, ok := h.Undo(); !ok || e.text != "c" {
+		t.Fatalf("Undo() = %v, %v", e, ok)
+	}
+	if e, _ := h.Undo(); e.text != "b" || h.UndoLen() != 1 || h.RedoLen() != 2 {
+		t.Fatalf("Undo() = %v, UndoLen() = %d, RedoLen() = %d", e, h.UndoLen(), h.RedoLen())
+	}
+	if e, ok := h.Redo(); !ok || e.text != "b" {
+		t.Fatalf("Redo() = %v, %v", e, ok)
+	}
+
+	h.Push(edit{2, "d"})
+	if h.CanRedo() {
+		t.Error("Expected Push to discard the commands to redo")
+	}
+	if _, ok := h.Redo(); ok {
+		t.Error("Expected nothing to redo")
+	}
+
+	if h.Len() != 3 || h.IsEmpty() {
+		t.Errorf("Len() = %d, want 3", h.Len())
+	}
+	h.Clear()
+	if h.CanUndo() || !h.IsEmpty() {
+		t.Error("Expected nothing to undo after Clear()")
+	}
+}
+
+func TestHistory_Capacity(t *testing.T) {
+	h := New[int](3)
+	for i := range 10 {
+		h.Push(i)
+	}
+	var undone []int
+	for {
+		v, ok := h.Undo()
+		if !ok {
+			break
+		}
+		undone = append(undone, v)
+	}
+	if !slices.Equal(undone, []int{9, 8, 7}) || h.Capacity() != 3 {
+		t.Errorf("Undone %v, want the last 3 commands", undone)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a negative capacity to panic")
+		}
+	}()
+	New[int](-1)
+}
+
+func TestHistory_Coalesce(t *testing.T) {
+	h := New[edit](0)
+	// Typing merges into the previous insertion when it continues it.
+	h.SetCoalesce(func(last, next edit) (edit, bool) {
+		if next.at != last.at+len(last.text) || strings.Contains(next.text, " ") {
+			return edit{}, false
+		}
+		return edit{last.at, last.text + next.text}, true
+	})
+
+	for i, c := range "hello" {
+		h.Push(edit{i, string(c)})
+	}
+	h.Push(edit{5, " "})
+	h.Push(edit{6, "w"})
+	h.Seal()
+	h.Push(edit{7, "o"})
+	if h.UndoLen() != 3 { // "hello", " w", "o"
+		t.Fatalf("UndoLen() = %d, want 3", h.UndoLen())
+	}
+
+	// Commands do not merge across an Undo.
+	h.Undo()
+	h.Push(edit{8, "x"}) // would continue " w"
+	var undone []string
+	for h.CanUndo() {
+		e, _ := h.Undo()
+		undone = append(undone, e.text)
+	}
+	if !slices.Equal(undone, []string{"x", " w", "hello"}) {
+		t.Errorf("Undone %q", undone)
+	}
+}
+
+func TestStates(t *testing.T) {
+	s := NewStates("", 0)
+	for _, text := range []string{"a", "ab", "abc"} {
+		s.Push(text)
+	}
+	if s.Current() != "abc" || s.UndoLen() != 3 {
+		t.Fatalf("Current() = %q, UndoLen() = %d", s.Current(), s.UndoLen())
+	}
+	if v, ok := s.Undo(); !ok || v != "ab" || s.Current() != "ab" {
+		t.Fatalf("Undo() = %q, %v", v, ok)
+	}
+	s.Undo()
+	if v, ok := s.Redo(); !ok || v != "ab" || s.RedoLen() != 1 {
+		t.Fatalf("Redo() = %q, %v, RedoLen() = %d", v, ok, s.RedoLen())
+	}
+
+	s.Push("abd")
+	if s.CanRedo() || s.Current() != "abd" {
+		t.Error("Expected Push to discard the states to redo")
+	}
+	for s.CanUndo() {
+		s.Undo()
+	}
+	if s.Current() != "" {
+		t.Errorf("Expected to undo back to the initial state, got %q", s.Current())
+	}
+	if _, ok := s.Undo(); ok {
+		t.Error("Expected nothing to undo")
+	}
+
+	s.Clear()
+	if s.CanRedo() || s.Current() != "" || s.Len() != 0 || !s.IsEmpty() {
+		t.Error("Expected Clear() to keep only the current state")
+	}
+}
+
+func TestStates_CapacityAndCoalesce(t *testing.T) {
+	s := NewStates(0, 2)
+	// Consecutive small increments coalesce into one step.
+	s.SetCoalesce(func(current, next int) (int, bool) { return next, next-current == 1 })
+
+	s.Push(10)
+	s.Push(11)
+	s.Push(12)
+	s.Push(20)
+	s.Push(30)
+	if s.UndoLen() != 2 || s.Capacity() != 2 {
+		t.Fatalf("UndoLen() = %d, want the capacity", s.UndoLen())
+	}
+	if v, _ := s.Undo(); v != 20 {
+		t.Errorf("Undo() = %d, want 20", v)
+	}
+	if v, _ := s.Undo(); v != 12 {
+		t.Errorf("Undo() = %d, want 12, the coalesced increments", v)
+	}
+	if s.CanUndo() {
+		t.Error("Expected older states to be forgotten beyond the capacity")
+	}
+
+	// States do not coalesce across a Seal.
+	s.Redo()
+	s.Redo()
+	s.Seal()
+	s.Push(31)
+	if v, _ := s.Undo(); v != 30 {
+		t.Errorf("Undo() = %d, want 30", v)
+	}
+}
@@ -0,0 +1,138 @@
+package history
+
+import "sync"
+
+// States is a thread-safe undo/redo history of snapshots of a state, such as immutable document values:
+// Push records a new current state, Undo restores the previous one and Redo the next one. Snapshots suit
+// states that are cheap to keep, such as persistent structures sharing their unchanged parts, where commands
+// suit large mutable states.
+//
+// With a capacity, the oldest states are forgotten beyond it. A coalesce function replaces the current state
+// with a pushed one without keeping the current state to undo, so that rapid changes take one step.
+type States[T any] struct {
+	mu      sync.Mutex
+	current T
+	core    core[T] // undo holds the states before current, redo those after it
+}
+
+// NewStates returns a new States whose current state is initial, keeping at most capacity earlier states,
+// or any number if capacity is 0. It panics if capacity is negative.
+func NewStates[T any](initial T, capacity int) *States[T] {
+	return &States[T]{current: initial, core: newCore[T](capacity)}
+}
+
+// SetCoalesce registers a function deciding whether a pushed state replaces the current one without keeping
+// it to undo: it returns the state to keep as current and true, or false to keep the current state to undo.
+// States are only coalesced with one pushed just before, never across an Undo, a Redo or a Seal.
+func (s *States[T]) SetCoalesce(fn func(current, next T) (T, bool)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.core.coalesce = fn
+}
+
+// Current returns the current state.
+func (s *States[T]) Current() T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+// Push makes state the current state, keeping the previous one to undo unless they are coalesced,
+// and discards the states that could be redone.
+func (s *States[T]) Push(state T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.core.redo.Clear()
+	if s.core.open && s.core.coalesce != nil {
+		if merged, ok := s.core.coalesce(s.current, state); ok {
+			s.current = merged
+			return
+		}
+	}
+	s.core.push(s.current)
+	s.current = state
+}
+
+// Undo restores the previous state and returns it. Returns (zero-value, false) if there is nothing to undo.
+func (s *States[T]) Undo() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	previous, ok := s.core.undo.Pop()
+	if !ok {
+		return previous, false
+	}
+	s.core.redo.Push(s.current)
+	s.current = previous
+	s.core.open = false
+	return previous, true
+}
+
+// Redo restores the next state and returns it. Returns (zero-value, false) if there is nothing to redo.
+func (s *States[T]) Redo() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next, ok := s.core.redo.Pop()
+	if !ok {
+		return next, false
+	}
+	s.core.undo.Push(s.current)
+	s.current = next
+	s.core.open = false
+	return next, true
+}
+
+// Seal ends the current group of changes, so that the next pushed state is not coalesced with the current one.
+func (s *States[T]) Seal() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.core.open = false
+}
+
+// CanUndo returns true if there is a state to undo to.
+func (s *States[T]) CanUndo() bool {
+	return s.UndoLen() > 0
+}
+
+// CanRedo returns true if there is a state to redo to.
+func (s *States[T]) CanRedo() bool {
+	return s.RedoLen() > 0
+}
+
+// UndoLen returns the number of earlier states.
+func (s *States[T]) UndoLen() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.core.undo.Len()
+}
+
+// RedoLen returns the number of later states.
+func (s *States[T]) RedoLen() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.core.redo.Len()
+}
+
+// Len returns the number of earlier and later states, not counting the current one.
+func (s *States[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.core.len()
+}
+
+// IsEmpty returns true if there is no earlier or later state, only the current one.
+func (s *States[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Capacity returns the largest number of earlier states kept, or 0 if unbounded.
+func (s *States[T]) Capacity() int {
+	return s.core.capacity
+}
+
+// Clear forgets every earlier and later state, keeping the current one.
+func (s *States[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.core.clear()
+}
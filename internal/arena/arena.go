@@ -0,0 +1,79 @@
+// Package arena provides a typed slab allocator for the nodes of the linked structures of kozo.
+package arena
+
+// Arena allocates values of type T in chunks, so that a structure with millions of nodes makes one allocation
+// per chunk instead of one per node, and the garbage collector scans a few large objects. Freed values are
+// reused by later allocations, and Reset releases every chunk at once.
+//
+// A nil *Arena is valid and allocates each value with new, so structures can hold an optional arena
+// without branching. An Arena is not safe for concurrent use; it is guarded by the lock of its structure.
+type Arena[T any] struct {
+	chunkSize int
+	chunk     []T  // the chunk being filled
+	chunks    int  // number of chunks allocated since the last Reset
+	free      []*T // freed values, reused first
+}
+
+// New returns a new empty Arena allocating chunks of chunkSize values. It panics if chunkSize is less than 1.
+func New[T any](chunkSize int) *Arena[T] {
+	if chunkSize < 1 {
+		panic("arena: chunk size must be at least 1")
+	}
+	return &Arena[T]{chunkSize: chunkSize}
+}
+
+// Alloc returns a pointer to a zero value of T.
+func (a *Arena[T]) Alloc() *T {
+	if a == nil {
+		return new(T)
+	}
+	if n := len(a.free); n > 0 {
+		p := a.free[n-1]
+		a.free[n-1] = nil
+		a.free = a.free[:n-1]
+		return p
+	}
+	if len(a.chunk) == cap(a.chunk) {
+		a.chunk = make([]T, 0, a.chunkSize)
+		a.chunks++
+	}
+	a.chunk = a.chunk[:len(a.chunk)+1]
+	return &a.chunk[len(a.chunk)-1]
+}
+
+// Free zeroes *p, releasing what it references, and makes it available to Alloc. p must have been returned
+// by Alloc since the last Reset, or by new when the structure was not using the arena, and must not be used
+// afterwards.
+func (a *Arena[T]) Free(p *T) {
+	if a == nil {
+		return
+	}
+	var zero T
+	*p = zero
+	a.free = append(a.free, p)
+}
+
+// Reset forgets every value allocated so far, leaving their chunks to the garbage collector once no pointer
+// into them remains.
+func (a *Arena[T]) Reset() {
+	if a == nil {
+		return
+	}
+	a.chunk, a.chunks, a.free = nil, 0, nil
+}
+
+// ChunkSize returns the number of values per chunk, or 0 for a nil Arena.
+func (a *Arena[T]) ChunkSize() int {
+	if a == nil {
+		return 0
+	}
+	return a.chunkSize
+}
+
+// Chunks returns the number of chunks allocated since the last Reset.
+func (a *Arena[T]) Chunks() int {
+	if a == nil {
+		return 0
+	}
+	return a.chunks
+}
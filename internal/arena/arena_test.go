@@ -0,0 +1,59 @@
+package arena
+
+import "testing"
+
+type pair struct {
+	key  int
+	next *pair
+}
+
+func TestArena(t *testing.T) {
+	a := New[pair](4)
+	var ps []*pair
+	for i := range 10 {
+		p := a.Alloc()
+		if *p != (pair{}) {
+			t.Fatalf("Alloc() returned a non-zero value %v", *p)
+		}
+		p.key = i
+		ps = append(ps, p)
+	}
+	if a.Chunks() != 3 || a.ChunkSize() != 4 {
+		t.Errorf("Chunks() = %d after 10 allocations of chunks of 4", a.Chunks())
+	}
+	for i, p := range ps {
+		if p.key != i {
+			t.Fatalf("Value %d was overwritten with %d", i, p.key)
+		}
+	}
+
+	ps[3].next = ps[4]
+	a.Free(ps[3])
+	if *ps[3] != (pair{}) {
+		t.Error("Expected Free() to zero the value")
+	}
+	if p := a.Alloc(); p != ps[3] || a.Chunks() != 3 {
+		t.Error("Expected Alloc() to reuse the freed value")
+	}
+
+	a.Reset()
+	if a.Chunks() != 0 || ps[9].key != 9 {
+		t.Error("Expected Reset() to forget the chunks without touching their values")
+	}
+	if p := a.Alloc(); p == ps[8] || p == ps[9] || a.Chunks() != 1 {
+		t.Error("Expected a new chunk after Reset()")
+	}
+}
+
+func TestArena_Nil(t *testing.T) {
+	var a *Arena[pair]
+	p, q := a.Alloc(), a.Alloc()
+	if p == nil || p == q {
+		t.Fatal("Expected a nil Arena to allocate distinct values")
+	}
+	a.Free(p)
+	a.Reset()
+	if a.Chunks() != 0 || a.ChunkSize() != 0 {
+		t.Error("Unexpected chunks in a nil Arena")
+	}
+}
@@ -0,0 +1,213 @@
+// Package kdtree provides a k-d tree for nearest-neighbor search among vectors of any dimension.
+package kdtree
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"math"
+	"slices"
+
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/heap"
+)
+
+// ErrDimension is returned by Build when the points do not all have the same, non-zero, number of dimensions.
+var ErrDimension = errors.New("kdtree: points have different dimensions")
+
+// Item is a point with its value, such as an embedding with the ID of the document it represents.
+type Item[V any] struct {
+	Point []float64
+	Value V
+}
+
+// Neighbor is an item found by a search, with its Euclidean distance to the query.
+type Neighbor[V any] struct {
+	Item[V]
+	Distance float64
+}
+
+// Tree is a k-d tree of points with values, built at once from all its items. Each node splits the points
+// around the median of the dimension along which they spread the most, so that searches visit O(log n) nodes
+// for well-spread points in few dimensions. Pruning weakens as dimensions grow, and in hundreds of dimensions
+// searches approach a linear scan, though results remain exact.
+//
+// A Tree is immutable, so it is safe for concurrent use without locking; build a new tree to change the items.
+// For the same reason it has no Clear and is a collection.Iterable rather than a collection.Container.
+// Queries must have the dimension of the tree, and panic otherwise like an out-of-range index.
+type Tree[V any] struct {
+	items []Item[V] // in tree order: the root is the middle item, each half a subtree
+	axes  []int     // the dimension each node splits along
+	dim   int
+}
+
+var _ collection.Iterable[Item[int]] = (*Tree[int])(nil)
+
+// Build returns a tree holding the given items, in O(n log² n). The tree keeps the point slices,
+// which must not be modified afterwards. It returns ErrDimension if the points do not all have the same dimension.
+func Build[V any](items []Item[V]) (*Tree[V], error) {
+	t := &Tree[V]{items: slices.Clone(items), axes: make([]int, len(items))}
+	if len(items) > 0 {
+		t.dim = len(items[0].Point)
+	}
+	for _, item := range items {
+		if len(item.Point) != t.dim || t.dim == 0 {
+			return nil, ErrDimension
+		}
+	}
+	t.build(0, len(t.items))
+	return t, nil
+}
+
+// Dim returns the number of dimensions of the points, or 0 if the tree is empty.
+func (t *Tree[V]) Dim() int {
+	return t.dim
+}
+
+// Len returns the number of items.
+func (t *Tree[V]) Len() int {
+	return len(t.items)
+}
+
+// IsEmpty returns true if the tree holds no items.
+func (t *Tree[V]) IsEmpty() bool {
+	return len(t.items) == 0
+}
+
+// Nearest returns the item nearest to q. Returns false if the tree is empty.
+func (t *Tree[V]) Nearest(q []float64) (Neighbor[V], bool) {
+	found := t.KNearest(q, 1)
+	if len(found) == 0 {
+		return Neighbor[V]{}, false
+	}
+	return found[0], true
+}
+
+// KNearest returns the k items nearest to q, nearest first. It returns fewer if the tree holds fewer.
+func (t *Tree[V]) KNearest(q []float64, k int) []Neighbor[V] {
+	t.checkQuery(q)
+	if k <= 0 {
+		return nil
+	}
+
+	// best holds the k nearest items found so far, the farthest on top.
+	best := heap.New(func(a, b Neighbor[V]) bool { return a.Distance > b.Distance })
+	var search func(lo, hi int)
+	search = func(lo, hi int) {
+		if lo >= hi {
+			return
+		}
+		mid := (lo + hi) / 2
+		item, axis := t.items[mid], t.axes[mid]
+
+		if d := squaredDistance(item.Point, q); best.Len() < k {
+			best.Push(Neighbor[V]{item, d})
+		} else if top, _ := best.Peek(); d < top.Distance {
+			best.PushPop(Neighbor[V]{item, d})
+		}
+
+		// Search the side of q first, then the other side if the splitting plane is nearer than the k-th item.
+		diff := q[axis] - item.Point[axis]
+		near, far := [2]int{lo, mid}, [2]int{mid + 1, hi}
+		if diff > 0 {
+			near, far = far, near
+		}
+		search(near[0], near[1])
+		if top, _ := best.Peek(); best.Len() < k || diff*diff < top.Distance {
+			search(far[0], far[1])
+		}
+	}
+	search(0, len(t.items))
+
+	found := best.Drain()
+	slices.Reverse(found)
+	for i := range found {
+		found[i].Distance = math.Sqrt(found[i].Distance)
+	}
+	return found
+}
+
+// Radius returns the items within distance r of q, boundary included, nearest first.
+func (t *Tree[V]) Radius(q []float64, r float64) []Neighbor[V] {
+	t.checkQuery(q)
+	if r < 0 {
+		return nil
+	}
+
+	var found []Neighbor[V]
+	r2 := r * r
+	var search func(lo, hi int)
+	search = func(lo, hi int) {
+		if lo >= hi {
+			return
+		}
+		mid := (lo + hi) / 2
+		item, axis := t.items[mid], t.axes[mid]
+		if d := squaredDistance(item.Point, q); d <= r2 {
+			found = append(found, Neighbor[V]{item, d})
+		}
+		diff := q[axis] - item.Point[axis]
+		if diff <= 0 || diff*diff <= r2 {
+			search(lo, mid)
+		}
+		if diff >= 0 || diff*diff <= r2 {
+			search(mid+1, hi)
+		}
+	}
+	search(0, len(t.items))
+
+	slices.SortFunc(found, func(a, b Neighbor[V]) int { return cmp.Compare(a.Distance, b.Distance) })
+	for i := range found {
+		found[i].Distance = math.Sqrt(found[i].Distance)
+	}
+	return found
+}
+
+// Items returns the items in tree order.
+func (t *Tree[V]) Items() []Item[V] {
+	return slices.Clone(t.items)
+}
+
+// All returns an iterator over the items in tree order.
+func (t *Tree[V]) All() iter.Seq[Item[V]] {
+	return slices.Values(t.items)
+}
+
+// build arranges items[lo:hi] into a subtree rooted at its middle, splitting along the axis of largest spread.
+func (t *Tree[V]) build(lo, hi int) {
+	if hi-lo <= 0 {
+		return
+	}
+	axis, spread := 0, -1.0
+	for d := range t.dim {
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, item := range t.items[lo:hi] {
+			low, high = min(low, item.Point[d]), max(high, item.Point[d])
+		}
+		if high-low > spread {
+			axis, spread = d, high-low
+		}
+	}
+
+	slices.SortFunc(t.items[lo:hi], func(a, b Item[V]) int { return cmp.Compare(a.Point[axis], b.Point[axis]) })
+	mid := (lo + hi) / 2
+	t.axes[mid] = axis
+	t.build(lo, mid)
+	t.build(mid+1, hi)
+}
+
+func (t *Tree[V]) checkQuery(q []float64) {
+	if len(t.items) > 0 && len(q) != t.dim {
+		panic(fmt.Sprintf("kdtree: query has %d dimensions, tree has %d", len(q), t.dim))
+	}
+}
+
+func squaredDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return sum
+}
@@ -0,0 +1,132 @@
+package kdtree
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// bruteForce returns every item with its distance to q, nearest first.
+func bruteForce(items []Item[int], q []float64) []Neighbor[int] {
+	all := make([]Neighbor[int], len(items))
+	for i, item := range items {
+		all[i] = Neighbor[int]{item, math.Sqrt(squaredDistance(item.Point, q))}
+	}
+	slices.SortStableFunc(all, func(a, b Neighbor[int]) int { return cmp.Compare(a.Distance, b.Distance) })
+	return all
+}
+
+func distances(found []Neighbor[int]) []float64 {
+	d := make([]float64, len(found))
+	for i, n := range found {
+		d[i] = n.Distance
+	}
+	return d
+}
+
+func TestBuild(t *testing.T) {
+	if _, err := Build([]Item[int]{{[]float64{1, 2}, 0}, {[]float64{1}, 1}}); !errors.Is(err, ErrDimension) {
+		t.Errorf("Expected ErrDimension for mixed dimensions, got %v", err)
+	}
+	if _, err := Build([]Item[int]{{nil, 0}}); !errors.Is(err, ErrDimension) {
+		t.Errorf("Expected ErrDimension for empty points, got %v", err)
+	}
+
+	empty, err := Build[int](nil)
+	if err != nil || !empty.IsEmpty() || empty.Dim() != 0 {
+		t.Fatalf("Build(nil) = %v, %v", empty, err)
+	}
+	if _, ok := empty.Nearest([]float64{1, 2, 3}); ok {
+		t.Error("Expected no nearest item in an empty tree")
+	}
+	if found := empty.Radius([]float64{0}, 10); len(found) != 0 {
+		t.Errorf("Radius() on empty tree = %v", found)
+	}
+
+	tr, _ := Build([]Item[int]{{[]float64{0, 0}, 1}, {[]float64{3, 4}, 2}, {[]float64{-1, 0}, 3}})
+	if tr.Len() != 3 || tr.Dim() != 2 || len(tr.Items()) != 3 || len(slices.Collect(tr.All())) != 3 {
+		t.Errorf("Len() = %d, Dim() = %d", tr.Len(), tr.Dim())
+	}
+}
+
+func TestTree_Queries(t *testing.T) {
+	tr, _ := Build([]Item[string]{
+		{[]float64{0, 0}, "origin"}, {[]float64{3, 4}, "far"}, {[]float64{1, 1}, "near"}, {[]float64{-2, 0}, "left"},
+	})
+
+	if n, ok := tr.Nearest([]float64{0.9, 0.8}); !ok || n.Value != "near" {
+		t.Errorf("Nearest() = %v, %v", n, ok)
+	}
+
+	found := tr.KNearest([]float64{0, 0}, 2)
+	if len(found) != 2 || found[0].Value != "origin" || found[0].Distance != 0 || found[1].Value != "near" {
+		t.Errorf("KNearest() = %v", found)
+	}
+	if found := tr.KNearest([]float64{0, 0}, 10); len(found) != 4 || found[3].Value != "far" || found[3].Distance != 5 {
+		t.Errorf("KNearest() beyond size = %v", found)
+	}
+	if found := tr.KNearest([]float64{0, 0}, 0); found != nil {
+		t.Errorf("KNearest(0) = %v", found)
+	}
+
+	found = tr.Radius([]float64{0, 0}, 2)
+	if len(found) != 3 || found[0].Value != "origin" || found[1].Value != "near" || found[2].Value != "left" {
+		t.Errorf("Radius() = %v", found)
+	}
+	if found := tr.Radius([]float64{0, 0}, 5); len(found) != 4 {
+		t.Errorf("Expected the boundary to be included, got %v", found)
+	}
+	if found := tr.Radius([]float64{0, 0}, -1); found != nil {
+		t.Errorf("Radius(-1) = %v", found)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a query of the wrong dimension to panic")
+		}
+	}()
+	tr.Nearest([]float64{1, 2, 3})
+}
+
+func TestTree_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for _, dim := range []int{1, 2, 3, 8} {
+		items := make([]Item[int], 500)
+		for i := range items {
+			p := make([]float64, dim)
+			for d := range p {
+				p[d] = float64(rng.Intn(50)) // duplicate coordinates exercise ties
+			}
+			items[i] = Item[int]{p, i}
+		}
+		tr, err := Build(items)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for range 50 {
+			q := make([]float64, dim)
+			for d := range q {
+				q[d] = rng.Float64() * 50
+			}
+			want := bruteForce(items, q)
+
+			k := 1 + rng.Intn(20)
+			if got := distances(tr.KNearest(q, k)); !slices.Equal(got, distances(want[:k])) {
+				t.Fatalf("dim %d: KNearest(%v, %d) = %v, want %v", dim, q, k, got, distances(want[:k]))
+			}
+
+			r := rng.Float64() * 20
+			i := 0
+			for i < len(want) && want[i].Distance <= r {
+				i++
+			}
+			if got := distances(tr.Radius(q, r)); !slices.Equal(got, distances(want[:i])) {
+				t.Fatalf("dim %d: Radius(%v, %v) = %v, want %v", dim, q, r, got, distances(want[:i]))
+			}
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package list
+
+import "iter"
+
+// Hook links a value into an Intrusive list. Embed it in the struct to be listed:
+//
+//	type Timer struct {
+//		list.Hook[Timer]
+//		Deadline time.Time
+//	}
+//
+// The links live in the value itself, so adding it to a list allocates nothing.
+// A value can be in at most one list at a time. The zero Hook is not linked.
+type Hook[T any] struct {
+	next, prev *T
+	list       *links[T] // nil while not linked
+}
+
+func (h *Hook[T]) hook() *Hook[T] {
+	return h
+}
+
+// Linked is satisfied by pointers to structs embedding a Hook, through the promoted hook method.
+type Linked[T any] interface {
+	*T
+	hook() *Hook[T]
+}
+
+// links is the identity and the ends of an Intrusive list, referenced by the hooks it owns.
+type links[T any] struct {
+	front, back *T
+	len         int
+}
+
+// Intrusive is a doubly linked list of values that embed a Hook, linking them through their own fields
+// instead of allocating an element per value. It suits high-throughput tracking of long-lived objects,
+// such as free lists, timer wheels or connection pools, where element allocations of List would dominate.
+// Values are their own handles: Remove and moves take the value and run in O(1).
+//
+// Like UnsyncList, it is not safe for concurrent use; guard it with the mutex of the structure that owns it.
+type Intrusive[T any, P Linked[T]] struct {
+	links links[T]
+}
+
+// NewIntrusive returns a new empty Intrusive list of values of a struct type embedding Hook.
+func NewIntrusive[T any, P Linked[T]]() *Intrusive[T, P] {
+	return &Intrusive[T, P]{}
+}
+
+// PushFront adds x at the front of the list. It panics if x is already in a list.
+func (l *Intrusive[T, P]) PushFront(x *T) {
+	l.insert(x, nil)
+}
+
+// PushBack adds x at the back of the list. It panics if x is already in a list.
+func (l *Intrusive[T, P]) PushBack(x *T) {
+	l.insert(x, l.links.back)
+}
+
+// InsertBefore adds x just before mark. It returns false without adding x if mark is not in the list,
+// and panics if x is already in a list.
+func (l *Intrusive[T, P]) InsertBefore(x, mark *T) bool {
+	if !l.Contains(mark) {
+		return false
+	}
+	l.insert(x, l.hookOf(mark).prev)
+	return true
+}
+
+// InsertAfter adds x just after mark. It returns false without adding x if mark is not in the list,
+// and panics if x is already in a list.
+func (l *Intrusive[T, P]) InsertAfter(x, mark *T) bool {
+	if !l.Contains(mark) {
+		return false
+	}
+	l.insert(x, mark)
+	return true
+}
+
+// Remove removes x from the list. Returns false if x is not in the list, so removing a value twice is harmless.
+func (l *Intrusive[T, P]) Remove(x *T) bool {
+	if !l.Contains(x) {
+		return false
+	}
+	l.unlink(x)
+	return true
+}
+
+// MoveToFront moves x to the front of the list. Returns false if x is not in the list.
+func (l *Intrusive[T, P]) MoveToFront(x *T) bool {
+	if !l.Contains(x) {
+		return false
+	}
+	if l.links.front != x {
+		l.unlink(x)
+		l.insert(x, nil)
+	}
+	return true
+}
+
+// MoveToBack moves x to the back of the list. Returns false if x is not in the list.
+func (l *Intrusive[T, P]) MoveToBack(x *T) bool {
+	if !l.Contains(x) {
+		return false
+	}
+	if l.links.back != x {
+		l.unlink(x)
+		l.insert(x, l.links.back)
+	}
+	return true
+}
+
+// MoveBefore moves x just before mark. Returns false if either is not in the list.
+func (l *Intrusive[T, P]) MoveBefore(x, mark *T) bool {
+	if !l.Contains(x) || !l.Contains(mark) {
+		return false
+	}
+	if x != mark {
+		l.unlink(x)
+		l.insert(x, l.hookOf(mark).prev)
+	}
+	return true
+}
+
+// MoveAfter moves x just after mark. Returns false if either is not in the list.
+func (l *Intrusive[T, P]) MoveAfter(x, mark *T) bool {
+	if !l.Contains(x) || !l.Contains(mark) {
+		return false
+	}
+	if x != mark {
+		l.unlink(x)
+		l.insert(x, mark)
+	}
+	return true
+}
+
+// Contains returns true if x is in the list, in O(1).
+func (l *Intrusive[T, P]) Contains(x *T) bool {
+	return x != nil && l.hookOf(x).list == &l.links
+}
+
+// Front returns the first value, or nil if the list is empty.
+func (l *Intrusive[T, P]) Front() *T {
+	return l.links.front
+}
+
+// Back returns the last value, or nil if the list is empty.
+func (l *Intrusive[T, P]) Back() *T {
+	return l.links.back
+}
+
+// Next returns the value after x, or nil if x is the last value or not in the list.
+func (l *Intrusive[T, P]) Next(x *T) *T {
+	if !l.Contains(x) {
+		return nil
+	}
+	return l.hookOf(x).next
+}
+
+// Prev returns the value before x, or nil if x is the first value or not in the list.
+func (l *Intrusive[T, P]) Prev(x *T) *T {
+	if !l.Contains(x) {
+		return nil
+	}
+	return l.hookOf(x).prev
+}
+
+// PopFront removes and returns the first value, or returns nil if the list is empty.
+func (l *Intrusive[T, P]) PopFront() *T {
+	x := l.links.front
+	if x != nil {
+		l.unlink(x)
+	}
+	return x
+}
+
+// PopBack removes and returns the last value, or returns nil if the list is empty.
+func (l *Intrusive[T, P]) PopBack() *T {
+	x := l.links.back
+	if x != nil {
+		l.unlink(x)
+	}
+	return x
+}
+
+// All returns an iterator over the values from front to back.
+// The value being visited may be removed during the iteration.
+func (l *Intrusive[T, P]) All() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		for x := l.links.front; x != nil; {
+			next := l.hookOf(x).next
+			if !yield(x) {
+				return
+			}
+			x = next
+		}
+	}
+}
+
+// Backward returns an iterator over the values from back to front.
+// The value being visited may be removed during the iteration.
+func (l *Intrusive[T, P]) Backward() iter.Seq[*T] {
+	return func(yield func(*T) bool) {
+		for x := l.links.back; x != nil; {
+			prev := l.hookOf(x).prev
+			if !yield(x) {
+				return
+			}
+			x = prev
+		}
+	}
+}
+
+// IsEmpty returns true if the list has no values.
+func (l *Intrusive[T, P]) IsEmpty() bool {
+	return l.links.len == 0
+}
+
+// Len returns the current number of values in the list.
+func (l *Intrusive[T, P]) Len() int {
+	return l.links.len
+}
+
+// Clear removes all values from the list, unlinking each so that it can be added to a list again. It runs in O(n).
+func (l *Intrusive[T, P]) Clear() {
+	for x := l.links.front; x != nil; {
+		h := l.hookOf(x)
+		x = h.next
+		*h = Hook[T]{}
+	}
+	l.links = links[T]{}
+}
+
+func (l *Intrusive[T, P]) hookOf(x *T) *Hook[T] {
+	return P(x).hook()
+}
+
+// insert links x after at, or at the front if at is nil.
+func (l *Intrusive[T, P]) insert(x, at *T) {
+	h := l.hookOf(x)
+	if h.list != nil {
+		panic("list: value is already in a list")
+	}
+	h.list = &l.links
+	h.prev = at
+	if at == nil {
+		h.next = l.links.front
+		l.links.front = x
+	} else {
+		a := l.hookOf(at)
+		h.next = a.next
+		a.next = x
+	}
+	if h.next == nil {
+		l.links.back = x
+	} else {
+		l.hookOf(h.next).prev = x
+	}
+	l.links.len++
+}
+
+// unlink removes x from the list.
+func (l *Intrusive[T, P]) unlink(x *T) {
+	h := l.hookOf(x)
+	if h.prev == nil {
+		l.links.front = h.next
+	} else {
+		l.hookOf(h.prev).next = h.next
+	}
+	if h.next == nil {
+		l.links.back = h.prev
+	} else {
+		l.hookOf(h.next).prev = h.prev
+	}
+	*h = Hook[T]{}
+	l.links.len--
+}
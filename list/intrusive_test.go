@@ -0,0 +1,183 @@
+package list
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type timer struct {
+	Hook[timer]
+	id int
+}
+
+// checkIntrusive verifies the links in both directions, ownership and the length.
+func checkIntrusive(t *testing.T, l *Intrusive[timer, *timer]) {
+	t.Helper()
+	n := 0
+	var prev *timer
+	for x := l.links.front; x != nil; x = x.next {
+		if x.list != &l.links || x.prev != prev {
+			t.Fatalf("Links of %d are inconsistent", x.id)
+		}
+		prev = x
+		n++
+	}
+	if l.links.back != prev || n != l.links.len {
+		t.Fatalf("List links %d values but Len is %d", n, l.links.len)
+	}
+}
+
+func ids(l *Intrusive[timer, *timer]) []int {
+	var out []int
+	for x := range l.All() {
+		out = append(out, x.id)
+	}
+	return out
+}
+
+func TestIntrusive_Basic(t *testing.T) {
+	l := NewIntrusive[timer]()
+	if !l.IsEmpty() || l.Front() != nil || l.PopFront() != nil {
+		t.Error("Expected new list to be empty")
+	}
+
+	a, b, c, d := &timer{id: 1}, &timer{id: 2}, &timer{id: 3}, &timer{id: 4}
+	l.PushBack(b)
+	l.PushFront(a)
+	l.PushBack(d)
+	if !l.InsertBefore(c, d) {
+		t.Error("Expected InsertBefore to succeed")
+	}
+	if got := ids(l); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("All() = %v", got)
+	}
+	if l.Next(a) != b || l.Prev(a) != nil || l.Prev(d) != c || l.Back() != d {
+		t.Error("Unexpected links")
+	}
+
+	if !l.Remove(b) || l.Remove(b) || l.Contains(b) {
+		t.Error("Expected Remove to succeed once")
+	}
+	if l.InsertAfter(&timer{id: 5}, b) || l.MoveToFront(b) || l.Next(b) != nil {
+		t.Error("Expected a removed value to be rejected")
+	}
+
+	l.MoveToFront(d)
+	l.MoveToBack(a)
+	if got := ids(l); !slices.Equal(got, []int{4, 3, 1}) {
+		t.Errorf("All() after moves = %v", got)
+	}
+	l.MoveBefore(a, d)
+	l.MoveAfter(d, c)
+	l.MoveAfter(c, c)
+	if got := ids(l); !slices.Equal(got, []int{1, 3, 4}) {
+		t.Errorf("All() after relative moves = %v", got)
+	}
+	var backward []int
+	for x := range l.Backward() {
+		backward = append(backward, x.id)
+	}
+	if !slices.Equal(backward, []int{4, 3, 1}) {
+		t.Errorf("Backward() = %v", backward)
+	}
+	checkIntrusive(t, l)
+
+	other := NewIntrusive[timer]()
+	if other.Contains(a) || other.Remove(a) {
+		t.Error("Expected a value of another list to be rejected")
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected adding a linked value to panic")
+			}
+		}()
+		other.PushBack(a)
+	}()
+
+	if l.PopFront() != a || l.PopBack() != d || l.Len() != 1 {
+		t.Error("Unexpected pops")
+	}
+	other.PushBack(a) // a popped value can join another list
+	l.Clear()
+	if !l.IsEmpty() || c.list != nil {
+		t.Error("Expected Clear to unlink every value")
+	}
+	l.PushBack(c)
+	checkIntrusive(t, l)
+	checkIntrusive(t, other)
+}
+
+func TestIntrusive_NoAllocations(t *testing.T) {
+	l := NewIntrusive[timer]()
+	timers := make([]timer, 100)
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := range timers {
+			l.PushBack(&timers[i])
+		}
+		l.MoveToFront(&timers[50])
+		for range timers {
+			l.PopFront()
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("Got %v allocations per run, want 0", allocs)
+	}
+}
+
+func TestIntrusive_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	l := NewIntrusive[timer]()
+	var ref []*timer
+
+	for i := range 20000 {
+		pick := func() (*timer, int) {
+			j := rng.Intn(len(ref))
+			return ref[j], j
+		}
+		switch op := rng.Intn(6); {
+		case op == 0 || len(ref) == 0:
+			x := &timer{id: i}
+			l.PushBack(x)
+			ref = append(ref, x)
+		case op == 1:
+			x := &timer{id: i}
+			l.PushFront(x)
+			ref = slices.Insert(ref, 0, x)
+		case op == 2:
+			mark, j := pick()
+			x := &timer{id: i}
+			l.InsertBefore(x, mark)
+			ref = slices.Insert(ref, j, x)
+		case op == 3:
+			x, j := pick()
+			if !l.Remove(x) {
+				t.Fatalf("Remove(%d) failed", x.id)
+			}
+			ref = slices.Delete(ref, j, j+1)
+		case op == 4:
+			x, j := pick()
+			l.MoveToBack(x)
+			ref = append(slices.Delete(ref, j, j+1), x)
+		default:
+			x, j := pick()
+			mark, _ := pick()
+			l.MoveBefore(x, mark)
+			if x != mark {
+				ref = slices.Delete(ref, j, j+1)
+				ref = slices.Insert(ref, slices.Index(ref, mark), x)
+			}
+		}
+
+		if i%500 == 0 {
+			checkIntrusive(t, l)
+			if got := slices.Collect(l.All()); !slices.Equal(got, ref) {
+				t.Fatalf("Step %d: list order disagrees with the reference", i)
+			}
+		}
+	}
+	if l.Len() != len(ref) {
+		t.Errorf("Len() = %d, want %d", l.Len(), len(ref))
+	}
+}
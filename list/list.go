@@ -0,0 +1,205 @@
+// Package list provides a generic doubly linked list whose insertions return element handles,
+// for O(1) removal and reordering.
+package list
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// List is a thread-safe doubly linked list. Every insertion returns an Element handle,
+// which allows removing or moving the element in O(1), e.g. to cancel a queued entry or to mark a cache entry as used.
+//
+// The list guards its structure; the Value of its elements is the caller's to synchronize.
+type List[T any] struct {
+	mu    sync.Mutex
+	items *UnsyncList[T]
+}
+
+var _ collection.Collection[int] = (*List[int])(nil)
+
+// New returns a new empty List.
+func New[T any]() *List[T] {
+	return &List[T]{items: NewUnsync[T]()}
+}
+
+// NewWithArena returns a new empty List allocating its elements in chunks of chunkSize; see NewUnsyncWithArena.
+// It panics if chunkSize is less than 1.
+func NewWithArena[T any](chunkSize int) *List[T] {
+	return &List[T]{items: NewUnsyncWithArena[T](chunkSize)}
+}
+
+// PushFront adds v at the front of the list and returns its element.
+func (l *List[T]) PushFront(v T) *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.PushFront(v)
+}
+
+// PushBack adds v at the back of the list and returns its element.
+func (l *List[T]) PushBack(v T) *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.PushBack(v)
+}
+
+// InsertBefore adds v just before mark and returns its element.
+// Returns nil without adding v if mark is not an element of the list.
+func (l *List[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.InsertBefore(v, mark)
+}
+
+// InsertAfter adds v just after mark and returns its element.
+// Returns nil without adding v if mark is not an element of the list.
+func (l *List[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.InsertAfter(v, mark)
+}
+
+// Remove removes e from the list and returns its value.
+// Returns (zero-value, false) if e is not an element of the list, for instance because it was already removed.
+func (l *List[T]) Remove(e *Element[T]) (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.Remove(e)
+}
+
+// MoveToFront moves e to the front of the list. Returns false if e is not an element of the list.
+func (l *List[T]) MoveToFront(e *Element[T]) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.MoveToFront(e)
+}
+
+// MoveToBack moves e to the back of the list. Returns false if e is not an element of the list.
+func (l *List[T]) MoveToBack(e *Element[T]) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.MoveToBack(e)
+}
+
+// MoveBefore moves e just before mark. Returns false if either is not an element of the list.
+func (l *List[T]) MoveBefore(e, mark *Element[T]) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.MoveBefore(e, mark)
+}
+
+// MoveAfter moves e just after mark. Returns false if either is not an element of the list.
+func (l *List[T]) MoveAfter(e, mark *Element[T]) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.MoveAfter(e, mark)
+}
+
+// Front returns the first element, or nil if the list is empty.
+func (l *List[T]) Front() *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.Front()
+}
+
+// Back returns the last element, or nil if the list is empty.
+func (l *List[T]) Back() *Element[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.Back()
+}
+
+// PopFront removes and returns the first value.
+// Returns (zero-value, false) if the list is empty.
+func (l *List[T]) PopFront() (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.PopFront()
+}
+
+// PopBack removes and returns the last value.
+// Returns (zero-value, false) if the list is empty.
+func (l *List[T]) PopBack() (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.PopBack()
+}
+
+// All returns an iterator over a snapshot of the values from front to back.
+func (l *List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range l.Values() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over a snapshot of the values from back to front.
+func (l *List[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		values := l.Values()
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Elements returns an iterator over a snapshot of the elements from front to back.
+// The list may be modified during the iteration; elements removed in the meantime are still visited.
+func (l *List[T]) Elements() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		l.mu.Lock()
+		elements := make([]*Element[T], 0, l.items.Len())
+		for e := range l.items.Elements() {
+			elements = append(elements, e)
+		}
+		l.mu.Unlock()
+
+		for _, e := range elements {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns the values from front to back as a new slice.
+func (l *List[T]) Values() []T {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.Values()
+}
+
+// IsEmpty returns true if the list has no elements.
+func (l *List[T]) IsEmpty() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.IsEmpty()
+}
+
+// Len returns the current number of elements in the list.
+func (l *List[T]) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.items.Len()
+}
+
+// Clear removes all elements from the list. Their handles become invalid, as if each had been removed.
+func (l *List[T]) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.items.Clear()
+}
+
+// Clone returns a copy of the list with new elements.
+func (l *List[T]) Clone() *List[T] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return &List[T]{items: l.items.Clone()}
+}
@@ -0,0 +1,75 @@
+package list
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestList_Basic(t *testing.T) {
+	l := New[string]()
+	b := l.PushBack("b")
+	a := l.PushFront("a")
+	c := l.InsertAfter("c", b)
+	if got := l.Values(); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Values() = %v", got)
+	}
+	if l.Front() != a || l.Back() != c || l.Len() != 3 {
+		t.Error("Unexpected ends")
+	}
+
+	l.MoveToBack(a)
+	l.MoveBefore(c, b)
+	if got := slices.Collect(l.All()); !slices.Equal(got, []string{"c", "b", "a"}) {
+		t.Errorf("All() = %v", got)
+	}
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Backward() = %v", got)
+	}
+
+	// Cancel entries while iterating over a snapshot.
+	for e := range l.Elements() {
+		if e.Value != "b" {
+			l.Remove(e)
+		}
+	}
+	if got := l.Values(); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("Values() after removals = %v", got)
+	}
+	if _, ok := l.Remove(a); ok {
+		t.Error("Expected a second Remove to fail")
+	}
+
+	c2 := l.Clone()
+	l.Clear()
+	if !l.IsEmpty() || c2.Len() != 1 {
+		t.Error("Expected Clear to empty only the original")
+	}
+	if v, ok := c2.PopBack(); !ok || v != "b" {
+		t.Errorf("PopBack() = %q, %v", v, ok)
+	}
+}
+
+func TestList_Concurrency(t *testing.T) {
+	l := New[int]()
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				e := l.PushBack(g*1000 + i)
+				l.MoveToFront(e)
+				if i%2 == 0 {
+					l.Remove(e)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if l.Len() != 8*250 {
+		t.Errorf("Len() = %d, want %d", l.Len(), 8*250)
+	}
+	checkInvariants(t, l.items)
+}
@@ -0,0 +1,300 @@
+package list
+
+import (
+	"iter"
+
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/internal/arena"
+)
+
+// Element is a handle to an element of a list, returned when it is added. It stays valid until the element
+// is removed, giving O(1) Remove and moves without searching the list.
+type Element[T any] struct {
+	// Value is the value stored in the element. The list never reads or writes it after insertion,
+	// so with a List, access to it must be synchronized by the caller.
+	Value T
+
+	next, prev *Element[T]
+	list       *UnsyncList[T] // nil once removed
+}
+
+// Next returns the next element, or nil if e is the last element or has been removed.
+// It must not be used on the elements of a List while the list may be modified; use Elements instead.
+func (e *Element[T]) Next() *Element[T] {
+	if e.list != nil && e.next != &e.list.root {
+		return e.next
+	}
+	return nil
+}
+
+// Prev returns the previous element, or nil if e is the first element or has been removed.
+// Like Next, it is meant for UnsyncList.
+func (e *Element[T]) Prev() *Element[T] {
+	if e.list != nil && e.prev != &e.list.root {
+		return e.prev
+	}
+	return nil
+}
+
+// UnsyncList is a doubly linked list without any locking.
+// It is intended for single-goroutine use, or as a building block guarded by the mutex of its owner
+// (e.g. an LRU cache), where the mutex of List adds overhead for no benefit.
+// It must not be used concurrently from multiple goroutines.
+type UnsyncList[T any] struct {
+	root     Element[T] // sentinel: root.next is the front, root.prev the back
+	len      int
+	elements *arena.Arena[Element[T]] // nil unless created with NewUnsyncWithArena
+}
+
+var _ collection.Collection[int] = (*UnsyncList[int])(nil)
+
+// NewUnsync returns a new empty UnsyncList.
+func NewUnsync[T any]() *UnsyncList[T] {
+	l := &UnsyncList[T]{}
+	l.root.next, l.root.prev = &l.root, &l.root
+	return l
+}
+
+// NewUnsyncWithArena returns a new empty UnsyncList allocating its elements in chunks of chunkSize,
+// which cuts allocations and garbage collection work for lists of millions of short-lived elements.
+// Removed elements are not reused, so that their handles stay safe to pass to Remove, and their memory
+// is only released by Clear. It panics if chunkSize is less than 1.
+func NewUnsyncWithArena[T any](chunkSize int) *UnsyncList[T] {
+	l := NewUnsync[T]()
+	l.elements = arena.New[Element[T]](chunkSize)
+	return l
+}
+
+// PushFront adds v at the front of the list and returns its element.
+func (l *UnsyncList[T]) PushFront(v T) *Element[T] {
+	return l.insert(l.newElement(v), &l.root)
+}
+
+// PushBack adds v at the back of the list and returns its element.
+func (l *UnsyncList[T]) PushBack(v T) *Element[T] {
+	return l.insert(l.newElement(v), l.root.prev)
+}
+
+// InsertBefore adds v just before mark and returns its element.
+// Returns nil without adding v if mark is not an element of the list.
+func (l *UnsyncList[T]) InsertBefore(v T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(l.newElement(v), mark.prev)
+}
+
+// InsertAfter adds v just after mark and returns its element.
+// Returns nil without adding v if mark is not an element of the list.
+func (l *UnsyncList[T]) InsertAfter(v T, mark *Element[T]) *Element[T] {
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(l.newElement(v), mark)
+}
+
+// Remove removes e from the list and returns its value.
+// Returns (zero-value, false) if e is not an element of the list, for instance because it was already removed,
+// so removing an element twice is harmless.
+func (l *UnsyncList[T]) Remove(e *Element[T]) (T, bool) {
+	if e.list != l {
+		var zero T
+		return zero, false
+	}
+	l.unlink(e)
+	return e.Value, true
+}
+
+// MoveToFront moves e to the front of the list. Returns false if e is not an element of the list.
+func (l *UnsyncList[T]) MoveToFront(e *Element[T]) bool {
+	if e.list != l {
+		return false
+	}
+	l.move(e, &l.root)
+	return true
+}
+
+// MoveToBack moves e to the back of the list. Returns false if e is not an element of the list.
+func (l *UnsyncList[T]) MoveToBack(e *Element[T]) bool {
+	if e.list != l {
+		return false
+	}
+	l.move(e, l.root.prev)
+	return true
+}
+
+// MoveBefore moves e just before mark. Returns false if either is not an element of the list.
+func (l *UnsyncList[T]) MoveBefore(e, mark *Element[T]) bool {
+	if e.list != l || mark.list != l {
+		return false
+	}
+	if e != mark {
+		l.move(e, mark.prev)
+	}
+	return true
+}
+
+// MoveAfter moves e just after mark. Returns false if either is not an element of the list.
+func (l *UnsyncList[T]) MoveAfter(e, mark *Element[T]) bool {
+	if e.list != l || mark.list != l {
+		return false
+	}
+	l.move(e, mark)
+	return true
+}
+
+// Front returns the first element, or nil if the list is empty.
+func (l *UnsyncList[T]) Front() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.next
+}
+
+// Back returns the last element, or nil if the list is empty.
+func (l *UnsyncList[T]) Back() *Element[T] {
+	if l.len == 0 {
+		return nil
+	}
+	return l.root.prev
+}
+
+// PopFront removes and returns the first value.
+// Returns (zero-value, false) if the list is empty.
+func (l *UnsyncList[T]) PopFront() (T, bool) {
+	if l.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return l.Remove(l.root.next)
+}
+
+// PopBack removes and returns the last value.
+// Returns (zero-value, false) if the list is empty.
+func (l *UnsyncList[T]) PopBack() (T, bool) {
+	if l.len == 0 {
+		var zero T
+		return zero, false
+	}
+	return l.Remove(l.root.prev)
+}
+
+// All returns an iterator over the values from front to back.
+// The element being visited may be removed during the iteration.
+func (l *UnsyncList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range l.Elements() {
+			if !yield(e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values from back to front.
+// The element being visited may be removed during the iteration.
+func (l *UnsyncList[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := l.root.prev; e != &l.root; {
+			prev := e.prev
+			if !yield(e.Value) {
+				return
+			}
+			e = prev
+		}
+	}
+}
+
+// Elements returns an iterator over the elements from front to back.
+// The element being visited may be removed during the iteration, e.g. to cancel matching entries.
+func (l *UnsyncList[T]) Elements() iter.Seq[*Element[T]] {
+	return func(yield func(*Element[T]) bool) {
+		for e := l.root.next; e != &l.root; {
+			next := e.next
+			if !yield(e) {
+				return
+			}
+			e = next
+		}
+	}
+}
+
+// Values returns the values from front to back as a new slice.
+func (l *UnsyncList[T]) Values() []T {
+	values := make([]T, 0, l.len)
+	for e := l.root.next; e != &l.root; e = e.next {
+		values = append(values, e.Value)
+	}
+	return values
+}
+
+// IsEmpty returns true if the list has no elements.
+func (l *UnsyncList[T]) IsEmpty() bool {
+	return l.len == 0
+}
+
+// Len returns the current number of elements in the list.
+func (l *UnsyncList[T]) Len() int {
+	return l.len
+}
+
+// Clear removes all elements from the list. Their handles become invalid, as if each had been removed.
+// It runs in O(n).
+func (l *UnsyncList[T]) Clear() {
+	for e := l.root.next; e != &l.root; {
+		next := e.next
+		e.next, e.prev, e.list = nil, nil, nil
+		e = next
+	}
+	l.root.next, l.root.prev = &l.root, &l.root
+	l.len = 0
+	l.elements.Reset()
+}
+
+// Clone returns a copy of the list with new elements, in an arena of its own if the list uses one.
+func (l *UnsyncList[T]) Clone() *UnsyncList[T] {
+	c := NewUnsync[T]()
+	if l.elements != nil {
+		c.elements = arena.New[Element[T]](l.elements.ChunkSize())
+	}
+	for e := l.root.next; e != &l.root; e = e.next {
+		c.PushBack(e.Value)
+	}
+	return c
+}
+
+func (l *UnsyncList[T]) newElement(v T) *Element[T] {
+	e := l.elements.Alloc()
+	e.Value = v
+	return e
+}
+
+// insert links e after at and returns it.
+func (l *UnsyncList[T]) insert(e, at *Element[T]) *Element[T] {
+	e.prev, e.next = at, at.next
+	at.next.prev = e
+	at.next = e
+	e.list = l
+	l.len++
+	return e
+}
+
+// unlink removes e from the list, keeping its value.
+func (l *UnsyncList[T]) unlink(e *Element[T]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.next, e.prev, e.list = nil, nil, nil
+	l.len--
+}
+
+// move relinks e after at.
+func (l *UnsyncList[T]) move(e, at *Element[T]) {
+	if e == at {
+		return
+	}
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev, e.next = at, at.next
+	at.next.prev = e
+	at.next = e
+}
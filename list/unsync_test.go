@@ -0,0 +1,199 @@
+package list
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkInvariants verifies the links in both directions, element ownership and the length.
+func checkInvariants[T any](t *testing.T, l *UnsyncList[T]) {
+	t.Helper()
+	n := 0
+	for e := l.root.next; e != &l.root; e = e.next {
+		if e.list != l {
+			t.Fatal("Element does not belong to its list")
+		}
+		if e.next.prev != e || e.prev.next != e {
+			t.Fatal("Element links are inconsistent")
+		}
+		n++
+	}
+	if n != l.len {
+		t.Fatalf("List links %d elements but Len is %d", n, l.len)
+	}
+}
+
+func TestUnsyncList_Basic(t *testing.T) {
+	l := NewUnsync[int]()
+	if !l.IsEmpty() || l.Front() != nil || l.Back() != nil {
+		t.Error("Expected new list to be empty")
+	}
+	if _, ok := l.PopFront(); ok {
+		t.Error("Expected PopFront of an empty list to fail")
+	}
+
+	two := l.PushBack(2)
+	one := l.PushFront(1)
+	four := l.PushBack(4)
+	three := l.InsertBefore(3, four)
+	five := l.InsertAfter(5, four)
+	if got := l.Values(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Values() = %v", got)
+	}
+	if l.Front() != one || l.Back() != five || one.Next() != two || three.Prev() != two || five.Next() != nil || one.Prev() != nil {
+		t.Error("Unexpected links")
+	}
+
+	if v, ok := l.Remove(three); !ok || v != 3 {
+		t.Errorf("Remove() = %d, %v", v, ok)
+	}
+	if _, ok := l.Remove(three); ok {
+		t.Error("Expected a second Remove to fail")
+	}
+	if l.InsertAfter(6, three) != nil || l.MoveToFront(three) || three.Next() != nil {
+		t.Error("Expected a removed element to be rejected")
+	}
+
+	l.MoveToFront(five)
+	l.MoveToBack(one)
+	if got := l.Values(); !slices.Equal(got, []int{5, 2, 4, 1}) {
+		t.Errorf("Values() after moves = %v", got)
+	}
+	l.MoveBefore(one, two)
+	l.MoveAfter(five, four)
+	l.MoveAfter(five, five)
+	if got := l.Values(); !slices.Equal(got, []int{1, 2, 4, 5}) {
+		t.Errorf("Values() after relative moves = %v", got)
+	}
+	if got := slices.Collect(l.Backward()); !slices.Equal(got, []int{5, 4, 2, 1}) {
+		t.Errorf("Backward() = %v", got)
+	}
+	checkInvariants(t, l)
+
+	other := NewUnsync[int]()
+	if other.MoveToFront(one) || other.InsertBefore(0, one) != nil {
+		t.Error("Expected an element of another list to be rejected")
+	}
+
+	c := l.Clone()
+	if v, _ := l.PopFront(); v != 1 {
+		t.Errorf("PopFront() = %d", v)
+	}
+	if v, _ := l.PopBack(); v != 5 {
+		t.Errorf("PopBack() = %d", v)
+	}
+	if got := c.Values(); !slices.Equal(got, []int{1, 2, 4, 5}) {
+		t.Errorf("Clone() = %v", got)
+	}
+
+	l.Clear()
+	if !l.IsEmpty() || two.Next() != nil {
+		t.Error("Expected Clear to empty the list")
+	}
+	if _, ok := l.Remove(two); ok {
+		t.Error("Expected a cleared element to be rejected")
+	}
+	checkInvariants(t, l)
+}
+
+func TestUnsyncList_RemoveWhileIterating(t *testing.T) {
+	l := NewUnsync[int]()
+	for i := range 10 {
+		l.PushBack(i)
+	}
+	for e := range l.Elements() {
+		if e.Value%2 == 1 {
+			l.Remove(e)
+		}
+	}
+	if got := l.Values(); !slices.Equal(got, []int{0, 2, 4, 6, 8}) {
+		t.Errorf("Values() = %v", got)
+	}
+	checkInvariants(t, l)
+}
+
+func TestUnsyncList_Random(t *testing.T) {
+	t.Run("Heap", func(t *testing.T) { testRandom(t, NewUnsync[int]()) })
+	t.Run("Arena", func(t *testing.T) { testRandom(t, NewUnsyncWithArena[int](64)) })
+}
+
+func testRandom(t *testing.T, l *UnsyncList[int]) {
+	rng := rand.New(rand.NewSource(1))
+	var ref []*Element[int] // elements in list order
+
+	for i := range 20000 {
+		pick := func() (*Element[int], int) {
+			j := rng.Intn(len(ref))
+			return ref[j], j
+		}
+		switch op := rng.Intn(6); {
+		case op == 0 || len(ref) == 0:
+			ref = append(ref, l.PushBack(i))
+		case op == 1:
+			ref = slices.Insert(ref, 0, l.PushFront(i))
+		case op == 2:
+			mark, j := pick()
+			ref = slices.Insert(ref, j, l.InsertBefore(i, mark))
+		case op == 3:
+			e, j := pick()
+			if v, ok := l.Remove(e); !ok || v != e.Value {
+				t.Fatalf("Remove() = %d, %v", v, ok)
+			}
+			ref = slices.Delete(ref, j, j+1)
+		case op == 4:
+			e, j := pick()
+			l.MoveToFront(e)
+			ref = slices.Insert(slices.Delete(ref, j, j+1), 0, e)
+		default:
+			e, j := pick()
+			mark, _ := pick()
+			l.MoveAfter(e, mark)
+			if e != mark {
+				ref = slices.Delete(ref, j, j+1)
+				k := slices.Index(ref, mark)
+				ref = slices.Insert(ref, k+1, e)
+			}
+		}
+
+		if i%500 == 0 {
+			checkInvariants(t, l)
+			var got []*Element[int]
+			for e := range l.Elements() {
+				got = append(got, e)
+			}
+			if !slices.Equal(got, ref) {
+				t.Fatalf("Step %d: list order disagrees with the reference", i)
+			}
+		}
+	}
+	if l.Len() != len(ref) {
+		t.Errorf("Len() = %d, want %d", l.Len(), len(ref))
+	}
+}
+
+func TestUnsyncList_Arena(t *testing.T) {
+	l := NewUnsyncWithArena[int](4)
+	var es []*Element[int]
+	for i := range 10 {
+		es = append(es, l.PushBack(i))
+	}
+	l.Remove(es[2])
+	if e := l.PushBack(10); e == es[2] {
+		t.Error("Expected removed elements not to be reused")
+	}
+	if _, ok := l.Remove(es[2]); ok {
+		t.Error("Expected a removed handle to stay removed")
+	}
+
+	c := l.Clone()
+	l.Clear()
+	if _, ok := l.Remove(es[5]); ok || l.Len() != 0 {
+		t.Error("Expected handles to be invalid after Clear()")
+	}
+	l.PushBack(1)
+	checkInvariants(t, l)
+	if !slices.Equal(c.Values(), []int{0, 1, 3, 4, 5, 6, 7, 8, 9, 10}) || !slices.Equal(l.Values(), []int{1}) {
+		t.Errorf("Values() = %v and %v", c.Values(), l.Values())
+	}
+}
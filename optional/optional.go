@@ -2,8 +2,9 @@ package data_structures
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
+
+	"github.com/dullkingsman/kozo/codec"
 )
 
 // Optional - Optional[T] represents an optional value of type T.
@@ -46,6 +47,11 @@ func Some[T any](v T) Optional[T] {
 	return Optional[T]{value: &v, nonEmpty: true}
 }
 
+// Null creates an Optional that is present but null, i.e. Some(null), such as a column set to NULL.
+func Null[T any]() Optional[T] {
+	return Optional[T]{value: nil, nonEmpty: true}
+}
+
 // None creates an empty Optional of type T.
 func None[T any]() Optional[T] {
 	return Optional[T]{value: nil, nonEmpty: false}
@@ -67,9 +73,13 @@ func None[T any]() Optional[T] {
 
 // MarshalJSON converts Optional[T] to JSON.
 // - None → gets caught by standard JSON marshalling because of the omitzero tag since this will only be run after go 1.24
-// - Some(value) → normal JSON of value
+// - Some(value) → value encoded with the codec registered for T (plain JSON by default)
 func (o Optional[T]) MarshalJSON() ([]byte, error) {
-	return json.Marshal(o.value)
+	if o.value == nil {
+		return []byte("null"), nil
+	}
+
+	return codec.Marshal(*o.value)
 }
 
 // UnmarshalJSON converts JSON into Optional[T].
@@ -89,7 +99,7 @@ func (o *Optional[T]) UnmarshalJSON(data []byte) error {
 
 	// Attempt to unmarshal normal value → Some(value)
 	var v T
-	if err := json.Unmarshal(data, &v); err != nil {
+	if err := codec.Unmarshal(data, &v); err != nil {
 		return fmt.Errorf("cannot unmarshal Optional: %w", err)
 	}
 
@@ -59,6 +59,21 @@ func TestSome(t *testing.T) {
 	}
 }
 
+func TestNull(t *testing.T) {
+	opt := Null[int]()
+	if !opt.IsSome() || !opt.IsNull() {
+		t.Error("Expected Null() to be Some(null)")
+	}
+
+	data, err := json.Marshal(opt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "null" {
+		t.Errorf("Expected null, got %s", data)
+	}
+}
+
 func TestNone(t *testing.T) {
 	tests := []struct {
 		name string
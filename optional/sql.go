@@ -0,0 +1,39 @@
+package data_structures
+
+import (
+	"database/sql"
+	"database/sql/driver"
+)
+
+// =========================
+// database/sql Support
+// =========================
+
+// Scan implements sql.Scanner, so an Optional can be scanned from a column directly.
+// - NULL → Some(nil)
+// - value → Some(value), converted to T as database/sql converts scan destinations
+// A column that is not selected is never scanned, leaving the Optional None.
+func (o *Optional[T]) Scan(src any) error {
+	var n sql.Null[T]
+	if err := n.Scan(src); err != nil {
+		return err
+	}
+
+	o.nonEmpty = true
+	o.value = nil
+	if n.Valid {
+		o.value = &n.V
+	}
+
+	return nil
+}
+
+// Value implements driver.Valuer, so an Optional can be passed as a query argument.
+// None and Some(nil) both bind as NULL; check IsNone first where None should leave a column untouched.
+func (o Optional[T]) Value() (driver.Value, error) {
+	if o.value == nil {
+		return nil, nil
+	}
+
+	return driver.DefaultParameterConverter.ConvertValue(*o.value)
+}
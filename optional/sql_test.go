@@ -0,0 +1,70 @@
+package data_structures
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+)
+
+var (
+	_ sql.Scanner   = (*Optional[int])(nil)
+	_ driver.Valuer = Optional[int]{}
+)
+
+func TestOptional_Scan(t *testing.T) {
+	var n Optional[int64]
+	if err := n.Scan(int64(7)); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := n.Unwrap(); !ok || v != 7 {
+		t.Errorf("Expected Some(7), got %v", n)
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatal(err)
+	}
+	if !n.IsSome() || !n.IsNull() {
+		t.Errorf("Expected Some(null), got %v", n)
+	}
+
+	var s Optional[string]
+	if err := s.Scan([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := s.Unwrap(); v != "hello" {
+		t.Errorf("Expected Some(hello), got %v", s)
+	}
+
+	var i Optional[int]
+	if err := i.Scan("not a number"); err == nil {
+		t.Error("Expected an error for an unconvertible value")
+	}
+}
+
+func TestOptional_Value(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		opt      driver.Valuer
+		expected driver.Value
+	}{
+		{"None", None[int](), nil},
+		{"Some(null)", Null[int](), nil},
+		{"int", Some(42), int64(42)},
+		{"string", Some("a"), "a"},
+		{"time", Some(now), now},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.opt.Value()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if v != tt.expected {
+				t.Errorf("Value() = %#v, want %#v", v, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/dullkingsman/kozo/codec"
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// OrderedMap is a thread-safe map that remembers the order in which keys were first set.
+// Get, Set and At are O(1); Delete is O(n) since it keeps positions dense for index access.
+// The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	mu      sync.RWMutex
+	keys    []K
+	entries map[K]*entry[V]
+}
+
+var (
+	_ codec.Snapshotter        = (*OrderedMap[int, int])(nil)
+	_ collection.Map[int, int] = (*OrderedMap[int, int])(nil)
+)
+
+type entry[V any] struct {
+	value V
+	index int
+}
+
+// New creates an empty OrderedMap.
+func New[K comparable, V any]() *OrderedMap[K, V] {
+	return NewWithCapacity[K, V](0)
+}
+
+// NewWithCapacity creates an empty OrderedMap with room for capacity keys.
+func NewWithCapacity[K comparable, V any](capacity int) *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		keys:    make([]K, 0, capacity),
+		entries: make(map[K]*entry[V], capacity),
+	}
+}
+
+// Set sets the value of key. A new key is appended at the end, while an existing key keeps its position.
+// It returns true if the key was new.
+func (m *OrderedMap[K, V]) Set(key K, value V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.set(key, value)
+}
+
+func (m *OrderedMap[K, V]) set(key K, value V) bool {
+	if e, ok := m.entries[key]; ok {
+		e.value = value
+		return false
+	}
+	if m.entries == nil {
+		m.entries = make(map[K]*entry[V])
+	}
+	m.entries[key] = &entry[V]{value: value, index: len(m.keys)}
+	m.keys = append(m.keys, key)
+	return true
+}
+
+// Get returns the value of key. Returns (zero-value, false) if the key is not present.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if e, ok := m.entries[key]; ok {
+		return e.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has returns true if the key is present.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	_, ok := m.entries[key]
+	return ok
+}
+
+// Delete removes key, shifting the keys after it one position forward.
+// It returns true if the key was present.
+func (m *OrderedMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return false
+	}
+	delete(m.entries, key)
+
+	copy(m.keys[e.index:], m.keys[e.index+1:])
+	var zero K
+	m.keys[len(m.keys)-1] = zero
+	m.keys = m.keys[:len(m.keys)-1]
+	for i := e.index; i < len(m.keys); i++ {
+		m.entries[m.keys[i]].index = i
+	}
+	return true
+}
+
+// At returns the key and value at position i in insertion order.
+// Returns (zero-value, zero-value, false) if i is out of range.
+func (m *OrderedMap[K, V]) At(i int) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if i < 0 || i >= len(m.keys) {
+		var (
+			zeroK K
+			zeroV V
+		)
+		return zeroK, zeroV, false
+	}
+	key := m.keys[i]
+	return key, m.entries[key].value, true
+}
+
+// IndexOf returns the position of key in insertion order, or -1 if it is not present.
+func (m *OrderedMap[K, V]) IndexOf(key K) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if e, ok := m.entries[key]; ok {
+		return e.index
+	}
+	return -1
+}
+
+// Len returns the number of keys.
+func (m *OrderedMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.keys)
+}
+
+// IsEmpty returns true if the map has no keys.
+func (m *OrderedMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Clear removes all keys.
+func (m *OrderedMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys = make([]K, 0)
+	m.entries = make(map[K]*entry[V])
+}
+
+// Keys returns the keys in insertion order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := make([]K, len(m.keys))
+	copy(res, m.keys)
+	return res
+}
+
+// Values returns the values in insertion order of their keys.
+func (m *OrderedMap[K, V]) Values() []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	res := make([]V, len(m.keys))
+	for i, key := range m.keys {
+		res[i] = m.entries[key].value
+	}
+	return res
+}
+
+// All returns an iterator over a snapshot of the key-value pairs in insertion order.
+// The snapshot is taken when iteration starts, so the map may be modified while iterating.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		keys, values := m.snapshot()
+		for i, key := range keys {
+			if !yield(key, values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward is like All but iterates from the most recently added key.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		keys, values := m.snapshot()
+		for i := len(keys) - 1; i >= 0; i-- {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (m *OrderedMap[K, V]) snapshot() ([]K, []V) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]K, len(m.keys))
+	values := make([]V, len(m.keys))
+	for i, key := range m.keys {
+		keys[i] = key
+		values[i] = m.entries[key].value
+	}
+	return keys, values
+}
+
+// Clone returns a new OrderedMap with the same keys, values and order.
+//
+// Note: For pointer or reference types (slices, maps), only the references are copied.
+func (m *OrderedMap[K, V]) Clone() *OrderedMap[K, V] {
+	keys, values := m.snapshot()
+	res := NewWithCapacity[K, V](len(keys))
+	for i, key := range keys {
+		res.set(key, values[i])
+	}
+	return res
+}
+
+// MarshalJSON encodes the map as a JSON object with its keys in insertion order,
+// encoding each value with the codec registered for V.
+// Keys follow encoding/json: strings, integers and encoding.TextMarshaler implementations.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	keys, values := m.snapshot()
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := encodeKey(key)
+		if err != nil {
+			return nil, err
+		}
+		quoted, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := codec.Marshal(values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(quoted)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON replaces the contents of the map with the members of a JSON object in document order,
+// decoding each value with the codec registered for V. A repeated key keeps its first position and last value.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil {
+		return err
+	} else if tok != json.Delim('{') {
+		return fmt.Errorf("orderedmap: expected a JSON object, got %v", tok)
+	}
+
+	res := New[K, V]()
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, err := decodeKey[K](tok.(string))
+		if err != nil {
+			return err
+		}
+
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+		var value V
+		if err := codec.Unmarshal(raw, &value); err != nil {
+			return err
+		}
+		res.set(key, value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys, m.entries = res.keys, res.entries
+	return nil
+}
+
+// Snapshot encodes the map as its JSON object in a snapshot envelope of kind "orderedmap";
+// see codec.Snapshotter.
+func (m *OrderedMap[K, V]) Snapshot() ([]byte, error) {
+	payload, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return codec.EncodeSnapshot("orderedmap", payload), nil
+}
+
+// Restore replaces the contents of the map with those of a snapshot made by Snapshot, keys in order.
+func (m *OrderedMap[K, V]) Restore(data []byte) error {
+	payload, err := codec.DecodeSnapshot("orderedmap", data)
+	if err != nil {
+		return err
+	}
+	if err := m.UnmarshalJSON(payload); err != nil {
+		return fmt.Errorf("%w: %v", codec.ErrInvalidSnapshot, err)
+	}
+	return nil
+}
+
+// encodeKey converts a key to a JSON object member name, as encoding/json does for map keys.
+func encodeKey[K comparable](key K) (string, error) {
+	if tm, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		return string(text), err
+	}
+
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	}
+	return "", fmt.Errorf("orderedmap: unsupported key type %T", key)
+}
+
+// decodeKey converts a JSON object member name back to a key.
+func decodeKey[K comparable](name string) (K, error) {
+	var key K
+	if tu, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		return key, tu.UnmarshalText([]byte(name))
+	}
+
+	v := reflect.ValueOf(&key).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(name)
+		return key, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(name, 10, v.Type().Bits())
+		v.SetInt(n)
+		return key, err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(name, 10, v.Type().Bits())
+		v.SetUint(n)
+		return key, err
+	}
+	return key, fmt.Errorf("orderedmap: unsupported key type %T", key)
+}
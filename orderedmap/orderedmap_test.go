@@ -0,0 +1,205 @@
+package orderedmap
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dullkingsman/kozo/codec"
+)
+
+func TestOrderedMap_Basic(t *testing.T) {
+	m := New[string, int]()
+	if !m.IsEmpty() {
+		t.Error("Expected new map to be empty")
+	}
+
+	if !m.Set("b", 1) || !m.Set("a", 2) || !m.Set("c", 3) {
+		t.Error("Expected new keys to be reported as new")
+	}
+	if m.Set("b", 10) {
+		t.Error("Expected an existing key not to be reported as new")
+	}
+
+	if v, ok := m.Get("b"); !ok || v != 10 {
+		t.Errorf("Get(b) = %d, %v; want 10, true", v, ok)
+	}
+	if _, ok := m.Get("z"); ok || m.Has("z") {
+		t.Error("Expected a missing key not to be found")
+	}
+	if got := m.Keys(); !slices.Equal(got, []string{"b", "a", "c"}) {
+		t.Errorf("Keys() = %v", got)
+	}
+	if got := m.Values(); !slices.Equal(got, []int{10, 2, 3}) {
+		t.Errorf("Values() = %v", got)
+	}
+
+	if k, v, ok := m.At(1); !ok || k != "a" || v != 2 {
+		t.Errorf("At(1) = %s, %d, %v", k, v, ok)
+	}
+	if _, _, ok := m.At(3); ok {
+		t.Error("Expected At to fail out of range")
+	}
+
+	if !m.Delete("b") || m.Delete("b") {
+		t.Error("Expected Delete to report presence")
+	}
+	if m.IndexOf("a") != 0 || m.IndexOf("c") != 1 || m.IndexOf("b") != -1 {
+		t.Errorf("Expected positions to shift after Delete, got %v", m.Keys())
+	}
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("Expected Clear to empty the map")
+	}
+}
+
+func TestOrderedMap_ZeroValue(t *testing.T) {
+	var m OrderedMap[string, int]
+	m.Set("a", 1)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Error("Expected the zero value to be usable")
+	}
+}
+
+func TestOrderedMap_Iteration(t *testing.T) {
+	m := New[int, string]()
+	for i, s := range []string{"x", "y", "z"} {
+		m.Set(i*10, s)
+	}
+
+	var forward []int
+	for k, v := range m.All() {
+		forward = append(forward, k)
+		m.Set(k+1, v) // Modifying during iteration is safe.
+	}
+	if !slices.Equal(forward, []int{0, 10, 20}) {
+		t.Errorf("All() = %v", forward)
+	}
+
+	var backward []int
+	for k := range m.Backward() {
+		backward = append(backward, k)
+		if len(backward) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(backward, []int{21, 11}) {
+		t.Errorf("Backward() = %v", backward)
+	}
+
+	c := m.Clone()
+	c.Set(99, "new")
+	if m.Has(99) || !slices.Equal(c.Keys()[:6], m.Keys()) {
+		t.Error("Expected Clone to be independent and keep order")
+	}
+}
+
+func TestOrderedMap_JSON(t *testing.T) {
+	input := `{"zeta":1,"alpha":{"n":2},"mid":null,"alpha":3}`
+	m := New[string, any]()
+	if err := json.Unmarshal([]byte(input), m); err != nil {
+		t.Fatal(err)
+	}
+	if got := m.Keys(); !slices.Equal(got, []string{"zeta", "alpha", "mid"}) {
+		t.Errorf("Keys() = %v", got)
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"zeta":1,"alpha":3,"mid":null}`; string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", data, expected)
+	}
+
+	ints := New[int, bool]()
+	ints.Set(3, true)
+	ints.Set(-1, false)
+	data, err = json.Marshal(ints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"3":true,"-1":false}` {
+		t.Errorf("Marshal mismatch. Got %s", data)
+	}
+	decoded := New[int, bool]()
+	if err := json.Unmarshal(data, decoded); err != nil || !slices.Equal(decoded.Keys(), []int{3, -1}) {
+		t.Errorf("Unmarshal() = %v, %v", decoded.Keys(), err)
+	}
+
+	times := New[time.Time, int]()
+	times.Set(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1)
+	data, err = json.Marshal(times)
+	if err != nil || string(data) != `{"2024-01-01T00:00:00Z":1}` {
+		t.Errorf("Marshal() = %s, %v", data, err)
+	}
+
+	for _, bad := range []string{`[1]`, `{"a":}`, `{"x":1}`} {
+		if err := json.Unmarshal([]byte(bad), New[int, int]()); err == nil {
+			t.Errorf("Expected Unmarshal(%s) to fail", bad)
+		}
+	}
+}
+
+func TestOrderedMap_Snapshot(t *testing.T) {
+	m := New[string, int]()
+	m.Set("c", 3)
+	m.Set("a", 1)
+	m.Set("b", 2)
+
+	data, err := m.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	m2 := New[string, int]()
+	m2.Set("z", 26)
+	if err := m2.Restore(data); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if !slices.Equal(m2.Keys(), []string{"c", "a", "b"}) || !slices.Equal(m2.Values(), []int{3, 1, 2}) {
+		t.Errorf("Expected keys [c a b] with values [3 1 2], got %v %v", m2.Keys(), m2.Values())
+	}
+
+	if err := m2.Restore([]byte(`{"a":1}`)); !errors.Is(err, codec.ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+	}
+	if err := m2.Restore(codec.EncodeSnapshot("orderedmap", []byte(`{"a":1`))); !errors.Is(err, codec.ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot for a bad payload, got %v", err)
+	}
+	if m2.Len() != 3 {
+		t.Errorf("Expected a failed Restore to keep the map, got %v", m2.Keys())
+	}
+}
+
+func TestOrderedMap_Concurrency(t *testing.T) {
+	m := New[int, int]()
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Set(i, i)
+			m.Get(i)
+			if i%2 == 0 {
+				m.Delete(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if m.Len() != 50 {
+		t.Errorf("Len() = %d, want 50", m.Len())
+	}
+	for i, k := range m.Keys() {
+		if m.IndexOf(k) != i {
+			t.Fatalf("IndexOf(%d) = %d, want %d", k, m.IndexOf(k), i)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package page
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dullkingsman/kozo/filter"
+)
+
+// ErrInvalidCursor is returned, possibly wrapped, when a cursor token is malformed
+// or was issued for a different sort.
+var ErrInvalidCursor = errors.New("page: invalid cursor")
+
+// Cursor is the decoded form of an opaque cursor token: the sort of the listing
+// and the values of the sort fields of the last item on the previous page (its keyset).
+type Cursor struct {
+	Sort   filter.SortSpec   `json:"s"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// NewCursor creates a cursor resuming after an item with the given keyset values, one per sort field,
+// where nil stands for NULL.
+func NewCursor(sort filter.SortSpec, values ...any) (Cursor, error) {
+	if len(values) != len(sort) {
+		return Cursor{}, fmt.Errorf("page: %d cursor values for %d sort fields", len(values), len(sort))
+	}
+
+	c := Cursor{Sort: sort, Values: make([]json.RawMessage, len(values))}
+	for i, v := range values {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return Cursor{}, err
+		}
+		c.Values[i] = data
+	}
+	return c, nil
+}
+
+// After creates a cursor resuming after item, taking its keyset values with filter.SortKey.
+func After[T any](sort filter.SortSpec, item T) (Cursor, error) {
+	values, err := filter.SortKey(sort, item)
+	if err != nil {
+		return Cursor{}, err
+	}
+	return NewCursor(sort, values...)
+}
+
+// Encode returns the cursor as an opaque, URL-safe token.
+// Tokens are not signed: clients can forge them, so treat the decoded values as untrusted input.
+func (c Cursor) Encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Decode parses a token written by Encode and checks that it was issued for sort,
+// so that a cursor cannot be replayed against a listing ordered differently.
+func Decode(token string, sort filter.SortSpec) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Cursor{}, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if !c.Sort.Equal(sort) {
+		return Cursor{}, fmt.Errorf("%w: issued for a different sort", ErrInvalidCursor)
+	}
+	if len(c.Values) != len(c.Sort) {
+		return Cursor{}, fmt.Errorf("%w: %d values for %d sort fields", ErrInvalidCursor, len(c.Values), len(c.Sort))
+	}
+	return c, nil
+}
+
+// Value decodes the i-th keyset value into v, which must be a pointer. A NULL value decodes as JSON null.
+func (c Cursor) Value(i int, v any) error {
+	if i < 0 || i >= len(c.Values) {
+		return fmt.Errorf("%w: no value %d", ErrInvalidCursor, i)
+	}
+	if err := json.Unmarshal(c.Values[i], v); err != nil {
+		return fmt.Errorf("%w: value %d: %v", ErrInvalidCursor, i, err)
+	}
+	return nil
+}
+
+// Next builds a cursor page from up to limit+1 items fetched with a lookahead, as Trim does,
+// and sets NextCursor to resume after the last item when there are more.
+func Next[T any](items []T, limit int, sort filter.SortSpec) (Page[T], error) {
+	p := Trim(items, limit)
+	if !p.HasMore || len(p.Items) == 0 {
+		return p, nil
+	}
+
+	c, err := After(sort, p.Items[len(p.Items)-1])
+	if err != nil {
+		return Page[T]{}, err
+	}
+	if p.NextCursor, err = c.Encode(); err != nil {
+		return Page[T]{}, err
+	}
+	return p, nil
+}
@@ -0,0 +1,106 @@
+package page
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dullkingsman/kozo/filter"
+)
+
+type event struct {
+	ID      int        `json:"id"`
+	At      time.Time  `json:"at"`
+	Retried *time.Time `json:"retried"`
+}
+
+var eventSort = filter.SortSpec{{Field: "at", Direction: filter.Desc}, {Field: "retried"}, {Field: "id"}}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	c, err := After(eventSort, event{ID: 7, At: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := c.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An equivalent sort written differently still accepts the token.
+	equivalent := filter.SortSpec{{Field: "at", Direction: filter.Desc}, {Field: "retried", Direction: filter.Asc}, {Field: "id", Nulls: filter.NullsLast}}
+	decoded, err := Decode(token, equivalent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		gotAt      time.Time
+		gotRetried *time.Time
+		gotID      int
+	)
+	if err := decoded.Value(0, &gotAt); err != nil || !gotAt.Equal(at) {
+		t.Errorf("Value(0) = %v, %v", gotAt, err)
+	}
+	if err := decoded.Value(1, &gotRetried); err != nil || gotRetried != nil {
+		t.Errorf("Value(1) = %v, %v; want NULL", gotRetried, err)
+	}
+	if err := decoded.Value(2, &gotID); err != nil || gotID != 7 {
+		t.Errorf("Value(2) = %v, %v", gotID, err)
+	}
+}
+
+func TestDecode_Invalid(t *testing.T) {
+	c, err := NewCursor(eventSort, time.Now(), nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := c.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		token string
+		sort  filter.SortSpec
+	}{
+		{token, filter.SortSpec{{Field: "at"}, {Field: "retried"}, {Field: "id"}}},
+		{token, eventSort[:2]},
+		{"not base64!", eventSort},
+		{"bm90IGpzb24", eventSort},
+	} {
+		if _, err := Decode(tt.token, tt.sort); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Decode(%q, %v) = %v, want ErrInvalidCursor", tt.token, tt.sort, err)
+		}
+	}
+
+	if _, err := NewCursor(eventSort, 1); err == nil {
+		t.Error("Expected NewCursor() to reject a value count mismatch")
+	}
+}
+
+func TestNext(t *testing.T) {
+	events := []event{{ID: 1}, {ID: 2}, {ID: 3}}
+	sort := filter.SortSpec{{Field: "id"}}
+
+	p, err := Next(events, 2, sort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.HasMore || len(p.Items) != 2 || p.NextCursor == "" {
+		t.Fatalf("Next() = %+v", p)
+	}
+
+	c, err := Decode(p.NextCursor, sort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var id int
+	if err := c.Value(0, &id); err != nil || id != 2 {
+		t.Errorf("Expected the cursor to resume after id 2, got %d, %v", id, err)
+	}
+
+	if p, err := Next(events, 3, sort); err != nil || p.HasMore || p.NextCursor != "" {
+		t.Errorf("Expected the last page to have no cursor, got %+v, %v", p, err)
+	}
+}
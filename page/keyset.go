@@ -0,0 +1,86 @@
+package page
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dullkingsman/kozo/filter"
+)
+
+// EncodeKeyset serializes a keyset into an opaque cursor token for sort.
+//
+// K is a struct with one optional.Optional field per sort field, matched by JSON name as in a filter.Spec,
+// and tagged omitzero, for example:
+//
+//	type EventKey struct {
+//		At optional.Optional[time.Time] `json:"at,omitzero"`
+//		ID optional.Optional[int64]     `json:"id,omitzero"`
+//	}
+//
+// Every sort field must be Some, with Some(null) standing for NULL, and no other field may be set,
+// so a token never carries more than the sort needs. Values are encoded with the codec registered for their type.
+func EncodeKeyset[K any](sort filter.SortSpec, key K) (string, error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("page: keyset %T is not a struct: %w", key, err)
+	}
+
+	c := Cursor{Sort: sort, Values: make([]json.RawMessage, len(sort))}
+	for i, f := range sort {
+		value, ok := fields[f.Field]
+		if !ok {
+			return "", fmt.Errorf("page: keyset %T has no value for sort field %q", key, f.Field)
+		}
+		c.Values[i] = value
+		delete(fields, f.Field)
+	}
+	for name := range fields {
+		return "", fmt.Errorf("page: keyset %T sets %q, which is not a sort field", key, name)
+	}
+
+	return c.Encode()
+}
+
+// DecodeKeyset parses a token written by EncodeKeyset, checking that it was issued for sort,
+// and returns its keyset with every sort field set. All failures wrap ErrInvalidCursor,
+// except a K without a field for some sort field, which is a programming error.
+func DecodeKeyset[K any](token string, sort filter.SortSpec) (K, error) {
+	var key K
+
+	c, err := Decode(token, sort)
+	if err != nil {
+		return key, err
+	}
+
+	fields := make(map[string]json.RawMessage, len(c.Sort))
+	for i, f := range c.Sort {
+		fields[f.Field] = c.Values[i]
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return key, err
+	}
+
+	if err := json.Unmarshal(data, &key); err != nil {
+		return key, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	// Every sort field was decoded into Some, so one that is missing now has no field in K.
+	if data, err = json.Marshal(key); err != nil {
+		return key, err
+	}
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return key, err
+	}
+	for _, f := range c.Sort {
+		if _, ok := decoded[f.Field]; !ok {
+			return key, fmt.Errorf("page: keyset %T has no field for sort field %q", key, f.Field)
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,88 @@
+package page
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dullkingsman/kozo/filter"
+	optional "github.com/dullkingsman/kozo/optional"
+)
+
+type eventKey struct {
+	At      optional.Optional[time.Time] `json:"at,omitzero"`
+	Retried optional.Optional[time.Time] `json:"retried,omitzero"`
+	ID      optional.Optional[int]       `json:"id,omitzero"`
+}
+
+func TestKeyset_RoundTrip(t *testing.T) {
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	token, err := EncodeKeyset(eventSort, eventKey{At: optional.Some(at), Retried: optional.Null[time.Time](), ID: optional.Some(42)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := DecodeKeyset[eventKey](token, eventSort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := key.At.Unwrap(); !ok || !v.Equal(at) {
+		t.Errorf("At = %v", key.At)
+	}
+	if !key.Retried.IsSome() || !key.Retried.IsNull() {
+		t.Errorf("Retried = %v, want Some(null)", key.Retried)
+	}
+	if v, ok := key.ID.Unwrap(); !ok || v != 42 {
+		t.Errorf("ID = %v", key.ID)
+	}
+
+	// Tokens are interchangeable with the cursors written by After.
+	c, err := Decode(token, eventSort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var id int
+	if err := c.Value(2, &id); err != nil || id != 42 {
+		t.Errorf("Value(2) = %d, %v", id, err)
+	}
+}
+
+func TestEncodeKeyset_Invalid(t *testing.T) {
+	sort := filter.SortSpec{{Field: "at"}, {Field: "id"}}
+
+	if _, err := EncodeKeyset(sort, eventKey{At: optional.Some(time.Now())}); err == nil {
+		t.Error("Expected an error for a missing sort field value")
+	}
+	if _, err := EncodeKeyset(sort, eventKey{At: optional.Some(time.Now()), ID: optional.Some(1), Retried: optional.Some(time.Now())}); err == nil {
+		t.Error("Expected an error for a value outside the sort")
+	}
+	if _, err := EncodeKeyset(sort, 1); err == nil {
+		t.Error("Expected an error for a non-struct keyset")
+	}
+}
+
+func TestDecodeKeyset_Invalid(t *testing.T) {
+	sort := filter.SortSpec{{Field: "id"}}
+
+	forged, err := NewCursor(sort, "not a number")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := forged.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DecodeKeyset[eventKey](token, sort); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for a mistyped value, got %v", err)
+	}
+	if _, err := DecodeKeyset[eventKey](token, filter.SortSpec{{Field: "at"}}); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for a different sort, got %v", err)
+	}
+
+	other := filter.SortSpec{{Field: "name"}}
+	c, _ := NewCursor(other, "x")
+	token, _ = c.Encode()
+	if _, err := DecodeKeyset[eventKey](token, other); err == nil || errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected a keyset without the sort field to be a programming error, got %v", err)
+	}
+}
@@ -0,0 +1,100 @@
+package page
+
+import (
+	"fmt"
+
+	"github.com/dullkingsman/kozo/dialect"
+	optional "github.com/dullkingsman/kozo/optional"
+)
+
+// Page is one page of a listing. Total is None when counting is skipped, as is usual for cursor pages.
+// NextCursor is empty for offset pages and for the last cursor page.
+type Page[T any] struct {
+	Items      []T                    `json:"items"`
+	Total      optional.Optional[int] `json:"total,omitzero"`
+	HasMore    bool                   `json:"has_more"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+// Limits bounds the page size that clients may request.
+type Limits struct {
+	Default int // used when no limit is requested
+	Max     int // larger limits are reduced to Max; 0 means no maximum
+}
+
+// clamp applies the limits to a requested page size.
+func (l Limits) clamp(limit int) int {
+	if limit <= 0 {
+		limit = l.Default
+	}
+	if l.Max > 0 && limit > l.Max {
+		limit = l.Max
+	}
+	return limit
+}
+
+// OffsetRequest requests the page of Limit items starting after Offset items.
+type OffsetRequest struct {
+	Offset int `json:"offset,omitempty"`
+	Limit  int `json:"limit,omitempty"`
+}
+
+// Normalize returns the request with the limits applied, so that a missing limit becomes l.Default
+// and an excessive one l.Max. It returns an error for a negative offset.
+func (r OffsetRequest) Normalize(l Limits) (OffsetRequest, error) {
+	if r.Offset < 0 {
+		return OffsetRequest{}, fmt.Errorf("page: negative offset %d", r.Offset)
+	}
+	return OffsetRequest{Offset: r.Offset, Limit: l.clamp(r.Limit)}, nil
+}
+
+// ToSQL renders the request as a parameterized "LIMIT ? OFFSET ?" clause and its arguments.
+// SQL Server and Oracle use OFFSET ... FETCH instead; Lookahead fetches one more row than the limit.
+func (r OffsetRequest) ToSQL(d dialect.Dialect) (string, []any) {
+	return "LIMIT " + d.Placeholder(1) + " OFFSET " + d.Placeholder(2), []any{r.Limit, r.Offset}
+}
+
+// Lookahead returns the request with the limit increased by one, so that fetching it tells whether there are more items.
+// Pass the result to Trim with the original limit.
+func (r OffsetRequest) Lookahead() OffsetRequest {
+	return OffsetRequest{Offset: r.Offset, Limit: r.Limit + 1}
+}
+
+// CursorRequest requests the page of Limit items following the one Cursor was issued for;
+// an empty Cursor requests the first page.
+type CursorRequest struct {
+	Cursor string `json:"cursor,omitempty"`
+	Limit  int    `json:"limit,omitempty"`
+}
+
+// Normalize returns the request with the limits applied.
+func (r CursorRequest) Normalize(l Limits) CursorRequest {
+	return CursorRequest{Cursor: r.Cursor, Limit: l.clamp(r.Limit)}
+}
+
+// Offset builds a page from the items fetched for r and the total number of items.
+func Offset[T any](items []T, total int, r OffsetRequest) Page[T] {
+	return Page[T]{
+		Items:   nonNil(items),
+		Total:   optional.Some(total),
+		HasMore: r.Offset+len(items) < total,
+	}
+}
+
+// Trim builds a page from up to limit+1 items fetched with a lookahead: HasMore reports
+// whether the extra item was found, and it is dropped from the page. A negative limit is treated as zero.
+func Trim[T any](items []T, limit int) Page[T] {
+	limit = max(limit, 0)
+	if len(items) > limit {
+		return Page[T]{Items: items[:limit:limit], HasMore: true}
+	}
+	return Page[T]{Items: nonNil(items)}
+}
+
+// nonNil returns items, or an empty slice if it is nil, so that empty pages encode as [].
+func nonNil[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
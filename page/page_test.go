@@ -0,0 +1,88 @@
+package page
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/dullkingsman/kozo/dialect"
+)
+
+func TestOffsetRequest_Normalize(t *testing.T) {
+	limits := Limits{Default: 20, Max: 100}
+	tests := []struct {
+		in, expected OffsetRequest
+	}{
+		{OffsetRequest{}, OffsetRequest{Limit: 20}},
+		{OffsetRequest{Offset: 40, Limit: 10}, OffsetRequest{Offset: 40, Limit: 10}},
+		{OffsetRequest{Limit: 500}, OffsetRequest{Limit: 100}},
+		{OffsetRequest{Limit: -1}, OffsetRequest{Limit: 20}},
+	}
+	for _, tt := range tests {
+		got, err := tt.in.Normalize(limits)
+		if err != nil || got != tt.expected {
+			t.Errorf("Normalize(%+v) = %+v, %v; want %+v", tt.in, got, err, tt.expected)
+		}
+	}
+
+	if _, err := (OffsetRequest{Offset: -1}).Normalize(limits); err == nil {
+		t.Error("Expected an error for a negative offset")
+	}
+	if got := (CursorRequest{Cursor: "x", Limit: 500}).Normalize(limits); got.Limit != 100 || got.Cursor != "x" {
+		t.Errorf("CursorRequest.Normalize() = %+v", got)
+	}
+}
+
+func TestOffsetRequest_ToSQL(t *testing.T) {
+	sql, args := OffsetRequest{Offset: 40, Limit: 20}.ToSQL(dialect.Offset(dialect.PostgreSQL, 2))
+	if sql != "LIMIT $3 OFFSET $4" || !reflect.DeepEqual(args, []any{20, 40}) {
+		t.Errorf("ToSQL() = %q, %v", sql, args)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	p := Offset([]int{3, 4}, 5, OffsetRequest{Offset: 2, Limit: 2})
+	if !p.HasMore || p.Total.UnwrapOr(0) != 5 {
+		t.Errorf("Expected more items after the middle page, got %+v", p)
+	}
+
+	p = Offset([]int{5}, 5, OffsetRequest{Offset: 4, Limit: 2})
+	if p.HasMore {
+		t.Errorf("Expected the last page to have no more items, got %+v", p)
+	}
+}
+
+func TestTrim(t *testing.T) {
+	p := Trim([]int{1, 2, 3}, 2)
+	if !p.HasMore || !reflect.DeepEqual(p.Items, []int{1, 2}) {
+		t.Errorf("Trim() = %+v", p)
+	}
+
+	p = Trim([]int{1, 2}, 2)
+	if p.HasMore || len(p.Items) != 2 {
+		t.Errorf("Trim() = %+v", p)
+	}
+
+	p = Trim([]int{1}, -1)
+	if !p.HasMore || len(p.Items) != 0 {
+		t.Errorf("Expected an empty page with more items for a negative limit, got %+v", p)
+	}
+}
+
+func TestPage_JSON(t *testing.T) {
+	data, err := json.Marshal(Trim[int](nil, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"items":[],"has_more":false}`; string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", data, expected)
+	}
+
+	data, err = json.Marshal(Offset([]int{1}, 1, OffsetRequest{Limit: 10}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"items":[1],"total":1,"has_more":false}`; string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", data, expected)
+	}
+}
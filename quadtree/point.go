@@ -0,0 +1,110 @@
+package quadtree
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/geom"
+)
+
+// PointItem is a point indexed by a PointTree with its value.
+type PointItem[V comparable] struct {
+	Point geom.Point
+	Value V
+}
+
+// PointTree is a thread-safe quadtree of points with values, such as places by their coordinates.
+// It covers a fixed rectangle given at construction, splitting it into quadrants as points accumulate,
+// so that rectangle queries and nearest-neighbor searches only visit the quadrants near their target.
+// Several values may share a point.
+type PointTree[V comparable] struct {
+	mu   sync.RWMutex
+	tree tree[V]
+}
+
+var _ collection.Collection[PointItem[int]] = (*PointTree[int])(nil)
+
+// NewPoint returns a new empty PointTree covering bounds.
+func NewPoint[V comparable](bounds geom.Rect) *PointTree[V] {
+	return &PointTree[V]{tree: newTree[V](bounds)}
+}
+
+// Bounds returns the rectangle covered by the tree.
+func (t *PointTree[V]) Bounds() geom.Rect {
+	return t.tree.root.bounds
+}
+
+// Insert adds v at p. It returns false without adding it if p lies outside the bounds of the tree.
+func (t *PointTree[V]) Insert(p geom.Point, v V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.insert(entry[V]{p.Bounds(), v})
+}
+
+// Remove removes one occurrence of v at p. It returns true if it was present.
+func (t *PointTree[V]) Remove(p geom.Point, v V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.remove(entry[V]{p.Bounds(), v})
+}
+
+// Search returns the points lying in r, boundary included.
+func (t *PointTree[V]) Search(r geom.Rect) []PointItem[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return pointItems(t.tree.search(r, nil))
+}
+
+// Nearest returns the k points nearest to p, nearest first. It returns fewer if the tree holds fewer.
+func (t *PointTree[V]) Nearest(p geom.Point, k int) []PointItem[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return pointItems(t.tree.nearest(p, k))
+}
+
+// Items returns every point in the tree.
+func (t *PointTree[V]) Items() []PointItem[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return pointItems(t.tree.all(nil))
+}
+
+// All returns an iterator over a snapshot of the points in the tree, in unspecified order.
+// The snapshot is taken when iteration starts, so the tree may be modified while iterating.
+func (t *PointTree[V]) All() iter.Seq[PointItem[V]] {
+	return func(yield func(PointItem[V]) bool) {
+		for _, item := range t.Items() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of points.
+func (t *PointTree[V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.size
+}
+
+// IsEmpty returns true if the tree holds no points.
+func (t *PointTree[V]) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Clear removes all points.
+func (t *PointTree[V]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tree.clear()
+}
+
+func pointItems[V comparable](entries []entry[V]) []PointItem[V] {
+	items := make([]PointItem[V], len(entries))
+	for i, e := range entries {
+		items[i] = PointItem[V]{e.bounds.Min, e.value}
+	}
+	return items
+}
@@ -0,0 +1,241 @@
+package quadtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/dullkingsman/kozo/geom"
+)
+
+// checkInvariants verifies that every entry lies in the deepest node containing it and that the size matches.
+func checkInvariants[V comparable](t *testing.T, tr *tree[V]) {
+	t.Helper()
+	count := 0
+	var walk func(n *node[V])
+	walk = func(n *node[V]) {
+		count += len(n.entries)
+		for _, e := range n.entries {
+			if !n.bounds.ContainsRect(e.bounds) {
+				t.Fatalf("Entry %v lies outside its node %v", e.bounds, n.bounds)
+			}
+			if n.children != nil && n.childFor(e.bounds) != nil {
+				t.Fatalf("Entry %v should be in a child of %v", e.bounds, n.bounds)
+			}
+		}
+		if n.children == nil {
+			return
+		}
+		for _, child := range n.children {
+			if child.depth != n.depth+1 || !n.bounds.ContainsRect(child.bounds) {
+				t.Fatal("Child node is malformed")
+			}
+			walk(child)
+		}
+	}
+	walk(tr.root)
+	if count != tr.size {
+		t.Fatalf("Tree holds %d entries but size is %d", count, tr.size)
+	}
+}
+
+func TestPointTree_Basic(t *testing.T) {
+	tr := NewPoint[string](geom.NewRect(0, 0, 100, 100))
+	if !tr.Insert(geom.Point{X: 10, Y: 10}, "a") || !tr.Insert(geom.Point{X: 50, Y: 50}, "b") || !tr.Insert(geom.Point{X: 90, Y: 20}, "c") {
+		t.Fatal("Expected points in bounds to be inserted")
+	}
+	tr.Insert(geom.Point{X: 50, Y: 50}, "b2") // shares a point
+	if tr.Insert(geom.Point{X: 101, Y: 0}, "out") || tr.Len() != 4 {
+		t.Errorf("Expected a point out of bounds to be rejected, Len() = %d", tr.Len())
+	}
+
+	var names []string
+	for _, item := range tr.Search(geom.NewRect(0, 0, 50, 50)) {
+		names = append(names, item.Value)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"a", "b", "b2"}) {
+		t.Errorf("Search() = %v", names)
+	}
+
+	near := tr.Nearest(geom.Point{X: 80, Y: 30}, 2)
+	if len(near) != 2 || near[0].Value != "c" || near[1].Point != (geom.Point{X: 50, Y: 50}) {
+		t.Errorf("Nearest() = %v", near)
+	}
+	if got := tr.Nearest(geom.Point{}, 10); len(got) != 4 {
+		t.Errorf("Expected Nearest to return every point, got %d", len(got))
+	}
+
+	if !tr.Remove(geom.Point{X: 50, Y: 50}, "b") || tr.Remove(geom.Point{X: 50, Y: 50}, "b") || tr.Remove(geom.Point{X: 10, Y: 10}, "z") {
+		t.Error("Expected Remove to match point and value once")
+	}
+	if len(tr.Items()) != 3 {
+		t.Errorf("Items() = %v", tr.Items())
+	}
+	n := 0
+	for item := range tr.All() {
+		if item.Value == "b" {
+			t.Error("Expected All not to yield a removed point")
+		}
+		n++
+	}
+	if n != 3 {
+		t.Errorf("Expected All to yield 3 points, got %d", n)
+	}
+	tr.Clear()
+	if !tr.IsEmpty() || len(tr.Nearest(geom.Point{}, 1)) != 0 {
+		t.Error("Expected Clear to empty the tree")
+	}
+}
+
+func TestRegionTree_Basic(t *testing.T) {
+	tr := NewRegion[string](geom.NewRect(0, 0, 100, 100))
+	tr.Insert(geom.NewRect(10, 10, 20, 20), "small")
+	tr.Insert(geom.NewRect(40, 40, 60, 60), "center") // straddles the first split
+	tr.Insert(geom.NewRect(70, 0, 100, 30), "corner")
+	if tr.Insert(geom.NewRect(90, 90, 110, 95), "out") {
+		t.Error("Expected a rectangle crossing the bounds to be rejected")
+	}
+
+	if got := tr.At(geom.Point{X: 50, Y: 50}); len(got) != 1 || got[0].Value != "center" {
+		t.Errorf("At() = %v", got)
+	}
+	var names []string
+	for _, item := range tr.Search(geom.NewRect(20, 0, 70, 45)) {
+		names = append(names, item.Value)
+	}
+	slices.Sort(names)
+	if !slices.Equal(names, []string{"center", "corner", "small"}) {
+		t.Errorf("Search() = %v", names)
+	}
+
+	near := tr.Nearest(geom.Point{X: 65, Y: 35}, 3)
+	if len(near) != 3 || near[0].Value != "center" || near[1].Value != "corner" || near[2].Value != "small" {
+		t.Errorf("Nearest() = %v", near)
+	}
+
+	if !tr.Remove(geom.NewRect(40, 40, 60, 60), "center") || tr.Len() != 2 {
+		t.Error("Expected Remove to remove the center region")
+	}
+}
+
+func TestPointTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	bounds := geom.NewRect(-1000, -1000, 1000, 1000)
+	tr := NewPoint[int](bounds)
+	var ref []PointItem[int]
+	randomPoint := func() geom.Point {
+		// Coarse coordinates make shared points and points on quadrant boundaries common.
+		return geom.Point{X: float64(rng.Intn(200)-100) * 10, Y: float64(rng.Intn(200)-100) * 10}
+	}
+
+	for i := range 3000 {
+		if len(ref) > 0 && rng.Intn(3) == 0 {
+			j := rng.Intn(len(ref))
+			if !tr.Remove(ref[j].Point, ref[j].Value) {
+				t.Fatalf("Remove(%v) failed", ref[j])
+			}
+			ref = slices.Delete(ref, j, j+1)
+		} else {
+			item := PointItem[int]{randomPoint(), i}
+			tr.Insert(item.Point, item.Value)
+			ref = append(ref, item)
+		}
+
+		if i%100 != 0 {
+			continue
+		}
+		checkInvariants(t, &tr.tree)
+
+		q := geom.NewRect(float64(rng.Intn(2000)-1000), float64(rng.Intn(2000)-1000), float64(rng.Intn(2000)-1000), float64(rng.Intn(2000)-1000))
+		var want []int
+		for _, item := range ref {
+			if q.Contains(item.Point) {
+				want = append(want, item.Value)
+			}
+		}
+		var got []int
+		for _, item := range tr.Search(q) {
+			got = append(got, item.Value)
+		}
+		slices.Sort(want)
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Fatalf("Step %d: Search(%v) = %v, want %v", i, q, got, want)
+		}
+
+		p, k := randomPoint(), 1+rng.Intn(10)
+		distances := make([]float64, len(ref))
+		for j, item := range ref {
+			distances[j] = item.Point.Distance(p)
+		}
+		slices.Sort(distances)
+		near := tr.Nearest(p, k)
+		if len(near) != min(k, len(ref)) {
+			t.Fatalf("Step %d: Nearest() returned %d points", i, len(near))
+		}
+		for j, item := range near {
+			if d := item.Point.Distance(p); d != distances[j] {
+				t.Fatalf("Step %d: neighbor %d is at %v, want %v", i, j, d, distances[j])
+			}
+		}
+	}
+	if tr.Len() != len(ref) {
+		t.Errorf("Len() = %d, want %d", tr.Len(), len(ref))
+	}
+}
+
+func TestRegionTree_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	tr := NewRegion[int](geom.NewRect(0, 0, 1024, 1024))
+	var ref []RegionItem[int]
+
+	for i := range 2000 {
+		if len(ref) > 0 && rng.Intn(3) == 0 {
+			j := rng.Intn(len(ref))
+			if !tr.Remove(ref[j].Rect, ref[j].Value) {
+				t.Fatalf("Remove(%v) failed", ref[j])
+			}
+			ref = slices.Delete(ref, j, j+1)
+		} else {
+			x, y := rng.Float64()*1000, rng.Float64()*1000
+			r := geom.NewRect(x, y, x+rng.Float64()*24, y+rng.Float64()*24)
+			tr.Insert(r, i)
+			ref = append(ref, RegionItem[int]{r, i})
+		}
+
+		if i%100 != 0 {
+			continue
+		}
+		checkInvariants(t, &tr.tree)
+
+		x, y := rng.Float64()*1000, rng.Float64()*1000
+		q := geom.NewRect(x, y, x+100, y+100)
+		var want, got []int
+		for _, item := range ref {
+			if q.Intersects(item.Rect) {
+				want = append(want, item.Value)
+			}
+		}
+		for _, item := range tr.Search(q) {
+			got = append(got, item.Value)
+		}
+		slices.Sort(want)
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Fatalf("Step %d: Search() = %v, want %v", i, got, want)
+		}
+
+		p := geom.Point{X: x, Y: y}
+		distances := make([]float64, len(ref))
+		for j, item := range ref {
+			distances[j] = item.Rect.Distance(p)
+		}
+		slices.Sort(distances)
+		for j, item := range tr.Nearest(p, 5) {
+			if d := item.Rect.Distance(p); d != distances[j] {
+				t.Fatalf("Step %d: neighbor %d is at %v, want %v", i, j, d, distances[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package quadtree
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/geom"
+)
+
+// RegionItem is a rectangle indexed by a RegionTree with its value.
+type RegionItem[V comparable] struct {
+	Rect  geom.Rect
+	Value V
+}
+
+// RegionTree is a thread-safe quadtree of rectangles with values, such as delivery zones or the bounding boxes
+// of areas. Each rectangle is stored in the smallest quadrant containing it entirely, so rectangles crossing
+// the center of a quadrant stay at that level. Like PointTree, it covers a fixed rectangle given at construction.
+type RegionTree[V comparable] struct {
+	mu   sync.RWMutex
+	tree tree[V]
+}
+
+var _ collection.Collection[RegionItem[int]] = (*RegionTree[int])(nil)
+
+// NewRegion returns a new empty RegionTree covering bounds.
+func NewRegion[V comparable](bounds geom.Rect) *RegionTree[V] {
+	return &RegionTree[V]{tree: newTree[V](bounds)}
+}
+
+// Bounds returns the rectangle covered by the tree.
+func (t *RegionTree[V]) Bounds() geom.Rect {
+	return t.tree.root.bounds
+}
+
+// Insert adds v with the rectangle r. It returns false without adding it if r does not lie entirely in the bounds of the tree.
+func (t *RegionTree[V]) Insert(r geom.Rect, v V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.insert(entry[V]{r, v})
+}
+
+// Remove removes one occurrence of v with the rectangle r. It returns true if it was present.
+func (t *RegionTree[V]) Remove(r geom.Rect, v V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.tree.remove(entry[V]{r, v})
+}
+
+// Search returns the rectangles intersecting r, including those only touching it.
+func (t *RegionTree[V]) Search(r geom.Rect) []RegionItem[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return regionItems(t.tree.search(r, nil))
+}
+
+// At returns the rectangles containing p.
+func (t *RegionTree[V]) At(p geom.Point) []RegionItem[V] {
+	return t.Search(p.Bounds())
+}
+
+// Nearest returns the k rectangles nearest to p, nearest first, measuring the distance from p to the nearest
+// point of each rectangle, so rectangles containing p come first. It returns fewer if the tree holds fewer.
+func (t *RegionTree[V]) Nearest(p geom.Point, k int) []RegionItem[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return regionItems(t.tree.nearest(p, k))
+}
+
+// Items returns every rectangle in the tree.
+func (t *RegionTree[V]) Items() []RegionItem[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return regionItems(t.tree.all(nil))
+}
+
+// All returns an iterator over a snapshot of the rectangles in the tree, in unspecified order.
+// The snapshot is taken when iteration starts, so the tree may be modified while iterating.
+func (t *RegionTree[V]) All() iter.Seq[RegionItem[V]] {
+	return func(yield func(RegionItem[V]) bool) {
+		for _, item := range t.Items() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of rectangles.
+func (t *RegionTree[V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.tree.size
+}
+
+// IsEmpty returns true if the tree holds no rectangles.
+func (t *RegionTree[V]) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Clear removes all rectangles.
+func (t *RegionTree[V]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tree.clear()
+}
+
+func regionItems[V comparable](entries []entry[V]) []RegionItem[V] {
+	items := make([]RegionItem[V], len(entries))
+	for i, e := range entries {
+		items[i] = RegionItem[V]{e.bounds, e.value}
+	}
+	return items
+}
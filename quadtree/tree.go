@@ -0,0 +1,214 @@
+// Package quadtree provides quadtrees indexing points and rectangles in the plane,
+// with rectangle queries and nearest-neighbor search.
+package quadtree
+
+import (
+	"github.com/dullkingsman/kozo/geom"
+	"github.com/dullkingsman/kozo/heap"
+)
+
+const (
+	// capacity is the number of entries a leaf holds before it splits.
+	capacity = 8
+	// maxDepth bounds the splitting, so that many entries at one point cannot split forever.
+	maxDepth = 24
+)
+
+// entry is an indexed rectangle, a single point for PointTree, with its value.
+type entry[V comparable] struct {
+	bounds geom.Rect
+	value  V
+}
+
+// tree is the quadtree shared by PointTree and RegionTree. Each entry lives in the deepest node whose quadrant
+// contains it entirely, so points always reach leaves while rectangles straddling a split stay above.
+type tree[V comparable] struct {
+	root *node[V]
+	size int
+}
+
+type node[V comparable] struct {
+	bounds   geom.Rect
+	depth    int
+	entries  []entry[V]
+	children *[4]*node[V] // nil for leaves
+}
+
+func newTree[V comparable](bounds geom.Rect) tree[V] {
+	return tree[V]{root: &node[V]{bounds: bounds}}
+}
+
+func (t *tree[V]) insert(e entry[V]) bool {
+	if !t.root.bounds.ContainsRect(e.bounds) {
+		return false
+	}
+	t.root.insert(e)
+	t.size++
+	return true
+}
+
+func (t *tree[V]) remove(e entry[V]) bool {
+	if !t.root.bounds.ContainsRect(e.bounds) || !t.root.remove(e) {
+		return false
+	}
+	t.size--
+	return true
+}
+
+func (t *tree[V]) clear() {
+	t.root = &node[V]{bounds: t.root.bounds}
+	t.size = 0
+}
+
+// search appends the entries intersecting r.
+func (t *tree[V]) search(r geom.Rect, out []entry[V]) []entry[V] {
+	return t.root.search(r, out)
+}
+
+// nearest returns the k entries nearest to p, nearest first, by best-first search: nodes and entries are visited
+// in order of their distance to p, and a node is never farther than the entries it holds.
+func (t *tree[V]) nearest(p geom.Point, k int) []entry[V] {
+	type candidate struct {
+		distance float64
+		node     *node[V] // nil for entries
+		entry    entry[V]
+		seq      int // breaks ties in the order candidates were found
+	}
+	pending := heap.New(func(a, b candidate) bool {
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		return a.seq < b.seq
+	})
+	seq := 0
+	push := func(c candidate) {
+		c.seq = seq
+		seq++
+		pending.Push(c)
+	}
+
+	var found []entry[V]
+	push(candidate{distance: t.root.bounds.Distance(p), node: t.root})
+	for len(found) < k && !pending.IsEmpty() {
+		c, _ := pending.Pop()
+		if c.node == nil {
+			found = append(found, c.entry)
+			continue
+		}
+		for _, e := range c.node.entries {
+			push(candidate{distance: e.bounds.Distance(p), entry: e})
+		}
+		if c.node.children != nil {
+			for _, child := range c.node.children {
+				push(candidate{distance: child.bounds.Distance(p), node: child})
+			}
+		}
+	}
+	return found
+}
+
+// all appends every entry, depth first.
+func (t *tree[V]) all(out []entry[V]) []entry[V] {
+	return t.root.search(t.root.bounds, out)
+}
+
+func (n *node[V]) insert(e entry[V]) {
+	for {
+		if n.children != nil {
+			if child := n.childFor(e.bounds); child != nil {
+				n = child
+				continue
+			}
+		}
+		n.entries = append(n.entries, e)
+		if n.children == nil && len(n.entries) > capacity && n.depth < maxDepth {
+			n.split()
+		}
+		return
+	}
+}
+
+// remove removes one entry equal to e from the subtree, collapsing children that hold too few entries to need them.
+func (n *node[V]) remove(e entry[V]) bool {
+	if n.children != nil {
+		if child := n.childFor(e.bounds); child != nil {
+			if !child.remove(e) {
+				return false
+			}
+			n.collapse()
+			return true
+		}
+	}
+	for i, x := range n.entries {
+		if x == e {
+			n.entries = append(n.entries[:i], n.entries[i+1:]...)
+			n.collapse()
+			return true
+		}
+	}
+	return false
+}
+
+func (n *node[V]) search(r geom.Rect, out []entry[V]) []entry[V] {
+	if !n.bounds.Intersects(r) {
+		return out
+	}
+	for _, e := range n.entries {
+		if e.bounds.Intersects(r) {
+			out = append(out, e)
+		}
+	}
+	if n.children != nil {
+		for _, child := range n.children {
+			out = child.search(r, out)
+		}
+	}
+	return out
+}
+
+// childFor returns the first child whose quadrant contains r entirely, or nil if r straddles the quadrants.
+func (n *node[V]) childFor(r geom.Rect) *node[V] {
+	for _, child := range n.children {
+		if child.bounds.ContainsRect(r) {
+			return child
+		}
+	}
+	return nil
+}
+
+// split divides a leaf into four quadrants and moves down the entries that fit in one.
+func (n *node[V]) split() {
+	c := n.bounds.Center()
+	n.children = &[4]*node[V]{
+		{bounds: geom.Rect{Min: n.bounds.Min, Max: c}, depth: n.depth + 1},
+		{bounds: geom.Rect{Min: geom.Point{X: c.X, Y: n.bounds.Min.Y}, Max: geom.Point{X: n.bounds.Max.X, Y: c.Y}}, depth: n.depth + 1},
+		{bounds: geom.Rect{Min: geom.Point{X: n.bounds.Min.X, Y: c.Y}, Max: geom.Point{X: c.X, Y: n.bounds.Max.Y}}, depth: n.depth + 1},
+		{bounds: geom.Rect{Min: c, Max: n.bounds.Max}, depth: n.depth + 1},
+	}
+	entries := n.entries
+	n.entries = nil
+	for _, e := range entries {
+		n.insert(e)
+	}
+}
+
+// collapse turns a node whose children are leaves back into a leaf if their entries fit in it.
+func (n *node[V]) collapse() {
+	if n.children == nil {
+		return
+	}
+	total := len(n.entries)
+	for _, child := range n.children {
+		if child.children != nil {
+			return
+		}
+		total += len(child.entries)
+	}
+	if total > capacity {
+		return
+	}
+	for _, child := range n.children {
+		n.entries = append(n.entries, child.entries...)
+	}
+	n.children = nil
+}
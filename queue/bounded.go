@@ -0,0 +1,285 @@
+package queue
+
+import (
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// BoundedQueue is a thread-safe, fixed-capacity FIFO data structure implemented with a circular buffer.
+// Enqueue blocks while the queue is full and Dequeue blocks while it is empty.
+// The Try and Timeout variants allow callers to give up instead of waiting indefinitely.
+type BoundedQueue[T any] struct {
+	mu    sync.Mutex
+	data  []T
+	head  int
+	tail  int
+	count int
+
+	// overwrite makes enqueueing on a full queue evict the oldest element instead of waiting.
+	overwrite bool
+
+	// notEmpty and notFull are created lazily by waiters and closed to wake them.
+	notEmpty chan struct{}
+	notFull  chan struct{}
+}
+
+var _ collection.Collection[int] = (*BoundedQueue[int])(nil)
+
+// NewBounded returns a new empty BoundedQueue that holds at most capacity elements.
+func NewBounded[T any](capacity int) *BoundedQueue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &BoundedQueue[T]{
+		data: make([]T, capacity),
+	}
+}
+
+// NewOverwriting returns a new empty BoundedQueue with ring semantics: enqueueing on a full queue
+// overwrites the oldest element instead of blocking. Useful for last-N-events buffers.
+func NewOverwriting[T any](capacity int) *BoundedQueue[T] {
+	q := NewBounded[T](capacity)
+	q.overwrite = true
+	return q
+}
+
+// Enqueue adds an element to the back of the queue, blocking until space is available.
+func (q *BoundedQueue[T]) Enqueue(v T) {
+	q.enqueue(v, true, -1)
+}
+
+// TryEnqueue adds an element to the back of the queue if space is available.
+// Returns false without blocking if the queue is full.
+func (q *BoundedQueue[T]) TryEnqueue(v T) bool {
+	return q.enqueue(v, false, 0)
+}
+
+// EnqueueTimeout adds an element to the back of the queue, waiting up to d for space to become available.
+// Returns false if the timeout elapses first.
+func (q *BoundedQueue[T]) EnqueueTimeout(v T, d time.Duration) bool {
+	return q.enqueue(v, true, d)
+}
+
+// EnqueueOverwrite adds an element to the back of the queue without blocking.
+// If the queue is full, the oldest element is evicted and returned with true.
+func (q *BoundedQueue[T]) EnqueueOverwrite(v T) (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.push(v)
+}
+
+// Dequeue removes and returns the front element of the queue, blocking until one is available.
+func (q *BoundedQueue[T]) Dequeue() T {
+	v, _ := q.dequeue(true, -1)
+	return v
+}
+
+// TryDequeue removes and returns the front element of the queue if one is available.
+// Returns (zero-value, false) without blocking if the queue is empty.
+func (q *BoundedQueue[T]) TryDequeue() (T, bool) {
+	return q.dequeue(false, 0)
+}
+
+// DequeueTimeout removes and returns the front element of the queue, waiting up to d for one to become available.
+// Returns (zero-value, false) if the timeout elapses first.
+func (q *BoundedQueue[T]) DequeueTimeout(d time.Duration) (T, bool) {
+	return q.dequeue(true, d)
+}
+
+// Peek returns the front element of the queue without removing it.
+// Returns (zero-value, false) if the queue is empty.
+func (q *BoundedQueue[T]) Peek() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.data[q.head], true
+}
+
+// IsEmpty returns true if the queue has no elements.
+func (q *BoundedQueue[T]) IsEmpty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count == 0
+}
+
+// IsFull returns true if the queue holds as many elements as its capacity.
+func (q *BoundedQueue[T]) IsFull() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count == len(q.data)
+}
+
+// Len returns the current number of elements in the queue.
+func (q *BoundedQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count
+}
+
+// All returns an iterator over a snapshot of the elements in FIFO order, without consuming them.
+// The snapshot is taken when iteration starts, so the queue may be modified while iterating.
+func (q *BoundedQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.mu.Lock()
+		snapshot := make([]T, q.count)
+		for i := range snapshot {
+			snapshot[i] = q.data[(q.head+i)%len(q.data)]
+		}
+		q.mu.Unlock()
+
+		for _, v := range snapshot {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Cap returns the maximum number of elements the queue can hold.
+func (q *BoundedQueue[T]) Cap() int {
+	return len(q.data)
+}
+
+// Clear discards all elements from the queue and wakes any blocked producers.
+func (q *BoundedQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// Zero out all elements to assist GC
+	var zero T
+	for i := 0; i < len(q.data); i++ {
+		q.data[i] = zero
+	}
+
+	q.head = 0
+	q.tail = 0
+	q.count = 0
+
+	broadcast(&q.notFull)
+}
+
+// enqueue implements the blocking, non-blocking and timed enqueue variants.
+// A negative timeout waits indefinitely.
+func (q *BoundedQueue[T]) enqueue(v T, block bool, timeout time.Duration) bool {
+	var deadline <-chan time.Time
+
+	for {
+		q.mu.Lock()
+		if q.overwrite || q.count < len(q.data) {
+			q.push(v)
+			q.mu.Unlock()
+			return true
+		}
+
+		if !block {
+			q.mu.Unlock()
+			return false
+		}
+
+		wait := waiter(&q.notFull)
+		q.mu.Unlock()
+
+		if deadline == nil && timeout >= 0 {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			deadline = timer.C
+		}
+
+		select {
+		case <-wait:
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+// dequeue implements the blocking, non-blocking and timed dequeue variants.
+// A negative timeout waits indefinitely.
+func (q *BoundedQueue[T]) dequeue(block bool, timeout time.Duration) (T, bool) {
+	var deadline <-chan time.Time
+
+	for {
+		q.mu.Lock()
+		if q.count > 0 {
+			v := q.data[q.head]
+
+			// Zero out the element to prevent memory leaks (GC can reclaim it)
+			var zero T
+			q.data[q.head] = zero
+
+			q.head = (q.head + 1) % len(q.data)
+			q.count--
+			broadcast(&q.notFull)
+			q.mu.Unlock()
+			return v, true
+		}
+
+		if !block {
+			q.mu.Unlock()
+			var zero T
+			return zero, false
+		}
+
+		wait := waiter(&q.notEmpty)
+		q.mu.Unlock()
+
+		if deadline == nil && timeout >= 0 {
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			deadline = timer.C
+		}
+
+		select {
+		case <-wait:
+		case <-deadline:
+			var zero T
+			return zero, false
+		}
+	}
+}
+
+// push adds an element to the back of the queue, evicting and returning the oldest element if full.
+// Must be called with lock held.
+func (q *BoundedQueue[T]) push(v T) (T, bool) {
+	var (
+		evicted T
+		full    = q.count == len(q.data)
+	)
+	if full {
+		evicted = q.data[q.head]
+		q.head = (q.head + 1) % len(q.data)
+		q.count--
+	}
+
+	q.data[q.tail] = v
+	q.tail = (q.tail + 1) % len(q.data)
+	q.count++
+	broadcast(&q.notEmpty)
+
+	return evicted, full
+}
+
+// waiter returns the channel to wait on for the next broadcast, creating it if needed.
+// Must be called with lock held.
+func waiter(ch *chan struct{}) chan struct{} {
+	if *ch == nil {
+		*ch = make(chan struct{})
+	}
+	return *ch
+}
+
+// broadcast wakes all goroutines waiting on ch, if any. Must be called with lock held.
+func broadcast(ch *chan struct{}) {
+	if *ch != nil {
+		close(*ch)
+		*ch = nil
+	}
+}
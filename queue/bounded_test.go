@@ -0,0 +1,198 @@
+package queue
+
+import (
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBoundedQueue(t *testing.T) {
+	q := NewBounded[int](2)
+
+	if q.Cap() != 2 {
+		t.Errorf("Expected capacity 2, got %d", q.Cap())
+	}
+
+	if !q.TryEnqueue(1) || !q.TryEnqueue(2) {
+		t.Fatal("Expected TryEnqueue to succeed while not full")
+	}
+
+	if !q.IsFull() {
+		t.Error("Expected full queue")
+	}
+
+	if q.TryEnqueue(3) {
+		t.Error("Expected TryEnqueue to fail on full queue")
+	}
+
+	v, ok := q.Peek()
+	if !ok || v != 1 {
+		t.Errorf("Peek expected 1, got %v", v)
+	}
+
+	v, ok = q.TryDequeue()
+	if !ok || v != 1 {
+		t.Errorf("TryDequeue expected 1, got %v", v)
+	}
+
+	if v := q.Dequeue(); v != 2 {
+		t.Errorf("Dequeue expected 2, got %v", v)
+	}
+
+	if _, ok := q.TryDequeue(); ok {
+		t.Error("Expected TryDequeue to fail on empty queue")
+	}
+
+	if !q.IsEmpty() {
+		t.Error("Expected empty queue")
+	}
+}
+
+func TestBoundedQueueAll(t *testing.T) {
+	q := NewOverwriting[int](3)
+	for i := 1; i <= 5; i++ {
+		q.Enqueue(i)
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("Expected [3 4 5], got %v", got)
+	}
+}
+
+func TestBoundedQueueTimeout(t *testing.T) {
+	q := NewBounded[int](1)
+
+	if _, ok := q.DequeueTimeout(10 * time.Millisecond); ok {
+		t.Error("Expected DequeueTimeout to time out on empty queue")
+	}
+
+	if !q.EnqueueTimeout(1, 10*time.Millisecond) {
+		t.Error("Expected EnqueueTimeout to succeed on empty queue")
+	}
+
+	if q.EnqueueTimeout(2, 10*time.Millisecond) {
+		t.Error("Expected EnqueueTimeout to time out on full queue")
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Dequeue()
+	}()
+
+	if !q.EnqueueTimeout(2, time.Second) {
+		t.Error("Expected EnqueueTimeout to succeed once space is freed")
+	}
+
+	v, ok := q.DequeueTimeout(time.Second)
+	if !ok || v != 2 {
+		t.Errorf("DequeueTimeout expected 2, got %v", v)
+	}
+}
+
+func TestBoundedQueueClearWakesProducers(t *testing.T) {
+	q := NewBounded[int](1)
+	q.Enqueue(1)
+
+	done := make(chan struct{})
+	go func() {
+		q.Enqueue(2)
+		close(done)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Clear()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected blocked Enqueue to proceed after Clear")
+	}
+
+	if v := q.Dequeue(); v != 2 {
+		t.Errorf("Dequeue expected 2, got %v", v)
+	}
+}
+
+func TestBoundedQueueConcurrency(t *testing.T) {
+	q := NewBounded[int](8)
+	var wg sync.WaitGroup
+	numOps := 1000
+	numGophers := 50
+
+	var mu sync.Mutex
+	sum := 0
+
+	for i := 0; i < numGophers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 1; j <= numOps; j++ {
+				q.Enqueue(j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			local := 0
+			for j := 0; j < numOps; j++ {
+				local += q.Dequeue()
+			}
+			mu.Lock()
+			sum += local
+			mu.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	expected := numGophers * numOps * (numOps + 1) / 2
+	if sum != expected {
+		t.Errorf("Expected sum %d, got %d", expected, sum)
+	}
+
+	if q.Len() != 0 {
+		t.Errorf("Expected 0 elements, got %d", q.Len())
+	}
+}
+
+func TestBoundedQueueOverwrite(t *testing.T) {
+	q := NewOverwriting[int](3)
+
+	for i := 1; i <= 5; i++ {
+		if !q.TryEnqueue(i) {
+			t.Fatalf("Expected TryEnqueue(%d) to succeed in overwrite mode", i)
+		}
+	}
+
+	if q.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", q.Len())
+	}
+
+	evicted, ok := q.EnqueueOverwrite(6)
+	if !ok || evicted != 3 {
+		t.Errorf("Expected 3 to be evicted, got %v (ok: %v)", evicted, ok)
+	}
+
+	for _, exp := range []int{4, 5, 6} {
+		if v := q.Dequeue(); v != exp {
+			t.Errorf("Expected %d, got %v", exp, v)
+		}
+	}
+
+	if _, ok := q.EnqueueOverwrite(7); ok {
+		t.Error("Expected no eviction on non-full queue")
+	}
+}
+
+func TestBoundedQueueEnqueueOverwriteOnBlockingQueue(t *testing.T) {
+	q := NewBounded[int](1)
+	q.Enqueue(1)
+
+	evicted, ok := q.EnqueueOverwrite(2)
+	if !ok || evicted != 1 {
+		t.Errorf("Expected 1 to be evicted, got %v (ok: %v)", evicted, ok)
+	}
+
+	if q.TryEnqueue(3) {
+		t.Error("Expected TryEnqueue to keep blocking semantics")
+	}
+}
@@ -0,0 +1,67 @@
+package queue
+
+import (
+	"context"
+)
+
+// AsChan starts a goroutine that dequeues elements and sends them on the returned channel,
+// so queue contents can be consumed with select. The goroutine waits while the queue is empty.
+//
+// When ctx is done the channel is closed. An element that was dequeued but not yet received
+// is put back at the front of the queue, so no element is lost on shutdown.
+// While a send is pending, that element is not counted by Len.
+func (q *Queue[T]) AsChan(ctx context.Context) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		for {
+			q.mu.Lock()
+			if q.count == 0 {
+				wait := waiter(&q.notEmpty)
+				q.mu.Unlock()
+
+				select {
+				case <-wait:
+					continue
+				case <-ctx.Done():
+					return
+				}
+			}
+			v := q.pop()
+			q.mu.Unlock()
+
+			select {
+			case out <- v:
+				q.mu.Lock()
+				q.recordDequeue(1)
+				q.mu.Unlock()
+			case <-ctx.Done():
+				q.mu.Lock()
+				q.pushFront(v)
+				q.mu.Unlock()
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// FromChan enqueues every element received from in until in is closed or ctx is done.
+// It blocks, so callers typically run it in its own goroutine.
+// Returns nil once in is closed and drained, or ctx.Err() if the context is done first.
+func (q *Queue[T]) FromChan(ctx context.Context, in <-chan T) error {
+	for {
+		select {
+		case v, ok := <-in:
+			if !ok {
+				return nil
+			}
+			q.Enqueue(v)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestQueueAsChan(t *testing.T) {
+	q := New[int]()
+	q.EnqueueAll(1, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ch := q.AsChan(ctx)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		q.Enqueue(3) // arrives while the pump waits on an empty queue
+	}()
+
+	for _, exp := range []int{1, 2, 3} {
+		select {
+		case v := <-ch:
+			if v != exp {
+				t.Errorf("Expected %d, got %d", exp, v)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for %d", exp)
+		}
+	}
+
+	if st := q.Stats(); st.Dequeued != 3 {
+		t.Errorf("Expected 3 dequeued, got %d", st.Dequeued)
+	}
+}
+
+func TestQueueAsChanShutdown(t *testing.T) {
+	q := New[int]()
+	q.EnqueueAll(1, 2, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := q.AsChan(ctx)
+
+	if v := <-ch; v != 1 {
+		t.Errorf("Expected 1, got %d", v)
+	}
+
+	time.Sleep(10 * time.Millisecond) // let the pump block sending 2
+	cancel()
+
+	// The pump may still send 2 before it observes cancellation, so collect whatever arrives.
+	var received []int
+	for v := range ch {
+		received = append(received, v)
+	}
+
+	// Every element is either received or kept in order, with an unsent one put back at the front.
+	got := append(received, q.ToSlice()...)
+	if !slices.Equal(got, []int{2, 3}) {
+		t.Errorf("Expected received then remaining elements to be [2 3], got %v then %v", received, q.ToSlice())
+	}
+	if st := q.Stats(); st.Dequeued != uint64(1+len(received)) {
+		t.Errorf("Expected %d dequeued, got %d", 1+len(received), st.Dequeued)
+	}
+}
+
+func TestQueueFromChan(t *testing.T) {
+	q := New[int]()
+	in := make(chan int)
+
+	go func() {
+		for i := 1; i <= 3; i++ {
+			in <- i
+		}
+		close(in)
+	}()
+
+	if err := q.FromChan(context.Background(), in); err != nil {
+		t.Fatalf("Expected nil error, got %v", err)
+	}
+
+	got := q.ToSlice()
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := q.FromChan(ctx, make(chan int)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,175 @@
+package queue
+
+import (
+	"context"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/heap"
+)
+
+// DelayQueue is a thread-safe queue that holds each element until its ready time has passed.
+// Ready elements are dequeued in ready-time order; elements with equal ready times keep FIFO order.
+type DelayQueue[T any] struct {
+	mu    sync.Mutex
+	items *heap.Heap[delayItem[T]]
+	seq   uint64
+
+	// changed is created lazily by waiters and closed whenever an element is added.
+	changed chan struct{}
+}
+
+var _ collection.Collection[int] = (*DelayQueue[int])(nil)
+
+type delayItem[T any] struct {
+	value   T
+	readyAt time.Time
+	seq     uint64
+}
+
+// lessDelay orders delayItems by ready time, then insertion order.
+func lessDelay[T any](a, b delayItem[T]) bool {
+	if a.readyAt.Equal(b.readyAt) {
+		return a.seq < b.seq
+	}
+	return a.readyAt.Before(b.readyAt)
+}
+
+// NewDelay returns a new empty DelayQueue.
+func NewDelay[T any]() *DelayQueue[T] {
+	return &DelayQueue[T]{items: heap.New(lessDelay[T])}
+}
+
+// Enqueue adds an element that becomes available once readyAt has passed.
+func (q *DelayQueue[T]) Enqueue(v T, readyAt time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.items.Push(delayItem[T]{value: v, readyAt: readyAt, seq: q.seq})
+	q.seq++
+	broadcast(&q.changed)
+}
+
+// EnqueueAfter adds an element that becomes available after d has elapsed.
+func (q *DelayQueue[T]) EnqueueAfter(v T, d time.Duration) {
+	q.Enqueue(v, time.Now().Add(d))
+}
+
+// TryDequeue removes and returns the earliest element if its ready time has passed.
+// Returns (zero-value, false) without blocking if no element is ready.
+func (q *DelayQueue[T]) TryDequeue() (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if next, ok := q.items.Peek(); !ok || next.readyAt.After(time.Now()) {
+		var zero T
+		return zero, false
+	}
+
+	next, _ := q.items.Pop()
+	return next.value, true
+}
+
+// DequeueWait removes and returns the earliest element, blocking until its ready time has passed.
+// Returns (zero-value, ctx.Err()) if the context is done first.
+func (q *DelayQueue[T]) DequeueWait(ctx context.Context) (T, error) {
+	var timer *time.Timer
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+
+	for {
+		q.mu.Lock()
+		var (
+			wait  = waiter(&q.changed)
+			delay = time.Duration(-1)
+		)
+		if next, ok := q.items.Peek(); ok {
+			delay = time.Until(next.readyAt)
+			if delay <= 0 {
+				q.items.Pop()
+				q.mu.Unlock()
+				return next.value, nil
+			}
+		}
+		q.mu.Unlock()
+
+		var ready <-chan time.Time
+		if delay > 0 {
+			if timer == nil {
+				timer = time.NewTimer(delay)
+			} else {
+				timer.Reset(delay)
+			}
+			ready = timer.C
+		}
+
+		select {
+		case <-ready:
+		case <-wait:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// PeekDeadline returns the ready time of the earliest element without removing it.
+// Returns (zero-value, false) if the queue is empty.
+func (q *DelayQueue[T]) PeekDeadline() (time.Time, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	next, ok := q.items.Peek()
+	return next.readyAt, ok
+}
+
+// IsEmpty returns true if the queue has no elements, ready or not.
+func (q *DelayQueue[T]) IsEmpty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.IsEmpty()
+}
+
+// Len returns the current number of elements in the queue, ready or not.
+func (q *DelayQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// All returns an iterator over a snapshot of the elements, ready or not, in ready-time order.
+// The snapshot is taken when iteration starts, so the queue may be modified while iterating.
+func (q *DelayQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.mu.Lock()
+		items := q.items.ToSlice()
+		q.mu.Unlock()
+
+		// Sequence numbers are unique, so lessDelay is a strict total order.
+		slices.SortFunc(items, func(a, b delayItem[T]) int {
+			if lessDelay(a, b) {
+				return -1
+			}
+			return 1
+		})
+		for _, item := range items {
+			if !yield(item.value) {
+				return
+			}
+		}
+	}
+}
+
+// Clear discards all elements from the queue.
+func (q *DelayQueue[T]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.items.Clear()
+}
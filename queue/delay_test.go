@@ -0,0 +1,88 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDelayQueueOrder(t *testing.T) {
+	q := NewDelay[string]()
+	now := time.Now()
+
+	q.Enqueue("c", now.Add(-1*time.Millisecond))
+	q.Enqueue("a", now.Add(-3*time.Millisecond))
+	q.Enqueue("b1", now.Add(-2*time.Millisecond))
+	q.Enqueue("b2", now.Add(-2*time.Millisecond))
+	q.Enqueue("later", now.Add(time.Hour))
+
+	if q.Len() != 5 {
+		t.Errorf("Expected length 5, got %d", q.Len())
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, []string{"a", "b1", "b2", "c", "later"}) {
+		t.Errorf("All() = %v", got)
+	}
+
+	for _, exp := range []string{"a", "b1", "b2", "c"} {
+		v, ok := q.TryDequeue()
+		if !ok || v != exp {
+			t.Errorf("Expected %s, got %v (ok: %v)", exp, v, ok)
+		}
+	}
+
+	if v, ok := q.TryDequeue(); ok {
+		t.Errorf("Expected no ready element, got %v", v)
+	}
+
+	deadline, ok := q.PeekDeadline()
+	if !ok || !deadline.Equal(now.Add(time.Hour)) {
+		t.Errorf("Expected pending deadline, got %v", deadline)
+	}
+
+	q.Clear()
+	if !q.IsEmpty() {
+		t.Error("Expected empty queue after Clear")
+	}
+}
+
+func TestDelayQueueDequeueWait(t *testing.T) {
+	q := NewDelay[int]()
+	q.EnqueueAfter(2, 30*time.Millisecond)
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		q.EnqueueAfter(1, 10*time.Millisecond) // earlier deadline arrives while waiting
+	}()
+
+	start := time.Now()
+	v, err := q.DequeueWait(context.Background())
+	if err != nil || v != 1 {
+		t.Errorf("Expected 1, got %v (err: %v)", v, err)
+	}
+	if time.Since(start) < 10*time.Millisecond {
+		t.Error("DequeueWait returned before the element was ready")
+	}
+
+	v, err = q.DequeueWait(context.Background())
+	if err != nil || v != 2 {
+		t.Errorf("Expected 2, got %v (err: %v)", v, err)
+	}
+}
+
+func TestDelayQueueDequeueWaitCancel(t *testing.T) {
+	q := NewDelay[int]()
+	q.EnqueueAfter(1, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := q.DequeueWait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if q.Len() != 1 {
+		t.Errorf("Expected pending element to remain, got length %d", q.Len())
+	}
+}
@@ -0,0 +1,216 @@
+package queue
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/heap"
+)
+
+// IndexedPriorityQueue is a thread-safe priority queue of unique keys, such as node IDs, timer handles or job IDs,
+// whose priorities can be changed or which can be removed after insertion, as Dijkstra's algorithm, timers and schedulers require.
+// The key with the least priority is dequeued first. Push, Pop, Update, DecreaseKey and Remove are O(log n);
+// Contains and Priority are O(1).
+type IndexedPriorityQueue[K comparable, P any] struct {
+	mu      sync.Mutex
+	items   *heap.Heap[*indexedItem[K, P]]
+	entries map[K]*indexedItem[K, P]
+	less    func(P, P) bool
+}
+
+var _ collection.Map[int, int] = (*IndexedPriorityQueue[int, int])(nil)
+
+type indexedItem[K comparable, P any] struct {
+	key      K
+	priority P
+	index    int // position in the heap
+}
+
+// NewIndexedPriority returns a new empty IndexedPriorityQueue that dequeues the key with the least priority first.
+func NewIndexedPriority[K comparable, P any](less func(P, P) bool) *IndexedPriorityQueue[K, P] {
+	return &IndexedPriorityQueue[K, P]{
+		items: heap.NewWithIndex(
+			func(a, b *indexedItem[K, P]) bool { return less(a.priority, b.priority) },
+			func(item *indexedItem[K, P], i int) { item.index = i },
+		),
+		entries: make(map[K]*indexedItem[K, P]),
+		less:    less,
+	}
+}
+
+// NewIndexedMinPriority returns a new empty IndexedPriorityQueue that dequeues the key with the smallest priority first.
+func NewIndexedMinPriority[K comparable, P cmp.Ordered]() *IndexedPriorityQueue[K, P] {
+	return NewIndexedPriority[K](cmp.Less[P])
+}
+
+// Push adds key with the given priority. It returns false, leaving the queue unchanged, if key is already queued.
+func (q *IndexedPriorityQueue[K, P]) Push(key K, priority P) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.entries[key]; ok {
+		return false
+	}
+	item := &indexedItem[K, P]{key: key, priority: priority}
+	q.entries[key] = item
+	q.items.Push(item)
+	return true
+}
+
+// Pop removes and returns the key with the least priority. Returns false if the queue is empty.
+func (q *IndexedPriorityQueue[K, P]) Pop() (K, P, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	item, ok := q.items.Pop()
+	if !ok {
+		var (
+			k K
+			p P
+		)
+		return k, p, false
+	}
+	delete(q.entries, item.key)
+	return item.key, item.priority, true
+}
+
+// Peek returns the key with the least priority without removing it. Returns false if the queue is empty.
+func (q *IndexedPriorityQueue[K, P]) Peek() (K, P, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	item, ok := q.items.Peek()
+	if !ok {
+		var (
+			k K
+			p P
+		)
+		return k, p, false
+	}
+	return item.key, item.priority, true
+}
+
+// Update changes the priority of key. It returns false if key is not queued.
+func (q *IndexedPriorityQueue[K, P]) Update(key K, priority P) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	item, ok := q.entries[key]
+	if !ok {
+		return false
+	}
+	item.priority = priority
+	q.items.Fix(item.index)
+	return true
+}
+
+// DecreaseKey lowers the priority of key, adding key if it is not queued, and returns true.
+// It returns false, leaving the queue unchanged, if key is queued with a priority that is not greater.
+// This is the relaxation step of Dijkstra's and Prim's algorithms.
+func (q *IndexedPriorityQueue[K, P]) DecreaseKey(key K, priority P) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	item, ok := q.entries[key]
+	if !ok {
+		item = &indexedItem[K, P]{key: key, priority: priority}
+		q.entries[key] = item
+		q.items.Push(item)
+		return true
+	}
+	if !q.less(priority, item.priority) {
+		return false
+	}
+	item.priority = priority
+	q.items.Fix(item.index)
+	return true
+}
+
+// Remove removes key and returns its priority. Returns false if key is not queued.
+func (q *IndexedPriorityQueue[K, P]) Remove(key K) (P, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	item, ok := q.entries[key]
+	if !ok {
+		var zero P
+		return zero, false
+	}
+	delete(q.entries, key)
+	q.items.Remove(item.index)
+	return item.priority, true
+}
+
+// Priority returns the priority of key. Returns false if key is not queued.
+func (q *IndexedPriorityQueue[K, P]) Priority(key K) (P, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if item, ok := q.entries[key]; ok {
+		return item.priority, true
+	}
+	var zero P
+	return zero, false
+}
+
+// Contains returns true if key is queued.
+func (q *IndexedPriorityQueue[K, P]) Contains(key K) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.entries[key]
+	return ok
+}
+
+// IsEmpty returns true if the queue has no keys.
+func (q *IndexedPriorityQueue[K, P]) IsEmpty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.IsEmpty()
+}
+
+// Len returns the number of queued keys.
+func (q *IndexedPriorityQueue[K, P]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.items.Len()
+}
+
+// All returns an iterator over a snapshot of the keys and their priorities, least priority first.
+// Keys of equal priority come in unspecified order. The snapshot is taken when iteration starts,
+// so the queue may be modified while iterating.
+func (q *IndexedPriorityQueue[K, P]) All() iter.Seq2[K, P] {
+	return func(yield func(K, P) bool) {
+		q.mu.Lock()
+		items := make([]indexedItem[K, P], q.items.Len())
+		for i := range items {
+			items[i] = *q.items.At(i)
+		}
+		q.mu.Unlock()
+
+		slices.SortFunc(items, func(a, b indexedItem[K, P]) int {
+			switch {
+			case q.less(a.priority, b.priority):
+				return -1
+			case q.less(b.priority, a.priority):
+				return 1
+			}
+			return 0
+		})
+		for _, item := range items {
+			if !yield(item.key, item.priority) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all keys.
+func (q *IndexedPriorityQueue[K, P]) Clear() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.items.Clear()
+	clear(q.entries)
+}
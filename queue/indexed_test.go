@@ -0,0 +1,144 @@
+package queue
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	q := NewIndexedMinPriority[string, int]()
+	if _, _, ok := q.Pop(); ok {
+		t.Error("Expected Pop on an empty queue to fail")
+	}
+
+	q.Push("a", 5)
+	q.Push("b", 3)
+	q.Push("c", 8)
+	if q.Push("a", 1) {
+		t.Error("Expected Push of a queued key to fail")
+	}
+	if k, p, ok := q.Peek(); !ok || k != "b" || p != 3 {
+		t.Errorf("Peek() = %s, %d, %v; want b, 3", k, p, ok)
+	}
+
+	if !q.Update("c", 1) || q.Update("x", 1) {
+		t.Error("Expected Update to succeed only for queued keys")
+	}
+	if q.DecreaseKey("a", 6) {
+		t.Error("Expected DecreaseKey with a greater priority to fail")
+	}
+	if !q.DecreaseKey("a", 2) || !q.DecreaseKey("d", 4) {
+		t.Error("Expected DecreaseKey to lower or add")
+	}
+	if p, ok := q.Priority("a"); !ok || p != 2 {
+		t.Errorf("Priority(a) = %d, %v; want 2", p, ok)
+	}
+
+	if p, ok := q.Remove("b"); !ok || p != 3 {
+		t.Errorf("Remove(b) = %d, %v; want 3", p, ok)
+	}
+	if _, ok := q.Remove("b"); ok || q.Contains("b") {
+		t.Error("Expected b to be gone")
+	}
+
+	var all []string
+	for k, p := range q.All() {
+		if p2, _ := q.Priority(k); p2 != p {
+			t.Errorf("All() yielded %s with priority %d, want %d", k, p, p2)
+		}
+		all = append(all, k)
+	}
+	if !slices.Equal(all, []string{"c", "a", "d"}) {
+		t.Errorf("All() = %v, want [c a d]", all)
+	}
+
+	var order []string
+	for !q.IsEmpty() {
+		k, _, _ := q.Pop()
+		order = append(order, k)
+	}
+	if !slices.Equal(order, []string{"c", "a", "d"}) {
+		t.Errorf("Pop order = %v, want [c a d]", order)
+	}
+
+	q.Push("z", 0)
+	q.Clear()
+	if q.Len() != 0 || q.Contains("z") {
+		t.Error("Expected an empty queue after Clear")
+	}
+}
+
+func TestIndexedPriorityQueueRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	q := NewIndexedPriority[int](func(a, b float64) bool { return a > b }) // max first
+	ref := map[int]float64{}
+
+	for range 5000 {
+		k := rng.Intn(200)
+		p := rng.Float64()
+		switch rng.Intn(4) {
+		case 0:
+			_, had := ref[k]
+			if q.Push(k, p) == had {
+				t.Fatalf("Push(%d) disagrees with the reference", k)
+			}
+			if !had {
+				ref[k] = p
+			}
+		case 1:
+			_, had := ref[k]
+			if q.Update(k, p) != had {
+				t.Fatalf("Update(%d) disagrees with the reference", k)
+			}
+			if had {
+				ref[k] = p
+			}
+		case 2:
+			_, had := ref[k]
+			if _, ok := q.Remove(k); ok != had {
+				t.Fatalf("Remove(%d) disagrees with the reference", k)
+			}
+			delete(ref, k)
+		case 3:
+			if len(ref) == 0 {
+				continue
+			}
+			k, p, _ := q.Pop()
+			for _, other := range ref {
+				if other > p {
+					t.Fatalf("Popped %d with %v while %v is queued", k, p, other)
+				}
+			}
+			delete(ref, k)
+		}
+		if q.Len() != len(ref) {
+			t.Fatalf("Len() = %d, want %d", q.Len(), len(ref))
+		}
+	}
+}
+
+func TestIndexedPriorityQueueConcurrency(t *testing.T) {
+	q := NewIndexedMinPriority[int, int]()
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				k := g*1000 + i
+				q.Push(k, i)
+				q.DecreaseKey(k, i-1)
+				if i%2 == 0 {
+					q.Remove(k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if q.Len() != 8*250 {
+		t.Errorf("Len() = %d, want %d", q.Len(), 8*250)
+	}
+}
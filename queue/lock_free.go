@@ -0,0 +1,163 @@
+package queue
+
+import (
+	"iter"
+	"sync/atomic"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// LockFreeQueue is an unbounded, lock-free multi-producer multi-consumer FIFO queue.
+// It implements the Michael–Scott algorithm on top of sync/atomic, so Enqueue and Dequeue
+// never block each other. The garbage collector rules out the ABA problem that
+// the algorithm otherwise needs tagged pointers for. The zero value is an empty queue ready to use.
+type LockFreeQueue[T any] struct {
+	head  atomic.Pointer[lfNode[T]]
+	tail  atomic.Pointer[lfNode[T]]
+	count atomic.Int64
+}
+
+var _ collection.Collection[int] = (*LockFreeQueue[int])(nil)
+
+type lfNode[T any] struct {
+	value T
+	next  atomic.Pointer[lfNode[T]]
+}
+
+// NewLockFree returns a new empty LockFreeQueue.
+func NewLockFree[T any]() *LockFreeQueue[T] {
+	q := &LockFreeQueue[T]{}
+	q.init()
+	return q
+}
+
+// init installs the sentinel node of a zero-value queue. Racing callers agree on a single sentinel:
+// head is set first, and tail, which is never reset to nil, only once head is.
+func (q *LockFreeQueue[T]) init() {
+	if q.tail.Load() != nil {
+		return
+	}
+	q.head.CompareAndSwap(nil, &lfNode[T]{})
+	q.tail.CompareAndSwap(nil, q.head.Load())
+}
+
+// Enqueue adds an element to the back of the queue.
+func (q *LockFreeQueue[T]) Enqueue(v T) {
+	q.init()
+	n := &lfNode[T]{value: v}
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+		if tail != q.tail.Load() {
+			continue
+		}
+
+		if next != nil {
+			// Tail is lagging behind, help move it forward.
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			q.count.Add(1)
+			return
+		}
+	}
+}
+
+// Dequeue removes and returns the front element of the queue.
+// Returns (zero-value, false) if the queue is empty.
+func (q *LockFreeQueue[T]) Dequeue() (T, bool) {
+	q.init()
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+		if head != q.head.Load() {
+			continue
+		}
+
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+
+		if head == tail {
+			// Tail is lagging behind, help move it forward.
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		// next becomes the new sentinel. Its value cannot be zeroed without racing with
+		// concurrent readers, so it stays reachable until the following Dequeue.
+		v := next.value
+		if q.head.CompareAndSwap(head, next) {
+			q.count.Add(-1)
+			return v, true
+		}
+	}
+}
+
+// Peek returns the front element of the queue without removing it.
+// Returns (zero-value, false) if the queue is empty.
+// Under concurrent use the result may already be stale when it is returned.
+func (q *LockFreeQueue[T]) Peek() (T, bool) {
+	q.init()
+	for {
+		head := q.head.Load()
+		next := head.next.Load()
+		if next == nil {
+			var zero T
+			return zero, false
+		}
+
+		v := next.value
+		if head == q.head.Load() {
+			return v, true
+		}
+	}
+}
+
+// IsEmpty returns true if the queue has no elements.
+func (q *LockFreeQueue[T]) IsEmpty() bool {
+	q.init()
+	return q.head.Load().next.Load() == nil
+}
+
+// Len returns the current number of elements in the queue.
+// Under concurrent use the result is approximate.
+func (q *LockFreeQueue[T]) Len() int {
+	n := q.count.Load()
+	if n < 0 {
+		// Dequeue may briefly overtake the matching Enqueue's increment.
+		return 0
+	}
+	return int(n)
+}
+
+// Clear removes the elements queued when it is called, by dequeuing them.
+// Elements enqueued concurrently may remain.
+func (q *LockFreeQueue[T]) Clear() {
+	q.init()
+	last := q.tail.Load()
+	for q.head.Load() != last {
+		if _, ok := q.Dequeue(); !ok {
+			return
+		}
+	}
+}
+
+// All returns an iterator over the elements in FIFO order, without consuming them.
+// It walks the queue as it goes and never blocks, so under concurrent use it may yield elements
+// dequeued since iteration started and may or may not yield elements enqueued since.
+func (q *LockFreeQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.init()
+		for n := q.head.Load().next.Load(); n != nil; n = n.next.Load() {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
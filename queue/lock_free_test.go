@@ -0,0 +1,131 @@
+package queue
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestLockFreeQueue(t *testing.T) {
+	q := NewLockFree[int]()
+
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue")
+	}
+
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3)
+
+	if q.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", q.Len())
+	}
+
+	v, ok := q.Peek()
+	if !ok || v != 1 {
+		t.Errorf("Peek expected 1, got %v", v)
+	}
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("All() = %v", got)
+	}
+
+	for _, exp := range []int{1, 2, 3} {
+		v, ok := q.Dequeue()
+		if !ok || v != exp {
+			t.Errorf("Dequeue expected %d, got %v", exp, v)
+		}
+	}
+
+	if _, ok := q.Dequeue(); ok {
+		t.Errorf("Expected false on empty dequeue")
+	}
+
+	if !q.IsEmpty() || q.Len() != 0 {
+		t.Errorf("Expected empty queue")
+	}
+
+	q.Enqueue(4)
+	q.Enqueue(5)
+	q.Clear()
+	if !q.IsEmpty() || q.Len() != 0 {
+		t.Errorf("Expected empty queue after Clear")
+	}
+}
+
+func TestLockFreeQueueZeroValue(t *testing.T) {
+	var q LockFreeQueue[int]
+	if !q.IsEmpty() {
+		t.Error("Expected a zero-value queue to be empty")
+	}
+	if _, ok := q.Dequeue(); ok {
+		t.Error("Expected false on empty dequeue")
+	}
+
+	var zero LockFreeQueue[int]
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			zero.Enqueue(i)
+		}()
+	}
+	wg.Wait()
+	if zero.Len() != 8 || len(slices.Collect(zero.All())) != 8 {
+		t.Errorf("Expected 8 elements enqueued concurrently, got %d", zero.Len())
+	}
+}
+
+func TestLockFreeQueueConcurrency(t *testing.T) {
+	q := NewLockFree[int]()
+	var wg sync.WaitGroup
+	numOps := 1000
+	numGophers := 50
+
+	var mu sync.Mutex
+	seen := make(map[int]bool, numOps*numGophers)
+
+	for i := 0; i < numGophers; i++ {
+		wg.Add(2)
+		go func(base int) {
+			defer wg.Done()
+			for j := 0; j < numOps; j++ {
+				q.Enqueue(base + j)
+			}
+		}(i * 10000)
+		go func() {
+			defer wg.Done()
+			for got := 0; got < numOps; {
+				if v, ok := q.Dequeue(); ok {
+					mu.Lock()
+					seen[v] = true
+					mu.Unlock()
+					got++
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if len(seen) != numOps*numGophers {
+		t.Errorf("Expected %d distinct elements, got %d", numOps*numGophers, len(seen))
+	}
+
+	if !q.IsEmpty() || q.Len() != 0 {
+		t.Errorf("Expected empty queue, got length %d", q.Len())
+	}
+}
+
+func TestLockFreeQueueOrderPerProducer(t *testing.T) {
+	q := NewLockFree[int]()
+	for i := 0; i < 100; i++ {
+		q.Enqueue(i)
+	}
+	for i := 0; i < 100; i++ {
+		v, ok := q.Dequeue()
+		if !ok || v != i {
+			t.Fatalf("Expected %d, got %v", i, v)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package queue
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dullkingsman/kozo/codec"
+)
+
+// DefaultSegmentSize is the size at which a PersistentQueue rolls over to a new segment file.
+const DefaultSegmentSize int64 = 4 << 20
+
+// MaxRecordSize is the largest encoded element a PersistentQueue stores. Reading a record header that
+// claims a larger payload fails with ErrCorrupt instead of allocating it.
+const MaxRecordSize = 64 << 20
+
+var (
+	// ErrClosed is returned by operations on a PersistentQueue that has been closed.
+	ErrClosed = errors.New("queue: closed")
+
+	// ErrCorrupt is wrapped by the error Dequeue and Peek return when a record fails its checksum or has
+	// an impossible length. The rest of the segment holding it is skipped, so later calls go on with
+	// the following segment.
+	ErrCorrupt = errors.New("queue: corrupt record")
+
+	// ErrRecordTooLarge is returned by Enqueue for an element whose encoding exceeds MaxRecordSize.
+	ErrRecordTooLarge = errors.New("queue: record too large")
+)
+
+const (
+	segmentExt   = ".seg"
+	indexFile    = "index"
+	recordHeader = 8 // 4-byte length + 4-byte CRC32 of the payload
+)
+
+// PersistentQueue is a thread-safe, file-backed FIFO queue whose contents survive process restarts.
+//
+// Elements are encoded with the codec registered for T and appended to segment files in dir.
+// A small index file records the read position, and fully consumed segments are deleted.
+// Writes reach the operating system immediately but are only guaranteed to be on disk after Sync.
+// After a crash, elements dequeued since the last Sync may be delivered again (at-least-once delivery).
+// Since its operations can fail with I/O errors, it has no Clear or All and is not a collection.Container.
+type PersistentQueue[T any] struct {
+	mu          sync.Mutex
+	dir         string
+	segmentSize int64
+
+	// segments lists the ids of live segment files in order. The last one is being written.
+	segments []uint64
+	sizes    map[uint64]int64
+	counts   map[uint64]int // number of unread records per segment
+
+	readFile  *os.File
+	readOff   int64
+	writeFile *os.File
+	index     *os.File
+
+	count  int
+	closed bool
+}
+
+// OpenPersistent opens (or creates) a PersistentQueue stored in dir using DefaultSegmentSize.
+func OpenPersistent[T any](dir string) (*PersistentQueue[T], error) {
+	return OpenPersistentWithSegmentSize[T](dir, DefaultSegmentSize)
+}
+
+// OpenPersistentWithSegmentSize opens (or creates) a PersistentQueue stored in dir,
+// rolling over to a new segment file once the current one reaches segmentSize bytes.
+func OpenPersistentWithSegmentSize[T any](dir string, segmentSize int64) (*PersistentQueue[T], error) {
+	if segmentSize < 1 {
+		segmentSize = DefaultSegmentSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	q := &PersistentQueue[T]{
+		dir:         dir,
+		segmentSize: segmentSize,
+		sizes:       make(map[uint64]int64),
+		counts:      make(map[uint64]int),
+	}
+	if err := q.open(); err != nil {
+		q.closeFiles()
+		return nil, err
+	}
+	return q, nil
+}
+
+// Enqueue appends an element to the back of the queue.
+func (q *PersistentQueue[T]) Enqueue(v T) error {
+	payload, err := codec.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if len(payload) > MaxRecordSize {
+		return fmt.Errorf("%w: %d bytes", ErrRecordTooLarge, len(payload))
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+
+	last := q.segments[len(q.segments)-1]
+	if q.sizes[last] > 0 && q.sizes[last]+recordHeader+int64(len(payload)) > q.segmentSize {
+		if err := q.roll(); err != nil {
+			return err
+		}
+		last = q.segments[len(q.segments)-1]
+	}
+
+	record := make([]byte, recordHeader+len(payload))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.ChecksumIEEE(payload))
+	copy(record[recordHeader:], payload)
+
+	if _, err := q.writeFile.WriteAt(record, q.sizes[last]); err != nil {
+		return err
+	}
+	q.sizes[last] += int64(len(record))
+	q.counts[last]++
+	q.count++
+
+	return nil
+}
+
+// Dequeue removes and returns the front element of the queue.
+// Returns (zero-value, false, nil) if the queue is empty. An element that fails to decode is skipped,
+// and the returned error wraps the codec's error.
+func (q *PersistentQueue[T]) Dequeue() (T, bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	v, next, ok, err := q.peek()
+	if !ok || err != nil {
+		return v, ok, err
+	}
+
+	q.readOff = next
+	q.counts[q.segments[0]]--
+	q.count--
+	if err := q.writeIndex(); err != nil {
+		return v, true, err
+	}
+
+	return v, true, nil
+}
+
+// Peek returns the front element of the queue without removing it.
+// Returns (zero-value, false, nil) if the queue is empty. Like Dequeue, it skips an element that fails to decode.
+func (q *PersistentQueue[T]) Peek() (T, bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	v, _, ok, err := q.peek()
+	return v, ok, err
+}
+
+// IsEmpty returns true if the queue has no elements.
+func (q *PersistentQueue[T]) IsEmpty() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count == 0
+}
+
+// Len returns the current number of elements in the queue.
+func (q *PersistentQueue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.count
+}
+
+// Sync commits the segment being written and the read position to stable storage.
+func (q *PersistentQueue[T]) Sync() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	if err := q.writeFile.Sync(); err != nil {
+		return err
+	}
+	return q.index.Sync()
+}
+
+// Close syncs and closes the queue. Further operations return ErrClosed.
+func (q *PersistentQueue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return ErrClosed
+	}
+	q.closed = true
+
+	err := errors.Join(q.writeFile.Sync(), q.index.Sync())
+	return errors.Join(err, q.closeFiles())
+}
+
+// open loads the index, discovers segments and recovers the write position. Must be called with lock held.
+func (q *PersistentQueue[T]) open() error {
+	var (
+		readSeg uint64
+		err     error
+	)
+
+	q.index, err = os.OpenFile(filepath.Join(q.dir, indexFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 16)
+	if _, err := q.index.ReadAt(buf, 0); err == nil {
+		readSeg = binary.BigEndian.Uint64(buf[0:8])
+		q.readOff = int64(binary.BigEndian.Uint64(buf[8:16]))
+	} else if !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		id, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		if id < readSeg {
+			// Already consumed, left behind by an interrupted deletion.
+			_ = os.Remove(filepath.Join(q.dir, name))
+			continue
+		}
+		q.segments = append(q.segments, id)
+	}
+	sort.Slice(q.segments, func(i, j int) bool { return q.segments[i] < q.segments[j] })
+
+	if len(q.segments) == 0 || q.segments[0] != readSeg {
+		// The read segment is gone (fresh queue or fully consumed), start reading at the first live one.
+		if len(q.segments) == 0 {
+			q.segments = []uint64{readSeg}
+		}
+		q.readOff = 0
+	}
+
+	for i, id := range q.segments {
+		f, err := os.OpenFile(q.segmentPath(id), os.O_RDWR|os.O_CREATE, 0o644)
+		if err != nil {
+			return err
+		}
+
+		start := int64(0)
+		if i == 0 {
+			info, err := f.Stat()
+			if err != nil {
+				f.Close()
+				return err
+			}
+			q.readOff = min(q.readOff, info.Size())
+			start = q.readOff
+		}
+		n, end, err := scanSegment(f, start)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		q.count += n
+		q.counts[id] = n
+		q.sizes[id] = end
+
+		switch {
+		case i == len(q.segments)-1:
+			// Drop a record torn by a crash so new records are appended after the last valid one.
+			if err := f.Truncate(end); err != nil {
+				f.Close()
+				return err
+			}
+			q.writeFile = f
+			if i == 0 {
+				q.readFile = f
+			}
+		case i == 0:
+			q.readFile = f
+		default:
+			f.Close()
+		}
+	}
+
+	return q.writeIndex()
+}
+
+// peek decodes the front element and returns it with the offset of the following record,
+// dropping fully consumed segments along the way. Must be called with lock held.
+func (q *PersistentQueue[T]) peek() (v T, next int64, ok bool, err error) {
+	if q.closed {
+		return v, 0, false, ErrClosed
+	}
+
+	for q.count > 0 {
+		head := q.segments[0]
+		if q.readOff >= q.sizes[head] {
+			if len(q.segments) == 1 {
+				break
+			}
+			if err := q.dropHead(); err != nil {
+				return v, 0, false, err
+			}
+			continue
+		}
+
+		payload, err := readRecord(q.readFile, q.readOff)
+		if errors.Is(err, ErrCorrupt) {
+			// Records after a corrupt one cannot be framed, so skip to the end of the segment.
+			err = fmt.Errorf("queue: segment %d at offset %d, skipping %d elements: %w", head, q.readOff, q.counts[head], err)
+			q.count -= q.counts[head]
+			q.counts[head] = 0
+			q.readOff = q.sizes[head]
+			if ierr := q.writeIndex(); ierr != nil {
+				err = errors.Join(err, ierr)
+			}
+			return v, 0, false, err
+		}
+		if err != nil {
+			return v, 0, false, fmt.Errorf("queue: reading segment %d: %w", head, err)
+		}
+		next := q.readOff + recordHeader + int64(len(payload))
+		if err := codec.Unmarshal(payload, &v); err != nil {
+			// The record is intact but its element cannot be decoded, so skip just this record.
+			err = fmt.Errorf("queue: segment %d at offset %d, skipping element: %w", head, q.readOff, err)
+			q.readOff = next
+			q.counts[head]--
+			q.count--
+			if ierr := q.writeIndex(); ierr != nil {
+				err = errors.Join(err, ierr)
+			}
+			var zero T
+			return zero, 0, false, err
+		}
+		return v, next, true, nil
+	}
+
+	return v, 0, false, nil
+}
+
+// roll starts a new segment file for writing. Must be called with lock held.
+func (q *PersistentQueue[T]) roll() error {
+	id := q.segments[len(q.segments)-1] + 1
+	f, err := os.OpenFile(q.segmentPath(id), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if err := q.writeFile.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if q.writeFile != q.readFile {
+		q.writeFile.Close()
+	}
+
+	q.writeFile = f
+	q.segments = append(q.segments, id)
+	q.sizes[id] = 0
+	return nil
+}
+
+// dropHead deletes the fully consumed head segment and moves reading to the next one.
+// Must be called with lock held.
+func (q *PersistentQueue[T]) dropHead() error {
+	head := q.segments[0]
+	next := q.segments[1]
+
+	var f *os.File
+	if len(q.segments) == 2 {
+		f = q.writeFile
+	} else {
+		var err error
+		if f, err = os.Open(q.segmentPath(next)); err != nil {
+			return err
+		}
+	}
+
+	q.readFile.Close()
+	q.readFile = f
+	q.readOff = 0
+	q.segments = q.segments[1:]
+	delete(q.sizes, head)
+	delete(q.counts, head)
+
+	// Persist the new read position before deleting, so a crash never points the index at a missing segment.
+	if err := q.writeIndex(); err != nil {
+		return err
+	}
+	return os.Remove(q.segmentPath(head))
+}
+
+// writeIndex records the read position. Must be called with lock held.
+func (q *PersistentQueue[T]) writeIndex() error {
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf[0:8], q.segments[0])
+	binary.BigEndian.PutUint64(buf[8:16], uint64(q.readOff))
+	_, err := q.index.WriteAt(buf, 0)
+	return err
+}
+
+// closeFiles closes every open file handle. Must be called with lock held.
+func (q *PersistentQueue[T]) closeFiles() error {
+	var errs []error
+	if q.readFile != nil && q.readFile != q.writeFile {
+		errs = append(errs, q.readFile.Close())
+	}
+	if q.writeFile != nil {
+		errs = append(errs, q.writeFile.Close())
+	}
+	if q.index != nil {
+		errs = append(errs, q.index.Close())
+	}
+	return errors.Join(errs...)
+}
+
+func (q *PersistentQueue[T]) segmentPath(id uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d%s", id, segmentExt))
+}
+
+// scanSegment counts the valid records in f starting at off and returns the offset after the last one.
+func scanSegment(f *os.File, off int64) (int, int64, error) {
+	n := 0
+	for {
+		payload, err := readRecord(f, off)
+		if err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, ErrCorrupt) {
+				return n, off, nil
+			}
+			return n, off, err
+		}
+		off += recordHeader + int64(len(payload))
+		n++
+	}
+}
+
+// readRecord reads and verifies the record at off.
+func readRecord(f *os.File, off int64) ([]byte, error) {
+	header := make([]byte, recordHeader)
+	if _, err := f.ReadAt(header, off); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[0:4])
+	if size > MaxRecordSize {
+		return nil, ErrCorrupt
+	}
+	payload := make([]byte, size)
+	if _, err := f.ReadAt(payload, off+recordHeader); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if crc32.ChecksumIEEE(payload) != binary.BigEndian.Uint32(header[4:8]) {
+		return nil, ErrCorrupt
+	}
+	return payload, nil
+}
@@ -0,0 +1,267 @@
+package queue
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dullkingsman/kozo/codec"
+)
+
+func TestPersistentQueue(t *testing.T) {
+	q, err := OpenPersistent[string](t.TempDir())
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer q.Close()
+
+	if !q.IsEmpty() {
+		t.Error("Expected empty queue")
+	}
+
+	for _, v := range []string{"a", "b", "c"} {
+		if err := q.Enqueue(v); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+
+	if q.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", q.Len())
+	}
+
+	v, ok, err := q.Peek()
+	if err != nil || !ok || v != "a" {
+		t.Errorf("Peek expected a, got %v (ok: %v, err: %v)", v, ok, err)
+	}
+
+	for _, exp := range []string{"a", "b", "c"} {
+		v, ok, err := q.Dequeue()
+		if err != nil || !ok || v != exp {
+			t.Errorf("Dequeue expected %s, got %v (ok: %v, err: %v)", exp, v, ok, err)
+		}
+	}
+
+	if _, ok, err := q.Dequeue(); ok || err != nil {
+		t.Errorf("Expected empty dequeue, got ok: %v, err: %v", ok, err)
+	}
+}
+
+func TestPersistentQueueReopen(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := OpenPersistentWithSegmentSize[int](dir, 64)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		if err := q.Enqueue(i); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		if v, _, _ := q.Dequeue(); v != i {
+			t.Fatalf("Expected %d, got %d", i, v)
+		}
+	}
+	if err := q.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if err := q.Enqueue(99); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected ErrClosed, got %v", err)
+	}
+
+	q, err = OpenPersistentWithSegmentSize[int](dir, 64)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer q.Close()
+
+	if q.Len() != 30 {
+		t.Errorf("Expected 30 elements after reopen, got %d", q.Len())
+	}
+
+	q.Enqueue(50)
+	for i := 20; i <= 50; i++ {
+		v, ok, err := q.Dequeue()
+		if err != nil || !ok || v != i {
+			t.Fatalf("Expected %d, got %v (ok: %v, err: %v)", i, v, ok, err)
+		}
+	}
+
+	segments, _ := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	if len(segments) != 1 {
+		t.Errorf("Expected consumed segments to be deleted, found %d", len(segments))
+	}
+}
+
+func TestPersistentQueueTornWrite(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := OpenPersistent[string](dir)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	q.Enqueue("complete")
+	q.Close()
+
+	// Simulate a crash in the middle of appending a record.
+	segments, _ := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	f, err := os.OpenFile(segments[0], os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte{0, 0, 0, 42, 1, 2})
+	f.Close()
+
+	q, err = OpenPersistent[string](dir)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer q.Close()
+
+	if q.Len() != 1 {
+		t.Errorf("Expected torn record to be dropped, got length %d", q.Len())
+	}
+
+	q.Enqueue("after")
+	for _, exp := range []string{"complete", "after"} {
+		v, ok, err := q.Dequeue()
+		if err != nil || !ok || v != exp {
+			t.Errorf("Expected %s, got %v (ok: %v, err: %v)", exp, v, ok, err)
+		}
+	}
+}
+
+func TestPersistentQueueDecodeError(t *testing.T) {
+	dir := t.TempDir()
+
+	q, _ := OpenPersistent[string](dir)
+	q.Enqueue("not a number")
+	q.Close()
+
+	q2, err := OpenPersistent[int](dir)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer q2.Close()
+
+	if _, _, err := q2.Dequeue(); err == nil || !strings.Contains(err.Error(), "cannot unmarshal") {
+		t.Errorf("Expected decode error, got %v", err)
+	}
+	if q2.Len() != 0 {
+		t.Error("Expected the undecodable element to be skipped")
+	}
+}
+
+func TestPersistentQueueDecodeErrorSkipsElement(t *testing.T) {
+	type job string
+	errRejected := errors.New("rejected")
+	codec.Register(codec.Funcs(
+		func(v job) ([]byte, error) { return []byte(v), nil },
+		func(data []byte, v *job) error {
+			if string(data) == "bad" {
+				return errRejected
+			}
+			*v = job(data)
+			return nil
+		},
+	))
+	defer codec.Unregister[job]()
+
+	dir := t.TempDir()
+	q, err := OpenPersistent[job](dir)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	for _, v := range []job{"bad", "good"} {
+		q.Enqueue(v)
+	}
+
+	if _, ok, err := q.Peek(); ok || !errors.Is(err, errRejected) {
+		t.Errorf("Expected Peek to fail with the codec error, got ok %v, err %v", ok, err)
+	}
+	if q.Len() != 1 {
+		t.Errorf("Expected 1 element after skipping bad, got %d", q.Len())
+	}
+	q.Close()
+
+	// The skip is persisted, so a reopened queue goes on with the next element.
+	q2, err := OpenPersistent[job](dir)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer q2.Close()
+	if v, ok, err := q2.Dequeue(); err != nil || !ok || v != "good" {
+		t.Errorf("Expected good after skipping bad, got %v (ok: %v, err: %v)", v, ok, err)
+	}
+}
+
+func TestPersistentQueueCorruptRecord(t *testing.T) {
+	dir := t.TempDir()
+
+	// Three 11-byte records fill the first segment, the fourth rolls over.
+	q, err := OpenPersistentWithSegmentSize[string](dir, 33)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer q.Close()
+	for _, v := range []string{"a", "b", "c", "d"} {
+		q.Enqueue(v)
+	}
+
+	// Flip a payload byte of "b" on disk, behind the back of the open queue.
+	segments, _ := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	f, err := os.OpenFile(segments[0], os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteAt([]byte{'x'}, 11+recordHeader+1)
+	f.Close()
+
+	if v, ok, err := q.Dequeue(); err != nil || !ok || v != "a" {
+		t.Errorf("Expected a, got %v (ok: %v, err: %v)", v, ok, err)
+	}
+	if _, _, err := q.Dequeue(); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Expected ErrCorrupt, got %v", err)
+	}
+	if q.Len() != 1 {
+		t.Errorf("Expected the rest of the segment to be skipped, got length %d", q.Len())
+	}
+	if v, ok, err := q.Dequeue(); err != nil || !ok || v != "d" {
+		t.Errorf("Expected d after the corrupt segment, got %v (ok: %v, err: %v)", v, ok, err)
+	}
+}
+
+func TestPersistentQueueRecordSize(t *testing.T) {
+	dir := t.TempDir()
+
+	q, err := OpenPersistent[string](dir)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer q.Close()
+
+	if err := q.Enqueue(strings.Repeat("x", MaxRecordSize)); !errors.Is(err, ErrRecordTooLarge) {
+		t.Errorf("Expected ErrRecordTooLarge, got %v", err)
+	}
+
+	// A corrupt header claiming a 4 GiB payload must not be allocated.
+	q.Enqueue("a")
+	segments, _ := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	f, err := os.OpenFile(segments[0], os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteAt([]byte{0xff, 0xff, 0xff, 0xff}, 0)
+	f.Close()
+
+	if _, _, err := q.Dequeue(); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("Expected ErrCorrupt, got %v", err)
+	}
+	if !q.IsEmpty() {
+		t.Errorf("Expected the corrupt record to be skipped, got length %d", q.Len())
+	}
+}
@@ -1,7 +1,12 @@
 package queue
 
 import (
+	"fmt"
+	"iter"
 	"sync"
+
+	"github.com/dullkingsman/kozo/codec"
+	"github.com/dullkingsman/kozo/collection"
 )
 
 // Queue is a thread-safe FIFO data structure implemented with a circular buffer.
@@ -11,12 +16,32 @@ type Queue[T any] struct {
 	head  int
 	tail  int
 	count int
+
+	// minCap is the capacity the buffer never shrinks below.
+	minCap int
+	// shrinkAt is the fraction of capacity below which the buffer is halved. Zero disables shrinking.
+	shrinkAt float64
+
+	// Instrumentation, see Stats.
+	enqueued  uint64
+	dequeued  uint64
+	highWater int
+	observer  Observer
+
+	// notEmpty is created lazily by AsChan pumps and closed to wake them.
+	notEmpty chan struct{}
 }
 
+var (
+	_ codec.Snapshotter          = (*Queue[int])(nil)
+	_ collection.Collection[int] = (*Queue[int])(nil)
+)
+
 // New returns a new empty Queue.
 func New[T any]() *Queue[T] {
 	return &Queue[T]{
-		data: make([]T, 2), // Initial small capacity
+		data:   make([]T, 2), // Initial small capacity
+		minCap: 2,
 	}
 }
 
@@ -26,7 +51,8 @@ func NewWithCapacity[T any](capacity int) *Queue[T] {
 		capacity = 1
 	}
 	return &Queue[T]{
-		data: make([]T, capacity),
+		data:   make([]T, capacity),
+		minCap: capacity,
 	}
 }
 
@@ -36,12 +62,36 @@ func (q *Queue[T]) Enqueue(v T) {
 	defer q.mu.Unlock()
 
 	if q.count == len(q.data) {
-		q.resize()
+		q.resize(q.count + 1)
 	}
 
 	q.data[q.tail] = v
 	q.tail = (q.tail + 1) % len(q.data)
 	q.count++
+	q.recordEnqueue(1)
+	broadcast(&q.notEmpty)
+}
+
+// EnqueueAll adds elements to the back of the queue in order.
+// The whole batch is added under a single lock acquisition with at most one resize.
+func (q *Queue[T]) EnqueueAll(items ...T) {
+	if len(items) == 0 {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count+len(items) > len(q.data) {
+		q.resize(q.count + len(items))
+	}
+
+	for _, v := range items {
+		q.data[q.tail] = v
+		q.tail = (q.tail + 1) % len(q.data)
+	}
+	q.count += len(items)
+	q.recordEnqueue(len(items))
+	broadcast(&q.notEmpty)
 }
 
 // Dequeue removes and returns the front element of the queue.
@@ -55,16 +105,38 @@ func (q *Queue[T]) Dequeue() (T, bool) {
 		return zero, false
 	}
 
-	v := q.data[q.head]
+	v := q.pop()
+	q.recordDequeue(1)
 
-	// Zero out the element to prevent memory leaks (GC can reclaim it)
-	var zero T
-	q.data[q.head] = zero
+	return v, true
+}
 
-	q.head = (q.head + 1) % len(q.data)
-	q.count--
+// DequeueN removes and returns up to n elements from the front of the queue in FIFO order.
+// Returns an empty slice if the queue is empty or n is not positive.
+func (q *Queue[T]) DequeueN(n int) []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
 
-	return v, true
+	if n > q.count {
+		n = q.count
+	}
+	if n <= 0 {
+		return []T{}
+	}
+
+	res := make([]T, n)
+	var zero T
+	for i := 0; i < n; i++ {
+		res[i] = q.data[q.head]
+		// Zero out the element to prevent memory leaks (GC can reclaim it)
+		q.data[q.head] = zero
+		q.head = (q.head + 1) % len(q.data)
+	}
+	q.count -= n
+	q.maybeShrink()
+	q.recordDequeue(n)
+
+	return res
 }
 
 // Peek returns the front element of the queue without removing it.
@@ -81,6 +153,69 @@ func (q *Queue[T]) Peek() (T, bool) {
 	return q.data[q.head], true
 }
 
+// PeekAt returns the element at position i without removing it, where 0 is the front.
+// Returns (zero-value, false) if i is out of range.
+func (q *Queue[T]) PeekAt(i int) (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if i < 0 || i >= q.count {
+		var zero T
+		return zero, false
+	}
+
+	return q.data[(q.head+i)%len(q.data)], true
+}
+
+// Contains returns true if the queue contains an element equal to v according to equals.
+func (q *Queue[T]) Contains(v T, equals func(T, T) bool) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := 0; i < q.count; i++ {
+		if equals(q.data[(q.head+i)%len(q.data)], v) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveFirst removes and returns the element closest to the front that satisfies pred.
+// The remaining elements keep their relative order. Returns (zero-value, false) if no element matches.
+func (q *Queue[T]) RemoveFirst(pred func(T) bool) (T, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for i := 0; i < q.count; i++ {
+		idx := (q.head + i) % len(q.data)
+		if !pred(q.data[idx]) {
+			continue
+		}
+
+		v := q.data[idx]
+
+		// Shift the following elements one slot towards the front to close the gap.
+		for j := i; j < q.count-1; j++ {
+			q.data[(q.head+j)%len(q.data)] = q.data[(q.head+j+1)%len(q.data)]
+		}
+
+		q.tail = (q.tail - 1 + len(q.data)) % len(q.data)
+
+		// Zero out the vacated slot to prevent memory leaks (GC can reclaim it)
+		var zero T
+		q.data[q.tail] = zero
+
+		q.count--
+		q.maybeShrink()
+		q.recordDequeue(1)
+
+		return v, true
+	}
+
+	var zero T
+	return zero, false
+}
+
 // IsEmpty returns true if the queue has no elements.
 func (q *Queue[T]) IsEmpty() bool {
 	q.mu.Lock()
@@ -109,14 +244,205 @@ func (q *Queue[T]) Clear() {
 	q.head = 0
 	q.tail = 0
 	q.count = 0
+	q.maybeShrink()
 }
 
-// resize grows the underlying slice. Must be called with lock held.
-func (q *Queue[T]) resize() {
+// Drain removes and returns all elements of the queue in FIFO order.
+// The queue is emptied atomically, so concurrent producers observe either the old or the empty state.
+func (q *Queue[T]) Drain() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	res := q.toSliceUnsafe()
+
+	// Zero out all elements to assist GC
+	var zero T
+	for i := 0; i < len(q.data); i++ {
+		q.data[i] = zero
+	}
+
+	q.head = 0
+	q.tail = 0
+	q.count = 0
+	q.maybeShrink()
+	q.recordDequeue(len(res))
+
+	return res
+}
+
+// ToSlice returns a copy of the elements of the queue in FIFO order without consuming them.
+func (q *Queue[T]) ToSlice() []T {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.toSliceUnsafe()
+}
+
+// All returns an iterator over a snapshot of the elements in FIFO order, without consuming them.
+// The snapshot is taken when iteration starts, so the queue may be modified while iterating.
+func (q *Queue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range q.ToSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON encodes the queue as a JSON array in FIFO order,
+// encoding each element with the codec registered for T.
+func (q *Queue[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalSlice(q.ToSlice())
+}
+
+// UnmarshalJSON replaces the contents of the queue with the elements of a JSON array,
+// decoding each element with the codec registered for T. The first array element becomes the front.
+// The buffer is never smaller than the capacity the queue was created with, and the shrink threshold is kept.
+func (q *Queue[T]) UnmarshalJSON(data []byte) error {
+	items, err := codec.UnmarshalSlice[T](data)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	capacity := max(len(items), q.minCap, 1)
+	q.data = make([]T, capacity)
+	copy(q.data, items)
+	q.head = 0
+	q.tail = len(items) % capacity
+	q.count = len(items)
+	q.highWater = max(q.highWater, q.count)
+	broadcast(&q.notEmpty)
+
+	return nil
+}
+
+// Snapshot encodes the queue as its JSON array in a snapshot envelope of kind "queue"; see codec.Snapshotter.
+func (q *Queue[T]) Snapshot() ([]byte, error) {
+	payload, err := q.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return codec.EncodeSnapshot("queue", payload), nil
+}
+
+// Restore replaces the contents of the queue with those of a snapshot made by Snapshot.
+func (q *Queue[T]) Restore(data []byte) error {
+	payload, err := codec.DecodeSnapshot("queue", data)
+	if err != nil {
+		return err
+	}
+	if err := q.UnmarshalJSON(payload); err != nil {
+		return fmt.Errorf("%w: %v", codec.ErrInvalidSnapshot, err)
+	}
+	return nil
+}
+
+// pop removes and returns the front element, which must exist. Must be called with lock held.
+func (q *Queue[T]) pop() T {
+	v := q.data[q.head]
+
+	// Zero out the element to prevent memory leaks (GC can reclaim it)
+	var zero T
+	q.data[q.head] = zero
+
+	q.head = (q.head + 1) % len(q.data)
+	q.count--
+	q.maybeShrink()
+
+	return v
+}
+
+// pushFront adds an element to the front of the queue. Must be called with lock held.
+func (q *Queue[T]) pushFront(v T) {
+	if q.count == len(q.data) {
+		q.resize(q.count + 1)
+	}
+
+	q.head = (q.head - 1 + len(q.data)) % len(q.data)
+	q.data[q.head] = v
+	q.count++
+	broadcast(&q.notEmpty)
+}
+
+// toSliceUnsafe copies the elements in FIFO order. Must be called with lock held.
+func (q *Queue[T]) toSliceUnsafe() []T {
+	res := make([]T, q.count)
+	for i := 0; i < q.count; i++ {
+		res[i] = q.data[(q.head+i)%len(q.data)]
+	}
+	return res
+}
+
+// Cap returns the current capacity of the underlying buffer.
+func (q *Queue[T]) Cap() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.data)
+}
+
+// SetShrinkThreshold enables automatic shrinking of the underlying buffer.
+// Whenever the number of elements falls below fraction * capacity, the buffer is halved,
+// but never below the capacity the queue was created with.
+// A fraction of zero or less disables automatic shrinking, which is the default.
+func (q *Queue[T]) SetShrinkThreshold(fraction float64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if fraction > 0.5 {
+		// Halving above one half would leave the buffer full and thrash on the next Enqueue.
+		fraction = 0.5
+	}
+	q.shrinkAt = fraction
+	q.maybeShrink()
+}
+
+// Compact shrinks the underlying buffer to fit the current elements,
+// but never below the capacity the queue was created with.
+func (q *Queue[T]) Compact() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	newCap := max(q.count, q.minCap, 1)
+	if newCap < len(q.data) {
+		q.realloc(newCap)
+	}
+}
+
+// maybeShrink halves the underlying slice while it is emptier than the shrink threshold.
+// Must be called with lock held.
+func (q *Queue[T]) maybeShrink() {
+	if q.shrinkAt <= 0 {
+		return
+	}
+
+	newCap := len(q.data)
+	for newCap/2 >= max(q.minCap, 1) && float64(q.count) < q.shrinkAt*float64(newCap) {
+		newCap /= 2
+	}
+
+	if newCap < len(q.data) {
+		q.realloc(newCap)
+	}
+}
+
+// resize grows the underlying slice by doubling until it holds at least needed elements.
+// Must be called with lock held.
+func (q *Queue[T]) resize(needed int) {
 	newCap := len(q.data) * 2
 	if newCap == 0 {
 		newCap = 1
 	}
+	for newCap < needed {
+		newCap *= 2
+	}
+	q.realloc(newCap)
+}
+
+// realloc moves the elements into a new slice of the given capacity, which must hold them all.
+// Must be called with lock held.
+func (q *Queue[T]) realloc(newCap int) {
 	newData := make([]T, newCap)
 
 	for i := 0; i < q.count; i++ {
@@ -125,5 +451,5 @@ func (q *Queue[T]) resize() {
 
 	q.data = newData
 	q.head = 0
-	q.tail = q.count
+	q.tail = q.count % newCap
 }
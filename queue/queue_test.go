@@ -1,8 +1,14 @@
 package queue
 
 import (
+	"encoding/json"
+	"errors"
+	"slices"
 	"sync"
 	"testing"
+
+	"github.com/dullkingsman/kozo/codec"
+	"github.com/dullkingsman/kozo/stack"
 )
 
 func TestQueue(t *testing.T) {
@@ -133,3 +139,295 @@ func TestQueueWrapAround(t *testing.T) {
 		}
 	}
 }
+
+func TestQueueBatch(t *testing.T) {
+	q := NewWithCapacity[int](2)
+	q.Enqueue(0)
+	q.Dequeue() // move head off zero so the batch wraps
+
+	q.EnqueueAll(1, 2, 3, 4, 5)
+	q.EnqueueAll()
+
+	if q.Len() != 5 {
+		t.Errorf("Expected length 5, got %d", q.Len())
+	}
+
+	got := q.DequeueN(3)
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("DequeueN(3) expected [1 2 3], got %v", got)
+	}
+
+	got = q.DequeueN(10)
+	if len(got) != 2 || got[0] != 4 || got[1] != 5 {
+		t.Errorf("DequeueN(10) expected [4 5], got %v", got)
+	}
+
+	if got := q.DequeueN(1); len(got) != 0 {
+		t.Errorf("Expected empty result from empty queue, got %v", got)
+	}
+
+	q.Enqueue(6)
+	if got := q.DequeueN(-1); len(got) != 0 || q.Len() != 1 {
+		t.Errorf("Expected DequeueN(-1) to be a no-op, got %v", got)
+	}
+}
+
+func TestQueueDrainAndToSlice(t *testing.T) {
+	q := NewWithCapacity[int](3)
+	q.Enqueue(0)
+	q.Dequeue()
+	q.EnqueueAll(1, 2, 3) // wraps around the ring
+
+	got := q.ToSlice()
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("ToSlice expected [1 2 3], got %v", got)
+	}
+	if q.Len() != 3 {
+		t.Errorf("ToSlice should not consume elements, got length %d", q.Len())
+	}
+
+	got = q.Drain()
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("Drain expected [1 2 3], got %v", got)
+	}
+	if !q.IsEmpty() {
+		t.Error("Expected empty queue after Drain")
+	}
+
+	if got := q.Drain(); len(got) != 0 {
+		t.Errorf("Expected empty Drain result, got %v", got)
+	}
+
+	q.Enqueue(4)
+	if v, ok := q.Dequeue(); !ok || v != 4 {
+		t.Errorf("Expected queue to be usable after Drain, got %v", v)
+	}
+}
+
+func TestQueueJSON(t *testing.T) {
+	q := NewWithCapacity[int](2)
+	q.Enqueue(0)
+	q.Dequeue()
+	q.EnqueueAll(1, 2, 3)
+
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("Expected [1,2,3], got %s", data)
+	}
+
+	var q2 Queue[int]
+	if err := json.Unmarshal(data, &q2); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	q2.Enqueue(4)
+
+	expected := []int{1, 2, 3, 4}
+	for _, exp := range expected {
+		v, ok := q2.Dequeue()
+		if !ok || v != exp {
+			t.Errorf("Expected %d, got %v", exp, v)
+		}
+	}
+
+	if err := json.Unmarshal([]byte("[]"), &q2); err != nil || !q2.IsEmpty() {
+		t.Errorf("Expected empty queue from [], got %v (err: %v)", q2.ToSlice(), err)
+	}
+	q2.Enqueue(5)
+	if v, ok := q2.Dequeue(); !ok || v != 5 {
+		t.Errorf("Expected queue to be usable after empty Unmarshal, got %v", v)
+	}
+}
+
+func TestQueueJSONKeepsMinCap(t *testing.T) {
+	q := NewWithCapacity[int](8)
+	q.SetShrinkThreshold(0.25)
+
+	if err := json.Unmarshal([]byte("[1,2]"), q); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if q.Cap() != 8 {
+		t.Errorf("Expected capacity 8 after Unmarshal, got %d", q.Cap())
+	}
+
+	for i := 3; i <= 32; i++ {
+		q.Enqueue(i)
+	}
+	for q.Len() > 1 {
+		q.Dequeue()
+	}
+	if q.Cap() != 8 {
+		t.Errorf("Expected buffer to shrink back to 8, got %d", q.Cap())
+	}
+	q.Compact()
+	if q.Cap() != 8 {
+		t.Errorf("Expected Compact to keep capacity 8, got %d", q.Cap())
+	}
+}
+
+func TestQueueAll(t *testing.T) {
+	q := NewWithCapacity[int](2)
+	q.Enqueue(0)
+	q.Dequeue()
+	q.EnqueueAll(1, 2, 3)
+
+	for v := range q.All() {
+		q.Enqueue(v * 10) // iterates over a snapshot
+	}
+	if got := q.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 10, 20, 30}) {
+		t.Errorf("Expected [1 2 3 10 20 30], got %v", got)
+	}
+}
+
+func TestQueueSnapshot(t *testing.T) {
+	q := New[int]()
+	q.EnqueueAll(1, 2, 3)
+
+	data, err := q.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	q2 := New[int]()
+	q2.Enqueue(9)
+	if err := q2.Restore(data); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if got := q2.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+
+	s := stack.New[int]()
+	s.Push(1)
+	other, _ := s.Snapshot()
+	if err := q2.Restore(other); !errors.Is(err, codec.ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot restoring a stack, got %v", err)
+	}
+	if err := q2.Restore(codec.EncodeSnapshot("queue", []byte(`[1,"x"]`))); !errors.Is(err, codec.ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot for a bad payload, got %v", err)
+	}
+	if q2.Len() != 3 {
+		t.Errorf("Expected a failed Restore to keep the queue, got %v", q2.ToSlice())
+	}
+}
+
+func TestQueueShrink(t *testing.T) {
+	q := NewWithCapacity[int](4)
+	q.SetShrinkThreshold(0.25)
+
+	for i := 0; i < 1000; i++ {
+		q.Enqueue(i)
+	}
+	if q.Cap() < 1000 {
+		t.Fatalf("Expected capacity to grow to at least 1000, got %d", q.Cap())
+	}
+
+	for i := 0; i < 1000; i++ {
+		v, ok := q.Dequeue()
+		if !ok || v != i {
+			t.Fatalf("Expected %d, got %v", i, v)
+		}
+	}
+
+	if q.Cap() != 4 {
+		t.Errorf("Expected capacity to shrink back to 4, got %d", q.Cap())
+	}
+}
+
+func TestQueueShrinkDisabledByDefault(t *testing.T) {
+	q := New[int]()
+	q.EnqueueAll(1, 2, 3, 4, 5, 6, 7, 8)
+	q.Drain()
+
+	if q.Cap() != 8 {
+		t.Errorf("Expected capacity to be retained, got %d", q.Cap())
+	}
+}
+
+func TestQueueCompact(t *testing.T) {
+	q := NewWithCapacity[int](2)
+	q.EnqueueAll(1, 2, 3, 4, 5, 6, 7, 8, 9)
+	q.DequeueN(6)
+
+	q.Compact()
+	if q.Cap() != 3 {
+		t.Errorf("Expected capacity 3 after Compact, got %d", q.Cap())
+	}
+
+	q.Enqueue(10)
+	expected := []int{7, 8, 9, 10}
+	for _, exp := range expected {
+		v, ok := q.Dequeue()
+		if !ok || v != exp {
+			t.Errorf("Expected %d, got %v", exp, v)
+		}
+	}
+
+	q.Compact()
+	if q.Cap() != 2 {
+		t.Errorf("Expected Compact not to shrink below initial capacity, got %d", q.Cap())
+	}
+}
+
+func TestQueueContainsAndRemoveFirst(t *testing.T) {
+	equals := func(a, b int) bool { return a == b }
+
+	q := NewWithCapacity[int](4)
+	q.EnqueueAll(0, 0)
+	q.DequeueN(2)
+	q.EnqueueAll(1, 2, 3, 4) // wraps around the ring
+
+	if !q.Contains(3, equals) {
+		t.Error("Expected queue to contain 3")
+	}
+	if q.Contains(5, equals) {
+		t.Error("Expected queue not to contain 5")
+	}
+
+	v, ok := q.RemoveFirst(func(v int) bool { return v%2 == 0 })
+	if !ok || v != 2 {
+		t.Errorf("RemoveFirst expected 2, got %v (ok: %v)", v, ok)
+	}
+
+	if _, ok := q.RemoveFirst(func(v int) bool { return v > 10 }); ok {
+		t.Error("Expected RemoveFirst to find nothing")
+	}
+
+	q.Enqueue(5)
+	expected := []int{1, 3, 4, 5}
+	got := q.ToSlice()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, got)
+			break
+		}
+	}
+}
+
+func TestQueuePeekAt(t *testing.T) {
+	q := NewWithCapacity[int](3)
+	q.EnqueueAll(0, 0)
+	q.DequeueN(2)
+	q.EnqueueAll(1, 2, 3) // wraps around the ring
+
+	for i, exp := range []int{1, 2, 3} {
+		v, ok := q.PeekAt(i)
+		if !ok || v != exp {
+			t.Errorf("PeekAt(%d) expected %d, got %v (ok: %v)", i, exp, v, ok)
+		}
+	}
+
+	if _, ok := q.PeekAt(3); ok {
+		t.Error("Expected PeekAt past the back to fail")
+	}
+	if _, ok := q.PeekAt(-1); ok {
+		t.Error("Expected PeekAt(-1) to fail")
+	}
+	if q.Len() != 3 {
+		t.Errorf("PeekAt should not consume elements, got length %d", q.Len())
+	}
+}
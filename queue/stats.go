@@ -0,0 +1,68 @@
+package queue
+
+// Stats is a point-in-time snapshot of a queue's health counters.
+type Stats struct {
+	// Enqueued is the total number of elements ever added.
+	Enqueued uint64
+	// Dequeued is the total number of elements ever handed back to callers
+	// (Dequeue, DequeueN, Drain and RemoveFirst). Elements discarded by Clear are not counted.
+	Dequeued uint64
+	// Len is the current number of elements.
+	Len int
+	// Cap is the current capacity of the underlying buffer.
+	Cap int
+	// HighWaterMark is the largest number of elements held at once.
+	HighWaterMark int
+}
+
+// Observer receives notifications whenever elements enter or leave a queue.
+// Hooks are called with the queue's lock held, so they must be fast and must not call back into the queue.
+type Observer interface {
+	// OnEnqueue is called after n elements were added, with the resulting length.
+	OnEnqueue(n int, length int)
+	// OnDequeue is called after n elements were handed back to the caller, with the resulting length.
+	OnDequeue(n int, length int)
+}
+
+// Stats returns a snapshot of the queue's counters.
+func (q *Queue[T]) Stats() Stats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return Stats{
+		Enqueued:      q.enqueued,
+		Dequeued:      q.dequeued,
+		Len:           q.count,
+		Cap:           len(q.data),
+		HighWaterMark: q.highWater,
+	}
+}
+
+// SetObserver installs o to be notified of every enqueue and dequeue. A nil observer disables notifications.
+func (q *Queue[T]) SetObserver(o Observer) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.observer = o
+}
+
+// recordEnqueue updates the counters after n elements were added. Must be called with lock held.
+func (q *Queue[T]) recordEnqueue(n int) {
+	q.enqueued += uint64(n)
+	if q.count > q.highWater {
+		q.highWater = q.count
+	}
+	if q.observer != nil {
+		q.observer.OnEnqueue(n, q.count)
+	}
+}
+
+// recordDequeue updates the counters after n elements were handed back. Must be called with lock held.
+func (q *Queue[T]) recordDequeue(n int) {
+	if n == 0 {
+		return
+	}
+	q.dequeued += uint64(n)
+	if q.observer != nil {
+		q.observer.OnDequeue(n, q.count)
+	}
+}
@@ -0,0 +1,58 @@
+package queue
+
+import "testing"
+
+type recordingObserver struct {
+	enqueued, dequeued, lastLen int
+}
+
+func (o *recordingObserver) OnEnqueue(n int, length int) {
+	o.enqueued += n
+	o.lastLen = length
+}
+
+func (o *recordingObserver) OnDequeue(n int, length int) {
+	o.dequeued += n
+	o.lastLen = length
+}
+
+func TestQueueStats(t *testing.T) {
+	q := NewWithCapacity[int](4)
+	o := &recordingObserver{}
+	q.SetObserver(o)
+
+	q.Enqueue(1)
+	q.EnqueueAll(2, 3, 4, 5)
+	q.Dequeue()
+	q.DequeueN(2)
+	q.RemoveFirst(func(v int) bool { return v == 5 })
+	q.Enqueue(6)
+	q.Drain()
+
+	st := q.Stats()
+	if st.Enqueued != 6 {
+		t.Errorf("Expected 6 enqueued, got %d", st.Enqueued)
+	}
+	if st.Dequeued != 6 {
+		t.Errorf("Expected 6 dequeued, got %d", st.Dequeued)
+	}
+	if st.Len != 0 {
+		t.Errorf("Expected length 0, got %d", st.Len)
+	}
+	if st.Cap != 8 {
+		t.Errorf("Expected capacity 8, got %d", st.Cap)
+	}
+	if st.HighWaterMark != 5 {
+		t.Errorf("Expected high-water mark 5, got %d", st.HighWaterMark)
+	}
+
+	if o.enqueued != 6 || o.dequeued != 6 || o.lastLen != 0 {
+		t.Errorf("Unexpected observer state: %+v", o)
+	}
+
+	q.SetObserver(nil)
+	q.Enqueue(7)
+	if o.enqueued != 6 {
+		t.Error("Expected observer to be detached")
+	}
+}
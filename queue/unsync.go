@@ -0,0 +1,130 @@
+package queue
+
+import (
+	"iter"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// UnsyncQueue is a FIFO data structure implemented with a circular buffer, without any locking.
+// It is intended for single-goroutine algorithmic use (e.g. BFS), where the mutex of Queue adds overhead for no benefit.
+// It must not be used concurrently from multiple goroutines.
+type UnsyncQueue[T any] struct {
+	data  []T
+	head  int
+	tail  int
+	count int
+}
+
+var _ collection.Collection[int] = (*UnsyncQueue[int])(nil)
+
+// NewUnsync returns a new empty UnsyncQueue.
+func NewUnsync[T any]() *UnsyncQueue[T] {
+	return &UnsyncQueue[T]{
+		data: make([]T, 2), // Initial small capacity
+	}
+}
+
+// NewUnsyncWithCapacity returns a new empty UnsyncQueue with pre-allocated capacity.
+func NewUnsyncWithCapacity[T any](capacity int) *UnsyncQueue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &UnsyncQueue[T]{
+		data: make([]T, capacity),
+	}
+}
+
+// Enqueue adds an element to the back of the queue.
+func (q *UnsyncQueue[T]) Enqueue(v T) {
+	if q.count == len(q.data) {
+		q.resize()
+	}
+
+	q.data[q.tail] = v
+	q.tail = (q.tail + 1) % len(q.data)
+	q.count++
+}
+
+// Dequeue removes and returns the front element of the queue.
+// Returns (zero-value, false) if the queue is empty.
+func (q *UnsyncQueue[T]) Dequeue() (T, bool) {
+	if q.count == 0 {
+		var zero T
+		return zero, false
+	}
+
+	v := q.data[q.head]
+
+	// Zero out the element to prevent memory leaks (GC can reclaim it)
+	var zero T
+	q.data[q.head] = zero
+
+	q.head = (q.head + 1) % len(q.data)
+	q.count--
+
+	return v, true
+}
+
+// Peek returns the front element of the queue without removing it.
+// Returns (zero-value, false) if the queue is empty.
+func (q *UnsyncQueue[T]) Peek() (T, bool) {
+	if q.count == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return q.data[q.head], true
+}
+
+// IsEmpty returns true if the queue has no elements.
+func (q *UnsyncQueue[T]) IsEmpty() bool {
+	return q.count == 0
+}
+
+// Len returns the current number of elements in the queue.
+func (q *UnsyncQueue[T]) Len() int {
+	return q.count
+}
+
+// Clear discards all elements from the queue.
+func (q *UnsyncQueue[T]) Clear() {
+	// Zero out all elements to assist GC
+	var zero T
+	for i := 0; i < len(q.data); i++ {
+		q.data[i] = zero
+	}
+
+	q.head = 0
+	q.tail = 0
+	q.count = 0
+}
+
+// All returns an iterator over the elements in FIFO order, without consuming them.
+// The queue must not be modified during the iteration.
+func (q *UnsyncQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < q.count; i++ {
+			if !yield(q.data[(q.head+i)%len(q.data)]) {
+				return
+			}
+		}
+	}
+}
+
+// resize doubles the underlying slice.
+func (q *UnsyncQueue[T]) resize() {
+	newCap := len(q.data) * 2
+	if newCap == 0 {
+		newCap = 1
+	}
+	newData := make([]T, newCap)
+
+	for i := 0; i < q.count; i++ {
+		newData[i] = q.data[(q.head+i)%len(q.data)]
+	}
+
+	q.data = newData
+	q.head = 0
+	q.tail = q.count
+}
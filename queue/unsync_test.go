@@ -0,0 +1,63 @@
+package queue
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUnsyncQueue(t *testing.T) {
+	q := NewUnsync[int]()
+
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue")
+	}
+
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Dequeue()
+	q.Enqueue(3) // wraps around
+	q.Enqueue(4) // triggers resize
+
+	if q.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", q.Len())
+	}
+
+	v, ok := q.Peek()
+	if !ok || v != 2 {
+		t.Errorf("Peek expected 2, got %v", v)
+	}
+
+	for _, exp := range []int{2, 3, 4} {
+		v, ok := q.Dequeue()
+		if !ok || v != exp {
+			t.Errorf("Dequeue expected %d, got %v", exp, v)
+		}
+	}
+
+	if _, ok := q.Dequeue(); ok {
+		t.Errorf("Expected false on empty dequeue")
+	}
+
+	q = NewUnsyncWithCapacity[int](0)
+	q.Enqueue(5)
+	q.Clear()
+	if !q.IsEmpty() {
+		t.Errorf("Expected empty queue after clear")
+	}
+}
+
+func TestUnsyncQueueAll(t *testing.T) {
+	q := NewUnsyncWithCapacity[int](3)
+	q.Enqueue(0)
+	q.Dequeue()
+	q.Enqueue(1)
+	q.Enqueue(2)
+	q.Enqueue(3) // wraps around
+
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+	if q.Len() != 3 {
+		t.Errorf("Expected All not to consume, got length %d", q.Len())
+	}
+}
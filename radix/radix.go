@@ -0,0 +1,301 @@
+// Package radix provides a radix tree, a trie with path compression, keyed by strings.
+package radix
+
+import (
+	"iter"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// Tree is a thread-safe map from strings to values, stored as a radix tree.
+// Chains of nodes with a single child are compressed into one edge labelled by their common prefix,
+// so keys with long shared prefixes, such as URL paths, cost little memory.
+// Get, Set, Delete and LongestPrefix are O(k) for keys of length k, independent of the number of keys.
+//
+// Keys are compared byte by byte. Iteration visits keys in ascending byte order.
+type Tree[V any] struct {
+	mu   sync.RWMutex
+	root node[V]
+	size int
+}
+
+var _ collection.Map[string, int] = (*Tree[int])(nil)
+
+type node[V any] struct {
+	prefix   string // label of the edge leading to the node, empty only for the root
+	value    V
+	hasValue bool
+	edges    []*node[V] // sorted by the first byte of their prefix, which is unique among siblings
+}
+
+// New returns a new empty Tree.
+func New[V any]() *Tree[V] {
+	return &Tree[V]{}
+}
+
+// Set sets the value of key. It returns true if the key was new.
+func (t *Tree[V]) Set(key string, value V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n, search := &t.root, key
+	for search != "" {
+		i, child := n.edge(search[0])
+		if child == nil {
+			n.edges = insertAt(n.edges, i, &node[V]{prefix: search, value: value, hasValue: true})
+			t.size++
+			return true
+		}
+
+		common := commonPrefix(search, child.prefix)
+		if common < len(child.prefix) {
+			// Split the edge where the key leaves it.
+			split := &node[V]{prefix: child.prefix[:common], edges: []*node[V]{child}}
+			child.prefix = child.prefix[common:]
+			n.edges[i] = split
+			child = split
+		}
+		n, search = child, search[common:]
+	}
+
+	isNew := !n.hasValue
+	n.value, n.hasValue = value, true
+	if isNew {
+		t.size++
+	}
+	return isNew
+}
+
+// Get returns the value of key. Returns (zero-value, false) if the key is not present.
+func (t *Tree[V]) Get(key string) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if n := t.find(key); n != nil && n.hasValue {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has returns true if the key is present.
+func (t *Tree[V]) Has(key string) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Delete removes key. It returns true if the key was present.
+// Nodes left without a value and with a single child are merged with it, keeping the tree compressed.
+func (t *Tree[V]) Delete(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var (
+		parent *node[V]
+		index  int
+	)
+	n, search := &t.root, key
+	for search != "" {
+		i, child := n.edge(search[0])
+		if child == nil || !strings.HasPrefix(search, child.prefix) {
+			return false
+		}
+		parent, index = n, i
+		n, search = child, search[len(child.prefix):]
+	}
+	if !n.hasValue {
+		return false
+	}
+
+	var zero V
+	n.value, n.hasValue = zero, false
+	t.size--
+
+	switch {
+	case parent == nil:
+		// The root keeps its place, whatever its children.
+	case len(n.edges) == 0:
+		parent.edges = removeAt(parent.edges, index)
+		if parent != &t.root && !parent.hasValue && len(parent.edges) == 1 {
+			parent.mergeChild()
+		}
+	case len(n.edges) == 1:
+		n.mergeChild()
+	}
+	return true
+}
+
+// LongestPrefix returns the longest key that is a prefix of s, and its value. Returns false if there is none.
+// This is the lookup behind routing tables, e.g. matching "/api/users/42" to a handler registered at "/api/users/".
+func (t *Tree[V]) LongestPrefix(s string) (string, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var (
+		best     *node[V]
+		matched  int
+		consumed int
+	)
+	n := &t.root
+	for {
+		if n.hasValue {
+			best, matched = n, consumed
+		}
+		if consumed == len(s) {
+			break
+		}
+		_, child := n.edge(s[consumed])
+		if child == nil || !strings.HasPrefix(s[consumed:], child.prefix) {
+			break
+		}
+		n, consumed = child, consumed+len(child.prefix)
+	}
+
+	if best == nil {
+		var zero V
+		return "", zero, false
+	}
+	return s[:matched], best.value, true
+}
+
+// WithPrefix returns an iterator over a snapshot of the key-value pairs whose keys start with prefix,
+// in ascending key order.
+func (t *Tree[V]) WithPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		keys, values := t.collect(prefix)
+		for i, key := range keys {
+			if !yield(key, values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over a snapshot of the key-value pairs in ascending key order.
+// The snapshot is taken when iteration starts, so the tree may be modified while iterating.
+func (t *Tree[V]) All() iter.Seq2[string, V] {
+	return t.WithPrefix("")
+}
+
+// Keys returns the keys in ascending order.
+func (t *Tree[V]) Keys() []string {
+	keys, _ := t.collect("")
+	return keys
+}
+
+// Len returns the number of keys.
+func (t *Tree[V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// IsEmpty returns true if the tree has no keys.
+func (t *Tree[V]) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Clear removes all keys.
+func (t *Tree[V]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = node[V]{}
+	t.size = 0
+}
+
+// find returns the node whose path spells key, or nil if there is none.
+func (t *Tree[V]) find(key string) *node[V] {
+	n, search := &t.root, key
+	for search != "" {
+		_, child := n.edge(search[0])
+		if child == nil || !strings.HasPrefix(search, child.prefix) {
+			return nil
+		}
+		n, search = child, search[len(child.prefix):]
+	}
+	return n
+}
+
+// collect copies the pairs whose keys start with prefix, in ascending key order.
+func (t *Tree[V]) collect(prefix string) ([]string, []V) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	keys := make([]string, 0)
+	values := make([]V, 0)
+
+	// Find the shallowest node whose path starts with prefix; the prefix may end inside its edge.
+	n, path, search := &t.root, "", prefix
+	for search != "" {
+		_, child := n.edge(search[0])
+		if child == nil {
+			return keys, values
+		}
+		if strings.HasPrefix(search, child.prefix) {
+			search = search[len(child.prefix):]
+		} else if strings.HasPrefix(child.prefix, search) {
+			search = ""
+		} else {
+			return keys, values
+		}
+		n, path = child, path+child.prefix
+	}
+
+	n.walk(path, func(k string, v V) {
+		keys = append(keys, k)
+		values = append(values, v)
+	})
+	return keys, values
+}
+
+// walk visits the pairs of the subtree in ascending key order, where path is the key of n.
+func (n *node[V]) walk(path string, visit func(string, V)) {
+	if n.hasValue {
+		visit(path, n.value)
+	}
+	for _, child := range n.edges {
+		child.walk(path+child.prefix, visit)
+	}
+}
+
+// edge returns the index of the edge starting with b, and its node, or the index to insert it at and nil.
+func (n *node[V]) edge(b byte) (int, *node[V]) {
+	i := sort.Search(len(n.edges), func(j int) bool { return n.edges[j].prefix[0] >= b })
+	if i < len(n.edges) && n.edges[i].prefix[0] == b {
+		return i, n.edges[i]
+	}
+	return i, nil
+}
+
+// mergeChild absorbs the only child of n, which has no value.
+func (n *node[V]) mergeChild() {
+	child := n.edges[0]
+	n.prefix += child.prefix
+	n.value, n.hasValue, n.edges = child.value, child.hasValue, child.edges
+}
+
+func commonPrefix(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
@@ -0,0 +1,183 @@
+package radix
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// checkInvariants verifies that the tree is fully compressed and its edges are sorted.
+func checkInvariants[V any](t *testing.T, tree *Tree[V]) {
+	t.Helper()
+
+	count := 0
+	var check func(n *node[V], root bool)
+	check = func(n *node[V], root bool) {
+		if n.hasValue {
+			count++
+		}
+		if !root && n.prefix == "" {
+			t.Fatal("Found a non-root node with an empty prefix")
+		}
+		if !root && !n.hasValue && len(n.edges) < 2 {
+			t.Fatalf("Found an uncompressed node %q with %d edges", n.prefix, len(n.edges))
+		}
+		for i, child := range n.edges {
+			if i > 0 && n.edges[i-1].prefix[0] >= child.prefix[0] {
+				t.Fatalf("Edges of %q are not sorted", n.prefix)
+			}
+			check(child, false)
+		}
+	}
+	check(&tree.root, true)
+
+	if count != tree.Len() {
+		t.Fatalf("Counted %d values, Len() = %d", count, tree.Len())
+	}
+}
+
+func TestTreeBasic(t *testing.T) {
+	tree := New[int]()
+	for i, key := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", ""} {
+		if !tree.Set(key, i) {
+			t.Errorf("Expected %q to be new", key)
+		}
+	}
+	if tree.Set("ruber", 40) {
+		t.Error("Expected ruber to exist")
+	}
+	checkInvariants(t, tree)
+
+	if v, ok := tree.Get("ruber"); !ok || v != 40 {
+		t.Errorf("Expected 40, got %v (ok: %v)", v, ok)
+	}
+	for _, key := range []string{"rom", "rubiconx", "r"} {
+		if tree.Has(key) {
+			t.Errorf("Expected %q to be missing", key)
+		}
+	}
+	if v, ok := tree.Get(""); !ok || v != 7 {
+		t.Errorf("Expected the empty key to be present, got %v (ok: %v)", v, ok)
+	}
+
+	expected := []string{"", "romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus"}
+	if keys := tree.Keys(); !slices.Equal(keys, expected) {
+		t.Errorf("Keys() = %v, want %v", keys, expected)
+	}
+
+	if tree.Delete("rom") || !tree.Delete("romanus") || tree.Delete("romanus") {
+		t.Error("Expected Delete to report presence once")
+	}
+	checkInvariants(t, tree)
+	if tree.Len() != 7 {
+		t.Errorf("Expected length 7, got %d", tree.Len())
+	}
+
+	tree.Clear()
+	if !tree.IsEmpty() || len(tree.root.edges) != 0 {
+		t.Error("Expected an empty tree")
+	}
+}
+
+func TestTreeLongestPrefix(t *testing.T) {
+	tree := New[string]()
+	tree.Set("/", "root")
+	tree.Set("/api/", "api")
+	tree.Set("/api/users/", "users")
+	tree.Set("/api/users/me", "me")
+
+	tests := []struct {
+		path, key, value string
+	}{
+		{"/api/users/42", "/api/users/", "users"},
+		{"/api/users/me", "/api/users/me", "me"},
+		{"/api/users", "/api/", "api"},
+		{"/static/app.js", "/", "root"},
+	}
+	for _, tt := range tests {
+		key, value, ok := tree.LongestPrefix(tt.path)
+		if !ok || key != tt.key || value != tt.value {
+			t.Errorf("LongestPrefix(%q) = %q, %q, %v; want %q, %q", tt.path, key, value, ok, tt.key, tt.value)
+		}
+	}
+
+	if _, _, ok := tree.LongestPrefix("api"); ok {
+		t.Error("Expected no prefix of a relative path")
+	}
+}
+
+func TestTreeWithPrefix(t *testing.T) {
+	tree := New[int]()
+	for i, key := range []string{"team", "test", "tester", "testing", "toast", "tea"} {
+		tree.Set(key, i)
+	}
+
+	tests := []struct {
+		prefix   string
+		expected []string
+	}{
+		{"te", []string{"tea", "team", "test", "tester", "testing"}},
+		{"tes", []string{"test", "tester", "testing"}},
+		{"teste", []string{"tester"}},
+		{"testx", nil},
+		{"x", nil},
+		{"", []string{"tea", "team", "test", "tester", "testing", "toast"}},
+	}
+	for _, tt := range tests {
+		var keys []string
+		for k := range tree.WithPrefix(tt.prefix) {
+			keys = append(keys, k)
+		}
+		if !slices.Equal(keys, tt.expected) {
+			t.Errorf("WithPrefix(%q) = %v, want %v", tt.prefix, keys, tt.expected)
+		}
+	}
+}
+
+// TestTreeRandom compares the tree against a map, checking invariants after every operation.
+func TestTreeRandom(t *testing.T) {
+	tree := New[int]()
+	model := map[string]int{}
+	r := rand.New(rand.NewPCG(1, 2))
+	key := func() string {
+		b := make([]byte, r.IntN(6))
+		for i := range b {
+			b[i] = "ab"[r.IntN(2)]
+		}
+		return string(b)
+	}
+
+	for i := range 5000 {
+		k := key()
+		if r.IntN(2) == 0 {
+			_, exists := model[k]
+			if tree.Set(k, i) == exists {
+				t.Fatalf("Set(%q) reported new=%v", k, exists)
+			}
+			model[k] = i
+		} else {
+			_, exists := model[k]
+			if tree.Delete(k) != exists {
+				t.Fatalf("Delete(%q) != %v", k, exists)
+			}
+			delete(model, k)
+		}
+		checkInvariants(t, tree)
+
+		probe := key()
+		v, ok := tree.Get(probe)
+		if expected, exists := model[probe]; ok != exists || v != expected {
+			t.Fatalf("Get(%q) = %d, %v; want %d, %v", probe, v, ok, expected, exists)
+		}
+	}
+
+	keys := make([]string, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if got := tree.Keys(); !slices.Equal(got, keys) {
+		t.Errorf("Keys() = %v, want %v", got, keys)
+	}
+}
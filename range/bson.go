@@ -0,0 +1,31 @@
+package _range
+
+// ToBSON returns the range as a MongoDB field condition using $gt, $gte, $lt and $lte,
+// e.g. {"$gte": 10, "$lt": 20} for [10, 20). It can be used directly as a bson.M value,
+// e.g. bson.M{"age": r.ToBSON()}.
+//
+// A range unbounded on both sides places no restriction and yields nil; omit the field in that case,
+// since an empty condition would only match documents whose field is an empty document.
+func (r Range[T]) ToBSON() map[string]any {
+	lower, upper := bounded(r.Min), bounded(r.Max)
+	if lower == nil && upper == nil {
+		return nil
+	}
+
+	cond := make(map[string]any, 2)
+	if lower != nil {
+		if lower.Inclusive {
+			cond["$gte"] = *lower.Value
+		} else {
+			cond["$gt"] = *lower.Value
+		}
+	}
+	if upper != nil {
+		if upper.Inclusive {
+			cond["$lte"] = *upper.Value
+		} else {
+			cond["$lt"] = *upper.Value
+		}
+	}
+	return cond
+}
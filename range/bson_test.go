@@ -0,0 +1,26 @@
+package _range
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRange_ToBSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        Range[int]
+		expected map[string]any
+	}{
+		{"Half-open", HalfOpen(10, 20), map[string]any{"$gte": 10, "$lt": 20}},
+		{"Closed", Closed(10, 20), map[string]any{"$gte": 10, "$lte": 20}},
+		{"Open lower only", GreaterThan(5), map[string]any{"$gt": 5}},
+		{"Upper only", AtMost(5), map[string]any{"$lte": 5}},
+		{"Unbounded", Range[int]{}, nil},
+	}
+
+	for _, tt := range tests {
+		if got := tt.r.ToBSON(); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: ToBSON() = %v, want %v", tt.name, got, tt.expected)
+		}
+	}
+}
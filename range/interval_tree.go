@@ -0,0 +1,311 @@
+package _range
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// Interval is a Range key with its associated value, as stored in an IntervalTree.
+type Interval[T, V any] struct {
+	Range Range[T]
+	Value V
+}
+
+// IntervalTree is a thread-safe collection of ranges with associated values that answers
+// stabbing queries ("all intervals containing x") and overlap queries.
+//
+// It is an AVL tree ordered by lower boundary, where every node also tracks the highest
+// upper boundary in its subtree so that whole subtrees can be skipped during queries.
+// A query reporting k intervals visits O(min(n, k log n)) nodes, i.e. O(log n) when nothing matches.
+// Unbounded and exclusive boundaries are supported.
+type IntervalTree[T, V any] struct {
+	mu   sync.RWMutex
+	root *itNode[T, V]
+	less func(T, T) bool
+	size int
+	seq  uint64
+}
+
+var _ collection.Collection[Interval[int, int]] = (*IntervalTree[int, int])(nil)
+
+type itNode[T, V any] struct {
+	interval    Interval[T, V]
+	seq         uint64
+	maxUpper    *RangeItem[T] // nil means unbounded
+	height      int
+	left, right *itNode[T, V]
+}
+
+// NewIntervalTree returns a new empty IntervalTree ordered by the given less function.
+func NewIntervalTree[T, V any](less func(T, T) bool) *IntervalTree[T, V] {
+	return &IntervalTree[T, V]{less: less}
+}
+
+// NewOrderedIntervalTree returns a new empty IntervalTree for cmp.Ordered types.
+func NewOrderedIntervalTree[T cmp.Ordered, V any]() *IntervalTree[T, V] {
+	return NewIntervalTree[T, V](cmp.Less[T])
+}
+
+// Insert adds a range with its associated value. Duplicate ranges are allowed.
+func (t *IntervalTree[T, V]) Insert(r Range[T], v V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := &itNode[T, V]{
+		interval: Interval[T, V]{Range: r.clone(), Value: v},
+		seq:      t.seq,
+		height:   1,
+	}
+	n.maxUpper = bounded(n.interval.Range.Max)
+	t.seq++
+	t.size++
+	t.root = t.insert(t.root, n)
+}
+
+// Delete removes one interval whose range is Equal to r and whose value satisfies match.
+// A nil match accepts any value. Returns false if no such interval exists.
+func (t *IntervalTree[T, V]) Delete(r Range[T], match func(V) bool) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var deleted bool
+	t.root = t.delete(t.root, r, match, &deleted)
+	if deleted {
+		t.size--
+	}
+	return deleted
+}
+
+// Stab returns all intervals containing x, ordered by lower boundary.
+func (t *IntervalTree[T, V]) Stab(x T) []Interval[T, V] {
+	return t.Overlapping(Closed(x, x))
+}
+
+// Overlapping returns all intervals sharing at least one value with q, ordered by lower boundary.
+func (t *IntervalTree[T, V]) Overlapping(q Range[T]) []Interval[T, V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	res := make([]Interval[T, V], 0)
+	if q.IsEmpty(t.less) {
+		return res
+	}
+	t.collect(t.root, q, &res)
+	return res
+}
+
+// All returns an iterator over a snapshot of all intervals, ordered by lower boundary.
+func (t *IntervalTree[T, V]) All() iter.Seq[Interval[T, V]] {
+	return func(yield func(Interval[T, V]) bool) {
+		t.mu.RLock()
+		snapshot := make([]Interval[T, V], 0, t.size)
+		var walk func(n *itNode[T, V])
+		walk = func(n *itNode[T, V]) {
+			if n == nil {
+				return
+			}
+			walk(n.left)
+			snapshot = append(snapshot, n.interval)
+			walk(n.right)
+		}
+		walk(t.root)
+		t.mu.RUnlock()
+
+		for _, iv := range snapshot {
+			if !yield(iv) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of intervals in the tree.
+func (t *IntervalTree[T, V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// IsEmpty returns true if the tree contains no intervals.
+func (t *IntervalTree[T, V]) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Clear removes all intervals from the tree.
+func (t *IntervalTree[T, V]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = nil
+	t.size = 0
+}
+
+// collect appends the intervals of the subtree rooted at n that overlap q. Must be called with lock held.
+func (t *IntervalTree[T, V]) collect(n *itNode[T, V], q Range[T], res *[]Interval[T, V]) {
+	// No interval in this subtree reaches the start of q.
+	if n == nil || !reaches(n.maxUpper, q.Min, t.less) {
+		return
+	}
+
+	t.collect(n.left, q, res)
+
+	// This node and its right subtree start after q ends.
+	if !reaches(q.Max, n.interval.Range.Min, t.less) {
+		return
+	}
+
+	if n.interval.Range.Overlaps(q, t.less) {
+		*res = append(*res, n.interval)
+	}
+
+	t.collect(n.right, q, res)
+}
+
+// compare orders nodes by lower boundary, then by insertion order.
+func (t *IntervalTree[T, V]) compare(a, b *itNode[T, V]) int {
+	if c := compareLower(a.interval.Range.Min, b.interval.Range.Min, t.less); c != 0 {
+		return c
+	}
+	switch {
+	case a.seq < b.seq:
+		return -1
+	case a.seq > b.seq:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (t *IntervalTree[T, V]) insert(root, n *itNode[T, V]) *itNode[T, V] {
+	if root == nil {
+		return n
+	}
+	if t.compare(n, root) < 0 {
+		root.left = t.insert(root.left, n)
+	} else {
+		root.right = t.insert(root.right, n)
+	}
+	return t.rebalance(root)
+}
+
+func (t *IntervalTree[T, V]) delete(root *itNode[T, V], r Range[T], match func(V) bool, deleted *bool) *itNode[T, V] {
+	if root == nil {
+		return nil
+	}
+
+	c := compareLower(r.Min, root.interval.Range.Min, t.less)
+	if c < 0 {
+		root.left = t.delete(root.left, r, match, deleted)
+		return t.rebalance(root)
+	}
+	if c > 0 {
+		root.right = t.delete(root.right, r, match, deleted)
+		return t.rebalance(root)
+	}
+
+	// Equal lower boundaries may sit on either side after rotations.
+	root.left = t.delete(root.left, r, match, deleted)
+	if *deleted {
+		return t.rebalance(root)
+	}
+
+	if root.interval.Range.Equal(r, t.less) && (match == nil || match(root.interval.Value)) {
+		*deleted = true
+		if root.left == nil {
+			return root.right
+		}
+		if root.right == nil {
+			return root.left
+		}
+
+		// Replace with the in-order successor.
+		var successor *itNode[T, V]
+		root.right = t.popMin(root.right, &successor)
+		successor.left, successor.right = root.left, root.right
+		return t.rebalance(successor)
+	}
+
+	root.right = t.delete(root.right, r, match, deleted)
+	return t.rebalance(root)
+}
+
+// popMin detaches the leftmost node of the subtree rooted at n.
+func (t *IntervalTree[T, V]) popMin(n *itNode[T, V], min **itNode[T, V]) *itNode[T, V] {
+	if n.left == nil {
+		*min = n
+		return n.right
+	}
+	n.left = t.popMin(n.left, min)
+	return t.rebalance(n)
+}
+
+// rebalance restores the AVL invariant at n and refreshes its cached height and max upper boundary.
+func (t *IntervalTree[T, V]) rebalance(n *itNode[T, V]) *itNode[T, V] {
+	t.update(n)
+	switch balance := itHeight(n.left) - itHeight(n.right); {
+	case balance > 1:
+		if itHeight(n.left.left) < itHeight(n.left.right) {
+			n.left = t.rotateLeft(n.left)
+		}
+		return t.rotateRight(n)
+	case balance < -1:
+		if itHeight(n.right.right) < itHeight(n.right.left) {
+			n.right = t.rotateRight(n.right)
+		}
+		return t.rotateLeft(n)
+	}
+	return n
+}
+
+func (t *IntervalTree[T, V]) rotateLeft(n *itNode[T, V]) *itNode[T, V] {
+	r := n.right
+	n.right = r.left
+	r.left = n
+	t.update(n)
+	t.update(r)
+	return r
+}
+
+func (t *IntervalTree[T, V]) rotateRight(n *itNode[T, V]) *itNode[T, V] {
+	l := n.left
+	n.left = l.right
+	l.right = n
+	t.update(n)
+	t.update(l)
+	return l
+}
+
+func (t *IntervalTree[T, V]) update(n *itNode[T, V]) {
+	n.height = 1 + max(itHeight(n.left), itHeight(n.right))
+	n.maxUpper = bounded(n.interval.Range.Max)
+	for _, child := range []*itNode[T, V]{n.left, n.right} {
+		if child != nil {
+			n.maxUpper = maxUpper(n.maxUpper, child.maxUpper, t.less)
+		}
+	}
+}
+
+func itHeight[T, V any](n *itNode[T, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// reaches reports whether an upper boundary admits some value at or beyond a lower boundary,
+// i.e. whether a range ending at upper can meet a range starting at lower.
+func reaches[T any](upper, lower *RangeItem[T], less func(T, T) bool) bool {
+	upper, lower = bounded(upper), bounded(lower)
+	if upper == nil || lower == nil {
+		return true
+	}
+	if less(*upper.Value, *lower.Value) {
+		return false
+	}
+	if less(*lower.Value, *upper.Value) {
+		return true
+	}
+	return upper.Inclusive && lower.Inclusive
+}
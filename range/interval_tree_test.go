@@ -0,0 +1,148 @@
+package _range
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestIntervalTree_Stab(t *testing.T) {
+	tree := NewOrderedIntervalTree[int, string]()
+	tree.Insert(Closed(1, 5), "a")
+	tree.Insert(HalfOpen(5, 10), "b")
+	tree.Insert(Open(0, 1), "c")
+	tree.Insert(AtLeast(8), "d")
+	tree.Insert(LessThan(2), "e")
+
+	tests := []struct {
+		x        int
+		expected []string
+	}{
+		{0, []string{"e"}},
+		{1, []string{"e", "a"}},
+		{5, []string{"a", "b"}},
+		{9, []string{"b", "d"}},
+		{10, []string{"d"}},
+		{-100, []string{"e"}},
+	}
+
+	for _, tt := range tests {
+		var got []string
+		for _, iv := range tree.Stab(tt.x) {
+			got = append(got, iv.Value)
+		}
+		slices.Sort(got)
+		slices.Sort(tt.expected)
+		if !slices.Equal(got, tt.expected) {
+			t.Errorf("Stab(%d) = %v, want %v", tt.x, got, tt.expected)
+		}
+	}
+}
+
+func TestIntervalTree_Overlapping(t *testing.T) {
+	tree := NewOrderedIntervalTree[int, int]()
+	tree.Insert(Closed(1, 3), 1)
+	tree.Insert(Closed(5, 7), 2)
+	tree.Insert(HalfOpen(7, 9), 3)
+
+	got := tree.Overlapping(Open(3, 7))
+	if len(got) != 1 || got[0].Value != 2 {
+		t.Errorf("Overlapping((3,7)) = %v, want only the [5,7] interval", got)
+	}
+
+	if got := tree.Overlapping(Open(5, 5)); len(got) != 0 {
+		t.Errorf("Overlapping with an empty query should return nothing, got %v", got)
+	}
+
+	if got := tree.Overlapping(New[int](nil, nil)); len(got) != 3 {
+		t.Errorf("Overlapping with an unbounded query should return everything, got %d", len(got))
+	}
+}
+
+func TestIntervalTree_Delete(t *testing.T) {
+	tree := NewOrderedIntervalTree[int, string]()
+	tree.Insert(Closed(1, 5), "a")
+	tree.Insert(Closed(1, 5), "b")
+
+	if tree.Delete(Open(1, 5), nil) {
+		t.Error("Delete should not remove a range that is not equal")
+	}
+	if !tree.Delete(Closed(1, 5), func(v string) bool { return v == "b" }) {
+		t.Fatal("Delete should remove the matching interval")
+	}
+	if tree.Len() != 1 || tree.Stab(3)[0].Value != "a" {
+		t.Errorf("expected only \"a\" to remain, got %v", tree.Stab(3))
+	}
+	if !tree.Delete(Closed(1, 5), nil) || !tree.IsEmpty() {
+		t.Error("expected tree to be empty after deleting the last interval")
+	}
+}
+
+func TestIntervalTree_Randomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewOrderedIntervalTree[int, int]()
+	var model []Interval[int, int]
+
+	randomRange := func() Range[int] {
+		a, b := rng.Intn(100), rng.Intn(100)
+		if a > b {
+			a, b = b, a
+		}
+		switch rng.Intn(5) {
+		case 0:
+			return Open(a, b)
+		case 1:
+			return HalfOpen(a, b)
+		case 2:
+			return AtLeast(a)
+		case 3:
+			return LessThan(b)
+		default:
+			return Closed(a, b)
+		}
+	}
+
+	for i := 0; i < 2000; i++ {
+		if len(model) > 0 && rng.Intn(3) == 0 {
+			victim := model[rng.Intn(len(model))]
+			if !tree.Delete(victim.Range, func(v int) bool { return v == victim.Value }) {
+				t.Fatalf("Delete(%s) failed", rangeString(victim.Range))
+			}
+			model = slices.DeleteFunc(model, func(iv Interval[int, int]) bool { return iv.Value == victim.Value })
+		} else {
+			r := randomRange()
+			tree.Insert(r, i)
+			model = append(model, Interval[int, int]{Range: r, Value: i})
+		}
+
+		q := randomRange()
+		var expected, got []int
+		for _, iv := range model {
+			if OverlapsOrdered(iv.Range, q) {
+				expected = append(expected, iv.Value)
+			}
+		}
+		for _, iv := range tree.Overlapping(q) {
+			got = append(got, iv.Value)
+		}
+		slices.Sort(expected)
+		slices.Sort(got)
+		if !slices.Equal(got, expected) {
+			t.Fatalf("step %d: Overlapping(%s) = %v, want %v", i, rangeString(q), got, expected)
+		}
+	}
+
+	if tree.Len() != len(model) {
+		t.Errorf("Len() = %d, want %d", tree.Len(), len(model))
+	}
+
+	var prev *RangeItem[int]
+	first := true
+	for iv := range tree.All() {
+		if !first && compareLower(prev, iv.Range.Min, cmp.Less[int]) > 0 {
+			t.Fatal("All() should yield intervals ordered by lower boundary")
+		}
+		prev, first = iv.Range.Min, false
+	}
+}
@@ -0,0 +1,214 @@
+package _range
+
+import (
+	"iter"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// Integer is a constraint that permits any integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is a constraint that permits any integer or floating-point type.
+type Number interface {
+	Integer | Float
+}
+
+// ClampStep snaps val into the range for numeric types.
+// Exclusive boundaries are approached by step, e.g. clamping 10 into (0, 5) with step 1 gives 4.
+func ClampStep[T Number](r Range[T], val, step T) T {
+	return r.Clamp(val, func(a, b T) bool { return a < b },
+		func(v T) T { return v + step },
+		func(v T) T { return v - step },
+	)
+}
+
+// Length returns the extent max - min of a bounded numeric range.
+// Returns (0, false) if either side is unbounded or the range is inverted.
+// Inclusivity does not affect the result, so [0, 10) and [0, 10] both have length 10.
+func Length[T Number](r Range[T]) (T, bool) {
+	if !r.IsBounded() || *r.Max.Value < *r.Min.Value {
+		return 0, false
+	}
+	return *r.Max.Value - *r.Min.Value, true
+}
+
+// Steps returns an iterator over min, min+step, min+2*step, ... that yields only values inside the range,
+// so an exclusive lower boundary skips min and the upper boundary's inclusivity is respected.
+// Yields nothing if the lower side is unbounded or step is not positive.
+// If the upper side is unbounded, the sequence only ends when the caller stops or the type overflows.
+func Steps[T Number](r Range[T], step T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if r.Min == nil || r.Min.Value == nil || step <= 0 {
+			return
+		}
+
+		min := *r.Min.Value
+		prev := min
+		for i := T(0); ; i++ {
+			// Multiply instead of accumulating to avoid floating-point drift.
+			v := min + i*step
+			if i > 0 && v <= prev {
+				return // overflow
+			}
+			prev = v
+
+			if !r.Min.Inclusive && v == min {
+				continue
+			}
+			if r.Max != nil && r.Max.Value != nil {
+				if v > *r.Max.Value || (!r.Max.Inclusive && v == *r.Max.Value) {
+					return
+				}
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Shift moves both boundaries of a numeric range by delta. Unbounded sides stay unbounded.
+func Shift[T Number](r Range[T], delta T) Range[T] {
+	add := func(v T) T { return v + delta }
+	return mapBounds(r, add, add)
+}
+
+// Expand widens a numeric range by moving the lower boundary down and the upper boundary up by by.
+// Unbounded sides stay unbounded.
+func Expand[T Number](r Range[T], by T) Range[T] {
+	return mapBounds(r, func(v T) T { return v - by }, func(v T) T { return v + by })
+}
+
+// Shrink narrows a numeric range by moving the lower boundary up and the upper boundary down by by.
+// Unbounded sides stay unbounded. Shrinking by more than half the length inverts the range, see Validate.
+func Shrink[T Number](r Range[T], by T) Range[T] {
+	return mapBounds(r, func(v T) T { return v + by }, func(v T) T { return v - by })
+}
+
+// Rand returns a uniformly distributed value from a bounded numeric range, respecting inclusivity.
+// Integers are drawn from the values the range admits, floats from the continuous interval.
+// Returns (0, false) if either side is unbounded or the range admits no value of type T.
+func Rand[T Number](r Range[T], src *rand.Rand) (T, bool) {
+	if !r.IsBounded() {
+		return 0, false
+	}
+	lo, hi := *r.Min.Value, *r.Max.Value
+
+	if isInteger[T]() {
+		// Integer type: step inside exclusive boundaries, giving up on overflow.
+		if !r.Min.Inclusive {
+			if lo+1 < lo {
+				return 0, false
+			}
+			lo++
+		}
+		if !r.Max.Inclusive {
+			if hi-1 > hi {
+				return 0, false
+			}
+			hi--
+		}
+		if hi < lo {
+			return 0, false
+		}
+		// Two's complement arithmetic gives the right span for signed types too.
+		return T(uint64(lo) + randUint64n(src, uint64(hi)-uint64(lo))), true
+	}
+
+	if hi < lo || (hi == lo && !(r.Min.Inclusive && r.Max.Inclusive)) {
+		return 0, false
+	}
+
+	// Interpolate rather than scaling hi-lo, which could overflow to infinity.
+	// Draws landing on an excluded boundary are retried; a range too narrow to hold
+	// any representable value between its boundaries eventually gives up.
+	for range 64 {
+		u := T(src.Float64())
+		v := lo*(1-u) + hi*u
+		if v < lo || v > hi {
+			continue
+		}
+		if (v == lo && !r.Min.Inclusive) || (v == hi && !r.Max.Inclusive) {
+			continue
+		}
+		return v, true
+	}
+	return 0, false
+}
+
+// randUint64n returns a uniformly distributed value in [0, n], without modulo bias.
+func randUint64n(src *rand.Rand, n uint64) uint64 {
+	if n == math.MaxUint64 {
+		return src.Uint64()
+	}
+	n++
+	// Reject the final partial block of size 2^64 mod n.
+	limit := math.MaxUint64 - (math.MaxUint64%n+1)%n
+	for {
+		if v := src.Uint64(); v <= limit {
+			return v % n
+		}
+	}
+}
+
+// Buckets splits [min, max) into n consecutive half-open ranges of equal width, e.g. for histograms.
+// The first bucket starts exactly at min and the last ends exactly at max. For integers that do not
+// divide evenly, widths differ by at most one, and buckets may be empty if n exceeds max - min.
+// Returns nil if n is not positive or max is not above min.
+func Buckets[T Number](min, max T, n int) []Range[T] {
+	if n <= 0 || !(min < max) {
+		return nil
+	}
+
+	edge := func(i int) T {
+		if isInteger[T]() {
+			// Two's complement arithmetic keeps the span exact for signed types,
+			// and splitting the multiplication avoids overflowing span * i.
+			span, n, i := uint64(max)-uint64(min), uint64(n), uint64(i)
+			return T(uint64(min) + span/n*i + span%n*i/n)
+		}
+		return min + (max-min)*T(i)/T(n)
+	}
+
+	res := make([]Range[T], n)
+	lo := min
+	for i := range n {
+		hi := max
+		if i < n-1 {
+			hi = edge(i + 1)
+		}
+		res[i] = HalfOpen(lo, hi)
+		lo = hi
+	}
+	return res
+}
+
+// BucketIndex returns the index of the bucket containing v, or -1 if none does.
+// The buckets must be disjoint and ordered, as returned by Buckets or MergeAll; the lookup is a binary search.
+func BucketIndex[T Number](buckets []Range[T], v T) int {
+	// Find the first bucket that does not end below v.
+	i := sort.Search(len(buckets), func(i int) bool {
+		upper := bounded(buckets[i].Max)
+		return upper == nil || v < *upper.Value || (v == *upper.Value && upper.Inclusive)
+	})
+	if i < len(buckets) && ContainsOrdered(buckets[i], v) {
+		return i
+	}
+	return -1
+}
+
+// isInteger reports whether T is an integer type.
+func isInteger[T Number]() bool {
+	var one T = 1
+	return one/2 == 0
+}
@@ -0,0 +1,200 @@
+package _range
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        Range[float64]
+		expected float64
+		ok       bool
+	}{
+		{"Closed", Closed(1.5, 4.0), 2.5, true},
+		{"Half-open", HalfOpen(0.0, 10.0), 10, true},
+		{"Point", Closed(3.0, 3.0), 0, true},
+		{"Inverted", Closed(4.0, 1.0), 0, false},
+		{"Unbounded", AtLeast(1.0), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Length(tt.r)
+			if got != tt.expected || ok != tt.ok {
+				t.Errorf("Length() = %v, %v; want %v, %v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+
+	if n, ok := Length(Closed[uint](3, 10)); !ok || n != 7 {
+		t.Errorf("Length() = %v, %v; want 7, true", n, ok)
+	}
+}
+
+func TestSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        Range[int]
+		step     int
+		expected []int
+	}{
+		{"Closed", Closed(0, 10), 5, []int{0, 5, 10}},
+		{"Half-open", HalfOpen(0, 10), 5, []int{0, 5}},
+		{"Open", Open(0, 10), 5, []int{5}},
+		{"Not aligned", Closed(1, 10), 4, []int{1, 5, 9}},
+		{"Unbounded below", AtMost(10), 1, nil},
+		{"Zero step", Closed(0, 10), 0, nil},
+		{"Empty", Open(3, 3), 1, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.Collect(Steps(tt.r, tt.step))
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Steps() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+
+	var got []int
+	for v := range Steps(AtLeast(1), 1) {
+		if v > 3 {
+			break
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected unbounded Steps to stop with the caller, got %v", got)
+	}
+
+	got8 := slices.Collect(Steps(AtLeast[int8](120), 5))
+	if !slices.Equal(got8, []int8{120, 125}) {
+		t.Errorf("Expected Steps to stop on overflow, got %v", got8)
+	}
+
+	floats := slices.Collect(Steps(Closed(0.0, 1.0), 0.1))
+	if len(floats) != 11 || math.Abs(floats[10]-1.0) > 1e-9 {
+		t.Errorf("Expected 11 float steps ending at 1, got %v", floats)
+	}
+}
+
+func TestShiftExpandShrink(t *testing.T) {
+	r := HalfOpen(10, 20)
+
+	if got := rangeString(Shift(r, 5)); got != "[15,25)" {
+		t.Errorf("Shift() = %s, want [15,25)", got)
+	}
+	if got := rangeString(Expand(r, 2)); got != "[8,22)" {
+		t.Errorf("Expand() = %s, want [8,22)", got)
+	}
+	if got := rangeString(Shrink(r, 2)); got != "[12,18)" {
+		t.Errorf("Shrink() = %s, want [12,18)", got)
+	}
+	if got := rangeString(Expand(AtLeast(10), 3)); got != "[7,)" {
+		t.Errorf("Expand() = %s, want [7,)", got)
+	}
+	if got := rangeString(r); got != "[10,20)" {
+		t.Errorf("Transformations must not modify the original, got %s", got)
+	}
+	if ValidateOrdered(Shrink(r, 6)) != ErrInverted {
+		t.Error("Expected over-shrunk range to be inverted")
+	}
+}
+
+func TestRand(t *testing.T) {
+	src := rand.New(rand.NewSource(42))
+
+	seen := map[int]bool{}
+	for i := 0; i < 1000; i++ {
+		v, ok := Rand(Open(0, 4), src)
+		if !ok || v < 1 || v > 3 {
+			t.Fatalf("Rand((0,4)) = %d, %v; want a value in [1,3]", v, ok)
+		}
+		seen[v] = true
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected every admitted integer to be drawn, got %v", seen)
+	}
+
+	for i := 0; i < 1000; i++ {
+		v, ok := Rand(HalfOpen(-1.5, 2.5), src)
+		if !ok || v < -1.5 || v >= 2.5 {
+			t.Fatalf("Rand([-1.5,2.5)) = %v, %v", v, ok)
+		}
+	}
+
+	if v, ok := Rand(Closed(int8(math.MinInt8), int8(math.MaxInt8)), src); !ok || v < math.MinInt8 {
+		t.Errorf("Rand() over the full int8 range = %d, %v", v, ok)
+	}
+	if v, ok := Rand(Closed(uint64(0), uint64(math.MaxUint64)), src); !ok {
+		t.Errorf("Rand() over the full uint64 range = %d, %v", v, ok)
+	}
+	if v, ok := Rand(Closed(7, 7), src); !ok || v != 7 {
+		t.Errorf("Rand([7,7]) = %d, %v; want 7, true", v, ok)
+	}
+
+	for _, r := range []Range[int]{Open(3, 4), Open(5, 5), Closed(7, 3), AtLeast(0), Open(math.MaxInt, math.MaxInt)} {
+		if _, ok := Rand(r, src); ok {
+			t.Errorf("Expected no value from %s", rangeString(r))
+		}
+	}
+	if _, ok := Rand(Open(1.0, 1.0), src); ok {
+		t.Error("Expected no value from an empty float range")
+	}
+}
+
+func TestBuckets(t *testing.T) {
+	var got []string
+	for _, b := range Buckets(0, 10, 3) {
+		got = append(got, rangeString(b))
+	}
+	if expected := []string{"[0,3)", "[3,6)", "[6,10)"}; !slices.Equal(got, expected) {
+		t.Errorf("Buckets(0, 10, 3) = %v, want %v", got, expected)
+	}
+
+	floats := Buckets(0.0, 1.0, 4)
+	if len(floats) != 4 || *floats[1].Min.Value != 0.25 || *floats[3].Max.Value != 1.0 {
+		t.Errorf("Buckets(0, 1, 4) = %v", floats)
+	}
+
+	wide := Buckets(int8(-100), int8(100), 4)
+	if *wide[2].Min.Value != 0 || *wide[3].Max.Value != 100 {
+		t.Errorf("Buckets() should not overflow small integer types, got %v", wide)
+	}
+
+	if Buckets(5, 5, 3) != nil || Buckets(0, 10, 0) != nil {
+		t.Error("Expected no buckets for an empty span or non-positive count")
+	}
+}
+
+func TestBucketIndex(t *testing.T) {
+	buckets := Buckets(0, 100, 10)
+
+	tests := []struct {
+		v        int
+		expected int
+	}{
+		{0, 0},
+		{9, 0},
+		{10, 1},
+		{55, 5},
+		{99, 9},
+		{100, -1},
+		{-1, -1},
+	}
+
+	for _, tt := range tests {
+		if got := BucketIndex(buckets, tt.v); got != tt.expected {
+			t.Errorf("BucketIndex(%d) = %d, want %d", tt.v, got, tt.expected)
+		}
+	}
+
+	gappy := []Range[int]{Closed(0, 5), Open(10, 20), AtLeast(30)}
+	if BucketIndex(gappy, 7) != -1 || BucketIndex(gappy, 10) != -1 || BucketIndex(gappy, 5) != 0 || BucketIndex(gappy, 1000) != 2 {
+		t.Error("BucketIndex() mismatch for custom buckets")
+	}
+}
@@ -0,0 +1,305 @@
+package _range
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Intersect returns the overlap of r and other using a custom less function, respecting inclusivity.
+// The boolean result is false if the ranges do not overlap, in which case the returned range is empty.
+func (r Range[T]) Intersect(other Range[T], less func(T, T) bool) (Range[T], bool) {
+	res := Range[T]{
+		Min: cloneItem(maxLower(r.Min, other.Min, less)),
+		Max: cloneItem(minUpper(r.Max, other.Max, less)),
+	}
+	return res, !res.IsEmpty(less)
+}
+
+// IntersectOrdered returns the overlap of a and b for ordered types.
+func IntersectOrdered[T cmp.Ordered](a, b Range[T]) (Range[T], bool) {
+	return a.Intersect(b, cmp.Less[T])
+}
+
+// Overlaps returns true if at least one value lies in both r and other, using a custom less function.
+func (r Range[T]) Overlaps(other Range[T], less func(T, T) bool) bool {
+	_, ok := r.Intersect(other, less)
+	return ok
+}
+
+// OverlapsOrdered returns true if a and b share at least one value, for ordered types.
+func OverlapsOrdered[T cmp.Ordered](a, b Range[T]) bool {
+	return a.Overlaps(b, cmp.Less[T])
+}
+
+// Adjacent returns true if r and other touch without overlapping, using a custom less function,
+// i.e. one ends exactly where the other begins and the shared boundary value belongs to exactly one of them
+// (e.g. [1, 5) and [5, 10]).
+func (r Range[T]) Adjacent(other Range[T], less func(T, T) bool) bool {
+	if r.IsEmpty(less) || other.IsEmpty(less) || r.Overlaps(other, less) {
+		return false
+	}
+	return touches(r.Max, other.Min, less) || touches(other.Max, r.Min, less)
+}
+
+// AdjacentOrdered returns true if a and b touch without overlapping, for ordered types.
+func AdjacentOrdered[T cmp.Ordered](a, b Range[T]) bool {
+	return a.Adjacent(b, cmp.Less[T])
+}
+
+// Encloses returns true if every value in other also lies in r, using a custom less function.
+// An empty other is enclosed by every range.
+func (r Range[T]) Encloses(other Range[T], less func(T, T) bool) bool {
+	if other.IsEmpty(less) {
+		return true
+	}
+	return compareLower(r.Min, other.Min, less) <= 0 && compareUpper(r.Max, other.Max, less) >= 0
+}
+
+// EnclosesOrdered returns true if every value in inner also lies in outer, for ordered types.
+func EnclosesOrdered[T cmp.Ordered](outer, inner Range[T]) bool {
+	return outer.Encloses(inner, cmp.Less[T])
+}
+
+// Equal returns true if r and other have the same boundaries, using a custom less function.
+// Boundaries are compared as written, so (3, 7] and [4, 7] are not equal; canonicalize discrete ranges first.
+func (r Range[T]) Equal(other Range[T], less func(T, T) bool) bool {
+	return compareLower(r.Min, other.Min, less) == 0 && compareUpper(r.Max, other.Max, less) == 0
+}
+
+// EqualOrdered returns true if a and b have the same boundaries, for ordered types.
+func EqualOrdered[T cmp.Ordered](a, b Range[T]) bool {
+	return a.Equal(b, cmp.Less[T])
+}
+
+// Canonical converts a range over a discrete domain into closed form, so ranges that denote
+// the same values compare equal: an exclusive lower boundary v becomes next(v) and an exclusive
+// upper boundary v becomes prev(v), e.g. (3, 7] → [4, 7] and [1, 5) → [1, 4].
+// Unbounded sides stay unbounded.
+func (r Range[T]) Canonical(next, prev func(T) T) Range[T] {
+	res := r.clone()
+	if res.Min != nil && !res.Min.Inclusive {
+		*res.Min.Value = next(*res.Min.Value)
+		res.Min.Inclusive = true
+	}
+	if res.Max != nil && !res.Max.Inclusive {
+		*res.Max.Value = prev(*res.Max.Value)
+		res.Max.Inclusive = true
+	}
+	return res
+}
+
+// CanonicalInteger converts an integer range into closed form, e.g. (3, 7] → [4, 7].
+func CanonicalInteger[T Integer](r Range[T]) Range[T] {
+	return r.Canonical(func(v T) T { return v + 1 }, func(v T) T { return v - 1 })
+}
+
+// Union returns the smallest range covering r and other using a custom less function,
+// provided the two overlap or touch (e.g. [1, 5) and [5, 10]) so that no gap is introduced.
+// The boolean result is false if a gap separates the ranges, in which case the returned range is the zero Range.
+func (r Range[T]) Union(other Range[T], less func(T, T) bool) (Range[T], bool) {
+	switch {
+	case r.IsEmpty(less):
+		return other.clone(), true
+	case other.IsEmpty(less):
+		return r.clone(), true
+	case separated(r.Max, other.Min, less) || separated(other.Max, r.Min, less):
+		return Range[T]{}, false
+	}
+
+	return r.Span(other, less), true
+}
+
+// UnionOrdered returns the union of a and b for ordered types.
+func UnionOrdered[T cmp.Ordered](a, b Range[T]) (Range[T], bool) {
+	return a.Union(b, cmp.Less[T])
+}
+
+// Span returns the smallest range covering both r and other using a custom less function,
+// including any gap between them.
+func (r Range[T]) Span(other Range[T], less func(T, T) bool) Range[T] {
+	return Range[T]{
+		Min: cloneItem(minLower(r.Min, other.Min, less)),
+		Max: cloneItem(maxUpper(r.Max, other.Max, less)),
+	}
+}
+
+// SpanOrdered returns the smallest range covering a and b for ordered types.
+func SpanOrdered[T cmp.Ordered](a, b Range[T]) Range[T] {
+	return a.Span(b, cmp.Less[T])
+}
+
+// MergeAll returns the minimal set of disjoint ranges covering the same values as rs, using a custom less function.
+// Overlapping and touching ranges are coalesced, empty ranges are dropped, and the result is ordered by lower boundary.
+// The input slice is not modified.
+func MergeAll[T any](rs []Range[T], less func(T, T) bool) []Range[T] {
+	sorted := make([]Range[T], 0, len(rs))
+	for _, r := range rs {
+		if !r.IsEmpty(less) {
+			sorted = append(sorted, r)
+		}
+	}
+	slices.SortStableFunc(sorted, func(a, b Range[T]) int {
+		return compareLower(a.Min, b.Min, less)
+	})
+
+	res := make([]Range[T], 0, len(sorted))
+	for _, r := range sorted {
+		if n := len(res); n > 0 {
+			if merged, ok := res[n-1].Union(r, less); ok {
+				res[n-1] = merged
+				continue
+			}
+		}
+		res = append(res, r.clone())
+	}
+
+	return res
+}
+
+// MergeAllOrdered returns the minimal set of disjoint ranges covering rs for ordered types.
+func MergeAllOrdered[T cmp.Ordered](rs []Range[T]) []Range[T] {
+	return MergeAll(rs, cmp.Less[T])
+}
+
+// clone returns a copy of the range that does not share boundaries with r.
+func (r Range[T]) clone() Range[T] {
+	return Range[T]{Min: cloneItem(r.Min), Max: cloneItem(r.Max)}
+}
+
+// separated reports whether a gap lies between an upper boundary and a later lower boundary,
+// i.e. some value is above upper and below lower.
+// Equal values are separated only when both boundaries exclude them.
+func separated[T any](upper, lower *RangeItem[T], less func(T, T) bool) bool {
+	upper, lower = bounded(upper), bounded(lower)
+	if upper == nil || lower == nil {
+		return false
+	}
+	if less(*upper.Value, *lower.Value) {
+		return true
+	}
+	if less(*lower.Value, *upper.Value) {
+		return false
+	}
+	return !upper.Inclusive && !lower.Inclusive
+}
+
+// touches reports whether an upper boundary and a lower boundary sit on the same value
+// with no gap between them.
+func touches[T any](upper, lower *RangeItem[T], less func(T, T) bool) bool {
+	upper, lower = bounded(upper), bounded(lower)
+	if upper == nil || lower == nil {
+		return false
+	}
+	return !less(*upper.Value, *lower.Value) && !less(*lower.Value, *upper.Value) &&
+		(upper.Inclusive || lower.Inclusive)
+}
+
+// bounded returns the boundary if it limits the range, or nil if that side is unbounded.
+func bounded[T any](item *RangeItem[T]) *RangeItem[T] {
+	if item == nil || item.Value == nil {
+		return nil
+	}
+	return item
+}
+
+// compareLower orders two lower boundaries: negative if a admits values below b's first value,
+// zero if they start at the same place, positive otherwise. Unbounded sorts first.
+func compareLower[T any](a, b *RangeItem[T], less func(T, T) bool) int {
+	a, b = bounded(a), bounded(b)
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	case less(*a.Value, *b.Value):
+		return -1
+	case less(*b.Value, *a.Value):
+		return 1
+	case a.Inclusive == b.Inclusive:
+		return 0
+	case a.Inclusive:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// compareUpper orders two upper boundaries: negative if a stops admitting values before b does,
+// zero if they end at the same place, positive otherwise. Unbounded sorts last.
+func compareUpper[T any](a, b *RangeItem[T], less func(T, T) bool) int {
+	a, b = bounded(a), bounded(b)
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	case less(*a.Value, *b.Value):
+		return -1
+	case less(*b.Value, *a.Value):
+		return 1
+	case a.Inclusive == b.Inclusive:
+		return 0
+	case a.Inclusive:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// maxLower returns the more restrictive of two lower boundaries.
+func maxLower[T any](a, b *RangeItem[T], less func(T, T) bool) *RangeItem[T] {
+	if compareLower(a, b, less) >= 0 {
+		return bounded(a)
+	}
+	return bounded(b)
+}
+
+// minLower returns the less restrictive of two lower boundaries.
+func minLower[T any](a, b *RangeItem[T], less func(T, T) bool) *RangeItem[T] {
+	if compareLower(a, b, less) <= 0 {
+		return bounded(a)
+	}
+	return bounded(b)
+}
+
+// minUpper returns the more restrictive of two upper boundaries.
+func minUpper[T any](a, b *RangeItem[T], less func(T, T) bool) *RangeItem[T] {
+	if compareUpper(a, b, less) <= 0 {
+		return bounded(a)
+	}
+	return bounded(b)
+}
+
+// maxUpper returns the less restrictive of two upper boundaries.
+func maxUpper[T any](a, b *RangeItem[T], less func(T, T) bool) *RangeItem[T] {
+	if compareUpper(a, b, less) >= 0 {
+		return bounded(a)
+	}
+	return bounded(b)
+}
+
+// mapBounds returns a copy of r with lower and upper applied to the bounded sides.
+// Unbounded sides and inclusivity are preserved.
+func mapBounds[T any](r Range[T], lower, upper func(T) T) Range[T] {
+	res := r.clone()
+	if res.Min != nil {
+		*res.Min.Value = lower(*res.Min.Value)
+	}
+	if res.Max != nil {
+		*res.Max.Value = upper(*res.Max.Value)
+	}
+	return res
+}
+
+// cloneItem copies a boundary so the result does not alias its source. Unbounded stays nil.
+func cloneItem[T any](item *RangeItem[T]) *RangeItem[T] {
+	if bounded(item) == nil {
+		return nil
+	}
+	v := *item.Value
+	return &RangeItem[T]{Value: &v, Inclusive: item.Inclusive}
+}
@@ -0,0 +1,223 @@
+package _range
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+// rangeString renders r in interval notation for test failure messages.
+func rangeString(r Range[int]) string {
+	s := "("
+	if r.Min != nil && r.Min.Value != nil {
+		if r.Min.Inclusive {
+			s = "["
+		}
+		s += fmt.Sprint(*r.Min.Value)
+	}
+	s += ","
+	end := ")"
+	if r.Max != nil && r.Max.Value != nil {
+		s += fmt.Sprint(*r.Max.Value)
+		if r.Max.Inclusive {
+			end = "]"
+		}
+	}
+	return s + end
+}
+
+func TestRange_Intersect(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Range[int]
+		expected string
+		ok       bool
+	}{
+		{"Overlapping closed", Closed(1, 10), Closed(5, 15), "[5,10]", true},
+		{"Nested", Closed(1, 10), Open(3, 4), "(3,4)", true},
+		{"Same value, exclusive wins", Closed(1, 10), Open(1, 10), "(1,10)", true},
+		{"Touching closed", Closed(1, 5), Closed(5, 10), "[5,5]", true},
+		{"Touching half-open", HalfOpen(1, 5), HalfOpen(5, 10), "[5,5)", false},
+		{"Disjoint", Closed(1, 2), Closed(3, 4), "[3,2]", false},
+		{"Unbounded sides", AtLeast(5), LessThan(8), "[5,8)", true},
+		{"Any", Range[int]{}, GreaterThan(3), "(3,)", true},
+		{"Both any", Range[int]{}, Range[int]{}, "(,)", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := IntersectOrdered(tt.a, tt.b)
+			if ok != tt.ok || rangeString(got) != tt.expected {
+				t.Errorf("Intersect = %s, %v; want %s, %v", rangeString(got), ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRange_IntersectDoesNotAlias(t *testing.T) {
+	a := Closed(1, 10)
+	got, _ := a.Intersect(Range[int]{}, func(x, y int) bool { return x < y })
+	*got.Min.Value = 100
+	if *a.Min.Value != 1 {
+		t.Error("Intersect result should not share boundaries with its inputs")
+	}
+}
+
+func TestRange_Union(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Range[int]
+		expected string
+		ok       bool
+	}{
+		{"Overlapping", Closed(1, 10), Closed(5, 15), "[1,15]", true},
+		{"Touching half-open", HalfOpen(1, 5), HalfOpen(5, 10), "[1,10)", true},
+		{"Touching closed and open", Closed(1, 5), Open(5, 10), "[1,10)", true},
+		{"Reversed order", Closed(5, 10), HalfOpen(1, 5), "[1,10]", true},
+		{"Both excluding shared point", Open(1, 5), Open(5, 10), "(,)", false},
+		{"Gap", Closed(1, 2), Closed(3, 4), "(,)", false},
+		{"Unbounded", AtMost(5), GreaterThan(3), "(,)", true},
+		{"Empty operand", Open(5, 5), Closed(1, 2), "[1,2]", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := UnionOrdered(tt.a, tt.b)
+			if ok != tt.ok || rangeString(got) != tt.expected {
+				t.Errorf("Union = %s, %v; want %s, %v", rangeString(got), ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRange_Span(t *testing.T) {
+	if got := rangeString(SpanOrdered(Closed(1, 2), Open(5, 8))); got != "[1,8)" {
+		t.Errorf("Span = %s, want [1,8)", got)
+	}
+	if got := rangeString(SpanOrdered(Open(1, 2), AtLeast(1))); got != "[1,)" {
+		t.Errorf("Span = %s, want [1,)", got)
+	}
+}
+
+func TestRange_OverlapsAndAdjacent(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     Range[int]
+		overlaps bool
+		adjacent bool
+	}{
+		{"Overlapping", Closed(1, 10), Closed(5, 15), true, false},
+		{"Sharing a closed point", Closed(1, 5), Closed(5, 10), true, false},
+		{"Half-open back to back", HalfOpen(1, 5), HalfOpen(5, 10), false, true},
+		{"Reversed order", HalfOpen(5, 10), HalfOpen(1, 5), false, true},
+		{"Open back to back", Open(1, 5), Open(5, 10), false, false},
+		{"Gap", Closed(1, 2), Closed(3, 4), false, false},
+		{"Unbounded", LessThan(5), AtLeast(5), false, true},
+		{"Unbounded overlapping", AtMost(5), AtLeast(5), true, false},
+		{"Any", Range[int]{}, Closed(1, 2), true, false},
+		{"Empty", Open(5, 5), Closed(5, 6), false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := OverlapsOrdered(tt.a, tt.b); got != tt.overlaps {
+				t.Errorf("Overlaps = %v, want %v", got, tt.overlaps)
+			}
+			if got := AdjacentOrdered(tt.a, tt.b); got != tt.adjacent {
+				t.Errorf("Adjacent = %v, want %v", got, tt.adjacent)
+			}
+		})
+	}
+}
+
+func TestRange_Encloses(t *testing.T) {
+	tests := []struct {
+		name         string
+		outer, inner Range[int]
+		expected     bool
+	}{
+		{"Nested", Closed(1, 10), Closed(2, 9), true},
+		{"Equal", Closed(1, 10), Closed(1, 10), true},
+		{"Closed encloses open", Closed(1, 10), Open(1, 10), true},
+		{"Open does not enclose closed", Open(1, 10), Closed(1, 10), false},
+		{"Half-open edge", HalfOpen(1, 10), Closed(1, 10), false},
+		{"Partial overlap", Closed(1, 10), Closed(5, 15), false},
+		{"Unbounded outer", AtLeast(0), Closed(5, 15), true},
+		{"Unbounded inner", Closed(0, 100), AtLeast(5), false},
+		{"Any encloses all", Range[int]{}, LessThan(3), true},
+		{"Empty inner", Closed(1, 2), Open(50, 50), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EnclosesOrdered(tt.outer, tt.inner); got != tt.expected {
+				t.Errorf("Encloses = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRange_Canonical(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        Range[int]
+		expected string
+	}{
+		{"Open lower", New(&RangeItem[int]{Value: ptr(3)}, &RangeItem[int]{Value: ptr(7), Inclusive: true}), "[4,7]"},
+		{"Half-open", HalfOpen(1, 5), "[1,4]"},
+		{"Open", Open(1, 5), "[2,4]"},
+		{"Closed", Closed(1, 5), "[1,5]"},
+		{"Unbounded", GreaterThan(10), "[11,)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rangeString(CanonicalInteger(tt.r)); got != tt.expected {
+				t.Errorf("Canonical() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+
+	if EqualOrdered(HalfOpen(1, 5), Closed(1, 4)) {
+		t.Error("Expected different boundary forms not to be equal before canonicalization")
+	}
+	if !EqualOrdered(CanonicalInteger(HalfOpen(1, 5)), CanonicalInteger(Open(0, 5))) {
+		t.Error("Expected canonical forms of [1,5) and (0,5) to be equal")
+	}
+	if !EqualOrdered(AtLeast(1), AtLeast(1)) || EqualOrdered(AtLeast(1), Range[int]{}) {
+		t.Error("Unexpected Equal result for unbounded ranges")
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestMergeAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []Range[int]
+		expected []string
+	}{
+		{"Empty input", nil, nil},
+		{"Disjoint stay apart", []Range[int]{Closed(5, 6), Closed(1, 2)}, []string{"[1,2]", "[5,6]"}},
+		{"Overlapping", []Range[int]{Closed(1, 5), Closed(3, 8), Closed(7, 10)}, []string{"[1,10]"}},
+		{"Touching half-open", []Range[int]{HalfOpen(60, 120), HalfOpen(0, 60)}, []string{"[0,120)"}},
+		{"Open boundaries leave a gap", []Range[int]{Open(1, 5), Open(5, 10)}, []string{"(1,5)", "(5,10)"}},
+		{"Nested", []Range[int]{Closed(1, 10), Open(2, 3), Closed(4, 5)}, []string{"[1,10]"}},
+		{"Unbounded absorbs", []Range[int]{Closed(1, 2), AtLeast(0), LessThan(-5)}, []string{"(,-5)", "[0,)"}},
+		{"Empty dropped", []Range[int]{Open(3, 3), Closed(7, 1), Closed(1, 2)}, []string{"[1,2]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range MergeAllOrdered(tt.input) {
+				got = append(got, rangeString(r))
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("MergeAll() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
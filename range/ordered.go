@@ -0,0 +1,71 @@
+package _range
+
+import "cmp"
+
+// OrderedRange wraps a Range over a cmp.Ordered type so that comparisons need no less function.
+// The embedded Range is still accessible, and encoding (JSON, text) is unchanged.
+type OrderedRange[T cmp.Ordered] struct {
+	Range[T]
+}
+
+// Ordered wraps r in an OrderedRange.
+func Ordered[T cmp.Ordered](r Range[T]) OrderedRange[T] {
+	return OrderedRange[T]{r}
+}
+
+// Contains returns true if val lies within the range.
+func (r OrderedRange[T]) Contains(val T) bool {
+	return ContainsOrdered(r.Range, val)
+}
+
+// Clamp snaps val to the nearest boundary, see ClampOrdered.
+func (r OrderedRange[T]) Clamp(val T) T {
+	return ClampOrdered(r.Range, val)
+}
+
+// IsEmpty returns true if no value can satisfy the range.
+func (r OrderedRange[T]) IsEmpty() bool {
+	return IsEmptyOrdered(r.Range)
+}
+
+// Validate returns ErrInverted or ErrEmpty for malformed ranges.
+func (r OrderedRange[T]) Validate() error {
+	return ValidateOrdered(r.Range)
+}
+
+// Overlaps returns true if at least one value lies in both ranges.
+func (r OrderedRange[T]) Overlaps(other OrderedRange[T]) bool {
+	return OverlapsOrdered(r.Range, other.Range)
+}
+
+// Adjacent returns true if the ranges touch without overlapping.
+func (r OrderedRange[T]) Adjacent(other OrderedRange[T]) bool {
+	return AdjacentOrdered(r.Range, other.Range)
+}
+
+// Encloses returns true if every value of other lies in this range.
+func (r OrderedRange[T]) Encloses(other OrderedRange[T]) bool {
+	return EnclosesOrdered(r.Range, other.Range)
+}
+
+// Equal returns true if both ranges have the same boundaries.
+func (r OrderedRange[T]) Equal(other OrderedRange[T]) bool {
+	return EqualOrdered(r.Range, other.Range)
+}
+
+// Intersect returns the overlap of two ranges and whether it is non-empty.
+func (r OrderedRange[T]) Intersect(other OrderedRange[T]) (OrderedRange[T], bool) {
+	res, ok := IntersectOrdered(r.Range, other.Range)
+	return Ordered(res), ok
+}
+
+// Union returns the combined range if the two overlap or touch.
+func (r OrderedRange[T]) Union(other OrderedRange[T]) (OrderedRange[T], bool) {
+	res, ok := UnionOrdered(r.Range, other.Range)
+	return Ordered(res), ok
+}
+
+// Span returns the smallest range covering both, including any gap.
+func (r OrderedRange[T]) Span(other OrderedRange[T]) OrderedRange[T] {
+	return Ordered(SpanOrdered(r.Range, other.Range))
+}
@@ -0,0 +1,57 @@
+package _range
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestOrderedRange(t *testing.T) {
+	a := Ordered(HalfOpen(1, 5))
+	b := Ordered(Closed(5, 10))
+
+	if !a.Contains(1) || a.Contains(5) {
+		t.Error("Contains() does not respect inclusivity")
+	}
+	if a.Overlaps(b) || !a.Adjacent(b) {
+		t.Error("Expected [1,5) and [5,10] to be adjacent, not overlapping")
+	}
+
+	u, ok := a.Union(b)
+	if !ok || !u.Equal(Ordered(Closed(1, 10))) {
+		t.Errorf("Union() = %v, %v; want [1,10]", u, ok)
+	}
+	if !u.Encloses(a) || a.Encloses(u) {
+		t.Error("Encloses() mismatch")
+	}
+	if _, ok := a.Intersect(b); ok {
+		t.Error("Expected adjacent ranges to have no intersection")
+	}
+	if got := a.Span(Ordered(Closed(8, 9))); got.String() != "[1,9]" {
+		t.Errorf("Span() = %v, want [1,9]", got)
+	}
+	if got := a.Clamp(100); got != 5 {
+		t.Errorf("Clamp() = %d, want 5", got)
+	}
+	if !Ordered(Open(3, 3)).IsEmpty() || Ordered(Closed(7, 3)).Validate() != ErrInverted {
+		t.Error("Expected malformed ranges to be detected")
+	}
+}
+
+func TestOrderedRange_Encoding(t *testing.T) {
+	data, err := json.Marshal(Ordered(AtLeast("m")))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"min":{"value":"m","inclusive":true},"max":null}`
+	if string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", data, expected)
+	}
+
+	var r OrderedRange[string]
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !r.Contains("z") || r.Contains("a") {
+		t.Error("Unmarshaled range does not match expected behavior")
+	}
+}
@@ -1,6 +1,20 @@
 package _range
 
-import "cmp"
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+
+	"github.com/dullkingsman/kozo/codec"
+)
+
+var (
+	// ErrInverted is returned by Validate when the lower boundary lies above the upper boundary, e.g. [7, 3].
+	ErrInverted = errors.New("range: min is greater than max")
+	// ErrEmpty is returned by Validate when both boundaries sit on the same value but exclude it, e.g. (5, 5).
+	ErrEmpty = errors.New("range: boundaries exclude the only value between them")
+)
 
 // Range represents an interval.
 type Range[T any] struct {
@@ -14,6 +28,48 @@ type RangeItem[T any] struct {
 	Inclusive bool `json:"inclusive"`
 }
 
+// MarshalJSON encodes the boundary, encoding Value with the codec registered for T.
+func (ri RangeItem[T]) MarshalJSON() ([]byte, error) {
+	value := []byte("null")
+	if ri.Value != nil {
+		var err error
+		if value, err = codec.Marshal(*ri.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(struct {
+		Value     json.RawMessage `json:"value"`
+		Inclusive bool            `json:"inclusive"`
+	}{value, ri.Inclusive})
+}
+
+// UnmarshalJSON decodes the boundary, decoding Value with the codec registered for T.
+func (ri *RangeItem[T]) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Value     json.RawMessage `json:"value"`
+		Inclusive bool            `json:"inclusive"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	ri.Inclusive = raw.Inclusive
+	ri.Value = nil
+
+	if len(raw.Value) == 0 || bytes.Equal(raw.Value, []byte("null")) {
+		return nil
+	}
+
+	var v T
+	if err := codec.Unmarshal([]byte(raw.Value), &v); err != nil {
+		return err
+	}
+	ri.Value = &v
+
+	return nil
+}
+
 // New creates a new Range with the given boundaries.
 func New[T any](min, max *RangeItem[T]) Range[T] {
 	return Range[T]{
@@ -116,6 +172,41 @@ func ContainsOrdered[T cmp.Ordered](r Range[T], val T) bool {
 	})
 }
 
+// Clamp snaps val to the nearest value inside the range using a custom less function.
+// Values already inside the range are returned unchanged.
+//
+// For an exclusive lower boundary next(min) is used as the closest value inside, and for an exclusive
+// upper boundary prev(max). If next or prev is nil, the boundary value itself is used.
+func (r Range[T]) Clamp(val T, less func(T, T) bool, next, prev func(T) T) T {
+	if r.Min != nil && r.Min.Value != nil {
+		min := *r.Min.Value
+		if less(val, min) || (!r.Min.Inclusive && !less(min, val)) {
+			if !r.Min.Inclusive && next != nil {
+				return next(min)
+			}
+			return min
+		}
+	}
+
+	if r.Max != nil && r.Max.Value != nil {
+		max := *r.Max.Value
+		if less(max, val) || (!r.Max.Inclusive && !less(val, max)) {
+			if !r.Max.Inclusive && prev != nil {
+				return prev(max)
+			}
+			return max
+		}
+	}
+
+	return val
+}
+
+// ClampOrdered snaps val to the nearest boundary for ordered types.
+// Exclusive boundaries snap to the boundary value itself; use Clamp or ClampStep to step inside instead.
+func ClampOrdered[T cmp.Ordered](r Range[T], val T) T {
+	return r.Clamp(val, cmp.Less[T], nil, nil)
+}
+
 // IsBounded returns true if both min and max are set.
 func (r Range[T]) IsBounded() bool {
 	return r.Min != nil && r.Min.Value != nil && r.Max != nil && r.Max.Value != nil
@@ -127,3 +218,37 @@ func (r Range[T]) IsAny() bool {
 	maxUnbounded := r.Max == nil || r.Max.Value == nil
 	return minUnbounded && maxUnbounded
 }
+
+// IsEmpty returns true if no value can satisfy the range, e.g. (5, 5) or [7, 3].
+// Ranges are treated as continuous, so (3, 4) is not empty even for integers.
+func (r Range[T]) IsEmpty(less func(T, T) bool) bool {
+	return r.Validate(less) != nil
+}
+
+// IsEmptyOrdered returns true if no value can satisfy the range, for ordered types.
+func IsEmptyOrdered[T cmp.Ordered](r Range[T]) bool {
+	return r.IsEmpty(cmp.Less[T])
+}
+
+// Validate returns ErrInverted or ErrEmpty if the range is malformed and can never match a value.
+// Use it to reject ranges built from user input instead of letting them silently match nothing.
+func (r Range[T]) Validate(less func(T, T) bool) error {
+	if !r.IsBounded() {
+		return nil
+	}
+
+	min, max := *r.Min.Value, *r.Max.Value
+	if less(max, min) {
+		return ErrInverted
+	}
+	if !less(min, max) && (!r.Min.Inclusive || !r.Max.Inclusive) {
+		// min == max, only [v, v] holds a value.
+		return ErrEmpty
+	}
+	return nil
+}
+
+// ValidateOrdered returns ErrInverted or ErrEmpty if the range is malformed, for ordered types.
+func ValidateOrdered[T cmp.Ordered](r Range[T]) error {
+	return r.Validate(cmp.Less[T])
+}
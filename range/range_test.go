@@ -2,6 +2,7 @@ package _range
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 )
 
@@ -93,3 +94,79 @@ func TestRange_JSON(t *testing.T) {
 		t.Error("Unmarshaled range does not match expected behavior")
 	}
 }
+
+func TestRange_JSONUnbounded(t *testing.T) {
+	data, err := json.Marshal(AtLeast(10))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"min":{"value":10,"inclusive":true},"max":null}`
+	if string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", data, expected)
+	}
+
+	var r Range[int]
+	if err := json.Unmarshal(data, &r); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if r.Max != nil || !ContainsOrdered(r, 10) || ContainsOrdered(r, 9) {
+		t.Error("Unmarshaled range does not match expected behavior")
+	}
+}
+
+func TestRange_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        Range[int]
+		expected error
+	}{
+		{"Closed", Closed(3, 7), nil},
+		{"Single point", Closed(5, 5), nil},
+		{"Open single point", Open(5, 5), ErrEmpty},
+		{"Half-open single point", HalfOpen(5, 5), ErrEmpty},
+		{"Inverted", Closed(7, 3), ErrInverted},
+		{"Unbounded", AtLeast(7), nil},
+		{"Any", Range[int]{}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateOrdered(tt.r); !errors.Is(err, tt.expected) {
+				t.Errorf("Validate() = %v, want %v", err, tt.expected)
+			}
+			if got := IsEmptyOrdered(tt.r); got != (tt.expected != nil) {
+				t.Errorf("IsEmpty() = %v, want %v", got, tt.expected != nil)
+			}
+		})
+	}
+}
+
+func TestRange_Clamp(t *testing.T) {
+	tests := []struct {
+		name    string
+		r       Range[int]
+		val     int
+		ordered int
+		stepped int
+	}{
+		{"Inside", Closed(1, 10), 5, 5, 5},
+		{"Below closed", Closed(1, 10), -3, 1, 1},
+		{"Above closed", Closed(1, 10), 42, 10, 10},
+		{"Below open", Open(1, 10), 1, 1, 2},
+		{"Above open", Open(1, 10), 10, 10, 9},
+		{"Above half-open", HalfOpen(0, 100), 500, 100, 99},
+		{"Unbounded above", AtLeast(1), 1000, 1000, 1000},
+		{"Any", Range[int]{}, -7, -7, -7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClampOrdered(tt.r, tt.val); got != tt.ordered {
+				t.Errorf("ClampOrdered(%d) = %d, want %d", tt.val, got, tt.ordered)
+			}
+			if got := ClampStep(tt.r, tt.val, 1); got != tt.stepped {
+				t.Errorf("ClampStep(%d) = %d, want %d", tt.val, got, tt.stepped)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package _range
+
+import "github.com/dullkingsman/kozo/dialect"
+
+// ToSQL returns a parameterized SQL predicate restricting column to the range, with its arguments.
+// Bounded, inclusive ranges use BETWEEN; otherwise each bounded side becomes a comparison joined by AND,
+// e.g. "col >= ? AND col < ?". A range unbounded on both sides yields "1=1".
+//
+// Values are always passed as arguments, never interpolated. The column is inserted verbatim,
+// so it must come from trusted code rather than user input.
+func (r Range[T]) ToSQL(column string, d dialect.Dialect) (string, []any) {
+	lower, upper := bounded(r.Min), bounded(r.Max)
+
+	switch {
+	case lower == nil && upper == nil:
+		return "1=1", nil
+	case lower != nil && upper != nil && lower.Inclusive && upper.Inclusive:
+		return column + " BETWEEN " + d.Placeholder(1) + " AND " + d.Placeholder(2),
+			[]any{*lower.Value, *upper.Value}
+	}
+
+	var (
+		sql  string
+		args []any
+	)
+	if lower != nil {
+		op := " > "
+		if lower.Inclusive {
+			op = " >= "
+		}
+		args = append(args, *lower.Value)
+		sql = column + op + d.Placeholder(len(args))
+	}
+	if upper != nil {
+		op := " < "
+		if upper.Inclusive {
+			op = " <= "
+		}
+		if sql != "" {
+			sql += " AND "
+		}
+		args = append(args, *upper.Value)
+		sql += column + op + d.Placeholder(len(args))
+	}
+
+	return sql, args
+}
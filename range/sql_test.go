@@ -0,0 +1,35 @@
+package _range
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dullkingsman/kozo/dialect"
+)
+
+func TestRange_ToSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        Range[int]
+		d        dialect.Dialect
+		expected string
+		args     []any
+	}{
+		{"Closed", Closed(1, 10), dialect.MySQL, "age BETWEEN ? AND ?", []any{1, 10}},
+		{"Half-open", HalfOpen(1, 10), dialect.PostgreSQL, "age >= $1 AND age < $2", []any{1, 10}},
+		{"Open", Open(1, 10), dialect.SQLServer, "age > @p1 AND age < @p2", []any{1, 10}},
+		{"Lower only", GreaterThan(5), dialect.PostgreSQL, "age > $1", []any{5}},
+		{"Upper only", AtMost(5), dialect.PostgreSQL, "age <= $1", []any{5}},
+		{"Unbounded", Range[int]{}, dialect.PostgreSQL, "1=1", nil},
+		{"Offset", HalfOpen(1, 10), dialect.Offset(dialect.PostgreSQL, 2), "age >= $3 AND age < $4", []any{1, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.r.ToSQL("age", tt.d)
+			if sql != tt.expected || !slices.Equal(args, tt.args) {
+				t.Errorf("ToSQL() = %q, %v; want %q, %v", sql, args, tt.expected, tt.args)
+			}
+		})
+	}
+}
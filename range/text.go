@@ -0,0 +1,235 @@
+package _range
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dullkingsman/kozo/codec"
+)
+
+// ErrSyntax is returned by Parse and UnmarshalText when the input is not in interval notation.
+var ErrSyntax = errors.New("range: invalid interval notation")
+
+// Parse reads a range in standard interval notation, e.g. "[10,20)", "(,100]" or "[2024-01-01T00:00:00Z,)".
+// Square brackets mark inclusive boundaries, parentheses exclusive ones, and an empty side is unbounded.
+//
+// Values are decoded with encoding.TextUnmarshaler if *T implements it, taken verbatim if T is a string,
+// and otherwise decoded as JSON with the codec registered for T. A string or text value may be written as
+// a Go-style quoted string, e.g. ["a,b",z], which is how MarshalText writes values containing the separator,
+// brackets, quotes or surrounding spaces. A comma inside a quoted value does not separate the boundaries.
+func Parse[T any](s string) (Range[T], error) {
+	var r Range[T]
+	if err := r.UnmarshalText([]byte(s)); err != nil {
+		return Range[T]{}, err
+	}
+	return r, nil
+}
+
+// String returns the range in interval notation, see Parse.
+func (r Range[T]) String() string {
+	text, err := r.MarshalText()
+	if err != nil {
+		return fmt.Sprintf("%%!range(%v)", err)
+	}
+	return string(text)
+}
+
+// MarshalText encodes the range in interval notation, see Parse.
+// Unbounded sides are written empty with a parenthesis, and string or text values that could not be read
+// back verbatim, including the empty string, are quoted.
+func (r Range[T]) MarshalText() ([]byte, error) {
+	var b strings.Builder
+
+	lower := bounded(r.Min)
+	if lower != nil && lower.Inclusive {
+		b.WriteByte('[')
+	} else {
+		b.WriteByte('(')
+	}
+	if lower != nil {
+		v, err := formatValue(*lower.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(v)
+	}
+
+	b.WriteByte(',')
+
+	upper := bounded(r.Max)
+	if upper != nil {
+		v, err := formatValue(*upper.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(v)
+	}
+	if upper != nil && upper.Inclusive {
+		b.WriteByte(']')
+	} else {
+		b.WriteByte(')')
+	}
+
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText decodes a range in interval notation, see Parse.
+func (r *Range[T]) UnmarshalText(text []byte) error {
+	s := strings.TrimSpace(string(text))
+	if len(s) < 3 {
+		return fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	left, right := s[0], s[len(s)-1]
+	if (left != '[' && left != '(') || (right != ']' && right != ')') {
+		return fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	lowerText, upperText, ok := splitBoundaries(s[1 : len(s)-1])
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrSyntax, s)
+	}
+
+	min, err := parseBoundary[T](lowerText, left == '[')
+	if err != nil {
+		return fmt.Errorf("range: lower boundary: %w", err)
+	}
+	max, err := parseBoundary[T](upperText, right == ']')
+	if err != nil {
+		return fmt.Errorf("range: upper boundary: %w", err)
+	}
+
+	r.Min, r.Max = min, max
+	return nil
+}
+
+// MarshalJSON encodes the range as a structured object with "min" and "max" boundaries.
+// It is defined so that MarshalText does not take over the JSON representation.
+func (r Range[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Min *RangeItem[T] `json:"min"`
+		Max *RangeItem[T] `json:"max"`
+	}{r.Min, r.Max})
+}
+
+// UnmarshalJSON decodes a range from a structured object with "min" and "max" boundaries.
+func (r *Range[T]) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Min *RangeItem[T] `json:"min"`
+		Max *RangeItem[T] `json:"max"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.Min, r.Max = raw.Min, raw.Max
+	return nil
+}
+
+// splitBoundaries splits the inside of the notation at its single separating comma.
+// A side that starts with a double quote extends to the matching closing quote, so it may contain commas.
+func splitBoundaries(s string) (string, string, bool) {
+	cut := -1
+	atStart := true
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case atStart && c == '"':
+			end := closingQuote(s, i)
+			if end < 0 {
+				return "", "", false
+			}
+			i = end
+			atStart = false
+		case c == ',':
+			if cut >= 0 {
+				return "", "", false
+			}
+			cut = i
+			atStart = true
+		case c != ' ' && c != '\t':
+			atStart = false
+		}
+	}
+	if cut < 0 {
+		return "", "", false
+	}
+	return s[:cut], s[cut+1:], true
+}
+
+// closingQuote returns the index of the unescaped double quote closing the one at start, or -1.
+func closingQuote(s string, start int) int {
+	for i := start + 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
+
+// parseBoundary decodes one side of the notation. An empty side is unbounded and yields nil.
+func parseBoundary[T any](s string, inclusive bool) (*RangeItem[T], error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+
+	var v T
+	switch target := any(&v).(type) {
+	case encoding.TextUnmarshaler:
+		text, err := unquoteBoundary(s)
+		if err != nil {
+			return nil, err
+		}
+		if err := target.UnmarshalText([]byte(text)); err != nil {
+			return nil, err
+		}
+	case *string:
+		text, err := unquoteBoundary(s)
+		if err != nil {
+			return nil, err
+		}
+		*target = text
+	default:
+		if err := codec.Unmarshal([]byte(s), &v); err != nil {
+			return nil, err
+		}
+	}
+
+	return &RangeItem[T]{Value: &v, Inclusive: inclusive}, nil
+}
+
+// formatValue encodes a boundary value, mirroring parseBoundary.
+func formatValue[T any](v T) (string, error) {
+	switch value := any(v).(type) {
+	case encoding.TextMarshaler:
+		text, err := value.MarshalText()
+		return quoteBoundary(string(text)), err
+	case string:
+		return quoteBoundary(value), nil
+	default:
+		data, err := codec.Marshal(v)
+		return string(data), err
+	}
+}
+
+// quoteBoundary quotes a string or text value that would not be read back verbatim.
+func quoteBoundary(s string) string {
+	if s == "" || strings.TrimSpace(s) != s || strings.ContainsAny(s, `,"[]()`) {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// unquoteBoundary reverses quoteBoundary. Values that do not start with a double quote are taken verbatim.
+func unquoteBoundary(s string) (string, error) {
+	if !strings.HasPrefix(s, `"`) {
+		return s, nil
+	}
+	return strconv.Unquote(s)
+}
@@ -0,0 +1,122 @@
+package _range
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[10,20)", "[10,20)"},
+		{"(,100]", "(,100]"},
+		{" ( 5 , ) ", "(5,)"},
+		{"[,]", "(,)"},
+		{"[-3,-1]", "[-3,-1]"},
+	}
+
+	for _, tt := range tests {
+		r, err := Parse[int](tt.input)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if got := r.String(); got != tt.expected {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+
+	r, _ := Parse[int]("(,100]")
+	if !ContainsOrdered(r, -1000) || !ContainsOrdered(r, 100) || ContainsOrdered(r, 101) {
+		t.Error("Parsed range does not match expected behavior")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, input := range []string{"", "10,20", "[10;20]", "{1,2}", "[1,2,3]"} {
+		if _, err := Parse[int](input); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Parse(%q) error = %v, want ErrSyntax", input, err)
+		}
+	}
+
+	if _, err := Parse[int]("[a,2]"); err == nil || errors.Is(err, ErrSyntax) {
+		t.Errorf("Expected a value error for a non-numeric boundary, got %v", err)
+	}
+}
+
+func TestParse_Types(t *testing.T) {
+	s, err := Parse[string]("[apple,banana)")
+	if err != nil || !ContainsOrdered(s, "avocado") || ContainsOrdered(s, "banana") {
+		t.Errorf("Parse[string]() = %v, %v", s, err)
+	}
+
+	f, err := Parse[float64]("(0.5,1e3]")
+	if err != nil || f.String() != "(0.5,1000]" {
+		t.Errorf("Parse[float64]() = %v, %v", f, err)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tr, err := Parse[time.Time]("[2024-01-01T00:00:00Z,)")
+	if err != nil || !tr.Min.Value.Equal(start) || tr.Max != nil {
+		t.Errorf("Parse[time.Time]() = %v, %v", tr, err)
+	}
+	if got := tr.String(); got != "[2024-01-01T00:00:00Z,)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestRange_TextRoundTripStrings(t *testing.T) {
+	bounds := []string{"a,b", "[x]", "(y)", `say "hi"`, " padded ", "", `back\slash`, "plain"}
+	for _, lower := range bounds {
+		for _, upper := range bounds {
+			r := HalfOpen(lower, upper)
+			text, err := r.MarshalText()
+			if err != nil {
+				t.Fatalf("MarshalText(%q, %q) failed: %v", lower, upper, err)
+			}
+
+			var got Range[string]
+			if err := got.UnmarshalText(text); err != nil {
+				t.Errorf("UnmarshalText(%s) failed: %v", text, err)
+				continue
+			}
+			if got.Min == nil || *got.Min.Value != lower || !got.Min.Inclusive ||
+				got.Max == nil || *got.Max.Value != upper || got.Max.Inclusive {
+				t.Errorf("Round trip of [%q,%q) via %s gave %v", lower, upper, text, got)
+			}
+		}
+	}
+
+	if got := HalfOpen("a,b", "plain").String(); got != `["a,b",plain)` {
+		t.Errorf("String() = %s, want %s", got, `["a,b",plain)`)
+	}
+	if _, err := Parse[string](`["a,b,c)`); !errors.Is(err, ErrSyntax) {
+		t.Errorf("Expected ErrSyntax for an unterminated quote, got %v", err)
+	}
+}
+
+func TestRange_TextInJSON(t *testing.T) {
+	// Defining MarshalText must not change the structured JSON representation.
+	data, err := json.Marshal(map[string]Range[int]{"a": HalfOpen(1, 2)})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	expected := `{"a":{"min":{"value":1,"inclusive":true},"max":{"value":2,"inclusive":false}}}`
+	if string(data) != expected {
+		t.Errorf("Marshal mismatch. Got %s, want %s", data, expected)
+	}
+
+	var flags struct {
+		Window Range[int] `json:"window"`
+	}
+	if err := json.Unmarshal([]byte(`{"window":{"min":null,"max":{"value":5,"inclusive":true}}}`), &flags); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if flags.Window.String() != "(,5]" {
+		t.Errorf("Unmarshal mismatch. Got %v", flags.Window)
+	}
+}
@@ -0,0 +1,130 @@
+package _range
+
+import (
+	"iter"
+	"time"
+)
+
+// TimeLess orders times chronologically. It can be passed wherever a less function is expected,
+// e.g. to NewIntervalTree for time keys.
+func TimeLess(a, b time.Time) bool {
+	return a.Before(b)
+}
+
+// Between returns the half-open range [t1, t2), swapping the arguments if t2 is before t1.
+// Half-open ranges tile without gaps or overlaps, which makes them the natural choice for time windows.
+func Between(t1, t2 time.Time) Range[time.Time] {
+	if t2.Before(t1) {
+		t1, t2 = t2, t1
+	}
+	return HalfOpen(t1, t2)
+}
+
+// Day returns the calendar day containing t in t's location, as [midnight, next midnight).
+// Days affected by daylight saving transitions are 23 or 25 hours long.
+func Day(t time.Time) Range[time.Time] {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	return HalfOpen(start, start.AddDate(0, 0, 1))
+}
+
+// Today returns the current local calendar day, as [midnight, next midnight).
+func Today() Range[time.Time] {
+	return Day(time.Now())
+}
+
+// LastN returns the window [now - d, now] ending at the current time, e.g. LastN(24 * time.Hour).
+func LastN(d time.Duration) Range[time.Time] {
+	now := time.Now()
+	return Closed(now.Add(-d), now)
+}
+
+// Since returns the range of all times at or after t.
+func Since(t time.Time) Range[time.Time] {
+	return AtLeast(t)
+}
+
+// Until returns the range of all times strictly before t.
+func Until(t time.Time) Range[time.Time] {
+	return LessThan(t)
+}
+
+// ContainsTime returns true if t lies within the range.
+func ContainsTime(r Range[time.Time], t time.Time) bool {
+	return r.Contains(t, TimeLess)
+}
+
+// OverlapsTime returns true if at least one time lies in both ranges.
+func OverlapsTime(a, b Range[time.Time]) bool {
+	return a.Overlaps(b, TimeLess)
+}
+
+// IntersectTime returns the overlap of two time ranges and whether it is non-empty.
+func IntersectTime(a, b Range[time.Time]) (Range[time.Time], bool) {
+	return a.Intersect(b, TimeLess)
+}
+
+// EnclosesTime returns true if every time in inner lies in outer.
+func EnclosesTime(outer, inner Range[time.Time]) bool {
+	return outer.Encloses(inner, TimeLess)
+}
+
+// Duration returns the extent max - min of a bounded time range.
+// Returns (0, false) if either side is unbounded or the range is inverted.
+func Duration(r Range[time.Time]) (time.Duration, bool) {
+	if !r.IsBounded() || r.Max.Value.Before(*r.Min.Value) {
+		return 0, false
+	}
+	return r.Max.Value.Sub(*r.Min.Value), true
+}
+
+// TimeSteps returns an iterator over min, min+step, min+2*step, ... that yields only times inside the range,
+// so an exclusive lower boundary skips min and the upper boundary's inclusivity is respected.
+// Yields nothing if the lower side is unbounded or step is not positive.
+// If the upper side is unbounded, the sequence only ends when the caller stops.
+func TimeSteps(r Range[time.Time], step time.Duration) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if r.Min == nil || r.Min.Value == nil || step <= 0 {
+			return
+		}
+
+		min := *r.Min.Value
+		for i := time.Duration(0); ; i++ {
+			v := min.Add(i * step)
+			if !r.Min.Inclusive && v.Equal(min) {
+				continue
+			}
+			if r.Max != nil && r.Max.Value != nil {
+				if v.After(*r.Max.Value) || (!r.Max.Inclusive && v.Equal(*r.Max.Value)) {
+					return
+				}
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// ShiftTime moves both boundaries of a time range by d. Unbounded sides stay unbounded.
+func ShiftTime(r Range[time.Time], d time.Duration) Range[time.Time] {
+	add := func(t time.Time) time.Time { return t.Add(d) }
+	return mapBounds(r, add, add)
+}
+
+// ExpandTime widens a time range by d on each bounded side, e.g. to pad a query window by 5 minutes.
+func ExpandTime(r Range[time.Time], d time.Duration) Range[time.Time] {
+	return mapBounds(r,
+		func(t time.Time) time.Time { return t.Add(-d) },
+		func(t time.Time) time.Time { return t.Add(d) },
+	)
+}
+
+// ShrinkTime narrows a time range by d on each bounded side.
+// Shrinking by more than half the duration inverts the range, see Validate.
+func ShrinkTime(r Range[time.Time], d time.Duration) Range[time.Time] {
+	return mapBounds(r,
+		func(t time.Time) time.Time { return t.Add(d) },
+		func(t time.Time) time.Time { return t.Add(-d) },
+	)
+}
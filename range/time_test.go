@@ -0,0 +1,111 @@
+package _range
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	d, ok := Duration(HalfOpen(start, start.Add(90*time.Minute)))
+	if !ok || d != 90*time.Minute {
+		t.Errorf("Duration() = %v, %v; want 1h30m, true", d, ok)
+	}
+
+	if _, ok := Duration(AtLeast(start)); ok {
+		t.Error("Expected unbounded range to have no duration")
+	}
+
+	if _, ok := Duration(Closed(start, start.Add(-time.Second))); ok {
+		t.Error("Expected inverted range to have no duration")
+	}
+}
+
+func TestTimeSteps(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	got := slices.Collect(TimeSteps(HalfOpen(start, start.Add(3*time.Hour)), time.Hour))
+	if len(got) != 3 || !got[0].Equal(start) || !got[2].Equal(start.Add(2*time.Hour)) {
+		t.Errorf("TimeSteps() = %v, want 3 hourly steps from %v", got, start)
+	}
+
+	got = slices.Collect(TimeSteps(Closed(start, start.Add(time.Hour)), 30*time.Minute))
+	if len(got) != 3 {
+		t.Errorf("Expected inclusive end to be yielded, got %v", got)
+	}
+}
+
+func TestTimeTransformations(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	r := HalfOpen(start, start.Add(time.Hour))
+
+	padded := ExpandTime(r, 5*time.Minute)
+	if !padded.Min.Value.Equal(start.Add(-5*time.Minute)) || !padded.Max.Value.Equal(start.Add(65*time.Minute)) {
+		t.Errorf("ExpandTime() = [%v, %v)", *padded.Min.Value, *padded.Max.Value)
+	}
+
+	shifted := ShiftTime(r, time.Hour)
+	if !shifted.Min.Value.Equal(start.Add(time.Hour)) || shifted.Max.Inclusive {
+		t.Errorf("ShiftTime() = [%v, %v)", *shifted.Min.Value, *shifted.Max.Value)
+	}
+
+	shrunk := ShrinkTime(r, 10*time.Minute)
+	if d, _ := Duration(shrunk); d != 40*time.Minute {
+		t.Errorf("ShrinkTime() duration = %v, want 40m", d)
+	}
+
+	if got := ExpandTime(LessThan(start), time.Minute); got.Min != nil {
+		t.Error("Expected unbounded side to stay unbounded")
+	}
+}
+
+func TestTimeConstructors(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	noon := time.Date(2024, 3, 15, 12, 30, 0, 0, loc)
+
+	day := Day(noon)
+	if !day.Min.Value.Equal(time.Date(2024, 3, 15, 0, 0, 0, 0, loc)) || !day.Min.Inclusive {
+		t.Errorf("Day() should start at midnight inclusive, got %v", *day.Min.Value)
+	}
+	if !day.Max.Value.Equal(time.Date(2024, 3, 16, 0, 0, 0, 0, loc)) || day.Max.Inclusive {
+		t.Errorf("Day() should end at the next midnight exclusive, got %v", *day.Max.Value)
+	}
+
+	r := Between(noon, noon.Add(-time.Hour))
+	if !r.Min.Value.Equal(noon.Add(-time.Hour)) || !r.Max.Value.Equal(noon) {
+		t.Errorf("Between() should order its arguments, got [%v, %v)", *r.Min.Value, *r.Max.Value)
+	}
+
+	last := LastN(time.Hour)
+	if d, _ := Duration(last); d != time.Hour || !ContainsTime(last, *last.Max.Value) {
+		t.Errorf("LastN() = [%v, %v]", *last.Min.Value, *last.Max.Value)
+	}
+
+	if !ContainsTime(Today(), time.Now()) {
+		t.Error("Expected Today() to contain the current time")
+	}
+}
+
+func TestTimeRelations(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	morning := Between(start.Add(6*time.Hour), start.Add(12*time.Hour))
+	afternoon := Between(start.Add(12*time.Hour), start.Add(18*time.Hour))
+
+	if ContainsTime(morning, start.Add(12*time.Hour)) {
+		t.Error("Expected the half-open window to exclude its end")
+	}
+	if OverlapsTime(morning, afternoon) {
+		t.Error("Expected consecutive windows not to overlap")
+	}
+	if !OverlapsTime(morning, Since(start.Add(11*time.Hour))) {
+		t.Error("Expected Since() to overlap the morning")
+	}
+	if _, ok := IntersectTime(morning, Until(start.Add(6*time.Hour))); ok {
+		t.Error("Expected Until() to end before the morning")
+	}
+	if !EnclosesTime(Day(start), afternoon) {
+		t.Error("Expected the day to enclose the afternoon")
+	}
+}
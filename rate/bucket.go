@@ -0,0 +1,103 @@
+package rate
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket is a thread-safe Limiter allowing a steady rate of events with bursts: the bucket holds up to
+// burst tokens, refills at rate tokens per second, and each event takes a token. Reservations borrow tokens
+// ahead of the refill, so that reserved events are spaced at the rate.
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64 // tokens per second
+	burst  int
+	tokens float64 // negative while reservations are pending
+	last   time.Time
+	now    func() time.Time
+}
+
+var _ Limiter = (*TokenBucket)(nil)
+
+// NewTokenBucket returns a new full TokenBucket refilling at rate tokens per second up to burst tokens.
+// It panics if rate is not positive or burst is less than 1.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if !(rate > 0) || burst < 1 {
+		panic("rate: rate must be positive and burst at least 1")
+	}
+	return &TokenBucket{rate: rate, burst: burst, tokens: float64(burst), last: time.Now(), now: time.Now}
+}
+
+// SetClock replaces the clock of the bucket, time.Now by default, such as with a fake clock in tests,
+// and refills the bucket.
+func (b *TokenBucket) SetClock(now func() time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.now = now
+	b.tokens, b.last = float64(b.burst), now()
+}
+
+// Allow reports whether one event may happen now, and takes a token if so.
+func (b *TokenBucket) Allow() bool {
+	return b.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, and takes n tokens if so.
+func (b *TokenBucket) AllowN(n int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+// Reserve reserves one token; see ReserveN.
+func (b *TokenBucket) Reserve() Reservation {
+	return b.ReserveN(1)
+}
+
+// ReserveN takes n tokens, borrowing those missing from the refill, and returns how long the caller must wait
+// for the bucket to have refilled them. It returns a Reservation that is not OK if n exceeds the burst.
+func (b *TokenBucket) ReserveN(n int) Reservation {
+	if n > b.burst {
+		return Reservation{}
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	b.tokens -= float64(n)
+	if b.tokens >= 0 {
+		return Reservation{OK: true}
+	}
+	return Reservation{OK: true, Delay: time.Duration(-b.tokens / b.rate * float64(time.Second))}
+}
+
+// Tokens returns the number of tokens available now, which is negative while reservations are pending.
+func (b *TokenBucket) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill()
+	return b.tokens
+}
+
+// Rate returns the number of tokens added per second.
+func (b *TokenBucket) Rate() float64 {
+	return b.rate
+}
+
+// Burst returns the largest number of tokens the bucket holds.
+func (b *TokenBucket) Burst() int {
+	return b.burst
+}
+
+// refill adds the tokens accrued since the last refill. A clock going backwards adds none.
+func (b *TokenBucket) refill() {
+	now := b.now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(float64(b.burst), b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
@@ -0,0 +1,85 @@
+package rate
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock is a clock advanced by hand.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestTokenBucket_Allow(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(10, 5) // a token every 100ms
+	b.SetClock(clock.now)
+
+	if !b.AllowN(5) || b.Allow() {
+		t.Fatal("Expected a full bucket to allow its burst and no more")
+	}
+	clock.advance(250 * time.Millisecond)
+	if !b.AllowN(2) || b.Allow() {
+		t.Errorf("Expected 2 tokens after 250ms, Tokens() = %v", b.Tokens())
+	}
+
+	clock.advance(time.Hour)
+	if b.Tokens() != 5 {
+		t.Errorf("Expected the bucket to refill up to its burst, Tokens() = %v", b.Tokens())
+	}
+	if b.AllowN(6) || b.Tokens() != 5 {
+		t.Error("Expected more than the burst to be refused without taking tokens")
+	}
+	if b.Rate() != 10 || b.Burst() != 5 {
+		t.Errorf("Rate() = %v, Burst() = %v", b.Rate(), b.Burst())
+	}
+}
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	clock := newFakeClock()
+	b := NewTokenBucket(10, 2)
+	b.SetClock(clock.now)
+
+	delays := []time.Duration{0, 0, 100 * time.Millisecond, 200 * time.Millisecond}
+	for i, want := range delays {
+		if r := b.Reserve(); !r.OK || r.Delay != want {
+			t.Errorf("Reservation %d = %+v, want delay %v", i, r, want)
+		}
+	}
+	if b.Allow() {
+		t.Error("Expected Allow() to fail while reservations are pending")
+	}
+	if r := b.ReserveN(3); r.OK {
+		t.Errorf("Expected reserving more than the burst to fail, got %+v", r)
+	}
+
+	clock.advance(200 * time.Millisecond)
+	if b.Tokens() != 0 || b.Allow() {
+		t.Errorf("Tokens() = %v once reservations are due", b.Tokens())
+	}
+	clock.advance(100 * time.Millisecond)
+	if !b.Allow() {
+		t.Error("Expected a token after the reservations")
+	}
+}
+
+func TestTokenBucket_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a zero rate to panic")
+		}
+	}()
+	NewTokenBucket(0, 1)
+}
@@ -0,0 +1,27 @@
+// Package rate provides rate limiters bounding how often events happen, such as requests
+// to a downstream service or items entering a pipeline.
+package rate
+
+import "time"
+
+// Limiter bounds the rate of events. Implementations are safe for concurrent use.
+type Limiter interface {
+	// Allow reports whether one event may happen now, and records it if so.
+	Allow() bool
+	// AllowN reports whether n events may happen now, and records them if so. Events are all or nothing.
+	AllowN(n int) bool
+	// Reserve reserves one event; see ReserveN.
+	Reserve() Reservation
+	// ReserveN reserves n events at the earliest time they fit, after those already reserved, and records
+	// them as happening then. The caller must wait Delay before acting. It returns a Reservation that is not OK,
+	// recording nothing, if n events can never fit.
+	ReserveN(n int) Reservation
+}
+
+// Reservation is the outcome of reserving events with a Limiter.
+type Reservation struct {
+	// OK is false if the events can never fit within the limits, such as more events than the burst of a bucket.
+	OK bool
+	// Delay is how long the caller must wait before the events happen. It is 0 if they may happen now.
+	Delay time.Duration
+}
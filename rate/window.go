@@ -0,0 +1,164 @@
+package rate
+
+import (
+	"sync"
+	"time"
+)
+
+// SlidingWindow is a thread-safe Limiter allowing at most limit events in any window of the given duration,
+// approximately, in constant memory. It counts events in consecutive fixed windows and estimates the count
+// of the sliding window ending now by weighting the previous fixed window by the part of it still covered:
+// 30% into a window, the estimate is 70% of the previous count plus the current count. This smooths the bursts
+// that fixed windows allow at their boundaries, assuming the events of the previous window were evenly spread.
+//
+// Reservations are counted in the fixed window in which they fall, which may lie in the future.
+type SlidingWindow struct {
+	mu     sync.Mutex
+	limit  int
+	window time.Duration
+	start  time.Time // start of the current fixed window
+	counts []int     // the previous window, the current one, then the windows holding future reservations
+	next   time.Time // the time of the last reservation, before which no event may be allowed
+	now    func() time.Time
+}
+
+var _ Limiter = (*SlidingWindow)(nil)
+
+// NewSlidingWindow returns a new SlidingWindow allowing limit events per window.
+// It panics if limit is less than 1 or window is not positive.
+func NewSlidingWindow(limit int, window time.Duration) *SlidingWindow {
+	if limit < 1 || window <= 0 {
+		panic("rate: limit must be at least 1 and window positive")
+	}
+	return &SlidingWindow{limit: limit, window: window, start: time.Now(), counts: make([]int, 2), now: time.Now}
+}
+
+// SetClock replaces the clock of the limiter, time.Now by default, such as with a fake clock in tests,
+// and forgets the recorded events.
+func (w *SlidingWindow) SetClock(now func() time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.now = now
+	w.start, w.counts, w.next = now(), make([]int, 2), time.Time{}
+}
+
+// Allow reports whether one event may happen now, and records it if so.
+func (w *SlidingWindow) Allow() bool {
+	return w.AllowN(1)
+}
+
+// AllowN reports whether n events may happen now, and records them if so.
+// It returns false while reservations are pending.
+func (w *SlidingWindow) AllowN(n int) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if n > w.limit {
+		return false
+	}
+	now := w.now()
+	w.advance(now)
+	i, at := w.slot(now, n)
+	if at.After(now) {
+		return false
+	}
+	w.counts[i] += n
+	return true
+}
+
+// Reserve reserves one event; see ReserveN.
+func (w *SlidingWindow) Reserve() Reservation {
+	return w.ReserveN(1)
+}
+
+// ReserveN records n events at the earliest time, not before earlier reservations, at which the estimate
+// of the sliding window ending then leaves room for them. It returns a Reservation that is not OK
+// if n exceeds the limit.
+func (w *SlidingWindow) ReserveN(n int) Reservation {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if n > w.limit {
+		return Reservation{}
+	}
+	now := w.now()
+	w.advance(now)
+	i, at := w.slot(now, n)
+	w.counts[i] += n
+	w.next = at
+	return Reservation{OK: true, Delay: at.Sub(now)}
+}
+
+// Count returns the estimated number of events in the sliding window ending now.
+func (w *SlidingWindow) Count() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.now()
+	w.advance(now)
+	return w.estimate(1, now)
+}
+
+// Limit returns the number of events allowed per window.
+func (w *SlidingWindow) Limit() int {
+	return w.limit
+}
+
+// Window returns the duration of the window.
+func (w *SlidingWindow) Window() time.Duration {
+	return w.window
+}
+
+// advance moves the current fixed window to the one containing now, dropping the counts of older windows.
+func (w *SlidingWindow) advance(now time.Time) {
+	k := int(now.Sub(w.start) / w.window)
+	if k <= 0 {
+		return
+	}
+	w.start = w.start.Add(time.Duration(k) * w.window)
+	if k >= len(w.counts) {
+		w.counts = w.counts[:0]
+	} else {
+		w.counts = append(w.counts[:0], w.counts[k:]...)
+	}
+	for len(w.counts) < 2 {
+		w.counts = append(w.counts, 0)
+	}
+}
+
+// estimate returns the estimated count of the sliding window ending at t, which lies in the fixed window i.
+func (w *SlidingWindow) estimate(i int, t time.Time) float64 {
+	start := w.start.Add(time.Duration(i-1) * w.window)
+	covered := 1 - float64(t.Sub(start))/float64(w.window)
+	return float64(w.counts[i-1])*covered + float64(w.counts[i])
+}
+
+// slot returns the earliest time, not before now nor the last reservation, at which n events fit,
+// with the index of the fixed window containing it, growing the counts to hold it.
+func (w *SlidingWindow) slot(now time.Time, n int) (int, time.Time) {
+	t := now
+	if w.next.After(t) {
+		t = w.next
+	}
+	for {
+		i := 1 + int(t.Sub(w.start)/w.window)
+		for len(w.counts) <= i {
+			w.counts = append(w.counts, 0)
+		}
+		start := w.start.Add(time.Duration(i-1) * w.window)
+		room := float64(w.limit - w.counts[i] - n)
+		switch {
+		case room < 0:
+			// Even without the previous window, the events do not fit in this one.
+			t = start.Add(w.window)
+		case w.estimate(i, t)+float64(n) <= float64(w.limit):
+			return i, t
+		default:
+			// Wait until the weight of the previous window has decreased enough: prev * (1 - f) <= room.
+			f := 1 - room/float64(w.counts[i-1])
+			at := start.Add(time.Duration(f*float64(w.window)) + 1)
+			if at.Sub(start) >= w.window {
+				t = start.Add(w.window)
+				continue
+			}
+			return i, at
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package rate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlidingWindow_Allow(t *testing.T) {
+	clock := newFakeClock()
+	w := NewSlidingWindow(10, time.Second)
+	w.SetClock(clock.now)
+
+	if !w.AllowN(10) || w.Allow() {
+		t.Fatal("Expected the limit to be allowed and no more")
+	}
+	if w.AllowN(11) {
+		t.Error("Expected more than the limit to be refused")
+	}
+
+	// 25% into the next window, the estimate is 75% of 10.
+	clock.advance(1250 * time.Millisecond)
+	if c := w.Count(); c != 7.5 {
+		t.Errorf("Count() = %v, want 7.5", c)
+	}
+	if !w.AllowN(2) || w.Allow() {
+		t.Errorf("Expected room for 2 events, Count() = %v", w.Count())
+	}
+
+	// Two windows later, everything has slid out.
+	clock.advance(2 * time.Second)
+	if w.Count() != 0 || !w.AllowN(10) {
+		t.Errorf("Count() = %v after the window slid", w.Count())
+	}
+	if w.Limit() != 10 || w.Window() != time.Second {
+		t.Errorf("Limit() = %v, Window() = %v", w.Limit(), w.Window())
+	}
+}
+
+func TestSlidingWindow_Reserve(t *testing.T) {
+	clock := newFakeClock()
+	w := NewSlidingWindow(4, time.Second)
+	w.SetClock(clock.now)
+
+	for range 4 {
+		if r := w.Reserve(); !r.OK || r.Delay != 0 {
+			t.Fatalf("Expected immediate reservations up to the limit, got %+v", r)
+		}
+	}
+	// The 5th fits in the next window once the previous weighs at most 3: 25% into it.
+	r := w.Reserve()
+	if want := 1250 * time.Millisecond; !r.OK || r.Delay < want || r.Delay > want+time.Microsecond {
+		t.Fatalf("Reserve() = %+v, want delay %v", r, want)
+	}
+	if w.Allow() {
+		t.Error("Expected Allow() to fail while a reservation is pending")
+	}
+	// Later reservations come after it.
+	if r2 := w.Reserve(); !r2.OK || r2.Delay <= r.Delay {
+		t.Errorf("Reserve() = %+v, want after %v", r2, r.Delay)
+	}
+	if r := w.ReserveN(5); r.OK {
+		t.Errorf("Expected reserving more than the limit to fail, got %+v", r)
+	}
+
+	// Replaying the reservations at their times never exceeds the limit.
+	clock = newFakeClock()
+	w.SetClock(clock.now)
+	var due []time.Time
+	for range 50 {
+		r := w.ReserveN(3)
+		due = append(due, clock.now().Add(r.Delay))
+	}
+	check := NewSlidingWindow(4, time.Second)
+	replay := newFakeClock()
+	check.SetClock(replay.now)
+	for _, at := range due {
+		replay.t = at
+		if !check.AllowN(3) {
+			t.Fatalf("Reserved events at %v exceed the limit", at.Sub(due[0]))
+		}
+	}
+}
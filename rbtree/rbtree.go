@@ -0,0 +1,499 @@
+// Package rbtree provides an ordered map backed by a red-black tree.
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+
+	"github.com/dullkingsman/kozo/cmpx"
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/internal/arena"
+	_range "github.com/dullkingsman/kozo/range"
+)
+
+// Tree is a thread-safe ordered map backed by a red-black tree.
+// Get, Set, Delete, Floor and Ceiling are O(log n) in the worst case, and a write rebalances
+// with at most three rotations, so latency stays predictable under heavy churn.
+//
+// Iteration does not take a snapshot: each step looks up the key following the last one yielded,
+// so iterators use no extra memory, never hold the lock while yielding, and remain valid
+// however the tree changes between steps. Keys added ahead of the iterator are visited, deleted ones are not.
+type Tree[K, V any] struct {
+	mu    sync.RWMutex
+	root  *node[K, V]
+	less  func(K, K) bool
+	size  int
+	nodes *arena.Arena[node[K, V]] // nil unless created with NewWithArena
+}
+
+var _ collection.Map[int, int] = (*Tree[int, int])(nil)
+
+type color bool
+
+const (
+	red   color = false
+	black color = true
+)
+
+type node[K, V any] struct {
+	key                 K
+	value               V
+	left, right, parent *node[K, V]
+	color               color
+}
+
+// New returns a new empty Tree ordered by the given less function.
+func New[K, V any](less func(K, K) bool) *Tree[K, V] {
+	return &Tree[K, V]{less: less}
+}
+
+// NewFunc returns a new empty Tree ordered by the comparator c, e.g. cmpx.By(key).Then(cmpx.By(other)).
+// Keys that c finds equivalent are the same key.
+func NewFunc[K, V any](c cmpx.Comparator[K]) *Tree[K, V] {
+	return New[K, V](c.Less())
+}
+
+// NewWithArena returns a new empty Tree ordered by the given less function, allocating its nodes in chunks
+// of chunkSize. Deleted nodes are reused and Clear releases them all at once, which cuts allocations and
+// garbage collection work for trees of millions of short-lived keys, at the cost of holding the memory
+// of the largest size the tree reached until Clear. It panics if chunkSize is less than 1.
+func NewWithArena[K, V any](less func(K, K) bool, chunkSize int) *Tree[K, V] {
+	return &Tree[K, V]{less: less, nodes: arena.New[node[K, V]](chunkSize)}
+}
+
+// NewOrdered returns a new empty Tree for cmp.Ordered keys.
+func NewOrdered[K cmp.Ordered, V any]() *Tree[K, V] {
+	return New[K, V](cmp.Less[K])
+}
+
+// Set sets the value of key. It returns true if the key was new.
+func (t *Tree[K, V]) Set(key K, value V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var parent *node[K, V]
+	n := t.root
+	for n != nil {
+		parent = n
+		switch {
+		case t.less(key, n.key):
+			n = n.left
+		case t.less(n.key, key):
+			n = n.right
+		default:
+			n.value = value
+			return false
+		}
+	}
+
+	z := t.nodes.Alloc()
+	*z = node[K, V]{key: key, value: value, parent: parent, color: red}
+	switch {
+	case parent == nil:
+		t.root = z
+	case t.less(key, parent.key):
+		parent.left = z
+	default:
+		parent.right = z
+	}
+	t.insertFixup(z)
+	t.size++
+	return true
+}
+
+// Get returns the value of key. Returns (zero-value, false) if the key is not present.
+func (t *Tree[K, V]) Get(key K) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if n := t.search(key); n != nil {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has returns true if the key is present.
+func (t *Tree[K, V]) Has(key K) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Delete removes key. It returns true if the key was present.
+func (t *Tree[K, V]) Delete(key K) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := t.search(key)
+	if n == nil {
+		return false
+	}
+	t.delete(n)
+	t.nodes.Free(n)
+	t.size--
+	return true
+}
+
+// Len returns the number of keys.
+func (t *Tree[K, V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// IsEmpty returns true if the tree has no keys.
+func (t *Tree[K, V]) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Clear removes all keys.
+func (t *Tree[K, V]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = nil
+	t.size = 0
+	t.nodes.Reset()
+}
+
+// Min returns the smallest key and its value. Returns false if the tree is empty.
+func (t *Tree[K, V]) Min() (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return pair(first(t.root))
+}
+
+// Max returns the largest key and its value. Returns false if the tree is empty.
+func (t *Tree[K, V]) Max() (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return pair(last(t.root))
+}
+
+// Floor returns the largest key less than or equal to key, and its value. Returns false if there is none.
+func (t *Tree[K, V]) Floor(key K) (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return pair(t.below(key, true))
+}
+
+// Ceiling returns the smallest key greater than or equal to key, and its value. Returns false if there is none.
+func (t *Tree[K, V]) Ceiling(key K) (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return pair(t.above(key, true))
+}
+
+// All returns an iterator over the key-value pairs in ascending key order.
+func (t *Tree[K, V]) All() iter.Seq2[K, V] {
+	return t.Range(_range.Range[K]{})
+}
+
+// Backward returns an iterator over the key-value pairs in descending key order.
+func (t *Tree[K, V]) Backward() iter.Seq2[K, V] {
+	return t.walk(
+		func() *node[K, V] { return last(t.root) },
+		func(k K) *node[K, V] { return t.below(k, false) },
+		func(K) bool { return false },
+	)
+}
+
+// Range returns an iterator over the key-value pairs whose keys lie in r, in ascending key order.
+// Unbounded and exclusive boundaries are supported, e.g. Range(_range.HalfOpen(from, to)).
+func (t *Tree[K, V]) Range(r _range.Range[K]) iter.Seq2[K, V] {
+	start := func() *node[K, V] { return first(t.root) }
+	if r.Min != nil && r.Min.Value != nil {
+		lower, inclusive := *r.Min.Value, r.Min.Inclusive
+		start = func() *node[K, V] { return t.above(lower, inclusive) }
+	}
+	beyond := func(K) bool { return false }
+	if r.Max != nil && r.Max.Value != nil {
+		upper, inclusive := *r.Max.Value, r.Max.Inclusive
+		beyond = func(k K) bool { return t.less(upper, k) || (!inclusive && !t.less(k, upper)) }
+	}
+	return t.walk(start, func(k K) *node[K, V] { return t.above(k, false) }, beyond)
+}
+
+// Keys returns the keys in ascending order.
+func (t *Tree[K, V]) Keys() []K {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	keys := make([]K, 0, t.size)
+	for n := first(t.root); n != nil; n = successor(n) {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// Values returns the values in ascending order of their keys.
+func (t *Tree[K, V]) Values() []V {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	values := make([]V, 0, t.size)
+	for n := first(t.root); n != nil; n = successor(n) {
+		values = append(values, n.value)
+	}
+	return values
+}
+
+// walk yields the node returned by start, then repeatedly the node returned by next for the last key yielded,
+// until there is none or its key is beyond. The lock is only held while looking up nodes.
+func (t *Tree[K, V]) walk(start func() *node[K, V], next func(K) *node[K, V], beyond func(K) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.mu.RLock()
+		n := start()
+		for n != nil && !beyond(n.key) {
+			key, value := n.key, n.value
+			t.mu.RUnlock()
+			if !yield(key, value) {
+				return
+			}
+			t.mu.RLock()
+			n = next(key)
+		}
+		t.mu.RUnlock()
+	}
+}
+
+// search returns the node of key, or nil if there is none.
+func (t *Tree[K, V]) search(key K) *node[K, V] {
+	n := t.root
+	for n != nil {
+		switch {
+		case t.less(key, n.key):
+			n = n.left
+		case t.less(n.key, key):
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// above returns the node with the smallest key greater than key, or equal to it if inclusive.
+func (t *Tree[K, V]) above(key K, inclusive bool) *node[K, V] {
+	var best *node[K, V]
+	n := t.root
+	for n != nil {
+		if t.less(key, n.key) || (inclusive && !t.less(n.key, key)) {
+			best, n = n, n.left
+		} else {
+			n = n.right
+		}
+	}
+	return best
+}
+
+// below returns the node with the largest key less than key, or equal to it if inclusive.
+func (t *Tree[K, V]) below(key K, inclusive bool) *node[K, V] {
+	var best *node[K, V]
+	n := t.root
+	for n != nil {
+		if t.less(n.key, key) || (inclusive && !t.less(key, n.key)) {
+			best, n = n, n.right
+		} else {
+			n = n.left
+		}
+	}
+	return best
+}
+
+// insertFixup restores the red-black properties after inserting the red node z.
+func (t *Tree[K, V]) insertFixup(z *node[K, V]) {
+	for isRed(z.parent) {
+		p := z.parent
+		g := p.parent // exists, since the root is black
+		if p == g.left {
+			if u := g.right; isRed(u) {
+				p.color, u.color, g.color = black, black, red
+				z = g
+				continue
+			}
+			if z == p.right {
+				t.rotateLeft(p)
+				z, p = p, z
+			}
+			p.color, g.color = black, red
+			t.rotateRight(g)
+		} else {
+			if u := g.left; isRed(u) {
+				p.color, u.color, g.color = black, black, red
+				z = g
+				continue
+			}
+			if z == p.left {
+				t.rotateRight(p)
+				z, p = p, z
+			}
+			p.color, g.color = black, red
+			t.rotateLeft(g)
+		}
+	}
+	t.root.color = black
+}
+
+// delete removes the node z and restores the red-black properties.
+func (t *Tree[K, V]) delete(z *node[K, V]) {
+	removed := z.color
+	var x, parent *node[K, V] // x replaces the removed node, parent is its parent, since x may be nil
+
+	switch {
+	case z.left == nil:
+		x, parent = z.right, z.parent
+		t.transplant(z, z.right)
+	case z.right == nil:
+		x, parent = z.left, z.parent
+		t.transplant(z, z.left)
+	default:
+		// Move the successor of z, which has no left child, into its place.
+		y := first(z.right)
+		removed = y.color
+		x = y.right
+		if y.parent == z {
+			parent = y
+		} else {
+			parent = y.parent
+			t.transplant(y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		t.transplant(z, y)
+		y.left = z.left
+		y.left.parent = y
+		y.color = z.color
+	}
+
+	if removed == black {
+		t.deleteFixup(x, parent)
+	}
+}
+
+// deleteFixup restores the red-black properties after removing a black node, whose place x took.
+// x carries an extra black that is moved up the tree until it can be absorbed.
+func (t *Tree[K, V]) deleteFixup(x, parent *node[K, V]) {
+	for x != t.root && !isRed(x) {
+		// The sibling of x exists, since the path through x lacks a black node.
+		if x == parent.left {
+			w := parent.right
+			if isRed(w) {
+				w.color, parent.color = black, red
+				t.rotateLeft(parent)
+				w = parent.right
+			}
+			if !isRed(w.left) && !isRed(w.right) {
+				w.color = red
+				x, parent = parent, parent.parent
+				continue
+			}
+			if !isRed(w.right) {
+				w.left.color, w.color = black, red
+				t.rotateRight(w)
+				w = parent.right
+			}
+			w.color, parent.color, w.right.color = parent.color, black, black
+			t.rotateLeft(parent)
+		} else {
+			w := parent.left
+			if isRed(w) {
+				w.color, parent.color = black, red
+				t.rotateRight(parent)
+				w = parent.left
+			}
+			if !isRed(w.left) && !isRed(w.right) {
+				w.color = red
+				x, parent = parent, parent.parent
+				continue
+			}
+			if !isRed(w.left) {
+				w.right.color, w.color = black, red
+				t.rotateLeft(w)
+				w = parent.left
+			}
+			w.color, parent.color, w.left.color = parent.color, black, black
+			t.rotateRight(parent)
+		}
+		x = t.root
+	}
+	if x != nil {
+		x.color = black
+	}
+}
+
+func (t *Tree[K, V]) rotateLeft(x *node[K, V]) {
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	t.transplant(x, y)
+	y.left = x
+	x.parent = y
+}
+
+func (t *Tree[K, V]) rotateRight(x *node[K, V]) {
+	y := x.left
+	x.left = y.right
+	if y.right != nil {
+		y.right.parent = x
+	}
+	t.transplant(x, y)
+	y.right = x
+	x.parent = y
+}
+
+// transplant replaces the subtree rooted at u with the one rooted at v in the parent of u.
+func (t *Tree[K, V]) transplant(u, v *node[K, V]) {
+	switch {
+	case u.parent == nil:
+		t.root = v
+	case u == u.parent.left:
+		u.parent.left = v
+	default:
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+func isRed[K, V any](n *node[K, V]) bool {
+	return n != nil && n.color == red
+}
+
+func first[K, V any](n *node[K, V]) *node[K, V] {
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+func last[K, V any](n *node[K, V]) *node[K, V] {
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+func successor[K, V any](n *node[K, V]) *node[K, V] {
+	if n.right != nil {
+		return first(n.right)
+	}
+	for n.parent != nil && n == n.parent.right {
+		n = n.parent
+	}
+	return n.parent
+}
+
+func pair[K, V any](n *node[K, V]) (K, V, bool) {
+	if n == nil {
+		var (
+			k K
+			v V
+		)
+		return k, v, false
+	}
+	return n.key, n.value, true
+}
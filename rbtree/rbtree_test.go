@@ -0,0 +1,283 @@
+package rbtree
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/dullkingsman/kozo/cmpx"
+	_range "github.com/dullkingsman/kozo/range"
+)
+
+// checkInvariants verifies the binary search tree order, the parent links and the red-black properties:
+// the root is black, red nodes have black children, and every path from a node to a leaf has the same number of black nodes.
+func checkInvariants[K, V any](t *testing.T, tr *Tree[K, V]) {
+	t.Helper()
+
+	if isRed(tr.root) {
+		t.Fatal("The root is red")
+	}
+	count := 0
+	var check func(n, parent *node[K, V], lower, upper *K) int
+	check = func(n, parent *node[K, V], lower, upper *K) int {
+		if n == nil {
+			return 1
+		}
+		count++
+		if n.parent != parent {
+			t.Fatalf("Node %v has a wrong parent link", n.key)
+		}
+		if (lower != nil && !tr.less(*lower, n.key)) || (upper != nil && !tr.less(n.key, *upper)) {
+			t.Fatalf("Node %v is out of order", n.key)
+		}
+		if isRed(n) && (isRed(n.left) || isRed(n.right)) {
+			t.Fatalf("Red node %v has a red child", n.key)
+		}
+		left := check(n.left, n, lower, &n.key)
+		right := check(n.right, n, &n.key, upper)
+		if left != right {
+			t.Fatalf("Node %v has black heights %d and %d", n.key, left, right)
+		}
+		if n.color == black {
+			left++
+		}
+		return left
+	}
+	check(tr.root, nil, nil, nil)
+
+	if count != tr.size {
+		t.Fatalf("Counted %d nodes, size is %d", count, tr.size)
+	}
+}
+
+func TestTree_Basic(t *testing.T) {
+	tr := NewOrdered[string, int]()
+	if !tr.Set("b", 1) || !tr.Set("a", 2) || !tr.Set("c", 3) || tr.Set("b", 4) {
+		t.Error("Set() reported the wrong novelty")
+	}
+	if v, ok := tr.Get("b"); !ok || v != 4 {
+		t.Errorf("Get(b) = %d, %v", v, ok)
+	}
+	if tr.Has("d") {
+		t.Error("Expected d to be missing")
+	}
+	if k, v, ok := tr.Min(); !ok || k != "a" || v != 2 {
+		t.Errorf("Min() = %s, %d, %v", k, v, ok)
+	}
+	if k, v, ok := tr.Max(); !ok || k != "c" || v != 3 {
+		t.Errorf("Max() = %s, %d, %v", k, v, ok)
+	}
+	if !slices.Equal(tr.Keys(), []string{"a", "b", "c"}) || !slices.Equal(tr.Values(), []int{2, 4, 3}) {
+		t.Errorf("Keys() = %v, Values() = %v", tr.Keys(), tr.Values())
+	}
+	if !tr.Delete("a") || tr.Delete("a") || tr.Len() != 2 {
+		t.Error("Expected Delete to report presence once")
+	}
+	checkInvariants(t, tr)
+
+	tr.Clear()
+	if !tr.IsEmpty() {
+		t.Error("Expected an empty tree")
+	}
+	if _, _, ok := tr.Min(); ok {
+		t.Error("Expected no minimum")
+	}
+}
+
+func TestTree_FloorCeiling(t *testing.T) {
+	tr := NewOrdered[int, string]()
+	for i := 0; i < 1000; i += 10 {
+		tr.Set(i, "")
+	}
+
+	tests := []struct {
+		key            int
+		floor, ceiling int
+		hasF, hasC     bool
+	}{
+		{-5, 0, 0, false, true},
+		{0, 0, 0, true, true},
+		{15, 10, 20, true, true},
+		{500, 500, 500, true, true},
+		{995, 990, 0, true, false},
+	}
+	for _, tt := range tests {
+		if k, _, ok := tr.Floor(tt.key); ok != tt.hasF || (ok && k != tt.floor) {
+			t.Errorf("Floor(%d) = %d, %v", tt.key, k, ok)
+		}
+		if k, _, ok := tr.Ceiling(tt.key); ok != tt.hasC || (ok && k != tt.ceiling) {
+			t.Errorf("Ceiling(%d) = %d, %v", tt.key, k, ok)
+		}
+	}
+}
+
+func TestTree_Range(t *testing.T) {
+	tr := NewOrdered[int, int]()
+	for i := range 500 {
+		tr.Set(i, i*i)
+	}
+
+	collect := func(r _range.Range[int]) []int {
+		var keys []int
+		for k, v := range tr.Range(r) {
+			if v != k*k {
+				t.Fatalf("Value of %d is %d", k, v)
+			}
+			keys = append(keys, k)
+		}
+		return keys
+	}
+
+	if got := collect(_range.HalfOpen(100, 104)); !slices.Equal(got, []int{100, 101, 102, 103}) {
+		t.Errorf("HalfOpen = %v", got)
+	}
+	if got := collect(_range.Open(100, 103)); !slices.Equal(got, []int{101, 102}) {
+		t.Errorf("Open = %v", got)
+	}
+	if got := collect(_range.AtLeast(497)); !slices.Equal(got, []int{497, 498, 499}) {
+		t.Errorf("AtLeast = %v", got)
+	}
+	if got := collect(_range.LessThan(2)); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("LessThan = %v", got)
+	}
+	if got := collect(_range.Closed(600, 700)); len(got) != 0 {
+		t.Errorf("Out of bounds = %v", got)
+	}
+
+	var backward []int
+	for k := range tr.Backward() {
+		backward = append(backward, k)
+		if len(backward) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(backward, []int{499, 498, 497}) {
+		t.Errorf("Backward() = %v", backward)
+	}
+}
+
+func TestTree_IterateWhileModifying(t *testing.T) {
+	tr := NewOrdered[int, int]()
+	for i := range 10 {
+		tr.Set(i*10, i)
+	}
+
+	// At each multiple of 10, delete the next key and add one just ahead.
+	var visited []int
+	for k := range tr.All() {
+		visited = append(visited, k)
+		if k%10 == 0 {
+			tr.Delete(k + 10)
+			tr.Set(k+5, 0)
+		}
+	}
+	if expected := []int{0, 5, 20, 25, 40, 45, 60, 65, 80, 85}; !slices.Equal(visited, expected) {
+		t.Errorf("Visited %v, want %v", visited, expected)
+	}
+	checkInvariants(t, tr)
+}
+
+func TestTree_NewFunc(t *testing.T) {
+	type version struct{ major, minor int }
+	tr := NewFunc[version, string](cmpx.By(func(v version) int { return v.major }).Then(cmpx.By(func(v version) int { return v.minor })).Reverse())
+	tr.Set(version{1, 2}, "b")
+	tr.Set(version{2, 0}, "c")
+	tr.Set(version{1, 10}, "a")
+	checkInvariants(t, tr)
+
+	if keys := tr.Keys(); !slices.Equal(keys, []version{{2, 0}, {1, 10}, {1, 2}}) {
+		t.Errorf("Keys() = %v", keys)
+	}
+}
+
+func TestTree_Random(t *testing.T) {
+	t.Run("Heap", func(t *testing.T) { testRandom(t, NewOrdered[int, int]()) })
+	t.Run("Arena", func(t *testing.T) { testRandom(t, NewWithArena[int, int](cmp.Less[int], 64)) })
+}
+
+func testRandom(t *testing.T, tr *Tree[int, int]) {
+	rng := rand.New(rand.NewSource(1))
+	ref := make(map[int]int)
+
+	for i := range 20000 {
+		k := rng.Intn(3000)
+		if rng.Intn(3) == 0 {
+			_, had := ref[k]
+			if tr.Delete(k) != had {
+				t.Fatalf("Delete(%d) disagrees with the reference", k)
+			}
+			delete(ref, k)
+		} else {
+			_, had := ref[k]
+			if tr.Set(k, i) == had {
+				t.Fatalf("Set(%d) disagrees with the reference", k)
+			}
+			ref[k] = i
+		}
+		if i%100 == 0 {
+			checkInvariants(t, tr)
+		}
+	}
+	checkInvariants(t, tr)
+
+	keys := tr.Keys()
+	if len(keys) != len(ref) || !slices.IsSorted(keys) {
+		t.Fatalf("Keys() has %d sorted=%v keys, want %d", len(keys), slices.IsSorted(keys), len(ref))
+	}
+	for k, v := range ref {
+		if got, ok := tr.Get(k); !ok || got != v {
+			t.Fatalf("Get(%d) = %d, %v; want %d", k, got, ok, v)
+		}
+	}
+
+	for k := range ref {
+		tr.Delete(k)
+		if len(ref)%7 == 0 {
+			checkInvariants(t, tr)
+		}
+	}
+	checkInvariants(t, tr)
+	if !tr.IsEmpty() {
+		t.Errorf("Expected the tree to be empty, has %d keys", tr.Len())
+	}
+
+	// The tree is usable again after Clear releases its nodes.
+	for k := range 100 {
+		tr.Set(k, k)
+	}
+	tr.Clear()
+	tr.Set(1, 1)
+	checkInvariants(t, tr)
+	if v, ok := tr.Get(1); !ok || v != 1 || tr.Len() != 1 {
+		t.Errorf("Get(1) = %d, %v after Clear()", v, ok)
+	}
+}
+
+func TestTree_Concurrency(t *testing.T) {
+	tr := NewOrdered[int, int]()
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				k := g*1000 + i
+				tr.Set(k, i)
+				tr.Get(k)
+				if i%2 == 0 {
+					tr.Delete(k)
+				}
+			}
+			for range tr.All() {
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tr.Len() != 8*250 {
+		t.Errorf("Len() = %d, want %d", tr.Len(), 8*250)
+	}
+	checkInvariants(t, tr)
+}
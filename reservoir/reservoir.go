@@ -0,0 +1,159 @@
+// Package reservoir keeps a uniform random sample of fixed size over a stream of unknown length.
+package reservoir
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// Sampler is a thread-safe reservoir sampler: after n items were added, it holds min(n, capacity) of them,
+// every subset of that size being equally likely, in O(capacity) memory whatever the length of the stream.
+// It samples log lines, requests or rows for inspection or estimation without storing the stream.
+//
+// Add implements Algorithm R of Vitter: the n-th item replaces a random item of the sample with probability
+// capacity/n. Samplers fed by different shards of a stream can be merged into a sample of the whole stream.
+type Sampler[T any] struct {
+	mu       sync.Mutex
+	capacity int
+	items    []T
+	count    uint64
+	rng      *rand.Rand
+}
+
+var _ collection.Container = (*Sampler[int])(nil)
+
+// New returns a new empty Sampler keeping at most capacity items. It panics if capacity is less than 1.
+func New[T any](capacity int) *Sampler[T] {
+	return NewSeeded[T](capacity, rand.Uint64())
+}
+
+// NewSeeded returns a new empty Sampler keeping at most capacity items, whose random choices are determined
+// by seed, so that the same stream gives the same sample. It panics if capacity is less than 1.
+func NewSeeded[T any](capacity int, seed uint64) *Sampler[T] {
+	if capacity < 1 {
+		panic("reservoir: capacity must be at least 1")
+	}
+	return &Sampler[T]{
+		capacity: capacity,
+		items:    make([]T, 0, capacity),
+		rng:      rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+	}
+}
+
+// Add offers item to the sample.
+func (s *Sampler[T]) Add(item T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(item)
+}
+
+// AddAll offers each of items to the sample in order.
+func (s *Sampler[T]) AddAll(items ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, item := range items {
+		s.add(item)
+	}
+}
+
+func (s *Sampler[T]) add(item T) {
+	s.count++
+	if len(s.items) < s.capacity {
+		s.items = append(s.items, item)
+		return
+	}
+	if i := s.rng.Uint64N(s.count); i < uint64(s.capacity) {
+		s.items[i] = item
+	}
+}
+
+// Sample returns the sampled items. Their order is not meaningful.
+func (s *Sampler[T]) Sample() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.items)
+}
+
+// Merge replaces the sample with a uniform sample of the items added to both samplers, as if s had also
+// seen the stream of other, which is left unchanged. This combines samplers fed by different shards or servers.
+// The samplers must have the same capacity; Merge panics otherwise.
+//
+// The number of items taken from each sample follows the hypergeometric distribution of drawing the capacity
+// from both streams without replacement, and the items taken from each are a random subset of its sample.
+func (s *Sampler[T]) Merge(other *Sampler[T]) {
+	if other == s {
+		return
+	}
+	if other.capacity != s.capacity {
+		panic("reservoir: cannot merge samplers of different capacities")
+	}
+
+	other.mu.Lock()
+	theirs, theirCount := slices.Clone(other.items), other.count
+	other.mu.Unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ours, ourCount := s.items, s.count
+	total := ourCount + theirCount
+	merged := make([]T, 0, s.capacity)
+	for a, b := ourCount, theirCount; len(merged) < s.capacity && a+b > 0; {
+		// Draw the next item of the merged sample from our stream with probability a/(a+b), and take it from
+		// the items of our sample not drawn yet, which are a uniform sample of the a items of our stream left.
+		if s.rng.Uint64N(a+b) < a {
+			merged = append(merged, takeRandom(s.rng, &ours))
+			a--
+		} else {
+			merged = append(merged, takeRandom(s.rng, &theirs))
+			b--
+		}
+	}
+	s.items, s.count = merged, total
+}
+
+// takeRandom removes a random item from items and returns it.
+func takeRandom[T any](rng *rand.Rand, items *[]T) T {
+	i := rng.IntN(len(*items))
+	last := len(*items) - 1
+	item := (*items)[i]
+	(*items)[i] = (*items)[last]
+	*items = (*items)[:last]
+	return item
+}
+
+// Count returns the number of items added, including those merged in.
+func (s *Sampler[T]) Count() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.count
+}
+
+// Len returns the number of sampled items, which is the smaller of Count and Capacity.
+func (s *Sampler[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.items)
+}
+
+// IsEmpty returns true if no item is sampled.
+func (s *Sampler[T]) IsEmpty() bool {
+	return s.Len() == 0
+}
+
+// Capacity returns the largest number of sampled items.
+func (s *Sampler[T]) Capacity() int {
+	return s.capacity
+}
+
+// Clear removes the sampled items and resets the count.
+func (s *Sampler[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.items)
+	s.items = s.items[:0]
+	s.count = 0
+}
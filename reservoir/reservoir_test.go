@@ -0,0 +1,111 @@
+package reservoir
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestSampler_Basic(t *testing.T) {
+	s := NewSeeded[int](3, 1)
+	s.AddAll(1, 2)
+	if got := s.Sample(); !slices.Equal(got, []int{1, 2}) || s.Len() != 2 || s.Count() != 2 {
+		t.Fatalf("Sample() = %v before the reservoir fills", got)
+	}
+	for i := 3; i <= 100; i++ {
+		s.Add(i)
+	}
+	got := s.Sample()
+	if len(got) != 3 || s.Count() != 100 || s.Capacity() != 3 {
+		t.Fatalf("Sample() = %v, Count() = %d", got, s.Count())
+	}
+	slices.Sort(got)
+	if got = slices.Compact(got); len(got) != 3 || got[0] < 1 || got[2] > 100 {
+		t.Errorf("Sample() = %v, want 3 distinct stream items", got)
+	}
+
+	again := NewSeeded[int](3, 1)
+	for i := 1; i <= 100; i++ {
+		again.Add(i)
+	}
+	if a, b := s.Sample(), again.Sample(); !slices.Equal(a, b) {
+		t.Errorf("Expected the same seed to give the same sample, got %v and %v", a, b)
+	}
+
+	s.Clear()
+	if s.Len() != 0 || !s.IsEmpty() || s.Count() != 0 {
+		t.Error("Expected an empty sampler after Clear()")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a capacity of 0 to panic")
+		}
+	}()
+	New[int](0)
+}
+
+// checkUniform verifies that each of n items was sampled about trials*k/n times.
+func checkUniform(t *testing.T, counts []int, trials, k int) {
+	t.Helper()
+	want := float64(trials*k) / float64(len(counts))
+	for item, c := range counts {
+		if math.Abs(float64(c)-want) > want*0.1 {
+			t.Fatalf("Item %d sampled %d times, want about %v", item, c, want)
+		}
+	}
+}
+
+func TestSampler_Uniform(t *testing.T) {
+	const n, k, trials = 50, 5, 20000
+	counts := make([]int, n)
+	for trial := range trials {
+		s := NewSeeded[int](k, uint64(trial))
+		for i := range n {
+			s.Add(i)
+		}
+		for _, item := range s.Sample() {
+			counts[item]++
+		}
+	}
+	checkUniform(t, counts, trials, k)
+}
+
+func TestSampler_Merge(t *testing.T) {
+	// Shards of very different sizes: the merged sample must still be uniform over the whole stream.
+	const k, trials = 5, 20000
+	counts := make([]int, 60)
+	for trial := range trials {
+		a, b := NewSeeded[int](k, uint64(trial)), NewSeeded[int](k, uint64(trial)+1<<32)
+		for i := range 10 {
+			a.Add(i)
+		}
+		for i := 10; i < 60; i++ {
+			b.Add(i)
+		}
+		a.Merge(b)
+		if a.Count() != 60 || a.Len() != k || b.Count() != 50 {
+			t.Fatalf("Count() = %d, Len() = %d after Merge()", a.Count(), a.Len())
+		}
+		for _, item := range a.Sample() {
+			counts[item]++
+		}
+	}
+	checkUniform(t, counts, trials, k)
+
+	small, empty := NewSeeded[string](4, 1), NewSeeded[string](4, 2)
+	small.AddAll("a", "b")
+	empty.Merge(small)
+	got := empty.Sample()
+	slices.Sort(got)
+	if !slices.Equal(got, []string{"a", "b"}) || empty.Count() != 2 {
+		t.Errorf("Merge() into an empty sampler = %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected merging different capacities to panic")
+		}
+	}()
+	small.Merge(New[string](5))
+}
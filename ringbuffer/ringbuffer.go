@@ -0,0 +1,172 @@
+// Package ringbuffer provides a fixed-capacity ring buffer that keeps the most recent values.
+package ringbuffer
+
+import (
+	"iter"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// RingBuffer is a thread-safe buffer of fixed capacity holding the most recently appended values:
+// once full, each Append overwrites the oldest value. Its storage is allocated once, at construction,
+// and Append never allocates, which makes it suited to keeping the last N samples of a metric.
+//
+// Unlike Queue, it never grows and is read by index, from 0 for the oldest value to Len()-1 for the newest.
+// Out-of-range indexes panic like slices.
+type RingBuffer[T any] struct {
+	mu    sync.RWMutex
+	data  []T
+	head  int // index of the oldest value in data
+	count int
+}
+
+var _ collection.Map[int, int] = (*RingBuffer[int])(nil)
+
+// New returns a new empty RingBuffer holding at most capacity values. It panics if capacity is less than 1.
+func New[T any](capacity int) *RingBuffer[T] {
+	if capacity < 1 {
+		panic("ringbuffer: capacity must be at least 1")
+	}
+	return &RingBuffer[T]{data: make([]T, capacity)}
+}
+
+// Append adds v as the newest value. If the buffer is full, the oldest value is overwritten and returned.
+func (r *RingBuffer[T]) Append(v T) (T, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.count < len(r.data) {
+		r.data[(r.head+r.count)%len(r.data)] = v
+		r.count++
+		var zero T
+		return zero, false
+	}
+	old := r.data[r.head]
+	r.data[r.head] = v
+	r.head = (r.head + 1) % len(r.data)
+	return old, true
+}
+
+// At returns the value at index i, where 0 is the oldest value.
+func (r *RingBuffer[T]) At(i int) T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if i < 0 || i >= r.count {
+		panic("ringbuffer: index out of range")
+	}
+	return r.at(i)
+}
+
+// Oldest returns the oldest value. Returns (zero-value, false) if the buffer is empty.
+func (r *RingBuffer[T]) Oldest() (T, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.at(0), true
+}
+
+// Newest returns the most recently appended value. Returns (zero-value, false) if the buffer is empty.
+func (r *RingBuffer[T]) Newest() (T, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.count == 0 {
+		var zero T
+		return zero, false
+	}
+	return r.at(r.count - 1), true
+}
+
+// Latest returns the n most recent values, oldest first, as a new slice. It returns fewer if the buffer holds fewer.
+func (r *RingBuffer[T]) Latest(n int) []T {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	n = min(max(n, 0), r.count)
+	values := make([]T, n)
+	for i := range values {
+		values[i] = r.at(r.count - n + i)
+	}
+	return values
+}
+
+// ToSlice returns all values, oldest first, as a new slice.
+func (r *RingBuffer[T]) ToSlice() []T {
+	return r.Latest(len(r.data))
+}
+
+// All returns an iterator over a snapshot of the values with their indexes, oldest first.
+func (r *RingBuffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range r.ToSlice() {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over a snapshot of the values, oldest first.
+func (r *RingBuffer[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range r.ToSlice() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over a snapshot of the values, newest first.
+func (r *RingBuffer[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		values := r.ToSlice()
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Len returns the number of values held.
+func (r *RingBuffer[T]) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.count
+}
+
+// Cap returns the maximum number of values held.
+func (r *RingBuffer[T]) Cap() int {
+	return len(r.data)
+}
+
+// IsEmpty returns true if the buffer holds no values.
+func (r *RingBuffer[T]) IsEmpty() bool {
+	return r.Len() == 0
+}
+
+// IsFull returns true if the next Append will overwrite the oldest value.
+func (r *RingBuffer[T]) IsFull() bool {
+	return r.Len() == len(r.data)
+}
+
+// Clear discards all values.
+func (r *RingBuffer[T]) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Zero out the values to let the GC reclaim what they reference.
+	clear(r.data)
+	r.head, r.count = 0, 0
+}
+
+func (r *RingBuffer[T]) at(i int) T {
+	return r.data[(r.head+i)%len(r.data)]
+}
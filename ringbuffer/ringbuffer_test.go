@@ -0,0 +1,118 @@
+package ringbuffer
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestRingBuffer_Basic(t *testing.T) {
+	r := New[int](3)
+	if !r.IsEmpty() || r.Cap() != 3 {
+		t.Error("Expected new buffer to be empty")
+	}
+	if _, ok := r.Newest(); ok {
+		t.Error("Expected Newest of an empty buffer to fail")
+	}
+
+	for i := 1; i <= 3; i++ {
+		if _, overwritten := r.Append(i); overwritten {
+			t.Errorf("Append(%d) overwrote a value", i)
+		}
+	}
+	if !r.IsFull() || r.At(0) != 1 || r.At(2) != 3 {
+		t.Errorf("ToSlice() = %v", r.ToSlice())
+	}
+	if old, overwritten := r.Append(4); !overwritten || old != 1 {
+		t.Errorf("Append(4) = %d, %v", old, overwritten)
+	}
+	r.Append(5)
+
+	if got := r.ToSlice(); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("ToSlice() = %v", got)
+	}
+	if got := r.Latest(2); !slices.Equal(got, []int{4, 5}) {
+		t.Errorf("Latest(2) = %v", got)
+	}
+	if got := r.Latest(10); !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("Latest(10) = %v", got)
+	}
+	if v, _ := r.Oldest(); v != 3 {
+		t.Errorf("Oldest() = %d", v)
+	}
+	if v, _ := r.Newest(); v != 5 {
+		t.Errorf("Newest() = %d", v)
+	}
+	if got := slices.Collect(r.Backward()); !slices.Equal(got, []int{5, 4, 3}) {
+		t.Errorf("Backward() = %v", got)
+	}
+	for i, v := range r.All() {
+		if v != r.At(i) {
+			t.Errorf("All() yields %d at %d", v, i)
+		}
+	}
+
+	r.Clear()
+	if !r.IsEmpty() || len(r.Latest(3)) != 0 {
+		t.Error("Expected Clear to empty the buffer")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected an out-of-range index to panic")
+		}
+	}()
+	r.At(0)
+}
+
+func TestRingBuffer_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, capacity := range []int{1, 2, 7, 64} {
+		r := New[int](capacity)
+		var ref []int
+		for i := range 2000 {
+			if rng.Intn(50) == 0 {
+				r.Clear()
+				ref = nil
+				continue
+			}
+			old, overwritten := r.Append(i)
+			ref = append(ref, i)
+			if len(ref) > capacity {
+				if !overwritten || old != ref[0] {
+					t.Fatalf("Append(%d) = %d, %v; want %d overwritten", i, old, overwritten, ref[0])
+				}
+				ref = ref[1:]
+			}
+
+			if !slices.Equal(r.ToSlice(), ref) {
+				t.Fatalf("Capacity %d, step %d: ToSlice() = %v, want %v", capacity, i, r.ToSlice(), ref)
+			}
+			n := rng.Intn(capacity + 2)
+			if got, want := r.Latest(n), ref[max(len(ref)-n, 0):]; !slices.Equal(got, want) {
+				t.Fatalf("Latest(%d) = %v, want %v", n, got, want)
+			}
+		}
+	}
+}
+
+func TestRingBuffer_Concurrency(t *testing.T) {
+	r := New[int](100)
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				r.Append(g*1000 + i)
+				r.Latest(10)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !r.IsFull() || r.Len() != 100 {
+		t.Errorf("Len() = %d, want 100", r.Len())
+	}
+}
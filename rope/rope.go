@@ -0,0 +1,256 @@
+// Package rope provides an immutable rope: a string stored as a balanced tree of chunks, for editing large texts.
+package rope
+
+import (
+	"io"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxLeaf is the largest chunk the rope stores in a single leaf.
+const maxLeaf = 512
+
+// Rope is an immutable string stored as a balanced binary tree of chunks. Insert, Delete, Slice and Concat
+// run in O(log n) for n bytes and return new ropes sharing most of their structure with the original,
+// where editing a Go string would copy it, making repeated edits of a large document quadratic.
+//
+// Positions are byte offsets, as for strings. Ropes are values: the zero Rope is empty, and since a rope
+// never changes, it is safe for concurrent use without locking. Out-of-range positions panic like slices.
+type Rope struct {
+	root *node
+}
+
+// node is a leaf holding a chunk of text, or a concatenation of two non-empty subtrees.
+// Trees are AVL-balanced: the heights of the children of a node differ by at most one.
+type node struct {
+	text        string // leaves only
+	left, right *node
+	length      int
+	height      int // 0 for leaves
+}
+
+// New returns a rope holding s.
+func New(s string) Rope {
+	return Rope{root: build(s)}
+}
+
+// Len returns the length of the rope in bytes.
+func (r Rope) Len() int {
+	return r.root.len()
+}
+
+// IsEmpty returns true if the rope holds no text.
+func (r Rope) IsEmpty() bool {
+	return r.root == nil
+}
+
+// At returns the byte at position i.
+func (r Rope) At(i int) byte {
+	if i < 0 || i >= r.Len() {
+		panic("rope: index out of range")
+	}
+	n := r.root
+	for n.height > 0 {
+		if i < n.left.length {
+			n = n.left
+		} else {
+			i -= n.left.length
+			n = n.right
+		}
+	}
+	return n.text[i]
+}
+
+// String returns the text of the rope.
+func (r Rope) String() string {
+	var b strings.Builder
+	b.Grow(r.Len())
+	for chunk := range r.Chunks() {
+		b.WriteString(chunk)
+	}
+	return b.String()
+}
+
+// WriteTo writes the text of the rope to w chunk by chunk, without building it as a single string.
+func (r Rope) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for chunk := range r.Chunks() {
+		n, err := io.WriteString(w, chunk)
+		total += int64(n)
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// Chunks returns an iterator over the chunks of the rope in order, which concatenated form its text.
+// Chunks are split at arbitrary byte positions, so a multi-byte character may span two chunks.
+func (r Rope) Chunks() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		r.root.chunks(yield)
+	}
+}
+
+// Concat returns a rope holding the text of r followed by the text of other.
+func (r Rope) Concat(other Rope) Rope {
+	return Rope{root: join(r.root, other.root)}
+}
+
+// Insert returns a rope with s inserted at position i.
+func (r Rope) Insert(i int, s string) Rope {
+	return r.InsertRope(i, New(s))
+}
+
+// InsertRope returns a rope with the text of other inserted at position i.
+func (r Rope) InsertRope(i int, other Rope) Rope {
+	r.checkPosition(i)
+	left, right := split(r.root, i)
+	return Rope{root: join(join(left, other.root), right)}
+}
+
+// Delete returns a rope without the bytes from position from up to, but not including, to.
+func (r Rope) Delete(from, to int) Rope {
+	r.checkRange(from, to)
+	left, rest := split(r.root, from)
+	_, right := split(rest, to-from)
+	return Rope{root: join(left, right)}
+}
+
+// Slice returns a rope holding the bytes from position from up to, but not including, to.
+func (r Rope) Slice(from, to int) Rope {
+	r.checkRange(from, to)
+	_, rest := split(r.root, from)
+	middle, _ := split(rest, to-from)
+	return Rope{root: middle}
+}
+
+// Split returns the ropes holding the bytes before and after position i.
+func (r Rope) Split(i int) (Rope, Rope) {
+	r.checkPosition(i)
+	left, right := split(r.root, i)
+	return Rope{root: left}, Rope{root: right}
+}
+
+func (r Rope) checkPosition(i int) {
+	if i < 0 || i > r.Len() {
+		panic("rope: position out of range")
+	}
+}
+
+func (r Rope) checkRange(from, to int) {
+	if from < 0 || to < from || to > r.Len() {
+		panic("rope: range out of bounds")
+	}
+}
+
+func (n *node) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.length
+}
+
+func (n *node) chunks(yield func(string) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.height == 0 {
+		return yield(n.text)
+	}
+	return n.left.chunks(yield) && n.right.chunks(yield)
+}
+
+func leaf(s string) *node {
+	if s == "" {
+		return nil
+	}
+	return &node{text: s, length: len(s)}
+}
+
+func concat(left, right *node) *node {
+	return &node{left: left, right: right, length: left.length + right.length, height: max(left.height, right.height) + 1}
+}
+
+func height(n *node) int {
+	if n == nil {
+		return -1
+	}
+	return n.height
+}
+
+// build returns a balanced tree of leaves holding s, splitting it between characters where possible.
+func build(s string) *node {
+	if len(s) <= maxLeaf {
+		return leaf(s)
+	}
+	// Split near the middle, on a multiple of maxLeaf so that leaves are full, then back off to a character boundary.
+	mid := (len(s) / maxLeaf / 2) * maxLeaf
+	if mid == 0 {
+		mid = maxLeaf
+	}
+	for i := mid; i > mid-utf8.UTFMax && i > 0; i-- {
+		if utf8.RuneStart(s[i]) {
+			mid = i
+			break
+		}
+	}
+	return join(build(s[:mid]), build(s[mid:]))
+}
+
+// join returns a balanced tree holding the text of a followed by the text of b, in O(|height(a) - height(b)|).
+func join(a, b *node) *node {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.height == 0 && b.height == 0 && a.length+b.length <= maxLeaf:
+		return leaf(a.text + b.text)
+	case a.height > b.height+1:
+		return balance(a.left, join(a.right, b))
+	case b.height > a.height+1:
+		return balance(join(a, b.left), b.right)
+	default:
+		return concat(a, b)
+	}
+}
+
+// balance returns the concatenation of left and right, whose heights differ by at most two, rotating if needed.
+func balance(left, right *node) *node {
+	switch {
+	case height(left) > height(right)+1:
+		if height(left.left) >= height(left.right) {
+			return concat(left.left, concat(left.right, right))
+		}
+		return concat(concat(left.left, left.right.left), concat(left.right.right, right))
+	case height(right) > height(left)+1:
+		if height(right.right) >= height(right.left) {
+			return concat(concat(left, right.left), right.right)
+		}
+		return concat(concat(left, right.left.left), concat(right.left.right, right.right))
+	default:
+		return concat(left, right)
+	}
+}
+
+// split returns the trees holding the first i bytes of n and the rest.
+func split(n *node, i int) (*node, *node) {
+	switch {
+	case n == nil:
+		return nil, nil
+	case i == 0:
+		return nil, n
+	case i == n.length:
+		return n, nil
+	case n.height == 0:
+		return leaf(n.text[:i]), leaf(n.text[i:])
+	case i <= n.left.length:
+		left, right := split(n.left, i)
+		return left, join(right, n.right)
+	default:
+		left, right := split(n.right, i-n.left.length)
+		return join(n.left, left), right
+	}
+}
@@ -0,0 +1,168 @@
+package rope
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// checkInvariants verifies cached lengths and heights, AVL balance and leaf sizes.
+func checkInvariants(t *testing.T, r Rope) {
+	t.Helper()
+	var walk func(n *node)
+	walk = func(n *node) {
+		if n.height == 0 {
+			if n.left != nil || n.right != nil || n.length != len(n.text) || n.length == 0 || n.length > maxLeaf {
+				t.Fatalf("Leaf of %d bytes caches length %d", len(n.text), n.length)
+			}
+			return
+		}
+		if n.left == nil || n.right == nil || n.text != "" {
+			t.Fatal("Inner node is malformed")
+		}
+		if n.length != n.left.length+n.right.length || n.height != max(n.left.height, n.right.height)+1 {
+			t.Fatal("Inner node caches a wrong length or height")
+		}
+		if d := n.left.height - n.right.height; d < -1 || d > 1 {
+			t.Fatalf("Children heights differ by %d", d)
+		}
+		walk(n.left)
+		walk(n.right)
+	}
+	if r.root != nil {
+		walk(r.root)
+	}
+}
+
+func TestRope_Basic(t *testing.T) {
+	var empty Rope
+	if !empty.IsEmpty() || empty.Len() != 0 || empty.String() != "" {
+		t.Error("Expected the zero rope to be empty")
+	}
+
+	r := New("Hello, world")
+	r = r.Insert(7, "big ")
+	if r.String() != "Hello, big world" || r.Len() != 16 || r.At(7) != 'b' {
+		t.Errorf("Insert() = %q", r.String())
+	}
+	d := r.Delete(5, 10)
+	if d.String() != "Hello world" {
+		t.Errorf("Delete() = %q", d.String())
+	}
+	if r.String() != "Hello, big world" {
+		t.Error("Expected edits not to change the original rope")
+	}
+	if s := r.Slice(7, 10).String(); s != "big" {
+		t.Errorf("Slice() = %q", s)
+	}
+	left, right := r.Split(5)
+	if left.String() != "Hello" || right.String() != ", big world" {
+		t.Errorf("Split() = %q, %q", left.String(), right.String())
+	}
+	if c := right.Concat(left); c.String() != ", big worldHello" {
+		t.Errorf("Concat() = %q", c.String())
+	}
+	if s := r.Delete(0, r.Len()); !s.IsEmpty() {
+		t.Error("Expected deleting everything to leave an empty rope")
+	}
+
+	var b strings.Builder
+	if n, err := r.WriteTo(&b); err != nil || n != 16 || b.String() != r.String() {
+		t.Errorf("WriteTo() = %d, %v", n, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected an out-of-range position to panic")
+		}
+	}()
+	r.Insert(17, "x")
+}
+
+func TestRope_Large(t *testing.T) {
+	text := strings.Repeat("héllo wörld ", 10_000)
+	r := New(text)
+	checkInvariants(t, r)
+	if r.Len() != len(text) || r.String() != text {
+		t.Fatal("Expected New to preserve the text")
+	}
+	if r.root.height > 12 {
+		t.Errorf("Height %d is too large for %d leaves", r.root.height, len(text)/maxLeaf)
+	}
+
+	// New splits between characters, so every chunk is valid UTF-8.
+	chunks := 0
+	for chunk := range r.Chunks() {
+		chunks++
+		if !utf8.ValidString(chunk) {
+			t.Fatalf("Chunk splits a character: %q", chunk)
+		}
+	}
+	if chunks < len(text)/maxLeaf {
+		t.Errorf("Got %d chunks", chunks)
+	}
+
+	// Appending one byte at a time stays balanced.
+	var grown Rope
+	for i := range 20_000 {
+		grown = grown.Insert(grown.Len(), string(rune('a'+i%26)))
+	}
+	checkInvariants(t, grown)
+	if grown.Len() != 20_000 || grown.At(27) != 'b' {
+		t.Errorf("Appending gave %d bytes", grown.Len())
+	}
+}
+
+func TestRope_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomText := func() string {
+		b := make([]byte, rng.Intn(1500))
+		for i := range b {
+			b[i] = byte('a' + rng.Intn(26))
+		}
+		return string(b)
+	}
+
+	r, ref := New(""), ""
+	for i := range 3000 {
+		switch op := rng.Intn(5); op {
+		case 0, 1:
+			at, s := rng.Intn(len(ref)+1), randomText()
+			r, ref = r.Insert(at, s), ref[:at]+s+ref[at:]
+		case 2:
+			from := rng.Intn(len(ref) + 1)
+			to := from + rng.Intn(len(ref)-from+1)
+			r, ref = r.Delete(from, to), ref[:from]+ref[to:]
+		case 3:
+			from := rng.Intn(len(ref) + 1)
+			to := from + rng.Intn(len(ref)-from+1)
+			if s := r.Slice(from, to); s.String() != ref[from:to] {
+				t.Fatalf("Step %d: Slice(%d, %d) disagrees with the reference", i, from, to)
+			}
+		default:
+			at := rng.Intn(len(ref) + 1)
+			left, right := r.Split(at)
+			checkInvariants(t, left)
+			checkInvariants(t, right)
+			r = right.Concat(left)
+			ref = ref[at:] + ref[:at]
+		}
+
+		if r.Len() != len(ref) {
+			t.Fatalf("Step %d: Len() = %d, want %d", i, r.Len(), len(ref))
+		}
+		if i%100 == 0 {
+			checkInvariants(t, r)
+			if r.String() != ref {
+				t.Fatalf("Step %d: text disagrees with the reference", i)
+			}
+			if len(ref) > 0 {
+				j := rng.Intn(len(ref))
+				if r.At(j) != ref[j] {
+					t.Fatalf("Step %d: At(%d) disagrees with the reference", i, j)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,411 @@
+// Package rtree provides an R-tree indexing rectangles in the plane, with intersection, containment
+// and nearest-neighbor queries.
+package rtree
+
+import (
+	"iter"
+	"math"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/geom"
+	"github.com/dullkingsman/kozo/heap"
+)
+
+const (
+	// maxEntries is the number of entries a node holds before it splits.
+	maxEntries = 8
+	// minEntries is the number of entries below which a node other than the root is dissolved.
+	minEntries = 3
+)
+
+// Item is a rectangle indexed by a Tree with its value.
+type Item[V comparable] struct {
+	Rect  geom.Rect
+	Value V
+}
+
+// Tree is a thread-safe R-tree of rectangles with values, such as the bounding boxes of map features or of
+// moving objects. Unlike a quadtree it needs no bounds: each node covers the smallest rectangle around its
+// entries, and nodes split as they fill, so that queries only visit the nodes overlapping their target.
+// All leaves lie at the same depth, and every node but the root holds between 3 and 8 entries.
+// Several values may share a rectangle.
+type Tree[V comparable] struct {
+	mu   sync.RWMutex
+	root *node[V]
+	size int
+}
+
+var _ collection.Collection[Item[int]] = (*Tree[int])(nil)
+
+// node is a leaf holding items, or an inner node holding children.
+type node[V comparable] struct {
+	bounds   geom.Rect // the smallest rectangle around the entries, meaningless while the node is empty
+	leaf     bool
+	items    []Item[V]
+	children []*node[V]
+}
+
+// New returns a new empty Tree.
+func New[V comparable]() *Tree[V] {
+	return &Tree[V]{root: &node[V]{leaf: true}}
+}
+
+// Insert adds v with the rectangle r.
+func (t *Tree[V]) Insert(r geom.Rect, v V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.insert(Item[V]{r, v})
+	t.size++
+}
+
+// Remove removes one occurrence of v with the rectangle r. It returns true if it was present.
+func (t *Tree[V]) Remove(r geom.Rect, v V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.size == 0 {
+		return false
+	}
+
+	var orphans []Item[V]
+	if !t.root.remove(Item[V]{r, v}, &orphans) {
+		return false
+	}
+	t.size--
+	for !t.root.leaf && len(t.root.children) <= 1 {
+		if len(t.root.children) == 0 {
+			t.root = &node[V]{leaf: true}
+		} else {
+			t.root = t.root.children[0]
+		}
+	}
+	for _, item := range orphans {
+		t.insert(item)
+	}
+	return true
+}
+
+// Search returns the rectangles intersecting r, including those only touching it.
+func (t *Tree[V]) Search(r geom.Rect) []Item[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.collect(r.Intersects, r.Intersects, nil)
+}
+
+// Within returns the rectangles lying entirely in r, boundary included.
+func (t *Tree[V]) Within(r geom.Rect) []Item[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.collect(r.Intersects, r.ContainsRect, nil)
+}
+
+// Containing returns the rectangles containing r entirely, boundary included.
+func (t *Tree[V]) Containing(r geom.Rect) []Item[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	contains := func(b geom.Rect) bool { return b.ContainsRect(r) }
+	return t.collect(contains, contains, nil)
+}
+
+// At returns the rectangles containing p.
+func (t *Tree[V]) At(p geom.Point) []Item[V] {
+	return t.Containing(p.Bounds())
+}
+
+// Nearest returns the k rectangles nearest to p, nearest first, measuring the distance from p to the nearest
+// point of each rectangle, so rectangles containing p come first. It returns fewer if the tree holds fewer.
+func (t *Tree[V]) Nearest(p geom.Point, k int) []Item[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	// Nodes and items are visited in order of their distance to p, and a node is never farther than its entries.
+	type candidate struct {
+		distance float64
+		node     *node[V] // nil for items
+		item     Item[V]
+		seq      int // breaks ties in the order candidates were found
+	}
+	pending := heap.New(func(a, b candidate) bool {
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		return a.seq < b.seq
+	})
+	seq := 0
+	push := func(c candidate) {
+		c.seq = seq
+		seq++
+		pending.Push(c)
+	}
+
+	var found []Item[V]
+	if t.size > 0 {
+		push(candidate{distance: t.root.bounds.Distance(p), node: t.root})
+	}
+	for len(found) < k && !pending.IsEmpty() {
+		c, _ := pending.Pop()
+		switch {
+		case c.node == nil:
+			found = append(found, c.item)
+		case c.node.leaf:
+			for _, item := range c.node.items {
+				push(candidate{distance: item.Rect.Distance(p), item: item})
+			}
+		default:
+			for _, child := range c.node.children {
+				push(candidate{distance: child.bounds.Distance(p), node: child})
+			}
+		}
+	}
+	return found
+}
+
+// Items returns every rectangle in the tree.
+func (t *Tree[V]) Items() []Item[V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.root.all(nil)
+}
+
+// All returns an iterator over a snapshot of the rectangles in the tree, in unspecified order.
+// The snapshot is taken when iteration starts, so the tree may be modified while iterating.
+func (t *Tree[V]) All() iter.Seq[Item[V]] {
+	return func(yield func(Item[V]) bool) {
+		for _, item := range t.Items() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Bounds returns the smallest rectangle containing every rectangle in the tree. Returns false if the tree is empty.
+func (t *Tree[V]) Bounds() (geom.Rect, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.root.bounds, t.size > 0
+}
+
+// Len returns the number of rectangles.
+func (t *Tree[V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// IsEmpty returns true if the tree holds no rectangles.
+func (t *Tree[V]) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Clear removes all rectangles.
+func (t *Tree[V]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = &node[V]{leaf: true}
+	t.size = 0
+}
+
+// insert adds item, growing a new root if the old one splits.
+func (t *Tree[V]) insert(item Item[V]) {
+	if sibling := t.root.insert(item); sibling != nil {
+		root := &node[V]{children: []*node[V]{t.root, sibling}}
+		root.fit()
+		t.root = root
+	}
+}
+
+// collect appends the items matching keep, descending only into the nodes matching visit.
+func (t *Tree[V]) collect(visit, keep func(geom.Rect) bool, out []Item[V]) []Item[V] {
+	if t.size == 0 {
+		return out
+	}
+	var walk func(n *node[V])
+	walk = func(n *node[V]) {
+		if !visit(n.bounds) {
+			return
+		}
+		for _, item := range n.items {
+			if keep(item.Rect) {
+				out = append(out, item)
+			}
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(t.root)
+	return out
+}
+
+// insert adds item to the subtree, descending into the child needing the least enlargement. It returns the new
+// sibling of n if n overflowed and split, which the caller must adopt.
+func (n *node[V]) insert(item Item[V]) *node[V] {
+	if n.leaf {
+		n.items = append(n.items, item)
+	} else {
+		best := n.children[0]
+		bestGrowth, bestArea := growth(best.bounds, item.Rect), best.bounds.Area()
+		for _, child := range n.children[1:] {
+			g, a := growth(child.bounds, item.Rect), child.bounds.Area()
+			if g < bestGrowth || g == bestGrowth && a < bestArea {
+				best, bestGrowth, bestArea = child, g, a
+			}
+		}
+		if sibling := best.insert(item); sibling != nil {
+			n.children = append(n.children, sibling)
+		}
+	}
+
+	if n.len() > maxEntries {
+		return n.split()
+	}
+	n.fit()
+	return nil
+}
+
+// remove removes one item equal to e from the subtree. Children left with fewer than minEntries entries are
+// dissolved, and their items appended to orphans for the caller to insert again.
+func (n *node[V]) remove(e Item[V], orphans *[]Item[V]) bool {
+	if n.leaf {
+		for i, item := range n.items {
+			if item == e {
+				n.items = append(n.items[:i], n.items[i+1:]...)
+				n.fit()
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, child := range n.children {
+		if !child.bounds.ContainsRect(e.Rect) || !child.remove(e, orphans) {
+			continue
+		}
+		if child.len() < minEntries {
+			n.children = append(n.children[:i], n.children[i+1:]...)
+			*orphans = child.all(*orphans)
+		}
+		n.fit()
+		return true
+	}
+	return false
+}
+
+// all appends every item of the subtree.
+func (n *node[V]) all(out []Item[V]) []Item[V] {
+	out = append(out, n.items...)
+	for _, child := range n.children {
+		out = child.all(out)
+	}
+	return out
+}
+
+func (n *node[V]) len() int {
+	if n.leaf {
+		return len(n.items)
+	}
+	return len(n.children)
+}
+
+func (n *node[V]) rect(i int) geom.Rect {
+	if n.leaf {
+		return n.items[i].Rect
+	}
+	return n.children[i].bounds
+}
+
+// fit shrinks the bounds of n to its entries.
+func (n *node[V]) fit() {
+	if n.len() == 0 {
+		n.bounds = geom.Rect{}
+		return
+	}
+	n.bounds = n.rect(0)
+	for i := 1; i < n.len(); i++ {
+		n.bounds = n.bounds.Union(n.rect(i))
+	}
+}
+
+// split moves part of the entries of an overflowing node to a new sibling, which it returns, following the
+// quadratic split of Guttman: the two entries that would waste the most area together seed the two groups, and
+// the others join the group they enlarge the least, most decided first.
+func (n *node[V]) split() *node[V] {
+	count := n.len()
+	seedA, seedB, worst := 0, 1, -1.0
+	for i := range count {
+		for j := i + 1; j < count; j++ {
+			if waste := n.rect(i).Union(n.rect(j)).Area() - n.rect(i).Area() - n.rect(j).Area(); waste > worst {
+				seedA, seedB, worst = i, j, waste
+			}
+		}
+	}
+
+	inA := make([]bool, count)
+	assigned := make([]bool, count)
+	inA[seedA], assigned[seedA], assigned[seedB] = true, true, true
+	boundsA, boundsB := n.rect(seedA), n.rect(seedB)
+	sizeA, sizeB := 1, 1
+	for remaining := count - 2; remaining > 0; remaining-- {
+		// Once a group needs every remaining entry to reach the minimum, it takes them all.
+		if sizeA+remaining == minEntries || sizeB+remaining == minEntries {
+			toA := sizeA+remaining == minEntries
+			for i := range count {
+				if !assigned[i] {
+					assigned[i], inA[i] = true, toA
+				}
+			}
+			break
+		}
+
+		next, nextDiff := -1, -1.0
+		for i := range count {
+			if assigned[i] {
+				continue
+			}
+			if diff := math.Abs(growth(boundsA, n.rect(i)) - growth(boundsB, n.rect(i))); diff > nextDiff {
+				next, nextDiff = i, diff
+			}
+		}
+		growthA, growthB := growth(boundsA, n.rect(next)), growth(boundsB, n.rect(next))
+		toA := growthA < growthB ||
+			growthA == growthB && (boundsA.Area() < boundsB.Area() || boundsA.Area() == boundsB.Area() && sizeA <= sizeB)
+		assigned[next], inA[next] = true, toA
+		if toA {
+			boundsA, sizeA = boundsA.Union(n.rect(next)), sizeA+1
+		} else {
+			boundsB, sizeB = boundsB.Union(n.rect(next)), sizeB+1
+		}
+	}
+
+	sibling := &node[V]{leaf: n.leaf}
+	if n.leaf {
+		items := n.items
+		n.items = nil
+		for i, item := range items {
+			if inA[i] {
+				n.items = append(n.items, item)
+			} else {
+				sibling.items = append(sibling.items, item)
+			}
+		}
+	} else {
+		children := n.children
+		n.children = nil
+		for i, child := range children {
+			if inA[i] {
+				n.children = append(n.children, child)
+			} else {
+				sibling.children = append(sibling.children, child)
+			}
+		}
+	}
+	n.fit()
+	sibling.fit()
+	return sibling
+}
+
+// growth returns the area by which r must grow to contain other.
+func growth(r, other geom.Rect) float64 {
+	return r.Union(other).Area() - r.Area()
+}
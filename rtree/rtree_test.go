@@ -0,0 +1,194 @@
+package rtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/dullkingsman/kozo/geom"
+)
+
+// checkInvariants verifies that bounds are tight, that leaves share one depth, that nodes hold between minEntries
+// and maxEntries entries, and that the size matches.
+func checkInvariants[V comparable](t *testing.T, tr *Tree[V]) {
+	t.Helper()
+	count, leafDepth := 0, -1
+	var walk func(n *node[V], depth int)
+	walk = func(n *node[V], depth int) {
+		if n != tr.root && (n.len() < minEntries || n.len() > maxEntries) {
+			t.Fatalf("Node holds %d entries", n.len())
+		}
+		if n.len() > 0 {
+			bounds := n.rect(0)
+			for i := range n.len() {
+				bounds = bounds.Union(n.rect(i))
+			}
+			if bounds != n.bounds {
+				t.Fatalf("Node bounds %v, want %v", n.bounds, bounds)
+			}
+		}
+		if n.leaf {
+			if leafDepth >= 0 && leafDepth != depth {
+				t.Fatalf("Leaves at depths %d and %d", leafDepth, depth)
+			}
+			leafDepth = depth
+			count += len(n.items)
+			return
+		}
+		for _, child := range n.children {
+			walk(child, depth+1)
+		}
+	}
+	walk(tr.root, 0)
+	if count != tr.size {
+		t.Fatalf("Tree holds %d items but size is %d", count, tr.size)
+	}
+}
+
+func values(items []Item[int]) []int {
+	vs := make([]int, len(items))
+	for i, item := range items {
+		vs[i] = item.Value
+	}
+	slices.Sort(vs)
+	return vs
+}
+
+func TestTree_Basic(t *testing.T) {
+	tr := New[string]()
+	if _, ok := tr.Bounds(); ok || !tr.IsEmpty() || tr.Search(geom.NewRect(0, 0, 10, 10)) != nil {
+		t.Fatal("Expected an empty tree")
+	}
+
+	tr.Insert(geom.NewRect(0, 0, 10, 10), "big")
+	tr.Insert(geom.NewRect(2, 2, 4, 4), "small")
+	tr.Insert(geom.NewRect(20, 20, 30, 30), "far")
+	tr.Insert(geom.NewRect(2, 2, 4, 4), "small2") // shares a rectangle
+	if tr.Len() != 4 {
+		t.Fatalf("Len() = %d", tr.Len())
+	}
+	if b, ok := tr.Bounds(); !ok || b != geom.NewRect(0, 0, 30, 30) {
+		t.Errorf("Bounds() = %v, %v", b, ok)
+	}
+
+	names := func(items []Item[string]) []string {
+		var ns []string
+		for _, item := range items {
+			ns = append(ns, item.Value)
+		}
+		slices.Sort(ns)
+		return ns
+	}
+	if got := names(tr.Search(geom.NewRect(10, 10, 20, 20))); !slices.Equal(got, []string{"big", "far"}) {
+		t.Errorf("Search() = %v", got)
+	}
+	if got := names(tr.Within(geom.NewRect(1, 1, 25, 25))); !slices.Equal(got, []string{"small", "small2"}) {
+		t.Errorf("Within() = %v", got)
+	}
+	if got := names(tr.Containing(geom.NewRect(3, 3, 5, 5))); !slices.Equal(got, []string{"big"}) {
+		t.Errorf("Containing() = %v", got)
+	}
+	if got := names(tr.At(geom.Point{X: 3, Y: 3})); !slices.Equal(got, []string{"big", "small", "small2"}) {
+		t.Errorf("At() = %v", got)
+	}
+	if got := tr.Nearest(geom.Point{X: 19, Y: 25}, 2); len(got) != 2 || got[0].Value != "far" || got[1].Value != "big" {
+		t.Errorf("Nearest() = %v", got)
+	}
+
+	if tr.Remove(geom.NewRect(0, 0, 10, 11), "big") || tr.Remove(geom.NewRect(0, 0, 10, 10), "small") {
+		t.Error("Expected removal of absent items to fail")
+	}
+	if !tr.Remove(geom.NewRect(0, 0, 10, 10), "big") || tr.Len() != 3 {
+		t.Error("Expected removal to succeed")
+	}
+	if got := names(tr.At(geom.Point{X: 3, Y: 3})); !slices.Equal(got, []string{"small", "small2"}) {
+		t.Errorf("At() after Remove() = %v", got)
+	}
+
+	tr.Clear()
+	if !tr.IsEmpty() || len(tr.Items()) != 0 {
+		t.Error("Expected an empty tree after Clear()")
+	}
+}
+
+func TestTree_MatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	tr := New[int]()
+	var items []Item[int]
+	randomRect := func(size float64) geom.Rect {
+		x, y := rng.Float64()*1000, rng.Float64()*1000
+		return geom.NewRect(x, y, x+rng.Float64()*size, y+rng.Float64()*size)
+	}
+
+	for i := range 2000 {
+		if len(items) > 0 && rng.Intn(3) == 0 {
+			j := rng.Intn(len(items))
+			if !tr.Remove(items[j].Rect, items[j].Value) {
+				t.Fatalf("Remove(%v) failed", items[j])
+			}
+			items = append(items[:j], items[j+1:]...)
+		} else {
+			r := randomRect(50)
+			if rng.Intn(5) == 0 {
+				r = r.Min.Bounds() // points too
+			}
+			tr.Insert(r, i)
+			items = append(items, Item[int]{r, i})
+		}
+		if i%100 == 0 {
+			checkInvariants(t, tr)
+		}
+	}
+	checkInvariants(t, tr)
+	if got, want := values(tr.Items()), values(items); !slices.Equal(got, want) {
+		t.Fatalf("Items() holds %d items, want %d", len(got), len(want))
+	}
+
+	for range 100 {
+		q := randomRect(200)
+		var search, within, containing []Item[int]
+		for _, item := range items {
+			if q.Intersects(item.Rect) {
+				search = append(search, item)
+			}
+			if q.ContainsRect(item.Rect) {
+				within = append(within, item)
+			}
+			if item.Rect.ContainsRect(q.Center().Bounds()) {
+				containing = append(containing, item)
+			}
+		}
+		if !slices.Equal(values(tr.Search(q)), values(search)) {
+			t.Fatalf("Search(%v) mismatch", q)
+		}
+		if !slices.Equal(values(tr.Within(q)), values(within)) {
+			t.Fatalf("Within(%v) mismatch", q)
+		}
+		if !slices.Equal(values(tr.At(q.Center())), values(containing)) {
+			t.Fatalf("At(%v) mismatch", q.Center())
+		}
+
+		k := 1 + rng.Intn(10)
+		found := tr.Nearest(q.Center(), k)
+		distances := make([]float64, len(items))
+		for i, item := range items {
+			distances[i] = item.Rect.Distance(q.Center())
+		}
+		slices.Sort(distances)
+		for i, item := range found {
+			if d := item.Rect.Distance(q.Center()); d != distances[i] {
+				t.Fatalf("Nearest() item %d at distance %v, want %v", i, d, distances[i])
+			}
+		}
+	}
+
+	for _, item := range items {
+		if !tr.Remove(item.Rect, item.Value) {
+			t.Fatalf("Remove(%v) failed", item)
+		}
+	}
+	checkInvariants(t, tr)
+	if !tr.IsEmpty() {
+		t.Errorf("Len() = %d after removing everything", tr.Len())
+	}
+}
@@ -0,0 +1,44 @@
+package seq
+
+import (
+	"iter"
+
+	"github.com/dullkingsman/kozo/queue"
+	"github.com/dullkingsman/kozo/set"
+)
+
+// ToSlice returns the elements of s as a new slice, in order.
+func ToSlice[T any](s iter.Seq[T]) []T {
+	var res []T
+	for v := range s {
+		res = append(res, v)
+	}
+	return res
+}
+
+// ToSet returns a new Set of the elements of s.
+func ToSet[T comparable](s iter.Seq[T]) *set.Set[T] {
+	res := set.New[T]()
+	for v := range s {
+		res.Add(v)
+	}
+	return res
+}
+
+// ToQueue returns a new Queue of the elements of s, the first one at the front.
+func ToQueue[T any](s iter.Seq[T]) *queue.Queue[T] {
+	res := queue.New[T]()
+	for v := range s {
+		res.Enqueue(v)
+	}
+	return res
+}
+
+// ToMap returns a new map of the pairs of s. A repeated key keeps its last value.
+func ToMap[K comparable, V any](s iter.Seq2[K, V]) map[K]V {
+	res := make(map[K]V)
+	for k, v := range s {
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,140 @@
+// Package seq provides lazy combinators over iter.Seq and iter.Seq2, so that the iterators of the collections
+// compose into pipelines without intermediate slices: no element is computed before it is pulled, and stopping
+// early stops the source.
+package seq
+
+import "iter"
+
+// Map returns a sequence of the results of f applied to the elements of s.
+func Map[T, U any](s iter.Seq[T], f func(T) U) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range s {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter returns a sequence of the elements of s satisfying pred.
+func Filter[T any](s iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if pred(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMap returns the concatenation of the sequences returned by f for the elements of s.
+func FlatMap[T, U any](s iter.Seq[T], f func(T) iter.Seq[U]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range s {
+			for u := range f(v) {
+				if !yield(u) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Take returns a sequence of the first n elements of s, or all of them if it has fewer.
+// It stops s after the n-th element, so it bounds infinite sequences.
+func Take[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+			if taken++; taken == n {
+				return
+			}
+		}
+	}
+}
+
+// Drop returns a sequence of the elements of s after the first n.
+func Drop[T any](s iter.Seq[T], n int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		dropped := 0
+		for v := range s {
+			if dropped < n {
+				dropped++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Zip returns a sequence of the pairs of elements of a and b at the same positions, ending with the shorter one.
+// When b is the shorter, the element of a after the last pair has been pulled from a.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for va := range a {
+			vb, ok := next()
+			if !ok || !yield(va, vb) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk returns a sequence of consecutive slices of size elements of s, the last one possibly shorter.
+// Each slice is new, so it may be kept. It panics if size is less than 1.
+func Chunk[T any](s iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("seq: chunk size must be at least 1")
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for v := range s {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Distinct returns a sequence of the elements of s without repetitions, keeping the first occurrences.
+// It remembers every distinct element seen, so its memory grows with them.
+func Distinct[T comparable](s iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for v := range s {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Reduce folds the elements of s into an accumulator, starting from init.
+func Reduce[T, A any](s iter.Seq[T], init A, f func(acc A, v T) A) A {
+	acc := init
+	for v := range s {
+		acc = f(acc, v)
+	}
+	return acc
+}
@@ -0,0 +1,81 @@
+package seq
+
+import "iter"
+
+// Map2 returns a sequence of the results of f applied to the pairs of s, such as to convert keys or values.
+func Map2[K, V, K2, V2 any](s iter.Seq2[K, V], f func(K, V) (K2, V2)) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		for k, v := range s {
+			if !yield(f(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter2 returns a sequence of the pairs of s satisfying pred.
+func Filter2[K, V any](s iter.Seq2[K, V], pred func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s {
+			if pred(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Take2 returns a sequence of the first n pairs of s, or all of them if it has fewer.
+func Take2[K, V any](s iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for k, v := range s {
+			if !yield(k, v) {
+				return
+			}
+			if taken++; taken == n {
+				return
+			}
+		}
+	}
+}
+
+// Drop2 returns a sequence of the pairs of s after the first n.
+func Drop2[K, V any](s iter.Seq2[K, V], n int) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		dropped := 0
+		for k, v := range s {
+			if dropped < n {
+				dropped++
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a sequence of the first elements of the pairs of s, such as the keys of a map.
+func Keys[K, V any](s iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range s {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the second elements of the pairs of s, such as the values of a map.
+func Values[K, V any](s iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package seq
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/dullkingsman/kozo/orderedmap"
+	"github.com/dullkingsman/kozo/stack"
+)
+
+// naturals returns the infinite sequence 0, 1, 2, ... counting the elements pulled.
+func naturals(pulled *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			*pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestSeq_Pipeline(t *testing.T) {
+	pulled := 0
+	odd := func(v int) bool { return v%2 == 1 }
+	square := func(v int) int { return v * v }
+
+	got := ToSlice(Take(Map(Filter(naturals(&pulled), odd), square), 3))
+	if !slices.Equal(got, []int{1, 9, 25}) {
+		t.Errorf("Expected [1 9 25], got %v", got)
+	}
+	if pulled != 6 {
+		t.Errorf("Expected 6 elements pulled lazily, got %d", pulled)
+	}
+
+	s := stack.New[int]()
+	s.Push(1, 2, 3, 4)
+	if got := ToSlice(Drop(s.All(), 1)); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("Expected [3 2 1], got %v", got)
+	}
+}
+
+func TestSeq_TakeDrop(t *testing.T) {
+	values := slices.Values([]int{1, 2, 3})
+	cases := []struct {
+		name string
+		s    iter.Seq[int]
+		want []int
+	}{
+		{"Take 0", Take(values, 0), nil},
+		{"Take 2", Take(values, 2), []int{1, 2}},
+		{"Take beyond", Take(values, 5), []int{1, 2, 3}},
+		{"Drop 0", Drop(values, 0), []int{1, 2, 3}},
+		{"Drop 2", Drop(values, 2), []int{3}},
+		{"Drop beyond", Drop(values, 5), nil},
+	}
+	for _, tc := range cases {
+		if got := ToSlice(tc.s); !slices.Equal(got, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, got)
+		}
+	}
+	// Sequences are reusable: each iteration starts over.
+	take := Take(values, 2)
+	if a, b := ToSlice(take), ToSlice(take); !slices.Equal(a, b) {
+		t.Errorf("Expected the same elements on each iteration, got %v and %v", a, b)
+	}
+}
+
+func TestSeq_Zip(t *testing.T) {
+	names := slices.Values([]string{"a", "b", "c"})
+	got := ToMap(Zip(names, slices.Values([]int{1, 2})))
+	if !maps.Equal(got, map[string]int{"a": 1, "b": 2}) {
+		t.Errorf("Expected {a:1 b:2}, got %v", got)
+	}
+
+	pulled := 0
+	var pairs []string
+	for name, n := range Zip(names, naturals(&pulled)) {
+		pairs = append(pairs, name+strconv.Itoa(n))
+	}
+	if !slices.Equal(pairs, []string{"a0", "b1", "c2"}) {
+		t.Errorf("Expected [a0 b1 c2], got %v", pairs)
+	}
+}
+
+func TestSeq_ChunkDistinctFlatMap(t *testing.T) {
+	chunks := ToSlice(Chunk(slices.Values([]int{1, 2, 3, 4, 5}), 2))
+	if len(chunks) != 3 || !slices.Equal(chunks[0], []int{1, 2}) || !slices.Equal(chunks[2], []int{5}) {
+		t.Errorf("Expected [[1 2] [3 4] [5]], got %v", chunks)
+	}
+	if len(ToSlice(Chunk(slices.Values([]int{}), 2))) != 0 {
+		t.Error("Expected no chunks of an empty sequence")
+	}
+	if got := ToSlice(Distinct(slices.Values([]int{3, 1, 3, 2, 1}))); !slices.Equal(got, []int{3, 1, 2}) {
+		t.Errorf("Expected [3 1 2], got %v", got)
+	}
+
+	repeat := func(v int) iter.Seq[int] { return slices.Values(slices.Repeat([]int{v}, v)) }
+	if got := ToSlice(FlatMap(slices.Values([]int{1, 2, 3}), repeat)); !slices.Equal(got, []int{1, 2, 2, 3, 3, 3}) {
+		t.Errorf("Expected [1 2 2 3 3 3], got %v", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected Chunk to panic on size 0")
+		}
+	}()
+	Chunk(slices.Values([]int{1}), 0)
+}
+
+func TestSeq_ReduceAndCollect(t *testing.T) {
+	words := slices.Values([]string{"go", "is", "fun", "go"})
+	if n := Reduce(words, 0, func(acc int, w string) int { return acc + len(w) }); n != 9 {
+		t.Errorf("Expected 9, got %d", n)
+	}
+
+	s := ToSet(words)
+	if s.Len() != 3 || !s.Contains("fun") {
+		t.Errorf("Expected a set of 3 words, got %v", s.ToSlice())
+	}
+	q := ToQueue(words)
+	if v, _ := q.Dequeue(); v != "go" || q.Len() != 3 {
+		t.Errorf("Expected go at the front of 4 words, got %q", v)
+	}
+}
+
+func TestSeq_Pairs(t *testing.T) {
+	m := orderedmap.New[string, int]()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+
+	odd := Filter2(m.All(), func(_ string, v int) bool { return v%2 == 1 })
+	if got := ToSlice(Keys(odd)); !slices.Equal(got, []string{"a", "c"}) {
+		t.Errorf("Expected [a c], got %v", got)
+	}
+	swapped := Map2(m.All(), func(k string, v int) (int, string) { return v, k })
+	if got := ToMap(swapped); !maps.Equal(got, map[int]string{1: "a", 2: "b", 3: "c"}) {
+		t.Errorf("Expected {1:a 2:b 3:c}, got %v", got)
+	}
+	if got := ToSlice(Values(Take2(Drop2(m.All(), 1), 1))); !slices.Equal(got, []int{2}) {
+		t.Errorf("Expected [2], got %v", got)
+	}
+}
@@ -1,7 +1,13 @@
 package set
 
 import (
+	"errors"
+	"fmt"
+	"iter"
 	"sync"
+
+	"github.com/dullkingsman/kozo/codec"
+	"github.com/dullkingsman/kozo/collection"
 )
 
 // AnySet is a thread-safe set for any type T, using a custom equality function.
@@ -13,6 +19,14 @@ type AnySet[T any] struct {
 	equals func(T, T) bool
 }
 
+// errNoEquals is returned when decoding into an AnySet that was not created with NewAny.
+var errNoEquals = errors.New("set: cannot unmarshal into AnySet without an equality function")
+
+var (
+	_ codec.Snapshotter          = (*AnySet[int])(nil)
+	_ collection.Collection[int] = (*AnySet[int])(nil)
+)
+
 // NewAny creates a new AnySet for any type T, using the provided equality function.
 func NewAny[T any](equals func(T, T) bool, items ...T) *AnySet[T] {
 	s := &AnySet[T]{
@@ -151,6 +165,18 @@ func (s *AnySet[T]) Iter(fn func(T) bool) {
 	}
 }
 
+// All returns an iterator over a snapshot of the items.
+// The snapshot is taken when iteration starts, so the set may be modified while iterating.
+func (s *AnySet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.ToSlice() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
 // Clone returns a new AnySet with the same items.
 func (s *AnySet[T]) Clone() *AnySet[T] {
 	s.mu.RLock()
@@ -288,3 +314,58 @@ func (s *AnySet[T]) Equal(other *AnySet[T]) bool {
 	}
 	return true
 }
+
+// MarshalJSON encodes the set as a JSON array, encoding each item with the codec registered for T.
+func (s *AnySet[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalSlice(s.ToSlice())
+}
+
+// UnmarshalJSON replaces the contents of the set with the items of a JSON array,
+// decoding each item with the codec registered for T.
+// The set must have been created with NewAny so that an equality function is available.
+func (s *AnySet[T]) UnmarshalJSON(data []byte) error {
+	if s.equals == nil {
+		return errNoEquals
+	}
+
+	items, err := codec.UnmarshalSlice[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items = make([]T, 0, len(items))
+	for _, item := range items {
+		if !s.containsUnsafe(item) {
+			s.items = append(s.items, item)
+		}
+	}
+	return nil
+}
+
+// Snapshot encodes the set as its JSON array in a snapshot envelope of kind "set", so that it restores
+// into a Set as well as into an AnySet; see codec.Snapshotter.
+func (s *AnySet[T]) Snapshot() ([]byte, error) {
+	payload, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return codec.EncodeSnapshot("set", payload), nil
+}
+
+// Restore replaces the contents of the set with those of a snapshot of a Set or an AnySet.
+// The set must have been created with NewAny so that an equality function is available.
+func (s *AnySet[T]) Restore(data []byte) error {
+	if s.equals == nil {
+		return errNoEquals
+	}
+	payload, err := codec.DecodeSnapshot("set", data)
+	if err != nil {
+		return err
+	}
+	if err := s.UnmarshalJSON(payload); err != nil {
+		return fmt.Errorf("%w: %v", codec.ErrInvalidSnapshot, err)
+	}
+	return nil
+}
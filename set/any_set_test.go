@@ -1,6 +1,8 @@
 package set
 
 import (
+	"encoding/json"
+	"slices"
 	"sort"
 	"testing"
 )
@@ -113,3 +115,33 @@ func TestAnySetToSlice(t *testing.T) {
 		t.Errorf("ToSlice returned unexpected result: %v", slice)
 	}
 }
+
+func TestAnySetAll(t *testing.T) {
+	s := NewAny(func(a, b int) bool { return a == b }, 1, 2, 3)
+	got := slices.Sorted(s.All())
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+}
+
+func TestAnySetJSON(t *testing.T) {
+	equals := func(a, b int) bool { return a == b }
+	s := NewAny(equals, 1, 2, 3)
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	s2 := NewAny(equals)
+	if err := json.Unmarshal(data, s2); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !s.Equal(s2) {
+		t.Errorf("Expected round trip to preserve items, got %v", s2.ToSlice())
+	}
+
+	var s3 AnySet[int]
+	if err := json.Unmarshal(data, &s3); err == nil {
+		t.Error("Expected error unmarshalling into AnySet without equality function")
+	}
+}
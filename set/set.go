@@ -1,7 +1,12 @@
 package set
 
 import (
+	"fmt"
+	"iter"
 	"sync"
+
+	"github.com/dullkingsman/kozo/codec"
+	"github.com/dullkingsman/kozo/collection"
 )
 
 // Set is a thread-safe, generic set for comparable types.
@@ -11,6 +16,11 @@ type Set[T comparable] struct {
 	m  map[T]struct{}
 }
 
+var (
+	_ codec.Snapshotter          = (*Set[int])(nil)
+	_ collection.Collection[int] = (*Set[int])(nil)
+)
+
 // New creates a new Set for comparable types.
 // If items are provided, they are added to the set.
 func New[T comparable](items ...T) *Set[T] {
@@ -115,6 +125,18 @@ func (s *Set[T]) Iter(fn func(T) bool) {
 	}
 }
 
+// All returns an iterator over a snapshot of the items, in non-deterministic order.
+// The snapshot is taken when iteration starts, so the set may be modified while iterating.
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range s.ToSlice() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
 // Clone returns a new Set with the same items.
 func (s *Set[T]) Clone() *Set[T] {
 	s.mu.RLock()
@@ -255,3 +277,47 @@ func (s *Set[T]) Equal(other *Set[T]) bool {
 	}
 	return true
 }
+
+// MarshalJSON encodes the set as a JSON array, encoding each item with the codec registered for T.
+// The order of items is non-deterministic.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalSlice(s.ToSlice())
+}
+
+// UnmarshalJSON replaces the contents of the set with the items of a JSON array,
+// decoding each item with the codec registered for T.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	items, err := codec.UnmarshalSlice[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m = make(map[T]struct{}, len(items))
+	for _, item := range items {
+		s.m[item] = struct{}{}
+	}
+	return nil
+}
+
+// Snapshot encodes the set as its JSON array in a snapshot envelope of kind "set"; see codec.Snapshotter.
+func (s *Set[T]) Snapshot() ([]byte, error) {
+	payload, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return codec.EncodeSnapshot("set", payload), nil
+}
+
+// Restore replaces the contents of the set with those of a snapshot made by Snapshot.
+func (s *Set[T]) Restore(data []byte) error {
+	payload, err := codec.DecodeSnapshot("set", data)
+	if err != nil {
+		return err
+	}
+	if err := s.UnmarshalJSON(payload); err != nil {
+		return fmt.Errorf("%w: %v", codec.ErrInvalidSnapshot, err)
+	}
+	return nil
+}
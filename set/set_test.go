@@ -1,8 +1,13 @@
 package set
 
 import (
+	"encoding/json"
+	"errors"
+	"slices"
 	"sort"
 	"testing"
+
+	"github.com/dullkingsman/kozo/codec"
 )
 
 func TestSet(t *testing.T) {
@@ -123,6 +128,21 @@ func TestSetIter(t *testing.T) {
 	}
 }
 
+func TestSetAll(t *testing.T) {
+	s := New(1, 2, 3)
+	got := slices.Sorted(s.All())
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+
+	for v := range s.All() {
+		s.Remove(v) // iterates over a snapshot
+	}
+	if !s.IsEmpty() {
+		t.Errorf("Expected an empty set, got %v", s.ToSlice())
+	}
+}
+
 func TestSetToSlice(t *testing.T) {
 	s := New(1, 2, 3)
 	slice := s.ToSlice()
@@ -131,3 +151,54 @@ func TestSetToSlice(t *testing.T) {
 		t.Errorf("ToSlice returned unexpected result: %v", slice)
 	}
 }
+
+func TestSetJSON(t *testing.T) {
+	s := New(1, 2, 3)
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	var s2 Set[int]
+	if err := json.Unmarshal(data, &s2); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if !s.Equal(&s2) {
+		t.Errorf("Expected round trip to preserve items, got %v", s2.ToSlice())
+	}
+
+	if err := json.Unmarshal([]byte(`[1, 1, 2]`), &s2); err != nil || s2.Len() != 2 {
+		t.Errorf("Expected duplicates to collapse, got %v (err: %v)", s2.ToSlice(), err)
+	}
+}
+
+func TestSetSnapshot(t *testing.T) {
+	s := New(1, 2, 3)
+	data, err := s.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	s2 := New(9)
+	if err := s2.Restore(data); err != nil || !s2.Equal(s) {
+		t.Errorf("Expected %v, got %v (err: %v)", s.ToSlice(), s2.ToSlice(), err)
+	}
+
+	anySet := NewAny(func(a, b int) bool { return a == b })
+	if err := anySet.Restore(data); err != nil || anySet.Len() != 3 || !anySet.Contains(2) {
+		t.Errorf("Expected an AnySet of 3 items, got %v (err: %v)", anySet.ToSlice(), err)
+	}
+
+	badPayload := codec.EncodeSnapshot("set", []byte(`[1,`))
+	if err := s2.Restore(badPayload); !errors.Is(err, codec.ErrInvalidSnapshot) || s2.Len() != 3 {
+		t.Errorf("Expected ErrInvalidSnapshot for a bad payload, got %v", err)
+	}
+	if err := anySet.Restore(badPayload); !errors.Is(err, codec.ErrInvalidSnapshot) || anySet.Len() != 3 {
+		t.Errorf("Expected ErrInvalidSnapshot for a bad payload in an AnySet, got %v", err)
+	}
+
+	data[len(data)-1] ^= 1
+	if err := s2.Restore(data); !errors.Is(err, codec.ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package shard
+
+import (
+	"cmp"
+	"hash/fnv"
+	"math"
+	"slices"
+	"sync"
+)
+
+// Rendezvous is a thread-safe Selector using rendezvous, or highest random weight, hashing: every node scores
+// every key with a hash of both, and a key goes to the node with the highest score. Removing a node only moves
+// its own keys, each to its next best node, and adding one only takes the keys it now scores highest on.
+// Weights scale the scores following Schindelhauer and Schomaker, so shares are exactly proportional to them.
+//
+// Lookups are O(n) for n nodes, which suits the tens of nodes of a typical cluster without the memory and
+// imbalance of virtual nodes on a ring.
+type Rendezvous struct {
+	mu    sync.RWMutex
+	nodes []weightedNode
+}
+
+var _ Selector = (*Rendezvous)(nil)
+
+type weightedNode struct {
+	name   string
+	hash   uint64
+	weight float64
+}
+
+// NewRendezvous returns a new Rendezvous without nodes.
+func NewRendezvous() *Rendezvous {
+	return &Rendezvous{}
+}
+
+// Add adds node with the given weight, or updates its weight if it is present. It panics if weight is not positive.
+func (r *Rendezvous) Add(node string, weight float64) {
+	if !(weight > 0) || math.IsInf(weight, 1) {
+		panic("shard: weight must be positive")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i := r.index(node); i >= 0 {
+		r.nodes[i].weight = weight
+		return
+	}
+	r.nodes = append(r.nodes, weightedNode{node, hash(node), weight})
+}
+
+// Remove removes node. It returns true if it was present.
+func (r *Rendezvous) Remove(node string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.index(node)
+	if i < 0 {
+		return false
+	}
+	r.nodes = slices.Delete(r.nodes, i, i+1)
+	return true
+}
+
+// Get returns the node of key. Returns false if there are no nodes.
+func (r *Rendezvous) Get(key string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.nodes) == 0 {
+		return "", false
+	}
+	h := hash(key)
+	best, bestScore := "", math.Inf(-1)
+	for _, n := range r.nodes {
+		if s := n.score(h); s > bestScore {
+			best, bestScore = n.name, s
+		}
+	}
+	return best, true
+}
+
+// GetN returns n distinct nodes for key in decreasing order of score, the first being that of Get.
+// It returns fewer if there are fewer nodes.
+func (r *Rendezvous) GetN(key string, n int) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if n <= 0 || len(r.nodes) == 0 {
+		return nil
+	}
+	type scored struct {
+		name  string
+		score float64
+	}
+	h := hash(key)
+	all := make([]scored, len(r.nodes))
+	for i, node := range r.nodes {
+		all[i] = scored{node.name, node.score(h)}
+	}
+	slices.SortStableFunc(all, func(a, b scored) int { return cmp.Compare(b.score, a.score) })
+
+	names := make([]string, min(n, len(all)))
+	for i := range names {
+		names[i] = all[i].name
+	}
+	return names
+}
+
+// Weight returns the weight of node. Returns false if it is not present.
+func (r *Rendezvous) Weight(node string) (float64, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if i := r.index(node); i >= 0 {
+		return r.nodes[i].weight, true
+	}
+	return 0, false
+}
+
+// Nodes returns the nodes in the order they were added.
+func (r *Rendezvous) Nodes() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, len(r.nodes))
+	for i, n := range r.nodes {
+		names[i] = n.name
+	}
+	return names
+}
+
+// Len returns the number of nodes.
+func (r *Rendezvous) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.nodes)
+}
+
+func (r *Rendezvous) index(node string) int {
+	return slices.IndexFunc(r.nodes, func(n weightedNode) bool { return n.name == node })
+}
+
+// score returns -weight / ln(u) for u uniform in (0, 1) drawn from the hashes of the node and the key.
+// The node of highest score is then that of the smallest -ln(u) / weight, an exponential variable of rate weight,
+// which is the node i with probability weight_i / Σ weight.
+func (n weightedNode) score(key uint64) float64 {
+	u := (float64(mix(n.hash^key)>>11) + 0.5) / (1 << 53)
+	return -n.weight / math.Log(u)
+}
+
+// hash returns the 64-bit FNV-1a hash of s, mixed so that similar names such as "cache-1" and "cache-2" differ
+// in every bit. Unlike hash/maphash, it is the same in every process.
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return mix(h.Sum64())
+}
+
+// mix is the finalizer of SplitMix64, spreading the combined hashes of a node and a key over all 64 bits.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,22 @@
+// Package shard provides strategies assigning keys to nodes, such as cache entries to servers,
+// so that most keys keep their node when nodes join or leave.
+package shard
+
+// Selector assigns keys to a changing set of weighted nodes. Implementations are deterministic across processes,
+// so that every client picks the same node for a key given the same nodes, and are safe for concurrent use.
+type Selector interface {
+	// Add adds node with the given weight, or updates its weight if it is present. A node of weight 2 receives
+	// twice as many keys as a node of weight 1. It panics if weight is not positive.
+	Add(node string, weight float64)
+	// Remove removes node. It returns true if it was present.
+	Remove(node string) bool
+	// Get returns the node of key. Returns false if there are no nodes.
+	Get(key string) (string, bool)
+	// GetN returns n distinct nodes for key in order of preference, the first being that of Get, such as the
+	// replicas of a key. It returns fewer if there are fewer nodes.
+	GetN(key string, n int) []string
+	// Nodes returns the nodes in the order they were added.
+	Nodes() []string
+	// Len returns the number of nodes.
+	Len() int
+}
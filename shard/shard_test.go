@@ -0,0 +1,114 @@
+package shard
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestRendezvous_Basic(t *testing.T) {
+	r := NewRendezvous()
+	if _, ok := r.Get("key"); ok || r.GetN("key", 2) != nil {
+		t.Fatal("Expected no node without nodes")
+	}
+
+	r.Add("a", 1)
+	r.Add("b", 1)
+	r.Add("c", 1)
+	r.Add("a", 2) // updates the weight
+	if r.Len() != 3 || !slices.Equal(r.Nodes(), []string{"a", "b", "c"}) {
+		t.Fatalf("Nodes() = %v", r.Nodes())
+	}
+	if w, ok := r.Weight("a"); !ok || w != 2 {
+		t.Errorf("Weight(a) = %v, %v", w, ok)
+	}
+
+	node, ok := r.Get("key")
+	replicas := r.GetN("key", 5)
+	if !ok || len(replicas) != 3 || replicas[0] != node {
+		t.Errorf("Get() = %v, GetN() = %v", node, replicas)
+	}
+	if got := r.GetN("key", 2); !slices.Equal(got, replicas[:2]) {
+		t.Errorf("GetN(2) = %v", got)
+	}
+
+	// The same nodes added in another order select the same nodes.
+	other := NewRendezvous()
+	for _, n := range []string{"c", "a", "b"} {
+		other.Add(n, 1)
+	}
+	other.Add("a", 2)
+	for i := range 100 {
+		key := fmt.Sprint("key-", i)
+		if !slices.Equal(r.GetN(key, 3), other.GetN(key, 3)) {
+			t.Fatalf("Selection of %s depends on the order of the nodes", key)
+		}
+	}
+
+	if r.Remove("d") || !r.Remove("a") || r.Len() != 2 {
+		t.Error("Unexpected removal")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a non-positive weight to panic")
+		}
+	}()
+	r.Add("d", 0)
+}
+
+func TestRendezvous_MinimalDisruption(t *testing.T) {
+	r := NewRendezvous()
+	for i := range 10 {
+		r.Add(fmt.Sprint("node-", i), 1)
+	}
+	before := make(map[string]string)
+	for i := range 10000 {
+		key := fmt.Sprint("key-", i)
+		before[key], _ = r.Get(key)
+	}
+
+	r.Remove("node-3")
+	for key, old := range before {
+		now, _ := r.Get(key)
+		if old != "node-3" && now != old {
+			t.Fatalf("Key %s moved from %s to %s", key, old, now)
+		}
+	}
+
+	r.Add("node-10", 1)
+	moved := 0
+	for key, old := range before {
+		now, _ := r.Get(key)
+		if now != old && now != "node-10" && old != "node-3" {
+			t.Fatalf("Key %s moved from %s to %s, not to the new node", key, old, now)
+		}
+		if now == "node-10" {
+			moved++
+		}
+	}
+	if moved < 700 || moved > 1300 {
+		t.Errorf("New node took %d of 10000 keys, want about 1000", moved)
+	}
+}
+
+func TestRendezvous_Weights(t *testing.T) {
+	r := NewRendezvous()
+	r.Add("small", 1)
+	r.Add("medium", 2)
+	r.Add("large", 5)
+
+	counts := make(map[string]int)
+	const keys = 80000
+	for i := range keys {
+		node, _ := r.Get(fmt.Sprint("key-", i))
+		counts[node]++
+	}
+	for node, weight := range map[string]float64{"small": 1, "medium": 2, "large": 5} {
+		want := keys * weight / 8
+		if got := float64(counts[node]); math.Abs(got-want) > want*0.05 {
+			t.Errorf("%s received %v keys, want about %v", node, got, want)
+		}
+	}
+}
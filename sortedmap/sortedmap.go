@@ -0,0 +1,496 @@
+package sortedmap
+
+import (
+	"cmp"
+	"iter"
+	"sort"
+	"sync"
+
+	"github.com/dullkingsman/kozo/cmpx"
+	"github.com/dullkingsman/kozo/collection"
+	_range "github.com/dullkingsman/kozo/range"
+)
+
+// degree is the minimum degree of the B-tree: every node but the root holds between degree-1
+// and 2*degree-1 keys. Wide nodes keep the tree shallow and lookups cache-friendly.
+const degree = 32
+
+// SortedMap is a thread-safe map that keeps its keys sorted, backed by a B-tree.
+// Get, Set, Delete, Floor and Ceiling are O(log n), and iteration visits keys in order.
+type SortedMap[K, V any] struct {
+	mu   sync.RWMutex
+	root *node[K, V]
+	less func(K, K) bool
+	size int
+}
+
+var _ collection.Map[int, int] = (*SortedMap[int, int])(nil)
+
+type node[K, V any] struct {
+	keys     []K
+	values   []V
+	children []*node[K, V] // nil for leaves
+}
+
+// New returns a new empty SortedMap ordered by the given less function.
+func New[K, V any](less func(K, K) bool) *SortedMap[K, V] {
+	return &SortedMap[K, V]{root: &node[K, V]{}, less: less}
+}
+
+// NewFunc returns a new empty SortedMap ordered by the comparator c, e.g. cmpx.By(key).Then(cmpx.By(other)).
+// Keys that c finds equivalent are the same key.
+func NewFunc[K, V any](c cmpx.Comparator[K]) *SortedMap[K, V] {
+	return New[K, V](c.Less())
+}
+
+// NewOrdered returns a new empty SortedMap for cmp.Ordered keys.
+func NewOrdered[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return New[K, V](cmp.Less[K])
+}
+
+// Set sets the value of key. It returns true if the key was new.
+func (m *SortedMap[K, V]) Set(key K, value V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.root.keys) == 2*degree-1 {
+		root := &node[K, V]{children: []*node[K, V]{m.root}}
+		root.splitChild(0)
+		m.root = root
+	}
+
+	if m.insert(m.root, key, value) {
+		m.size++
+		return true
+	}
+	return false
+}
+
+// Get returns the value of key. Returns (zero-value, false) if the key is not present.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for n := m.root; ; {
+		i, found := m.search(n, key)
+		if found {
+			return n.values[i], true
+		}
+		if n.leaf() {
+			var zero V
+			return zero, false
+		}
+		n = n.children[i]
+	}
+}
+
+// Has returns true if the key is present.
+func (m *SortedMap[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Delete removes key. It returns true if the key was present.
+func (m *SortedMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	deleted := m.delete(m.root, key)
+	if len(m.root.keys) == 0 && !m.root.leaf() {
+		m.root = m.root.children[0]
+	}
+	if deleted {
+		m.size--
+	}
+	return deleted
+}
+
+// Len returns the number of keys.
+func (m *SortedMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.size
+}
+
+// IsEmpty returns true if the map has no keys.
+func (m *SortedMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Clear removes all keys.
+func (m *SortedMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.root = &node[K, V]{}
+	m.size = 0
+}
+
+// Min returns the smallest key and its value. Returns false if the map is empty.
+func (m *SortedMap[K, V]) Min() (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	n := m.root
+	if len(n.keys) == 0 {
+		return zero[K, V]()
+	}
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n.keys[0], n.values[0], true
+}
+
+// Max returns the largest key and its value. Returns false if the map is empty.
+func (m *SortedMap[K, V]) Max() (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	n := m.root
+	if len(n.keys) == 0 {
+		return zero[K, V]()
+	}
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	last := len(n.keys) - 1
+	return n.keys[last], n.values[last], true
+}
+
+// Floor returns the largest key less than or equal to key, and its value. Returns false if there is none.
+func (m *SortedMap[K, V]) Floor(key K) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var best *node[K, V]
+	var bestIndex int
+	for n := m.root; n != nil; {
+		i, found := m.search(n, key)
+		if found {
+			return n.keys[i], n.values[i], true
+		}
+		if i > 0 {
+			best, bestIndex = n, i-1
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+
+	if best == nil {
+		return zero[K, V]()
+	}
+	return best.keys[bestIndex], best.values[bestIndex], true
+}
+
+// Ceiling returns the smallest key greater than or equal to key, and its value. Returns false if there is none.
+func (m *SortedMap[K, V]) Ceiling(key K) (K, V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var best *node[K, V]
+	var bestIndex int
+	for n := m.root; n != nil; {
+		i, found := m.search(n, key)
+		if found {
+			return n.keys[i], n.values[i], true
+		}
+		if i < len(n.keys) {
+			best, bestIndex = n, i
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+
+	if best == nil {
+		return zero[K, V]()
+	}
+	return best.keys[bestIndex], best.values[bestIndex], true
+}
+
+// All returns an iterator over a snapshot of the key-value pairs in ascending key order.
+// The snapshot is taken when iteration starts, so the map may be modified while iterating.
+func (m *SortedMap[K, V]) All() iter.Seq2[K, V] {
+	return m.Range(_range.Range[K]{})
+}
+
+// Backward returns an iterator over a snapshot of the key-value pairs in descending key order.
+func (m *SortedMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		keys, values := m.collect(_range.Range[K]{})
+		for i := len(keys) - 1; i >= 0; i-- {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over a snapshot of the key-value pairs whose keys lie in r, in ascending key order.
+// Unbounded and exclusive boundaries are supported, e.g. Range(_range.HalfOpen(from, to)).
+func (m *SortedMap[K, V]) Range(r _range.Range[K]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		keys, values := m.collect(r)
+		for i, key := range keys {
+			if !yield(key, values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns the keys in ascending order.
+func (m *SortedMap[K, V]) Keys() []K {
+	keys, _ := m.collect(_range.Range[K]{})
+	return keys
+}
+
+// Values returns the values in ascending order of their keys.
+func (m *SortedMap[K, V]) Values() []V {
+	_, values := m.collect(_range.Range[K]{})
+	return values
+}
+
+// collect copies the pairs whose keys lie in r, in ascending key order.
+func (m *SortedMap[K, V]) collect(r _range.Range[K]) ([]K, []V) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var below, above func(K) bool
+	if r.Min != nil && r.Min.Value != nil {
+		lower, inclusive := *r.Min.Value, r.Min.Inclusive
+		below = func(k K) bool { return m.less(k, lower) || (!inclusive && !m.less(lower, k)) }
+	}
+	if r.Max != nil && r.Max.Value != nil {
+		upper, inclusive := *r.Max.Value, r.Max.Inclusive
+		above = func(k K) bool { return m.less(upper, k) || (!inclusive && !m.less(k, upper)) }
+	}
+
+	keys := make([]K, 0)
+	values := make([]V, 0)
+	m.root.ascend(below, above, func(k K, v V) {
+		keys = append(keys, k)
+		values = append(values, v)
+	})
+	return keys, values
+}
+
+// ascend visits the pairs of the subtree in order, starting at the first key that is not below
+// and stopping at the first key that is above. It returns false once a key above has been seen.
+func (n *node[K, V]) ascend(below, above func(K) bool, visit func(K, V)) bool {
+	i := 0
+	if below != nil {
+		i = sort.Search(len(n.keys), func(j int) bool { return !below(n.keys[j]) })
+	}
+	for ; i <= len(n.keys); i++ {
+		if !n.leaf() && !n.children[i].ascend(below, above, visit) {
+			return false
+		}
+		if i == len(n.keys) {
+			break
+		}
+		if above != nil && above(n.keys[i]) {
+			return false
+		}
+		visit(n.keys[i], n.values[i])
+	}
+	return true
+}
+
+// search returns the index of the first key of n that is not less than key, and whether it equals key.
+func (m *SortedMap[K, V]) search(n *node[K, V], key K) (int, bool) {
+	i := sort.Search(len(n.keys), func(j int) bool { return !m.less(n.keys[j], key) })
+	return i, i < len(n.keys) && !m.less(key, n.keys[i])
+}
+
+// insert sets key in the subtree of n, which is not full, splitting full nodes on the way down.
+func (m *SortedMap[K, V]) insert(n *node[K, V], key K, value V) bool {
+	for {
+		i, found := m.search(n, key)
+		if found {
+			n.values[i] = value
+			return false
+		}
+		if n.leaf() {
+			n.keys = insertAt(n.keys, i, key)
+			n.values = insertAt(n.values, i, value)
+			return true
+		}
+
+		if len(n.children[i].keys) == 2*degree-1 {
+			n.splitChild(i)
+			if !m.less(key, n.keys[i]) {
+				if !m.less(n.keys[i], key) {
+					n.values[i] = value
+					return false
+				}
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+// splitChild splits the full child i of n around its median key, which moves up into n.
+func (n *node[K, V]) splitChild(i int) {
+	child := n.children[i]
+	mid := degree - 1
+
+	right := &node[K, V]{
+		keys:   append([]K(nil), child.keys[mid+1:]...),
+		values: append([]V(nil), child.values[mid+1:]...),
+	}
+	if !child.leaf() {
+		right.children = append([]*node[K, V](nil), child.children[mid+1:]...)
+		clear(child.children[mid+1:])
+		child.children = child.children[:mid+1]
+	}
+
+	n.keys = insertAt(n.keys, i, child.keys[mid])
+	n.values = insertAt(n.values, i, child.values[mid])
+	n.children = insertAt(n.children, i+1, right)
+
+	clear(child.keys[mid:])
+	clear(child.values[mid:])
+	child.keys = child.keys[:mid]
+	child.values = child.values[:mid]
+}
+
+// delete removes key from the subtree of n, making sure every node it descends into has at least degree keys.
+func (m *SortedMap[K, V]) delete(n *node[K, V], key K) bool {
+	for {
+		i, found := m.search(n, key)
+		if n.leaf() {
+			if !found {
+				return false
+			}
+			n.keys = removeAt(n.keys, i)
+			n.values = removeAt(n.values, i)
+			return true
+		}
+
+		if found {
+			left, right := n.children[i], n.children[i+1]
+			switch {
+			case len(left.keys) >= degree:
+				// Replace the key with its predecessor, then delete the predecessor.
+				pred := left.last()
+				n.keys[i], n.values[i] = pred.keys[len(pred.keys)-1], pred.values[len(pred.values)-1]
+				n, key = left, n.keys[i]
+			case len(right.keys) >= degree:
+				// Replace the key with its successor, then delete the successor.
+				succ := right.first()
+				n.keys[i], n.values[i] = succ.keys[0], succ.values[0]
+				n, key = right, n.keys[i]
+			default:
+				n.merge(i)
+				n = left
+			}
+			continue
+		}
+
+		if len(n.children[i].keys) < degree {
+			i = n.fill(i)
+		}
+		n = n.children[i]
+	}
+}
+
+// fill makes sure child i of n has at least degree keys, borrowing from a sibling or merging with one.
+// It returns the index of the child that now holds the keys of child i.
+func (n *node[K, V]) fill(i int) int {
+	child := n.children[i]
+	switch {
+	case i > 0 && len(n.children[i-1].keys) >= degree:
+		// Rotate the last key of the left sibling through n.
+		left := n.children[i-1]
+		last := len(left.keys) - 1
+		child.keys = insertAt(child.keys, 0, n.keys[i-1])
+		child.values = insertAt(child.values, 0, n.values[i-1])
+		n.keys[i-1], n.values[i-1] = left.keys[last], left.values[last]
+		left.keys, left.values = removeAt(left.keys, last), removeAt(left.values, last)
+		if !left.leaf() {
+			child.children = insertAt(child.children, 0, left.children[last+1])
+			left.children = removeAt(left.children, last+1)
+		}
+		return i
+	case i < len(n.keys) && len(n.children[i+1].keys) >= degree:
+		// Rotate the first key of the right sibling through n.
+		right := n.children[i+1]
+		child.keys = append(child.keys, n.keys[i])
+		child.values = append(child.values, n.values[i])
+		n.keys[i], n.values[i] = right.keys[0], right.values[0]
+		right.keys, right.values = removeAt(right.keys, 0), removeAt(right.values, 0)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+		return i
+	case i < len(n.keys):
+		n.merge(i)
+		return i
+	default:
+		n.merge(i - 1)
+		return i - 1
+	}
+}
+
+// merge joins child i+1 of n and the key between them into child i.
+func (n *node[K, V]) merge(i int) {
+	left, right := n.children[i], n.children[i+1]
+	left.keys = append(append(left.keys, n.keys[i]), right.keys...)
+	left.values = append(append(left.values, n.values[i]), right.values...)
+	if !left.leaf() {
+		left.children = append(left.children, right.children...)
+	}
+
+	n.keys = removeAt(n.keys, i)
+	n.values = removeAt(n.values, i)
+	n.children = removeAt(n.children, i+1)
+}
+
+func (n *node[K, V]) leaf() bool {
+	return n.children == nil
+}
+
+// first returns the leaf holding the smallest key of the subtree.
+func (n *node[K, V]) first() *node[K, V] {
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n
+}
+
+// last returns the leaf holding the largest key of the subtree.
+func (n *node[K, V]) last() *node[K, V] {
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n
+}
+
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+// removeAt removes element i, zeroing the freed slot so the garbage collector can reclaim it.
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
+
+func zero[K, V any]() (K, V, bool) {
+	var (
+		k K
+		v V
+	)
+	return k, v, false
+}
@@ -0,0 +1,267 @@
+package sortedmap
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dullkingsman/kozo/cmpx"
+	_range "github.com/dullkingsman/kozo/range"
+)
+
+// checkInvariants verifies the B-tree properties: sorted keys, node sizes and uniform leaf depth.
+func checkInvariants[K, V any](t *testing.T, m *SortedMap[K, V]) {
+	t.Helper()
+	leafDepth := -1
+	count := 0
+	var walk func(n *node[K, V], depth int, root bool)
+	walk = func(n *node[K, V], depth int, root bool) {
+		count += len(n.keys)
+		if len(n.keys) != len(n.values) {
+			t.Fatalf("Node has %d keys but %d values", len(n.keys), len(n.values))
+		}
+		if len(n.keys) > 2*degree-1 || (!root && len(n.keys) < degree-1) {
+			t.Fatalf("Node has %d keys", len(n.keys))
+		}
+		for i := 1; i < len(n.keys); i++ {
+			if !m.less(n.keys[i-1], n.keys[i]) {
+				t.Fatal("Node keys are not sorted")
+			}
+		}
+		if n.leaf() {
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				t.Fatalf("Leaves at depths %d and %d", leafDepth, depth)
+			}
+			return
+		}
+		if len(n.children) != len(n.keys)+1 {
+			t.Fatalf("Node has %d keys but %d children", len(n.keys), len(n.children))
+		}
+		for _, c := range n.children {
+			walk(c, depth+1, false)
+		}
+	}
+	walk(m.root, 0, true)
+	if count != m.size {
+		t.Fatalf("Tree holds %d keys but size is %d", count, m.size)
+	}
+}
+
+func TestSortedMap_Basic(t *testing.T) {
+	m := NewOrdered[string, int]()
+	if !m.IsEmpty() {
+		t.Error("Expected new map to be empty")
+	}
+	if _, _, ok := m.Min(); ok {
+		t.Error("Expected Min of an empty map to fail")
+	}
+
+	for i, k := range []string{"m", "c", "x", "a"} {
+		if !m.Set(k, i) {
+			t.Errorf("Expected %q to be new", k)
+		}
+	}
+	if m.Set("c", 10) {
+		t.Error("Expected an existing key not to be new")
+	}
+
+	if v, ok := m.Get("c"); !ok || v != 10 {
+		t.Errorf("Get(c) = %d, %v", v, ok)
+	}
+	if m.Has("b") {
+		t.Error("Expected b to be missing")
+	}
+	if got := m.Keys(); !slices.Equal(got, []string{"a", "c", "m", "x"}) {
+		t.Errorf("Keys() = %v", got)
+	}
+	if k, _, _ := m.Min(); k != "a" {
+		t.Errorf("Min() = %s", k)
+	}
+	if k, _, _ := m.Max(); k != "x" {
+		t.Errorf("Max() = %s", k)
+	}
+
+	if !m.Delete("m") || m.Delete("m") || m.Len() != 3 {
+		t.Error("Expected Delete to remove m once")
+	}
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("Expected Clear to empty the map")
+	}
+}
+
+func TestSortedMap_FloorCeiling(t *testing.T) {
+	m := NewOrdered[int, string]()
+	for i := 0; i < 1000; i += 10 {
+		m.Set(i, "")
+	}
+
+	tests := []struct {
+		key            int
+		floor, ceiling int
+		hasF, hasC     bool
+	}{
+		{-5, 0, 0, false, true},
+		{0, 0, 0, true, true},
+		{15, 10, 20, true, true},
+		{500, 500, 500, true, true},
+		{995, 990, 0, true, false},
+	}
+	for _, tt := range tests {
+		if k, _, ok := m.Floor(tt.key); ok != tt.hasF || (ok && k != tt.floor) {
+			t.Errorf("Floor(%d) = %d, %v", tt.key, k, ok)
+		}
+		if k, _, ok := m.Ceiling(tt.key); ok != tt.hasC || (ok && k != tt.ceiling) {
+			t.Errorf("Ceiling(%d) = %d, %v", tt.key, k, ok)
+		}
+	}
+}
+
+func TestSortedMap_Range(t *testing.T) {
+	m := NewOrdered[int, int]()
+	for i := range 500 {
+		m.Set(i, i*i)
+	}
+
+	collect := func(r _range.Range[int]) []int {
+		var keys []int
+		for k, v := range m.Range(r) {
+			if v != k*k {
+				t.Fatalf("Value of %d is %d", k, v)
+			}
+			keys = append(keys, k)
+		}
+		return keys
+	}
+
+	if got := collect(_range.HalfOpen(100, 104)); !slices.Equal(got, []int{100, 101, 102, 103}) {
+		t.Errorf("HalfOpen = %v", got)
+	}
+	if got := collect(_range.Open(100, 103)); !slices.Equal(got, []int{101, 102}) {
+		t.Errorf("Open = %v", got)
+	}
+	if got := collect(_range.AtLeast(497)); !slices.Equal(got, []int{497, 498, 499}) {
+		t.Errorf("AtLeast = %v", got)
+	}
+	if got := collect(_range.LessThan(2)); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("LessThan = %v", got)
+	}
+	if got := collect(_range.Closed(600, 700)); len(got) != 0 {
+		t.Errorf("Out of bounds = %v", got)
+	}
+
+	var backward []int
+	for k := range m.Backward() {
+		backward = append(backward, k)
+		if len(backward) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(backward, []int{499, 498, 497}) {
+		t.Errorf("Backward() = %v", backward)
+	}
+}
+
+func TestSortedMap_Random(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	m := NewOrdered[int, int]()
+	ref := make(map[int]int)
+
+	for i := range 20000 {
+		k := rng.Intn(3000)
+		if rng.Intn(3) == 0 {
+			_, had := ref[k]
+			if m.Delete(k) != had {
+				t.Fatalf("Delete(%d) disagrees with the reference", k)
+			}
+			delete(ref, k)
+		} else {
+			_, had := ref[k]
+			if m.Set(k, i) == had {
+				t.Fatalf("Set(%d) disagrees with the reference", k)
+			}
+			ref[k] = i
+		}
+		if i%1000 == 0 {
+			checkInvariants(t, m)
+		}
+	}
+	checkInvariants(t, m)
+
+	keys := m.Keys()
+	if len(keys) != len(ref) || !slices.IsSorted(keys) {
+		t.Fatalf("Keys() has %d sorted=%v keys, want %d", len(keys), slices.IsSorted(keys), len(ref))
+	}
+	for k, v := range ref {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("Get(%d) = %d, %v; want %d", k, got, ok, v)
+		}
+	}
+
+	for k := range ref {
+		m.Delete(k)
+	}
+	checkInvariants(t, m)
+	if !m.IsEmpty() {
+		t.Errorf("Expected the map to be empty, has %d keys", m.Len())
+	}
+}
+
+func TestSortedMap_CustomLess(t *testing.T) {
+	m := New[string, int](func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) })
+	m.Set("b", 1)
+	m.Set("A", 2)
+	m.Set("B", 3) // equal to "b" under the ordering
+
+	if m.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", m.Len())
+	}
+	if v, _ := m.Get("b"); v != 3 {
+		t.Errorf("Get(b) = %d, want 3", v)
+	}
+}
+
+func TestSortedMap_NewFunc(t *testing.T) {
+	m := NewFunc[string, int](cmpx.ByFunc(strings.ToLower, cmpx.Natural[string]()).Reverse())
+	m.Set("a", 1)
+	m.Set("C", 3)
+	m.Set("b", 2)
+	m.Set("c", 4) // equivalent to "C" under the ordering
+	checkInvariants(t, m)
+
+	if keys := m.Keys(); !slices.Equal(keys, []string{"C", "b", "a"}) {
+		t.Errorf("Keys() = %v, want [C b a]", keys)
+	}
+	if v, _ := m.Get("C"); v != 4 {
+		t.Errorf("Get(C) = %d, want 4", v)
+	}
+}
+
+func TestSortedMap_Concurrency(t *testing.T) {
+	m := NewOrdered[int, int]()
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				k := g*1000 + i
+				m.Set(k, i)
+				m.Get(k)
+				if i%2 == 0 {
+					m.Delete(k)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if m.Len() != 8*250 {
+		t.Errorf("Len() = %d, want %d", m.Len(), 8*250)
+	}
+	checkInvariants(t, m)
+}
@@ -0,0 +1,109 @@
+package sqlmap
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// mapping maps column names onto the fields of a struct type.
+type mapping struct {
+	fields map[string][]int // column name → field index path
+	order  []string         // column names in declaration order
+}
+
+// mappings caches mappings by struct type.
+var mappings sync.Map
+
+// mappingFor resolves, and caches, the columns of a struct type t.
+//
+// A field's column is named by its `db` tag, as in sqlx, or the column of its `gorm:"column:..."` tag,
+// falling back to the snake_case of its Go name. A tag of "-" skips the field. The fields of embedded
+// structs are promoted, as in Go.
+func mappingFor(t reflect.Type) (*mapping, error) {
+	if m, ok := mappings.Load(t); ok {
+		return m.(*mapping), nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("sqlmap: %v is not a struct", t)
+	}
+
+	m := &mapping{fields: make(map[string][]int)}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || (f.Anonymous && indirectType(f.Type).Kind() == reflect.Struct) {
+			continue
+		}
+		name := columnName(f)
+		if name == "-" {
+			continue
+		}
+		if _, ok := m.fields[name]; ok {
+			return nil, fmt.Errorf("sqlmap: %v maps column %q twice", t, name)
+		}
+		m.fields[name] = f.Index
+		m.order = append(m.order, name)
+	}
+
+	mappings.Store(t, m)
+	return m, nil
+}
+
+// columnName returns the column a struct field maps to.
+func columnName(f reflect.StructField) string {
+	if tag, ok := f.Tag.Lookup("db"); ok {
+		if name, _, _ := strings.Cut(tag, ","); name != "" {
+			return name
+		}
+	}
+	if tag, ok := f.Tag.Lookup("gorm"); ok {
+		if tag == "-" {
+			return "-"
+		}
+		for setting := range strings.SplitSeq(tag, ";") {
+			if name, ok := strings.CutPrefix(strings.TrimSpace(setting), "column:"); ok && name != "" {
+				return name
+			}
+		}
+	}
+	return snakeCase(f.Name)
+}
+
+// snakeCase converts a Go name to snake_case, keeping initialisms together: "UserID" → "user_id".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// fieldForSet returns the field at index in the struct v, allocating nil embedded struct pointers on the way.
+func fieldForSet(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
@@ -0,0 +1,150 @@
+package sqlmap
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/dullkingsman/kozo/dialect"
+)
+
+// isNoner is implemented by optional.Optional[T].
+type isNoner interface {
+	IsNone() bool
+}
+
+// Named rewrites the named parameters of a query, written ":name" as in sqlx, to the placeholders of d
+// and returns the query with its arguments taken from arg, in order of appearance.
+//
+// arg is a struct, or pointer to one, or a map[string]any. A struct field is named by its `db` tag,
+// the column of its `gorm:"column:..."` tag, or else the snake_case of its Go name.
+// An optional.Optional field that is Some(nil) binds as NULL; one that is None is an error,
+// since leaving a value out must be decided when writing the query.
+//
+// Quoted strings and identifiers, comments and PostgreSQL "::" casts are left untouched.
+func Named(query string, arg any, d dialect.Dialect) (string, []any, error) {
+	lookup, err := namedLookup(arg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var (
+		b    strings.Builder
+		args []any
+	)
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := closingQuote(query, i)
+			b.WriteString(query[i:end])
+			i = end
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+		case strings.HasPrefix(query[i:], "::"):
+			b.WriteString("::")
+			i += 2
+		case c == ':' && i+1 < len(query) && isNameStart(query[i+1]):
+			end := i + 1
+			for end < len(query) && isNamePart(query[end]) {
+				end++
+			}
+			name := query[i+1 : end]
+			value, err := lookup(name)
+			if err != nil {
+				return "", nil, err
+			}
+			args = append(args, value)
+			b.WriteString(d.Placeholder(len(args)))
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+
+	return b.String(), args, nil
+}
+
+// namedLookup returns a function resolving parameter names against arg.
+func namedLookup(arg any) (func(name string) (any, error), error) {
+	if values, ok := arg.(map[string]any); ok {
+		return func(name string) (any, error) {
+			value, ok := values[name]
+			if !ok {
+				return nil, fmt.Errorf("sqlmap: no value for parameter :%s", name)
+			}
+			return value, nil
+		}, nil
+	}
+
+	v := reflect.ValueOf(arg)
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, fmt.Errorf("sqlmap: nil %T argument", arg)
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, fmt.Errorf("sqlmap: %T argument is not a struct or map[string]any", arg)
+	}
+	m, err := mappingFor(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	return func(name string) (any, error) {
+		index, ok := m.fields[name]
+		if !ok {
+			return nil, fmt.Errorf("sqlmap: %v has no field for parameter :%s", v.Type(), name)
+		}
+		field, err := v.FieldByIndexErr(index)
+		if err != nil {
+			return nil, fmt.Errorf("sqlmap: parameter :%s: %w", name, err)
+		}
+		value := field.Interface()
+		if o, ok := value.(isNoner); ok && o.IsNone() {
+			return nil, fmt.Errorf("sqlmap: parameter :%s is None", name)
+		}
+		return value, nil
+	}, nil
+}
+
+// closingQuote returns the index after the quoted section starting at query[start],
+// treating a doubled quote as an escaped one.
+func closingQuote(query string, start int) int {
+	quote := query[start]
+	for i := start + 1; i < len(query); i++ {
+		if query[i] != quote {
+			continue
+		}
+		if i+1 < len(query) && query[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(query)
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNamePart(c byte) bool {
+	return isNameStart(c) || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,67 @@
+package sqlmap
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dullkingsman/kozo/dialect"
+	optional "github.com/dullkingsman/kozo/optional"
+)
+
+type accountParams struct {
+	ID       int64
+	Name     optional.Optional[string]
+	Nickname optional.Optional[string]
+}
+
+func TestNamed(t *testing.T) {
+	params := accountParams{ID: 7, Name: optional.Some("ann"), Nickname: optional.Null[string]()}
+	query, args, err := Named(
+		`UPDATE accounts SET name = :name, nickname = :nickname, note = ':id' -- :id
+WHERE id = :id AND created_at::date > now()::date /* :name */`,
+		&params, dialect.PostgreSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `UPDATE accounts SET name = $1, nickname = $2, note = ':id' -- :id
+WHERE id = $3 AND created_at::date > now()::date /* :name */`
+	if query != expected {
+		t.Errorf("Named() query = %q, want %q", query, expected)
+	}
+	if !reflect.DeepEqual(args, []any{params.Name, params.Nickname, int64(7)}) {
+		t.Errorf("Named() args = %v", args)
+	}
+}
+
+func TestNamed_Map(t *testing.T) {
+	query, args, err := Named("SELECT * FROM t WHERE a = :a OR b = :a", map[string]any{"a": 1}, dialect.MySQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "SELECT * FROM t WHERE a = ? OR b = ?" || !reflect.DeepEqual(args, []any{1, 1}) {
+		t.Errorf("Named() = %q, %v", query, args)
+	}
+}
+
+func TestNamed_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		arg   any
+	}{
+		{"None", "SELECT :name", accountParams{}},
+		{"Unknown field", "SELECT :email", accountParams{}},
+		{"Unknown key", "SELECT :b", map[string]any{"a": 1}},
+		{"Nil", "SELECT :id", (*accountParams)(nil)},
+		{"Not a struct", "SELECT :id", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := Named(tt.query, tt.arg, dialect.MySQL); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package sqlmap
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+)
+
+// Row is the scanning interface shared by *sql.Row and *sql.Rows.
+type Row interface {
+	Scan(dest ...any) error
+}
+
+// RowScanner scans rows of a result set into structs of type T, matching columns to fields once.
+// Fields of type optional.Optional map NULL to Some(nil), and fields whose column is not selected
+// keep their zero value, which for an Optional is None.
+type RowScanner[T any] struct {
+	fields [][]int // field index path per column, nil for discarded columns
+}
+
+// NewRowScanner matches the columns of a result set, as returned by (*sql.Rows).Columns, to the fields of T,
+// which must be a struct. See Named for how fields are named. A column without a matching field is an error,
+// so that typos in queries fail loudly.
+func NewRowScanner[T any](columns []string) (*RowScanner[T], error) {
+	m, err := mappingFor(reflect.TypeFor[T]())
+	if err != nil {
+		return nil, err
+	}
+
+	s := &RowScanner[T]{fields: make([][]int, len(columns))}
+	for i, column := range columns {
+		index, ok := m.fields[column]
+		if !ok {
+			return nil, fmt.Errorf("sqlmap: %v has no field for column %q", reflect.TypeFor[T](), column)
+		}
+		s.fields[i] = index
+	}
+	return s, nil
+}
+
+// Scan scans the current row into dst. Fields without a column are left untouched.
+func (s *RowScanner[T]) Scan(row Row, dst *T) error {
+	v := reflect.ValueOf(dst).Elem()
+	dest := make([]any, len(s.fields))
+	for i, index := range s.fields {
+		dest[i] = fieldForSet(v, index).Addr().Interface()
+	}
+	return row.Scan(dest...)
+}
+
+// ScanAll scans every remaining row into a new T and closes rows.
+func ScanAll[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	s, err := NewRowScanner[T](columns)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]T, 0)
+	for rows.Next() {
+		var item T
+		if err := s.Scan(rows, &item); err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return res, rows.Close()
+}
+
+// ScanOne scans the first row into a new T and closes rows.
+// It returns sql.ErrNoRows if the result set is empty.
+func ScanOne[T any](rows *sql.Rows) (T, error) {
+	var item T
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return item, err
+	}
+	s, err := NewRowScanner[T](columns)
+	if err != nil {
+		return item, err
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return item, err
+		}
+		return item, sql.ErrNoRows
+	}
+	if err := s.Scan(rows, &item); err != nil {
+		return item, err
+	}
+	return item, rows.Close()
+}
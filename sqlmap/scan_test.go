@@ -0,0 +1,146 @@
+package sqlmap
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	optional "github.com/dullkingsman/kozo/optional"
+)
+
+// fakeDriver serves a fixed result set for every query.
+type fakeDriver struct{}
+
+type fakeConn struct{}
+
+type fakeStmt struct{}
+
+type fakeRows struct {
+	next int
+}
+
+var (
+	fakeColumns = []string{"id", "name", "nickname", "created_at"}
+	fakeValues  = [][]driver.Value{
+		{int64(1), "ann", "annie", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{int64(2), "bob", nil, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+)
+
+func (fakeDriver) Open(string) (driver.Conn, error)         { return fakeConn{}, nil }
+func (fakeConn) Prepare(string) (driver.Stmt, error)        { return fakeStmt{}, nil }
+func (fakeConn) Close() error                               { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                  { return nil, errors.New("not supported") }
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, errors.New("not supported") }
+func (fakeStmt) Query([]driver.Value) (driver.Rows, error)  { return &fakeRows{}, nil }
+func (*fakeRows) Columns() []string                         { return fakeColumns }
+func (*fakeRows) Close() error                              { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(fakeValues) {
+		return io.EOF
+	}
+	copy(dest, fakeValues[r.next])
+	r.next++
+	return nil
+}
+
+func init() {
+	sql.Register("sqlmap-fake", fakeDriver{})
+}
+
+type base struct {
+	ID int64
+}
+
+type account struct {
+	base
+	Name      string
+	Nickname  optional.Optional[string]
+	Email     optional.Optional[string] // never selected
+	CreatedAt time.Time                 `db:"created_at"`
+}
+
+func query(t *testing.T) *sql.Rows {
+	t.Helper()
+	db, err := sql.Open("sqlmap-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query("SELECT id, name, nickname, created_at FROM accounts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestScanAll(t *testing.T) {
+	accounts, err := ScanAll[account](query(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 2 {
+		t.Fatalf("Expected 2 accounts, got %d", len(accounts))
+	}
+
+	ann, bob := accounts[0], accounts[1]
+	if ann.ID != 1 || ann.Name != "ann" || ann.Nickname.UnwrapOr("") != "annie" || !ann.CreatedAt.Equal(fakeValues[0][3].(time.Time)) {
+		t.Errorf("Unexpected first row %+v", ann)
+	}
+	if !bob.Nickname.IsSome() || !bob.Nickname.IsNull() {
+		t.Errorf("Expected a NULL column to scan as Some(null), got %v", bob.Nickname)
+	}
+	if !ann.Email.IsNone() || !bob.Email.IsNone() {
+		t.Error("Expected a column that is not selected to leave None")
+	}
+}
+
+func TestScanOne(t *testing.T) {
+	a, err := ScanOne[*account](query(t))
+	if err == nil || a != nil {
+		t.Error("Expected a pointer type to be rejected")
+	}
+
+	one, err := ScanOne[account](query(t))
+	if err != nil || one.Name != "ann" {
+		t.Errorf("ScanOne() = %+v, %v", one, err)
+	}
+}
+
+func TestNewRowScanner(t *testing.T) {
+	type gormModel struct {
+		UserID   int64  `gorm:"column:uid;primaryKey"`
+		Ignored  string `db:"-"`
+		HTTPCode int
+	}
+	if _, err := NewRowScanner[gormModel]([]string{"uid", "http_code"}); err != nil {
+		t.Errorf("NewRowScanner() failed: %v", err)
+	}
+	if _, err := NewRowScanner[gormModel]([]string{"uid", "-"}); err == nil {
+		t.Error("Expected an error for a skipped field's column")
+	}
+	if _, err := NewRowScanner[account]([]string{"id", "unknown"}); err == nil {
+		t.Error("Expected an error for a column without a field")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, expected := range map[string]string{
+		"ID":        "id",
+		"UserID":    "user_id",
+		"CreatedAt": "created_at",
+		"HTTPCode":  "http_code",
+		"Address2":  "address2",
+		"name":      "name",
+	} {
+		if got := snakeCase(in); got != expected {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, expected)
+		}
+	}
+}
@@ -1,7 +1,12 @@
 package stack
 
 import (
+	"fmt"
+	"iter"
 	"sync"
+
+	"github.com/dullkingsman/kozo/codec"
+	"github.com/dullkingsman/kozo/collection"
 )
 
 // Stack is a thread-safe LIFO data structure.
@@ -10,6 +15,11 @@ type Stack[T any] struct {
 	elements []T
 }
 
+var (
+	_ codec.Snapshotter          = (*Stack[int])(nil)
+	_ collection.Collection[int] = (*Stack[int])(nil)
+)
+
 // New returns a new empty Stack.
 func New[T any]() *Stack[T] {
 	return &Stack[T]{}
@@ -22,11 +32,15 @@ func NewWithCapacity[T any](capacity int) *Stack[T] {
 	}
 }
 
-// Push adds an element to the top of the stack.
-func (s *Stack[T]) Push(v T) {
+// Push adds one or more elements to the top of the stack.
+// Elements are pushed in order, so the last item ends up on top.
+func (s *Stack[T]) Push(items ...T) {
+	if len(items) == 0 {
+		return
+	}
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.elements = append(s.elements, v)
+	s.elements = append(s.elements, items...)
 }
 
 // Pop removes and returns the top element of the stack.
@@ -52,6 +66,33 @@ func (s *Stack[T]) Pop() (T, bool) {
 	return v, true
 }
 
+// PopN removes and returns up to n elements from the top of the stack, topmost first.
+// Returns an empty slice if the stack is empty or n is not positive.
+func (s *Stack[T]) PopN(n int) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := len(s.elements)
+	if n > l {
+		n = l
+	}
+	if n <= 0 {
+		return []T{}
+	}
+
+	res := make([]T, n)
+	var zero T
+	for i := 0; i < n; i++ {
+		index := l - 1 - i
+		res[i] = s.elements[index]
+		// Zero out the element to prevent memory leaks (GC can reclaim it)
+		s.elements[index] = zero
+	}
+	s.elements = s.elements[:l-n]
+
+	return res
+}
+
 // Peek returns the top element of the stack without removing it.
 // Returns (zero-value, false) if the stack is empty.
 func (s *Stack[T]) Peek() (T, bool) {
@@ -67,6 +108,51 @@ func (s *Stack[T]) Peek() (T, bool) {
 	return s.elements[l-1], true
 }
 
+// PeekN returns up to n elements from the top of the stack without removing them, topmost first.
+// Returns an empty slice if the stack is empty or n is not positive.
+func (s *Stack[T]) PeekN(n int) []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := len(s.elements)
+	if n > l {
+		n = l
+	}
+	if n <= 0 {
+		return []T{}
+	}
+
+	res := make([]T, n)
+	for i := 0; i < n; i++ {
+		res[i] = s.elements[l-1-i]
+	}
+
+	return res
+}
+
+// Contains returns true if the stack contains an element equal to v according to equals.
+func (s *Stack[T]) Contains(v T, equals func(T, T) bool) bool {
+	_, ok := s.Search(func(item T) bool {
+		return equals(item, v)
+	})
+	return ok
+}
+
+// Search returns the depth of the topmost element that satisfies pred, where 0 is the top.
+// Returns (-1, false) if no element matches.
+func (s *Stack[T]) Search(pred func(T) bool) (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := len(s.elements)
+	for depth := 0; depth < l; depth++ {
+		if pred(s.elements[l-1-depth]) {
+			return depth, true
+		}
+	}
+	return -1, false
+}
+
 // IsEmpty returns true if the stack has no elements.
 func (s *Stack[T]) IsEmpty() bool {
 	s.mu.Lock()
@@ -81,6 +167,91 @@ func (s *Stack[T]) Len() int {
 	return len(s.elements)
 }
 
+// Drain removes and returns all elements of the stack, topmost first.
+// The stack is emptied atomically.
+func (s *Stack[T]) Drain() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := len(s.elements)
+	res := make([]T, l)
+	var zero T
+	for i := 0; i < l; i++ {
+		res[i] = s.elements[l-1-i]
+		// Zero out the element to prevent memory leaks (GC can reclaim it)
+		s.elements[l-1-i] = zero
+	}
+	s.elements = s.elements[:0]
+
+	return res
+}
+
+// Reverse reverses the order of the elements in place, so the bottom element becomes the top.
+func (s *Stack[T]) Reverse() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, j := 0, len(s.elements)-1; i < j; i, j = i+1, j-1 {
+		s.elements[i], s.elements[j] = s.elements[j], s.elements[i]
+	}
+}
+
+// Rotate rotates the top n elements in place, moving the n-th element from the top to the top
+// and shifting the ones above it down by one. Rotate(2) is equivalent to Swap.
+// Returns false if the stack has fewer than n elements or n is negative.
+func (s *Stack[T]) Rotate(n int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l := len(s.elements)
+	if n < 0 || n > l {
+		return false
+	}
+	if n < 2 {
+		return true
+	}
+
+	v := s.elements[l-n]
+	copy(s.elements[l-n:], s.elements[l-n+1:])
+	s.elements[l-1] = v
+	return true
+}
+
+// Cap returns the capacity of the underlying slice.
+func (s *Stack[T]) Cap() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return cap(s.elements)
+}
+
+// Reserve grows the underlying slice, if necessary, so that n more elements can be pushed without re-allocation.
+func (s *Stack[T]) Reserve(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if n <= cap(s.elements)-len(s.elements) {
+		return
+	}
+
+	elements := make([]T, len(s.elements), len(s.elements)+n)
+	copy(elements, s.elements)
+	s.elements = elements
+}
+
+// Compact shrinks the underlying slice to fit the current elements, releasing memory held after bursts.
+func (s *Stack[T]) Compact() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.elements) == cap(s.elements) {
+		return
+	}
+
+	elements := make([]T, len(s.elements))
+	copy(elements, s.elements)
+	s.elements = elements
+}
+
 // Clear discards all elements from the stack.
 func (s *Stack[T]) Clear() {
 	s.mu.Lock()
@@ -108,3 +279,77 @@ func (s *Stack[T]) Swap() bool {
 	s.elements[l-1], s.elements[l-2] = s.elements[l-2], s.elements[l-1]
 	return true
 }
+
+// ToSlice returns a copy of the elements of the stack ordered from bottom to top,
+// i.e. in push order, so the last element is the one Pop would return.
+func (s *Stack[T]) ToSlice() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]T, len(s.elements))
+	copy(res, s.elements)
+	return res
+}
+
+// All returns an iterator over a snapshot of the stack, from top to bottom (pop order).
+// The snapshot is taken when iteration starts, so the stack may be modified while iterating.
+func (s *Stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		snapshot := s.ToSlice()
+		for i := len(snapshot) - 1; i >= 0; i-- {
+			if !yield(snapshot[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a new Stack with the same elements.
+//
+// Note: For pointer or reference types (slices, maps), only the references are copied.
+func (s *Stack[T]) Clone() *Stack[T] {
+	return &Stack[T]{
+		elements: s.ToSlice(),
+	}
+}
+
+// MarshalJSON encodes the stack as a JSON array ordered from bottom to top,
+// encoding each element with the codec registered for T.
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalSlice(s.ToSlice())
+}
+
+// UnmarshalJSON replaces the contents of the stack with the elements of a JSON array
+// ordered from bottom to top, decoding each element with the codec registered for T.
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	items, err := codec.UnmarshalSlice[T](data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.elements = items
+	return nil
+}
+
+// Snapshot encodes the stack as its JSON array in a snapshot envelope of kind "stack"; see codec.Snapshotter.
+func (s *Stack[T]) Snapshot() ([]byte, error) {
+	payload, err := s.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	return codec.EncodeSnapshot("stack", payload), nil
+}
+
+// Restore replaces the contents of the stack with those of a snapshot made by Snapshot.
+func (s *Stack[T]) Restore(data []byte) error {
+	payload, err := codec.DecodeSnapshot("stack", data)
+	if err != nil {
+		return err
+	}
+	if err := s.UnmarshalJSON(payload); err != nil {
+		return fmt.Errorf("%w: %v", codec.ErrInvalidSnapshot, err)
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package stack
 
 import (
+	"encoding/json"
+	"errors"
 	"sync"
 	"testing"
+
+	"github.com/dullkingsman/kozo/codec"
 )
 
 func TestStack(t *testing.T) {
@@ -96,3 +100,239 @@ func TestStackConcurrency(t *testing.T) {
 		t.Errorf("Expected length 0 after popping all, got %d", s.Len())
 	}
 }
+
+func TestStackBatch(t *testing.T) {
+	s := New[int]()
+	s.Push(1, 2, 3, 4)
+	s.Push()
+
+	if s.Len() != 4 {
+		t.Errorf("Expected size 4, got %d", s.Len())
+	}
+
+	peeked := s.PeekN(2)
+	if len(peeked) != 2 || peeked[0] != 4 || peeked[1] != 3 {
+		t.Errorf("PeekN(2) expected [4 3], got %v", peeked)
+	}
+	if s.Len() != 4 {
+		t.Errorf("PeekN should not remove elements, got size %d", s.Len())
+	}
+
+	popped := s.PopN(3)
+	if len(popped) != 3 || popped[0] != 4 || popped[1] != 3 || popped[2] != 2 {
+		t.Errorf("PopN(3) expected [4 3 2], got %v", popped)
+	}
+
+	popped = s.PopN(10)
+	if len(popped) != 1 || popped[0] != 1 {
+		t.Errorf("PopN(10) expected [1], got %v", popped)
+	}
+
+	if got := s.PopN(1); len(got) != 0 {
+		t.Errorf("Expected empty PopN result, got %v", got)
+	}
+	if got := s.PeekN(-1); len(got) != 0 {
+		t.Errorf("Expected empty PeekN result, got %v", got)
+	}
+}
+
+func TestStackToSliceAndAll(t *testing.T) {
+	s := New[int]()
+	s.Push(1, 2, 3)
+
+	slice := s.ToSlice()
+	if len(slice) != 3 || slice[0] != 1 || slice[1] != 2 || slice[2] != 3 {
+		t.Errorf("ToSlice expected [1 2 3], got %v", slice)
+	}
+
+	var got []int
+	for v := range s.All() {
+		got = append(got, v)
+		s.Push(v * 10) // mutating during iteration must not affect the snapshot
+	}
+	if len(got) != 3 || got[0] != 3 || got[1] != 2 || got[2] != 1 {
+		t.Errorf("All expected [3 2 1], got %v", got)
+	}
+
+	got = got[:0]
+	for v := range s.All() {
+		got = append(got, v)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if len(got) != 2 || got[0] != 10 || got[1] != 20 {
+		t.Errorf("Expected early stop after [10 20], got %v", got)
+	}
+
+	if s.Len() != 6 {
+		t.Errorf("Expected size 6, got %d", s.Len())
+	}
+}
+
+func TestStackClone(t *testing.T) {
+	s := New[int]()
+	s.Push(1, 2)
+
+	c := s.Clone()
+	c.Push(3)
+	s.Pop()
+
+	if got := c.ToSlice(); len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("Clone expected [1 2 3], got %v", got)
+	}
+	if got := s.ToSlice(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("Original expected [1], got %v", got)
+	}
+}
+
+func TestStackJSON(t *testing.T) {
+	s := New[int]()
+	s.Push(1, 2, 3)
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != "[1,2,3]" {
+		t.Errorf("Expected bottom-to-top [1,2,3], got %s", data)
+	}
+
+	var s2 Stack[int]
+	if err := json.Unmarshal(data, &s2); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+
+	for _, exp := range []int{3, 2, 1} {
+		v, ok := s2.Pop()
+		if !ok || v != exp {
+			t.Errorf("Expected %d, got %v", exp, v)
+		}
+	}
+
+	if err := json.Unmarshal([]byte("null"), &s2); err != nil || !s2.IsEmpty() {
+		t.Errorf("Expected empty stack from null, got %v (err: %v)", s2.ToSlice(), err)
+	}
+}
+
+func TestStackSnapshot(t *testing.T) {
+	s := New[int]()
+	s.Push(1, 2, 3)
+
+	data, err := s.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+	s2 := New[int]()
+	s2.Push(9)
+	if err := s2.Restore(data); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if v, ok := s2.Peek(); !ok || v != 3 || s2.Len() != 3 {
+		t.Errorf("Expected 3 elements with 3 on top, got %v", s2.ToSlice())
+	}
+
+	if err := s2.Restore(data[1:]); !errors.Is(err, codec.ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot, got %v", err)
+	}
+	if err := s2.Restore(codec.EncodeSnapshot("stack", []byte(`{"a":1}`))); !errors.Is(err, codec.ErrInvalidSnapshot) {
+		t.Errorf("Expected ErrInvalidSnapshot for a bad payload, got %v", err)
+	}
+	if s2.Len() != 3 {
+		t.Errorf("Expected a failed Restore to keep the stack, got %v", s2.ToSlice())
+	}
+}
+
+func TestStackCapacity(t *testing.T) {
+	s := New[int]()
+	s.Reserve(100)
+	if s.Cap() < 100 {
+		t.Errorf("Expected capacity of at least 100, got %d", s.Cap())
+	}
+
+	s.Push(1, 2, 3)
+	before := s.Cap()
+	s.Reserve(10)
+	if s.Cap() != before {
+		t.Errorf("Reserve should not reallocate when space is available, got %d -> %d", before, s.Cap())
+	}
+
+	s.Compact()
+	if s.Cap() != 3 {
+		t.Errorf("Expected capacity 3 after Compact, got %d", s.Cap())
+	}
+
+	if got := s.ToSlice(); len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Errorf("Compact should keep elements, got %v", got)
+	}
+
+	s.Clear()
+	s.Compact()
+	if s.Cap() != 0 {
+		t.Errorf("Expected capacity 0 after Clear and Compact, got %d", s.Cap())
+	}
+}
+
+func TestStackContainsAndSearch(t *testing.T) {
+	equals := func(a, b string) bool { return a == b }
+
+	s := New[string]()
+	s.Push("global", "outer", "inner", "outer")
+
+	if !s.Contains("global", equals) {
+		t.Error("Expected stack to contain global")
+	}
+	if s.Contains("missing", equals) {
+		t.Error("Expected stack not to contain missing")
+	}
+
+	depth, ok := s.Search(func(v string) bool { return v == "outer" })
+	if !ok || depth != 0 {
+		t.Errorf("Expected topmost outer at depth 0, got %d (ok: %v)", depth, ok)
+	}
+
+	depth, ok = s.Search(func(v string) bool { return v == "global" })
+	if !ok || depth != 3 {
+		t.Errorf("Expected global at depth 3, got %d (ok: %v)", depth, ok)
+	}
+
+	if depth, ok := s.Search(func(string) bool { return false }); ok || depth != -1 {
+		t.Errorf("Expected (-1, false), got (%d, %v)", depth, ok)
+	}
+}
+
+func TestStackDrainReverseRotate(t *testing.T) {
+	s := New[int]()
+	s.Push(1, 2, 3, 4)
+
+	s.Reverse()
+	if got := s.ToSlice(); len(got) != 4 || got[0] != 4 || got[3] != 1 {
+		t.Errorf("Reverse expected [4 3 2 1], got %v", got)
+	}
+	s.Reverse()
+
+	if !s.Rotate(3) {
+		t.Error("Expected Rotate(3) to succeed")
+	}
+	if got := s.ToSlice(); len(got) != 4 || got[0] != 1 || got[1] != 3 || got[2] != 4 || got[3] != 2 {
+		t.Errorf("Rotate(3) expected [1 3 4 2], got %v", got)
+	}
+
+	if s.Rotate(5) || s.Rotate(-1) {
+		t.Error("Expected Rotate to fail for out-of-range n")
+	}
+	if !s.Rotate(0) || !s.Rotate(1) {
+		t.Error("Expected Rotate(0) and Rotate(1) to be no-ops")
+	}
+
+	drained := s.Drain()
+	if len(drained) != 4 || drained[0] != 2 || drained[1] != 4 || drained[2] != 3 || drained[3] != 1 {
+		t.Errorf("Drain expected [2 4 3 1], got %v", drained)
+	}
+	if !s.IsEmpty() {
+		t.Error("Expected empty stack after Drain")
+	}
+	if got := s.Drain(); len(got) != 0 {
+		t.Errorf("Expected empty Drain result, got %v", got)
+	}
+}
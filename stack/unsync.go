@@ -0,0 +1,116 @@
+package stack
+
+import (
+	"iter"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// UnsyncStack is a LIFO data structure without any locking.
+// It is intended for single-goroutine algorithmic use (e.g. DFS), where the mutex of Stack adds overhead for no benefit.
+// It must not be used concurrently from multiple goroutines.
+type UnsyncStack[T any] struct {
+	elements []T
+}
+
+var _ collection.Collection[int] = (*UnsyncStack[int])(nil)
+
+// NewUnsync returns a new empty UnsyncStack.
+func NewUnsync[T any]() *UnsyncStack[T] {
+	return &UnsyncStack[T]{}
+}
+
+// NewUnsyncWithCapacity returns a new empty UnsyncStack with pre-allocated capacity.
+func NewUnsyncWithCapacity[T any](capacity int) *UnsyncStack[T] {
+	return &UnsyncStack[T]{
+		elements: make([]T, 0, capacity),
+	}
+}
+
+// Push adds one or more elements to the top of the stack.
+// Elements are pushed in order, so the last item ends up on top.
+func (s *UnsyncStack[T]) Push(items ...T) {
+	s.elements = append(s.elements, items...)
+}
+
+// Pop removes and returns the top element of the stack.
+// Returns (zero-value, false) if the stack is empty.
+func (s *UnsyncStack[T]) Pop() (T, bool) {
+	l := len(s.elements)
+	if l == 0 {
+		var zero T
+		return zero, false
+	}
+
+	index := l - 1
+	v := s.elements[index]
+
+	// Zero out the element to prevent memory leaks (GC can reclaim it)
+	var zero T
+	s.elements[index] = zero
+	s.elements = s.elements[:index]
+
+	return v, true
+}
+
+// Peek returns the top element of the stack without removing it.
+// Returns (zero-value, false) if the stack is empty.
+func (s *UnsyncStack[T]) Peek() (T, bool) {
+	l := len(s.elements)
+	if l == 0 {
+		var zero T
+		return zero, false
+	}
+
+	return s.elements[l-1], true
+}
+
+// DropBottom removes up to n elements from the bottom of the stack, the oldest ones, and returns how many
+// it removed. It runs in O(len), which suits bounding a stack, such as an undo history, to a maximum size.
+func (s *UnsyncStack[T]) DropBottom(n int) int {
+	n = max(0, min(n, len(s.elements)))
+	if n == 0 {
+		return 0
+	}
+	kept := copy(s.elements, s.elements[n:])
+
+	// Zero out the vacated tail to prevent memory leaks (GC can reclaim it)
+	var zero T
+	for i := kept; i < len(s.elements); i++ {
+		s.elements[i] = zero
+	}
+	s.elements = s.elements[:kept]
+	return n
+}
+
+// IsEmpty returns true if the stack has no elements.
+func (s *UnsyncStack[T]) IsEmpty() bool {
+	return len(s.elements) == 0
+}
+
+// Len returns the current number of elements in the stack.
+func (s *UnsyncStack[T]) Len() int {
+	return len(s.elements)
+}
+
+// All returns an iterator over the elements from top to bottom (pop order).
+// The stack must not be modified during the iteration.
+func (s *UnsyncStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.elements) - 1; i >= 0; i-- {
+			if !yield(s.elements[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Clear discards all elements from the stack.
+func (s *UnsyncStack[T]) Clear() {
+	// Zero out all elements to assist GC
+	var zero T
+	for i := range s.elements {
+		s.elements[i] = zero
+	}
+	s.elements = s.elements[:0]
+}
@@ -0,0 +1,73 @@
+package stack
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUnsyncStack(t *testing.T) {
+	s := NewUnsyncWithCapacity[int](1)
+
+	if !s.IsEmpty() {
+		t.Errorf("Expected empty stack")
+	}
+
+	s.Push(1, 2)
+	s.Push(3)
+
+	if s.Len() != 3 {
+		t.Errorf("Expected size 3, got %d", s.Len())
+	}
+
+	v, ok := s.Peek()
+	if !ok || v != 3 {
+		t.Errorf("Expected 3, got %v (ok: %v)", v, ok)
+	}
+
+	for _, exp := range []int{3, 2, 1} {
+		v, ok := s.Pop()
+		if !ok || v != exp {
+			t.Errorf("Expected %d, got %v (ok: %v)", exp, v, ok)
+		}
+	}
+
+	if _, ok := s.Pop(); ok {
+		t.Errorf("Expected ok=false when popping from empty stack")
+	}
+
+	s.Push(10)
+	s.Clear()
+	if s.Len() != 0 || !NewUnsync[int]().IsEmpty() {
+		t.Errorf("Expected Len 0 after Clear, got %d", s.Len())
+	}
+}
+
+func TestUnsyncStack_DropBottom(t *testing.T) {
+	s := NewUnsync[int]()
+	s.Push(1, 2, 3, 4, 5)
+	if n := s.DropBottom(2); n != 2 || s.Len() != 3 {
+		t.Fatalf("DropBottom(2) = %d, Len() = %d", n, s.Len())
+	}
+	for _, exp := range []int{5, 4} {
+		if v, _ := s.Pop(); v != exp {
+			t.Errorf("Expected %d, got %d", exp, v)
+		}
+	}
+	if n := s.DropBottom(10); n != 1 || !s.IsEmpty() {
+		t.Errorf("DropBottom(10) = %d, Len() = %d", n, s.Len())
+	}
+	if n := s.DropBottom(-1); n != 0 {
+		t.Errorf("DropBottom(-1) = %d", n)
+	}
+}
+
+func TestUnsyncStack_All(t *testing.T) {
+	s := NewUnsync[int]()
+	s.Push(1, 2, 3)
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("Expected pop order [3 2 1], got %v", got)
+	}
+	if s.Len() != 3 {
+		t.Errorf("Expected All not to pop, got length %d", s.Len())
+	}
+}
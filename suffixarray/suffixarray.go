@@ -0,0 +1,188 @@
+// Package suffixarray provides a suffix array with its LCP array, for substring search in large static texts.
+package suffixarray
+
+import (
+	"bytes"
+	"slices"
+	"sort"
+)
+
+// Array is the suffix array of a text: the starting positions of its suffixes in lexicographic order,
+// with the length of the longest common prefix of each pair of adjacent suffixes. Once built in O(n log n)
+// for n bytes, it finds every occurrence of a pattern of length m in O(m log n), where scanning the text
+// would take O(n) per search.
+//
+// An Array is immutable, so it is safe for concurrent use without locking; build a new one to change the text.
+type Array struct {
+	text []byte
+	sa   []int // sa[i] is the position of the i-th smallest suffix
+	lcp  []int // lcp[i] is the length of the common prefix of the suffixes at sa[i-1] and sa[i]; lcp[0] is 0
+}
+
+// New returns the suffix array of text. The array keeps text, which must not be modified afterwards.
+func New(text []byte) *Array {
+	sa := build(text)
+	return &Array{text: text, sa: sa, lcp: lcp(text, sa)}
+}
+
+// Text returns the indexed text, which must not be modified.
+func (a *Array) Text() []byte {
+	return a.text
+}
+
+// Len returns the length of the text in bytes.
+func (a *Array) Len() int {
+	return len(a.text)
+}
+
+// Suffixes returns the starting positions of the suffixes of the text in lexicographic order.
+func (a *Array) Suffixes() []int {
+	return slices.Clone(a.sa)
+}
+
+// LCP returns the longest common prefix array: the i-th length is that of the common prefix of the (i-1)-th
+// and i-th suffixes in lexicographic order, and the first is 0.
+func (a *Array) LCP() []int {
+	return slices.Clone(a.lcp)
+}
+
+// Lookup returns the positions of every occurrence of pattern in the text, in increasing order, including
+// overlapping ones. An empty pattern occurs at every position.
+func (a *Array) Lookup(pattern []byte) []int {
+	lo, hi := a.find(pattern)
+	if lo == hi {
+		return nil
+	}
+	found := slices.Clone(a.sa[lo:hi])
+	slices.Sort(found)
+	return found
+}
+
+// Count returns the number of occurrences of pattern in the text, including overlapping ones.
+func (a *Array) Count(pattern []byte) int {
+	lo, hi := a.find(pattern)
+	return hi - lo
+}
+
+// Contains returns true if pattern occurs in the text.
+func (a *Array) Contains(pattern []byte) bool {
+	return a.Count(pattern) > 0
+}
+
+// LongestRepeated returns the longest substring occurring at least twice in the text, possibly overlapping itself.
+// It returns nil if no byte repeats. The result shares the memory of the text.
+func (a *Array) LongestRepeated() []byte {
+	best := 0
+	for i, l := range a.lcp {
+		if l > a.lcp[best] {
+			best = i
+		}
+	}
+	if len(a.lcp) == 0 || a.lcp[best] == 0 {
+		return nil
+	}
+	start := a.sa[best]
+	return a.text[start : start+a.lcp[best]]
+}
+
+// find returns the range of the suffix array whose suffixes start with pattern.
+func (a *Array) find(pattern []byte) (int, int) {
+	prefix := func(i int) []byte {
+		suffix := a.text[a.sa[i]:]
+		return suffix[:min(len(suffix), len(pattern))]
+	}
+	lo := sort.Search(len(a.sa), func(i int) bool { return bytes.Compare(prefix(i), pattern) >= 0 })
+	hi := lo + sort.Search(len(a.sa)-lo, func(i int) bool { return bytes.Compare(prefix(lo+i), pattern) > 0 })
+	return lo, hi
+}
+
+// build sorts the suffixes of text by prefix doubling: once they are sorted by their first k bytes, sorting them
+// by the ranks of their first and second halves of k bytes sorts them by their first 2k bytes. Each round is
+// a pair of counting sorts, and ranks become distinct after at most log n rounds.
+func build(text []byte) []int {
+	n := len(text)
+	sa, rank, next, tmp := make([]int, n), make([]int, n), make([]int, n), make([]int, n)
+	for i, b := range text {
+		sa[i], rank[i] = i, int(b)
+	}
+	slices.SortStableFunc(sa, func(i, j int) int { return int(text[i]) - int(text[j]) })
+
+	classes := 256
+	for k := 1; n > 0; k *= 2 {
+		// Order by the second half: suffixes without one first, then the others in the order of their second half.
+		j := 0
+		for i := n - k; i < n; i++ {
+			if i >= 0 {
+				tmp[j] = i
+				j++
+			}
+		}
+		for _, p := range sa {
+			if p >= k {
+				tmp[j] = p - k
+				j++
+			}
+		}
+
+		// Then stably by the first half.
+		count := make([]int, classes+1)
+		for _, p := range tmp {
+			count[rank[p]+1]++
+		}
+		for c := 1; c <= classes; c++ {
+			count[c] += count[c-1]
+		}
+		for _, p := range tmp {
+			sa[count[rank[p]]] = p
+			count[rank[p]]++
+		}
+
+		second := func(p int) int {
+			if p+k < n {
+				return rank[p+k]
+			}
+			return -1
+		}
+		next[sa[0]] = 0
+		for i := 1; i < n; i++ {
+			prev, cur := sa[i-1], sa[i]
+			next[cur] = next[prev]
+			if rank[prev] != rank[cur] || second(prev) != second(cur) {
+				next[cur]++
+			}
+		}
+		rank, next = next, rank
+		classes = rank[sa[n-1]] + 1
+		if classes == n {
+			break
+		}
+	}
+	return sa
+}
+
+// lcp computes the LCP array with the algorithm of Kasai et al., in O(n): the suffix following a suffix in the text
+// shares at least one byte less with its predecessor in the array than that suffix did.
+func lcp(text []byte, sa []int) []int {
+	n := len(text)
+	rank := make([]int, n)
+	for i, p := range sa {
+		rank[p] = i
+	}
+	out := make([]int, n)
+	h := 0
+	for p := range n {
+		if rank[p] == 0 {
+			h = 0
+			continue
+		}
+		q := sa[rank[p]-1]
+		for p+h < n && q+h < n && text[p+h] == text[q+h] {
+			h++
+		}
+		out[rank[p]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return out
+}
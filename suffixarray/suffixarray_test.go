@@ -0,0 +1,102 @@
+package suffixarray
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// naiveSuffixes sorts the suffixes of text by comparing them directly.
+func naiveSuffixes(text []byte) []int {
+	sa := make([]int, len(text))
+	for i := range sa {
+		sa[i] = i
+	}
+	slices.SortFunc(sa, func(i, j int) int { return bytes.Compare(text[i:], text[j:]) })
+	return sa
+}
+
+func naiveLookup(text, pattern []byte) []int {
+	var found []int
+	for i := 0; i+len(pattern) <= len(text) && i < len(text); i++ {
+		if bytes.HasPrefix(text[i:], pattern) {
+			found = append(found, i)
+		}
+	}
+	return found
+}
+
+func TestArray_Banana(t *testing.T) {
+	a := New([]byte("banana"))
+	if got := a.Suffixes(); !slices.Equal(got, []int{5, 3, 1, 0, 4, 2}) {
+		t.Errorf("Suffixes() = %v", got)
+	}
+	if got := a.LCP(); !slices.Equal(got, []int{0, 1, 3, 0, 0, 2}) {
+		t.Errorf("LCP() = %v", got)
+	}
+	if got := a.Lookup([]byte("ana")); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("Lookup(ana) = %v", got)
+	}
+	if a.Count([]byte("a")) != 3 || a.Count([]byte("nab")) != 0 || a.Count(nil) != 6 {
+		t.Error("Unexpected counts")
+	}
+	if !a.Contains([]byte("banana")) || a.Contains([]byte("bananas")) {
+		t.Error("Unexpected containment")
+	}
+	if got := a.LongestRepeated(); string(got) != "ana" {
+		t.Errorf("LongestRepeated() = %q", got)
+	}
+	if a.Len() != 6 || string(a.Text()) != "banana" {
+		t.Errorf("Len() = %d", a.Len())
+	}
+}
+
+func TestArray_Edges(t *testing.T) {
+	empty := New(nil)
+	if empty.Len() != 0 || empty.Lookup([]byte("a")) != nil || empty.Count(nil) != 0 || empty.LongestRepeated() != nil {
+		t.Error("Unexpected results on an empty text")
+	}
+	if got := New([]byte("abc")).LongestRepeated(); got != nil {
+		t.Errorf("LongestRepeated() without repeats = %q", got)
+	}
+	if got := New([]byte("aaaa")).Lookup([]byte("aa")); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("Expected overlapping occurrences, got %v", got)
+	}
+}
+
+func TestArray_MatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, alphabet := range []int{1, 2, 4, 256} {
+		for _, n := range []int{1, 2, 7, 100, 1000} {
+			text := make([]byte, n)
+			for i := range text {
+				text[i] = byte(rng.Intn(alphabet))
+			}
+			a := New(text)
+			if !slices.Equal(a.sa, naiveSuffixes(text)) {
+				t.Fatalf("alphabet %d, n %d: suffix array mismatch", alphabet, n)
+			}
+			for i := 1; i < n; i++ {
+				x, y := text[a.sa[i-1]:], text[a.sa[i]:]
+				l := 0
+				for l < len(x) && l < len(y) && x[l] == y[l] {
+					l++
+				}
+				if a.lcp[i] != l {
+					t.Fatalf("alphabet %d, n %d: lcp[%d] = %d, want %d", alphabet, n, i, a.lcp[i], l)
+				}
+			}
+			for range 20 {
+				from := rng.Intn(n)
+				pattern := text[from:min(n, from+1+rng.Intn(5))]
+				if rng.Intn(4) == 0 {
+					pattern = append(slices.Clone(pattern), byte(rng.Intn(alphabet)))
+				}
+				if got, want := a.Lookup(pattern), naiveLookup(text, pattern); !slices.Equal(got, want) {
+					t.Fatalf("Lookup(%v) = %v, want %v", pattern, got, want)
+				}
+			}
+		}
+	}
+}
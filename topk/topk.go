@@ -0,0 +1,248 @@
+// Package topk tracks the approximate most frequent items of a stream in bounded memory.
+package topk
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+	"github.com/dullkingsman/kozo/heap"
+)
+
+// Tracker is a thread-safe heavy hitters tracker implementing the Space-Saving algorithm.
+// It monitors at most capacity items; when a new item arrives and all counters are taken,
+// it replaces the item with the smallest count and inherits that count as its error.
+//
+// Counts are overestimated by at most Error, which is never more than Total()/capacity,
+// so every item occurring more than Total()/capacity times is guaranteed to be tracked.
+// A capacity of a few times the number of items reported gives accurate top lists for skewed streams.
+// Add is O(log capacity).
+type Tracker[T comparable] struct {
+	mu       sync.Mutex
+	capacity int
+	counters *heap.Heap[*counter[T]] // min-heap by count
+	index    map[T]*counter[T]
+	total    uint64
+}
+
+var _ collection.Collection[Item[int]] = (*Tracker[int])(nil)
+
+// Item is a tracked item with its estimated count.
+type Item[T any] struct {
+	Value T `json:"value"`
+	// Count is an upper bound of the number of occurrences of Value.
+	Count uint64 `json:"count"`
+	// Error bounds the overestimation of Count, so Value occurred at least Count-Error times.
+	Error uint64 `json:"error"`
+}
+
+type counter[T any] struct {
+	Item[T]
+	index int // position in the heap
+}
+
+// New returns a new empty Tracker monitoring at most capacity items. It panics if capacity is less than 1.
+func New[T comparable](capacity int) *Tracker[T] {
+	if capacity < 1 {
+		panic("topk: capacity must be at least 1")
+	}
+	return &Tracker[T]{capacity: capacity, counters: newCounterHeap[T](), index: make(map[T]*counter[T], capacity)}
+}
+
+// Add records one occurrence of item.
+func (t *Tracker[T]) Add(item T) {
+	t.AddCount(item, 1)
+}
+
+// AddCount records count occurrences of item, e.g. pre-aggregated from a log batch.
+func (t *Tracker[T]) AddCount(item T, count uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total += count
+	t.add(item, count)
+}
+
+func (t *Tracker[T]) add(item T, count uint64) {
+	if c, ok := t.index[item]; ok {
+		c.Count += count
+		t.counters.Fix(c.index)
+		return
+	}
+
+	if t.counters.Len() < t.capacity {
+		c := &counter[T]{Item: Item[T]{Value: item, Count: count}}
+		t.counters.Push(c)
+		t.index[item] = c
+		return
+	}
+
+	// Replace the item with the smallest count, which the new item may have occurred as often as.
+	c, _ := t.counters.Peek()
+	delete(t.index, c.Value)
+	c.Value, c.Count, c.Error = item, c.Count+count, c.Count
+	t.index[item] = c
+	t.counters.Fix(0)
+}
+
+// Top returns the n tracked items with the largest counts, in descending order of count.
+// Ties are ordered by ascending error, i.e. more certain items first.
+// A negative n is treated as zero.
+func (t *Tracker[T]) Top(n int) []Item[T] {
+	items := t.Items()
+	return items[:max(min(n, len(items)), 0)]
+}
+
+// Items returns all tracked items in descending order of count.
+func (t *Tracker[T]) Items() []Item[T] {
+	t.mu.Lock()
+	items := t.snapshot()
+	t.mu.Unlock()
+
+	slices.SortStableFunc(items, func(a, b Item[T]) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Error, b.Error)
+	})
+	return items
+}
+
+// All returns an iterator over a snapshot of the tracked items in descending order of count, like Items.
+// The snapshot is taken when iteration starts, so the tracker may be modified while iterating.
+func (t *Tracker[T]) All() iter.Seq[Item[T]] {
+	return func(yield func(Item[T]) bool) {
+		for _, item := range t.Items() {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// Get returns the tracked item equal to item. Returns false if it is not tracked,
+// in which case it occurred at most as often as the smallest tracked count.
+func (t *Tracker[T]) Get(item T) (Item[T], bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if c, ok := t.index[item]; ok {
+		return c.Item, true
+	}
+	return Item[T]{}, false
+}
+
+// Merge adds the items of other to t, as if t had also seen the stream of other.
+// This combines trackers fed by different shards or time windows, e.g. per-server trackers into a global one.
+// The trackers should have the same capacity for the error bounds to hold.
+//
+// Following Agarwal et al., "Mergeable Summaries", an item missing from a full tracker is assumed
+// to have occurred as often as its smallest count, which is added to both its count and its error.
+func (t *Tracker[T]) Merge(other *Tracker[T]) {
+	if other == t {
+		return
+	}
+
+	other.mu.Lock()
+	theirs := other.snapshot()
+	theirMin, theirTotal := other.floor(), other.total
+	other.mu.Unlock()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ours := t.snapshot()
+	ourMin := t.floor()
+
+	merged := make(map[T]Item[T], len(ours)+len(theirs))
+	for _, item := range ours {
+		item.Count += theirMin
+		item.Error += theirMin
+		merged[item.Value] = item
+	}
+	for _, item := range theirs {
+		if m, ok := merged[item.Value]; ok {
+			// Replace the assumed count with the actual one.
+			m.Count += item.Count - theirMin
+			m.Error += item.Error - theirMin
+			merged[item.Value] = m
+		} else {
+			item.Count += ourMin
+			item.Error += ourMin
+			merged[item.Value] = item
+		}
+	}
+
+	items := make([]Item[T], 0, len(merged))
+	for _, item := range merged {
+		items = append(items, item)
+	}
+	slices.SortFunc(items, func(a, b Item[T]) int { return cmp.Compare(b.Count, a.Count) })
+
+	t.counters.Clear()
+	clear(t.index)
+	for _, item := range items[:min(t.capacity, len(items))] {
+		c := &counter[T]{Item: item}
+		t.counters.Push(c)
+		t.index[item.Value] = c
+	}
+	t.total += theirTotal
+}
+
+// floor returns the count an untracked item may have: the smallest count if the tracker is full, or 0.
+func (t *Tracker[T]) floor() uint64 {
+	if t.counters.Len() < t.capacity {
+		return 0
+	}
+	c, _ := t.counters.Peek()
+	return c.Count
+}
+
+// snapshot copies the tracked items in heap order.
+func (t *Tracker[T]) snapshot() []Item[T] {
+	items := make([]Item[T], t.counters.Len())
+	for i := range items {
+		items[i] = t.counters.At(i).Item
+	}
+	return items
+}
+
+// Total returns the number of occurrences recorded, including those merged in.
+func (t *Tracker[T]) Total() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.total
+}
+
+// Len returns the number of tracked items.
+func (t *Tracker[T]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.counters.Len()
+}
+
+// IsEmpty returns true if no item is tracked.
+func (t *Tracker[T]) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Cap returns the maximum number of tracked items.
+func (t *Tracker[T]) Cap() int {
+	return t.capacity
+}
+
+// Clear removes all items and resets the total.
+func (t *Tracker[T]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.counters.Clear()
+	clear(t.index)
+	t.total = 0
+}
+
+func newCounterHeap[T any]() *heap.Heap[*counter[T]] {
+	return heap.NewWithIndex(
+		func(a, b *counter[T]) bool { return a.Count < b.Count },
+		func(c *counter[T], i int) { c.index = i },
+	)
+}
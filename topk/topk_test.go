@@ -0,0 +1,122 @@
+package topk
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestTrackerExact(t *testing.T) {
+	tr := New[string](3)
+	for _, e := range []string{"/a", "/b", "/a", "/c", "/a", "/b"} {
+		tr.Add(e)
+	}
+	tr.AddCount("/c", 5)
+
+	expected := []Item[string]{{"/c", 6, 0}, {"/a", 3, 0}, {"/b", 2, 0}}
+	if top := tr.Top(5); !slices.Equal(top, expected) {
+		t.Errorf("Top() = %v, want %v", top, expected)
+	}
+	if top := tr.Top(1); len(top) != 1 || top[0].Value != "/c" {
+		t.Errorf("Top(1) = %v", top)
+	}
+	if top := tr.Top(-1); len(top) != 0 {
+		t.Errorf("Expected Top(-1) to be empty, got %v", top)
+	}
+
+	// A new item replaces the smallest count and inherits it as its error.
+	tr.Add("/d")
+	if item, ok := tr.Get("/d"); !ok || item.Count != 3 || item.Error != 2 {
+		t.Errorf("Get(/d) = %v, %v", item, ok)
+	}
+	if _, ok := tr.Get("/b"); ok {
+		t.Error("Expected /b to be replaced")
+	}
+	if tr.Total() != 12 || tr.Len() != 3 {
+		t.Errorf("Expected total 12 and length 3, got %d and %d", tr.Total(), tr.Len())
+	}
+	for item := range tr.All() {
+		if item.Value != "/c" {
+			t.Errorf("Expected All to start with /c, got %v", item)
+		}
+		break
+	}
+
+	tr.Clear()
+	if tr.Len() != 0 || !tr.IsEmpty() || tr.Total() != 0 || len(tr.Top(3)) != 0 {
+		t.Error("Expected an empty tracker")
+	}
+}
+
+// zipfStream returns a skewed stream over 1000 items and the true count of each.
+func zipfStream(seed uint64, n int) ([]uint64, map[uint64]uint64) {
+	z := rand.NewZipf(rand.New(rand.NewPCG(seed, seed)), 1.2, 1, 999)
+	stream := make([]uint64, n)
+	counts := map[uint64]uint64{}
+	for i := range stream {
+		stream[i] = z.Uint64()
+		counts[stream[i]]++
+	}
+	return stream, counts
+}
+
+// checkBounds verifies that every tracked item brackets its true count, and that frequent items are tracked.
+func checkBounds(t *testing.T, tr *Tracker[uint64], counts map[uint64]uint64) {
+	t.Helper()
+	for _, item := range tr.Items() {
+		actual := counts[item.Value]
+		if item.Count < actual || item.Count-item.Error > actual {
+			t.Errorf("Item %d: count %d, error %d, actual %d", item.Value, item.Count, item.Error, actual)
+		}
+	}
+	threshold := tr.Total() / uint64(tr.Cap())
+	for v, c := range counts {
+		if _, ok := tr.Get(v); c > threshold && !ok {
+			t.Errorf("Item %d occurring %d > %d times is not tracked", v, c, threshold)
+		}
+	}
+}
+
+func TestTrackerStream(t *testing.T) {
+	stream, counts := zipfStream(1, 100000)
+	tr := New[uint64](50)
+	for _, v := range stream {
+		tr.Add(v)
+	}
+	checkBounds(t, tr, counts)
+
+	for i, item := range tr.Top(5) {
+		if item.Value != uint64(i) {
+			t.Errorf("Expected item %d at rank %d, got %v", i, i, item)
+		}
+	}
+}
+
+func TestTrackerMerge(t *testing.T) {
+	a, countsA := zipfStream(1, 50000)
+	b, countsB := zipfStream(2, 50000)
+	for v, c := range countsB {
+		countsA[v] += c
+	}
+
+	left, right := New[uint64](50), New[uint64](50)
+	for _, v := range a {
+		left.Add(v)
+	}
+	for _, v := range b {
+		right.Add(v)
+	}
+	left.Merge(right)
+	left.Merge(left)
+
+	if left.Total() != 100000 || left.Len() != 50 {
+		t.Errorf("Expected total 100000 and length 50, got %d and %d", left.Total(), left.Len())
+	}
+	checkBounds(t, left, countsA)
+
+	for i, item := range left.Top(3) {
+		if item.Value != uint64(i) {
+			t.Errorf("Expected item %d at rank %d, got %v", i, i, item)
+		}
+	}
+}
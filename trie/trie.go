@@ -0,0 +1,264 @@
+// Package trie provides a trie keyed by slices of comparable elements.
+package trie
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dullkingsman/kozo/collection"
+)
+
+// Trie is a thread-safe map from slices of comparable elements to values, stored as a prefix tree.
+// It indexes hierarchical keys, such as permission paths or file path segments, without joining them into strings.
+// Get, Set, Delete and the prefix lookups are O(k) for keys of length k, independent of the number of keys.
+//
+// Children are visited in the order they were added, so iteration is deterministic
+// but not sorted, since K need not be ordered.
+type Trie[K comparable, V any] struct {
+	mu   sync.RWMutex
+	root *node[K, V]
+	size int
+}
+
+var _ collection.Map[[]int, int] = (*Trie[int, int])(nil)
+
+type node[K comparable, V any] struct {
+	value    V
+	hasValue bool
+	children map[K]*node[K, V]
+	order    []K // keys of children in the order they were added
+}
+
+// New returns a new empty Trie.
+func New[K comparable, V any]() *Trie[K, V] {
+	return &Trie[K, V]{root: &node[K, V]{}}
+}
+
+// Set sets the value of key. It returns true if the key was new.
+func (t *Trie[K, V]) Set(key []K, value V) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	n := t.root
+	for _, k := range key {
+		child, ok := n.children[k]
+		if !ok {
+			child = &node[K, V]{}
+			if n.children == nil {
+				n.children = make(map[K]*node[K, V])
+			}
+			n.children[k] = child
+			n.order = append(n.order, k)
+		}
+		n = child
+	}
+
+	isNew := !n.hasValue
+	n.value, n.hasValue = value, true
+	if isNew {
+		t.size++
+	}
+	return isNew
+}
+
+// Get returns the value of key. Returns (zero-value, false) if the key is not present.
+func (t *Trie[K, V]) Get(key []K) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if n := t.find(key); n != nil && n.hasValue {
+		return n.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Has returns true if the key is present.
+func (t *Trie[K, V]) Has(key []K) bool {
+	_, ok := t.Get(key)
+	return ok
+}
+
+// Delete removes key. It returns true if the key was present.
+// Nodes left without values below them are removed.
+func (t *Trie[K, V]) Delete(key []K) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	path := make([]*node[K, V], 0, len(key)+1)
+	n := t.root
+	path = append(path, n)
+	for _, k := range key {
+		if n = n.children[k]; n == nil {
+			return false
+		}
+		path = append(path, n)
+	}
+	if !n.hasValue {
+		return false
+	}
+
+	var zero V
+	n.value, n.hasValue = zero, false
+	t.size--
+
+	// Prune the nodes that no longer lead to a value, bottom up.
+	for i := len(key) - 1; i >= 0; i-- {
+		child := path[i+1]
+		if child.hasValue || len(child.children) > 0 {
+			break
+		}
+		path[i].removeChild(key[i])
+	}
+	return true
+}
+
+// LongestPrefix returns the longest key that is a prefix of s, and its value. Returns false if there is none.
+// The returned key is a subslice of s.
+func (t *Trie[K, V]) LongestPrefix(s []K) ([]K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var (
+		best  *node[K, V]
+		depth int
+	)
+	n := t.root
+	for i := 0; ; i++ {
+		if n.hasValue {
+			best, depth = n, i
+		}
+		if i == len(s) {
+			break
+		}
+		if n = n.children[s[i]]; n == nil {
+			break
+		}
+	}
+
+	if best == nil {
+		var zero V
+		return nil, zero, false
+	}
+	return s[:depth], best.value, true
+}
+
+// Prefixes returns an iterator over a snapshot of the keys that are prefixes of s, and their values,
+// from the shortest to the longest. For permission paths, these are the grants covering s.
+// The yielded keys are subslices of s.
+func (t *Trie[K, V]) Prefixes(s []K) iter.Seq2[[]K, V] {
+	return func(yield func([]K, V) bool) {
+		t.mu.RLock()
+		var (
+			depths []int
+			values []V
+		)
+		n := t.root
+		for i := 0; n != nil; i++ {
+			if n.hasValue {
+				depths = append(depths, i)
+				values = append(values, n.value)
+			}
+			if i == len(s) {
+				break
+			}
+			n = n.children[s[i]]
+		}
+		t.mu.RUnlock()
+
+		for i, depth := range depths {
+			if !yield(s[:depth], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// WithPrefix returns an iterator over a snapshot of the key-value pairs whose keys start with prefix.
+// Each key is visited before the keys it is a prefix of. The yielded keys are fresh slices.
+func (t *Trie[K, V]) WithPrefix(prefix []K) iter.Seq2[[]K, V] {
+	return func(yield func([]K, V) bool) {
+		keys, values := t.collect(prefix)
+		for i, key := range keys {
+			if !yield(key, values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over a snapshot of the key-value pairs.
+// The snapshot is taken when iteration starts, so the trie may be modified while iterating.
+func (t *Trie[K, V]) All() iter.Seq2[[]K, V] {
+	return t.WithPrefix(nil)
+}
+
+// Keys returns the keys, each before the keys it is a prefix of.
+func (t *Trie[K, V]) Keys() [][]K {
+	keys, _ := t.collect(nil)
+	return keys
+}
+
+// Len returns the number of keys.
+func (t *Trie[K, V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.size
+}
+
+// IsEmpty returns true if the trie has no keys.
+func (t *Trie[K, V]) IsEmpty() bool {
+	return t.Len() == 0
+}
+
+// Clear removes all keys.
+func (t *Trie[K, V]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.root = &node[K, V]{}
+	t.size = 0
+}
+
+// find returns the node whose path spells key, or nil if there is none.
+func (t *Trie[K, V]) find(key []K) *node[K, V] {
+	n := t.root
+	for _, k := range key {
+		if n = n.children[k]; n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// collect copies the pairs whose keys start with prefix.
+func (t *Trie[K, V]) collect(prefix []K) ([][]K, []V) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	keys := make([][]K, 0)
+	values := make([]V, 0)
+	if n := t.find(prefix); n != nil {
+		n.walk(slices.Clone(prefix), func(k []K, v V) {
+			keys = append(keys, slices.Clone(k))
+			values = append(values, v)
+		})
+	}
+	return keys, values
+}
+
+// walk visits the pairs of the subtree in pre-order, where path is the key of n.
+// path is reused between calls to visit.
+func (n *node[K, V]) walk(path []K, visit func([]K, V)) {
+	if n.hasValue {
+		visit(path, n.value)
+	}
+	for _, k := range n.order {
+		n.children[k].walk(append(path, k), visit)
+	}
+}
+
+func (n *node[K, V]) removeChild(k K) {
+	delete(n.children, k)
+	n.order = slices.DeleteFunc(n.order, func(o K) bool { return o == k })
+}
@@ -0,0 +1,149 @@
+package trie
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func path(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "/")
+}
+
+func joined(keys [][]string) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		out[i] = strings.Join(k, "/")
+	}
+	return out
+}
+
+func TestTrieBasic(t *testing.T) {
+	tr := New[string, int]()
+	for i, key := range []string{"org/eng", "org/eng/backend", "org/sales", "org", "docs/api"} {
+		if !tr.Set(path(key), i) {
+			t.Errorf("Expected %q to be new", key)
+		}
+	}
+	if tr.Set(path("org"), 30) {
+		t.Error("Expected org to exist")
+	}
+
+	if v, ok := tr.Get(path("org")); !ok || v != 30 {
+		t.Errorf("Expected 30, got %v (ok: %v)", v, ok)
+	}
+	if tr.Has(path("docs")) || tr.Has(path("org/eng/frontend")) || tr.Has(nil) {
+		t.Error("Expected intermediate and missing keys to be absent")
+	}
+
+	expected := []string{"org", "org/eng", "org/eng/backend", "org/sales", "docs/api"}
+	if keys := joined(tr.Keys()); !slices.Equal(keys, expected) {
+		t.Errorf("Keys() = %v, want %v", keys, expected)
+	}
+
+	if tr.Delete(path("docs")) || !tr.Delete(path("docs/api")) || tr.Delete(path("docs/api")) {
+		t.Error("Expected Delete to report presence once")
+	}
+	if _, ok := tr.root.children["docs"]; ok {
+		t.Error("Expected the docs node to be pruned")
+	}
+	if !tr.Delete(path("org/eng")) || !tr.Has(path("org/eng/backend")) {
+		t.Error("Expected deleting a prefix to keep longer keys")
+	}
+	if tr.Len() != 3 {
+		t.Errorf("Expected length 3, got %d", tr.Len())
+	}
+
+	tr.Set(nil, -1)
+	if v, ok := tr.Get([]string{}); !ok || v != -1 {
+		t.Errorf("Expected the empty key to be present, got %v (ok: %v)", v, ok)
+	}
+
+	tr.Clear()
+	if !tr.IsEmpty() || tr.Has(path("org")) {
+		t.Error("Expected an empty trie")
+	}
+}
+
+func TestTriePrefixQueries(t *testing.T) {
+	grants := New[string, string]()
+	grants.Set(path("org"), "read")
+	grants.Set(path("org/eng/backend"), "write")
+	grants.Set(path("org/eng/backend/db"), "admin")
+
+	key, value, ok := grants.LongestPrefix(path("org/eng/backend/api"))
+	if !ok || strings.Join(key, "/") != "org/eng/backend" || value != "write" {
+		t.Errorf("LongestPrefix() = %v, %q, %v", key, value, ok)
+	}
+	if _, _, ok := grants.LongestPrefix(path("docs")); ok {
+		t.Error("Expected no prefix of docs")
+	}
+
+	var covering []string
+	for k, v := range grants.Prefixes(path("org/eng/backend/db/users")) {
+		covering = append(covering, strings.Join(k, "/")+"="+v)
+	}
+	if expected := []string{"org=read", "org/eng/backend=write", "org/eng/backend/db=admin"}; !slices.Equal(covering, expected) {
+		t.Errorf("Prefixes() = %v, want %v", covering, expected)
+	}
+
+	var under [][]string
+	for k := range grants.WithPrefix(path("org/eng")) {
+		under = append(under, k)
+	}
+	if got := joined(under); !slices.Equal(got, []string{"org/eng/backend", "org/eng/backend/db"}) {
+		t.Errorf("WithPrefix() = %v", got)
+	}
+	under[0][0] = "changed"
+	if !grants.Has(path("org/eng/backend")) {
+		t.Error("Expected yielded keys not to alias the trie")
+	}
+}
+
+// TestTrieRandom compares the trie against a map.
+func TestTrieRandom(t *testing.T) {
+	tr := New[byte, int]()
+	model := map[string]int{}
+	r := rand.New(rand.NewPCG(1, 2))
+	key := func() []byte {
+		b := make([]byte, r.IntN(5))
+		for i := range b {
+			b[i] = "abc"[r.IntN(3)]
+		}
+		return b
+	}
+
+	for i := range 5000 {
+		k := key()
+		_, exists := model[string(k)]
+		if r.IntN(2) == 0 {
+			if tr.Set(k, i) == exists {
+				t.Fatalf("Set(%q) reported new=%v", k, exists)
+			}
+			model[string(k)] = i
+		} else {
+			if tr.Delete(k) != exists {
+				t.Fatalf("Delete(%q) != %v", k, exists)
+			}
+			delete(model, string(k))
+		}
+		if tr.Len() != len(model) {
+			t.Fatalf("Len() = %d, want %d", tr.Len(), len(model))
+		}
+	}
+
+	count := 0
+	for k, v := range tr.All() {
+		if model[string(k)] != v {
+			t.Fatalf("All() yielded %q = %d, want %d", k, v, model[string(k)])
+		}
+		count++
+	}
+	if count != len(model) {
+		t.Errorf("All() yielded %d pairs, want %d", count, len(model))
+	}
+}